@@ -138,6 +138,11 @@ type BackupSpec struct {
 	// +kubebuilder:validation:Enum=primary;prefer-standby
 	Target BackupTarget `json:"target,omitempty"`
 
+	// The name of the instance that should perform this backup, overriding
+	// the `target` policy. Only supported with the `volumeSnapshot` method.
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
 	// The backup method to be used, possible options are `barmanObjectStore`,
 	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
 	// +optional
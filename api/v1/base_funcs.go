@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -75,3 +77,20 @@ func ListStatusPods(podList []corev1.Pod) map[PodStatus][]string {
 
 	return podsNames
 }
+
+// GetResyncInterval gets the interval between two drift checks, zero
+// if the drift detection is disabled
+func (configuration DriftDetectionConfiguration) GetResyncInterval() time.Duration {
+	if configuration.ResyncInterval == nil {
+		return 0
+	}
+	return configuration.ResyncInterval.Duration
+}
+
+// GetDriftPolicy gets the action taken when a drift is detected
+func (configuration DriftDetectionConfiguration) GetDriftPolicy() DriftPolicy {
+	if configuration.DriftPolicy == "" {
+		return DriftPolicyCorrect
+	}
+	return configuration.DriftPolicy
+}
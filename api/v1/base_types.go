@@ -21,6 +21,7 @@ package v1
 
 import (
 	machineryapi "github.com/cloudnative-pg/machinery/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PodStatus represent the possible status of pods
@@ -51,3 +52,51 @@ type SecretKeySelector = machineryapi.SecretKeySelector
 // the key of a ConfigMap
 // +kubebuilder:object:generate:=false
 type ConfigMapKeySelector = machineryapi.ConfigMapKeySelector
+
+// DriftPolicy is the action taken when the state of an object in PostgreSQL
+// drifts from its specification
+// +enum
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect means the object is applied again to correct the drift
+	DriftPolicyCorrect DriftPolicy = "correct"
+
+	// DriftPolicyReport means the drift is only reported in the status
+	DriftPolicyReport DriftPolicy = "report"
+)
+
+// DriftDetectionConfiguration configures the periodic comparison of the state
+// of an object in PostgreSQL with its specification, to detect the changes
+// made outside the operator
+type DriftDetectionConfiguration struct {
+	// The interval between two comparisons of the state of the object in
+	// PostgreSQL with its specification, like `1h`. The drift is not
+	// detected when not set.
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+
+	// The action taken when a drift is detected: `correct` applies the
+	// specification again, `report` only records the drift in the status
+	// +kubebuilder:validation:Enum=correct;report
+	// +kubebuilder:default:=correct
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+}
+
+// DriftStatus is the outcome of the last comparison of the state of an
+// object in PostgreSQL with its specification
+type DriftStatus struct {
+	// The time of the last comparison
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// The differences found by the last comparison. Empty when the state
+	// of the object matches its specification.
+	// +optional
+	Differences []string `json:"differences,omitempty"`
+
+	// The time the drift was last corrected
+	// +optional
+	LastCorrectionTime *metav1.Time `json:"lastCorrectionTime,omitempty"`
+}
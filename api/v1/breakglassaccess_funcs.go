@@ -0,0 +1,76 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// SetAsFailed sets the access as failed with the given error
+func (access *BreakGlassAccess) SetAsFailed(err error) {
+	access.Status.Applied = ptr.To(false)
+	access.Status.Message = err.Error()
+}
+
+// SetAsReady sets the access as working correctly
+func (access *BreakGlassAccess) SetAsReady() {
+	access.Status.Message = ""
+	access.Status.Applied = ptr.To(true)
+	access.Status.ObservedGeneration = access.Generation
+}
+
+// GetClusterRef returns the cluster reference of the access
+func (access *BreakGlassAccess) GetClusterRef() corev1.LocalObjectReference {
+	return access.Spec.ClusterRef
+}
+
+// GetStatusMessage returns the status message of the access
+func (access *BreakGlassAccess) GetStatusMessage() string {
+	return access.Status.Message
+}
+
+// SetStatusObservedGeneration sets the observed generation of the access
+func (access *BreakGlassAccess) SetStatusObservedGeneration(obsGeneration int64) {
+	access.Status.ObservedGeneration = obsGeneration
+}
+
+// IsActive returns true if the access has been granted, is not
+// being deleted, and has not expired at the passed time
+func (access *BreakGlassAccess) IsActive(now time.Time) bool {
+	return access.DeletionTimestamp.IsZero() &&
+		access.Status.Phase == BreakGlassAccessPhaseActive &&
+		access.Status.ExpirationTime != nil &&
+		now.Before(access.Status.ExpirationTime.Time)
+}
+
+// GetHBARule returns the pg_hba rule granted by the access, matching
+// the role being granted the access, or nil if there is none
+func (access *BreakGlassAccess) GetHBARule() *HBARule {
+	if access.Spec.HBARule == nil {
+		return nil
+	}
+
+	rule := access.Spec.HBARule.DeepCopy()
+	rule.Users = []string{access.Spec.Role}
+	return rule
+}
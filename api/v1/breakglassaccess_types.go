@@ -0,0 +1,141 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BreakGlassAccessPhase is the phase of a BreakGlassAccess
+type BreakGlassAccessPhase string
+
+const (
+	// BreakGlassAccessPhaseActive means that the access has been granted
+	// and has not expired yet
+	BreakGlassAccessPhaseActive BreakGlassAccessPhase = "active"
+
+	// BreakGlassAccessPhaseExpired means that the access has expired and
+	// has been revoked
+	BreakGlassAccessPhaseExpired BreakGlassAccessPhase = "expired"
+)
+
+// BreakGlassAccessSpec defines a time-limited access granted to a role
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="a BreakGlassAccess cannot be changed, create a new one instead"
+// +kubebuilder:validation:XValidation:rule="has(self.hbaRule) || (has(self.inRoles) && self.inRoles.size() > 0)",message="at least one of hbaRule and inRoles is required"
+type BreakGlassAccessSpec struct {
+	// The corresponding cluster
+	ClusterRef corev1.LocalObjectReference `json:"cluster"`
+
+	// The name of the PostgreSQL role being granted the access. The role
+	// must already exist
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self != 'postgres' && self != 'streaming_replica'",message="reserved roles cannot be granted a break-glass access"
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('pg_') && !self.startsWith('cnpg_')",message="reserved roles cannot be granted a break-glass access"
+	Role string `json:"role"`
+
+	// The pg_hba rule allowing the role to connect while the access is
+	// active. The `users` field is set to the name of the role
+	// +kubebuilder:validation:XValidation:rule="!has(self.users)",message="users cannot be set in the hbaRule of a BreakGlassAccess"
+	// +optional
+	HBARule *HBARule `json:"hbaRule,omitempty"`
+
+	// The roles the role is made a member of while the access is active
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	InRoles []string `json:"inRoles,omitempty"`
+
+	// How long the access lasts once granted, up to 168 hours
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s') && duration(self) <= duration('168h')",message="duration must be positive and not greater than 168h"
+	Duration metav1.Duration `json:"duration"`
+
+	// Why the access has been granted, for auditing purposes
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// BreakGlassAccessStatus defines the observed state of a BreakGlassAccess
+type BreakGlassAccessStatus struct {
+	// A sequence number representing the latest
+	// desired state that was synchronized
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The phase of the access
+	// +optional
+	Phase BreakGlassAccessPhase `json:"phase,omitempty"`
+
+	// Applied is true if the access was reconciled correctly
+	// +optional
+	Applied *bool `json:"applied,omitempty"`
+
+	// Message is the reconciliation error message
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// When the access has been granted
+	// +optional
+	GrantTime *metav1.Time `json:"grantTime,omitempty"`
+
+	// When the access expires
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// The role memberships granted by this access, which are revoked
+	// when it expires. The memberships the role already had are not
+	// included
+	// +optional
+	GrantedRoles []string `json:"grantedRoles,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Role",type="string",JSONPath=".spec.role"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Expiration",type="string",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Latest reconciliation message"
+
+// BreakGlassAccess is the Schema for the breakglassaccesses API
+type BreakGlassAccess struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired BreakGlassAccess.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec BreakGlassAccessSpec `json:"spec"`
+	// Most recently observed status of the BreakGlassAccess. This data may not be up
+	// to date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status BreakGlassAccessStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BreakGlassAccessList contains a list of BreakGlassAccesses
+type BreakGlassAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BreakGlassAccess `json:"items"`
+}
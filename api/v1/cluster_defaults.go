@@ -22,6 +22,8 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -103,6 +105,48 @@ func (r *Cluster) setDefaults(preserveUserSettings bool) {
 			IsAlterSystemEnabled:          r.Spec.PostgresConfiguration.EnableAlterSystem,
 		}
 		sanitizedParameters := postgres.CreatePostgresqlConfiguration(info).GetConfigurationParameters()
+
+		// The parameters stored by a previous defaulting are not user
+		// settings, as long as their value was not changed
+		storedDefaults := r.getDefaultedParameters()
+		isUserSetting := func(key string) bool {
+			value, found := r.Spec.PostgresConfiguration.Parameters[key]
+			if !found {
+				return false
+			}
+			storedDefault, isStoredDefault := storedDefaults[key]
+			return !isStoredDefault || value != storedDefault
+		}
+
+		// The default values of the tuned parameters, of the ones read
+		// from ConfigMaps and Secrets and of the ones of the configuration
+		// profile must not be stored in the spec, otherwise they would take
+		// precedence over the actual ones. The instance manager applies the
+		// defaults anyway, and the values explicitly set by the user are
+		// always kept
+		var externalParameters []string
+		if r.Spec.ConfigurationProfileRef != nil {
+			externalParameters = slices.Collect(maps.Keys(sanitizedParameters))
+		}
+		if r.Spec.PostgresConfiguration.Tuning != "" {
+			externalParameters = append(externalParameters, postgres.TunedConfigurationParameters...)
+		}
+		for _, source := range r.Spec.PostgresConfiguration.ParametersFrom {
+			externalParameters = append(externalParameters, source.Name)
+		}
+		for _, key := range externalParameters {
+			if !isUserSetting(key) {
+				delete(sanitizedParameters, key)
+			}
+		}
+
+		defaultedParameters := make(map[string]string)
+		for key, value := range sanitizedParameters {
+			if !isUserSetting(key) {
+				defaultedParameters[key] = value
+			}
+		}
+		r.setDefaultedParameters(defaultedParameters)
 		r.Spec.PostgresConfiguration.Parameters = sanitizedParameters
 	}
 
@@ -384,3 +428,33 @@ func NewLivenessPingerConfigFromAnnotations(
 
 	return &cfg, nil
 }
+
+// getDefaultedParameters returns the PostgreSQL parameters stored in the
+// spec by the defaulting, with the value they were stored with
+func (r *Cluster) getDefaultedParameters() map[string]string {
+	var result map[string]string
+	if value, ok := r.Annotations[utils.DefaultedParametersAnnotationName]; ok {
+		// A corrupted annotation is ignored, and every stored parameter
+		// is considered set by the user
+		_ = json.Unmarshal([]byte(value), &result)
+	}
+	return result
+}
+
+// setDefaultedParameters records the PostgreSQL parameters stored in the
+// spec by the defaulting, to tell them apart from the user settings
+func (r *Cluster) setDefaultedParameters(parameters map[string]string) {
+	if len(parameters) == 0 {
+		delete(r.Annotations, utils.DefaultedParametersAnnotationName)
+		return
+	}
+
+	value, err := json.Marshal(parameters)
+	if err != nil {
+		return
+	}
+	if r.Annotations == nil {
+		r.Annotations = make(map[string]string)
+	}
+	r.Annotations[utils.DefaultedParametersAnnotationName] = string(value)
+}
@@ -117,6 +117,108 @@ var _ = Describe("cluster default configuration", func() {
 		cluster := Cluster{}
 		cluster.Default()
 		Expect(cluster.Spec.PostgresConfiguration.Parameters).ToNot(BeEmpty())
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "32"))
+	})
+
+	It("doesn't store the defaults of the tuned parameters when a tuning profile is set", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLTP,
+				},
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).ToNot(HaveKey("max_parallel_workers"))
+	})
+
+	It("keeps the explicit values of the tuned parameters when a tuning profile is set", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLTP,
+					Parameters: map[string]string{
+						"max_parallel_workers": "8",
+					},
+				},
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "8"))
+	})
+
+	It("keeps the explicit values of the tuned parameters matching the defaults", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLTP,
+					Parameters: map[string]string{
+						"max_parallel_workers": "32",
+					},
+				},
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "32"))
+	})
+
+	It("drops the stored defaults of the tuned parameters when a tuning profile is set", func() {
+		cluster := Cluster{}
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "32"))
+		Expect(cluster.Annotations).To(HaveKey(utils.DefaultedParametersAnnotationName))
+
+		cluster.Spec.PostgresConfiguration.Tuning = TuningProfileOLTP
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).ToNot(HaveKey("max_parallel_workers"))
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_replication_slots", "32"))
+	})
+
+	It("keeps the explicit values of the tuned parameters matching the defaults on update", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Parameters: map[string]string{
+						"max_parallel_workers": "32",
+					},
+				},
+			},
+		}
+		cluster.Default()
+
+		cluster.Spec.PostgresConfiguration.Tuning = TuningProfileOLTP
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "32"))
+	})
+
+	It("keeps the stored defaults of the tuned parameters changed by the user", func() {
+		cluster := Cluster{}
+		cluster.Default()
+
+		cluster.Spec.PostgresConfiguration.Parameters["max_parallel_workers"] = "8"
+		cluster.Spec.PostgresConfiguration.Tuning = TuningProfileOLTP
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("max_parallel_workers", "8"))
+	})
+
+	It("doesn't store the defaults of the parameters read from ConfigMaps and Secrets", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					ParametersFrom: []ParameterSource{
+						{
+							Name: "max_parallel_workers",
+							ConfigMapKeyRef: &ConfigMapKeySelector{
+								LocalObjectReference: LocalObjectReference{Name: "tuning"},
+								Key:                  "max_parallel_workers",
+							},
+						},
+					},
+				},
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).ToNot(HaveKey("max_parallel_workers"))
 	})
 
 	It("defaults the anti-affinity", func() {
@@ -23,8 +23,10 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,6 +46,7 @@ import (
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	contextutils "github.com/cloudnative-pg/cloudnative-pg/pkg/utils/context"
@@ -148,6 +151,25 @@ func GetExternalClustersEnabledPluginNames(externalClusters []ExternalCluster) (
 	return pluginNames
 }
 
+// Matches checks if the override applies to the instance with
+// the passed name and labels
+func (o InstanceParametersOverride) Matches(instanceName string, instanceLabels map[string]string) bool {
+	if o.InstanceName != "" {
+		return o.InstanceName == instanceName
+	}
+
+	if o.Selector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(o.Selector)
+	if err != nil {
+		return false
+	}
+
+	return selector.Matches(labels.Set(instanceLabels))
+}
+
 // GetShmLimit gets the `/dev/shm` memory size limit
 func (e *EphemeralVolumesSizeLimitConfiguration) GetShmLimit() *resource.Quantity {
 	if e == nil {
@@ -433,6 +455,79 @@ func (roleConfiguration *RoleConfiguration) GetRoleSecretName() string {
 	return ""
 }
 
+// GetPasswordSecretNames gets the names of the secrets storing the
+// credentials of the role, including the alternate password secret used
+// by the password rotation
+func (roleConfiguration *RoleConfiguration) GetPasswordSecretNames() []string {
+	var result []string
+	if name := roleConfiguration.GetRoleSecretName(); name != "" {
+		result = append(result, name)
+	}
+	if rotation := roleConfiguration.PasswordRotation; rotation != nil &&
+		rotation.GetStrategy() == PasswordRotationStrategyAlternateSecrets &&
+		rotation.AlternatePasswordSecret != nil && rotation.AlternatePasswordSecret.Name != "" {
+		result = append(result, rotation.AlternatePasswordSecret.Name)
+	}
+	return result
+}
+
+// GetPasswordRotationNextLogin gets the name of the login created next to
+// a role whose password is rotated, which inherits the privileges of the role
+func (roleConfiguration *RoleConfiguration) GetPasswordRotationNextLogin() string {
+	return roleConfiguration.Name + PasswordRotationNextLoginSuffix
+}
+
+// GetPasswordRotationSecretName gets the name of the secret storing the
+// credentials of the passed login of a role whose password is rotated
+func (roleConfiguration *RoleConfiguration) GetPasswordRotationSecretName(login string) string {
+	rotation := roleConfiguration.PasswordRotation
+	if rotation != nil && rotation.GetStrategy() == PasswordRotationStrategyAlternateSecrets &&
+		login != roleConfiguration.Name && rotation.AlternatePasswordSecret != nil {
+		return rotation.AlternatePasswordSecret.Name
+	}
+	return roleConfiguration.GetRoleSecretName()
+}
+
+// defaultPasswordRotationGracePeriod is the default time the previous
+// credentials of a role stay valid after a password rotation
+const defaultPasswordRotationGracePeriod = 24 * time.Hour
+
+// GetStrategy gets the password rotation strategy, defaulting to nextRole
+func (rotation *PasswordRotationConfiguration) GetStrategy() PasswordRotationStrategy {
+	if rotation.Strategy == "" {
+		return PasswordRotationStrategyNextRole
+	}
+	return rotation.Strategy
+}
+
+// GetGracePeriod gets the time the previous credentials stay valid after
+// a rotation, defaulting to a day or, when shorter, half the interval
+func (rotation *PasswordRotationConfiguration) GetGracePeriod() time.Duration {
+	if rotation.GracePeriod != nil {
+		return rotation.GracePeriod.Duration
+	}
+	return min(defaultPasswordRotationGracePeriod, rotation.Interval.Duration/2)
+}
+
+// GetActiveLogin gets the login whose credentials are in use, which is
+// the passed role until the first rotation
+func (state *PasswordRotationState) GetActiveLogin(roleName string) string {
+	if state == nil || state.ActiveLogin == "" {
+		return roleName
+	}
+	return state.ActiveLogin
+}
+
+// GetSecretName gets the name of the service binding Secret of the passed
+// role in the passed cluster
+func (binding *ServiceBinding) GetSecretName(clusterName, roleName string) string {
+	if binding.SecretName != "" {
+		return binding.SecretName
+	}
+	name := fmt.Sprintf("%s-%s-%s", clusterName, roleName, binding.Database)
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
 // GetRoleInherit return the inherit attribute of a roleConfiguration
 func (roleConfiguration *RoleConfiguration) GetRoleInherit() bool {
 	if roleConfiguration.Inherit != nil {
@@ -563,13 +658,37 @@ func (cluster *Cluster) GetExternalClusterSecrets() *stringset.Data {
 	return secrets
 }
 
+// GetParametersFromSecrets gets the names of the secrets containing
+// the values of PostgreSQL configuration options
+func (cluster *Cluster) GetParametersFromSecrets() *stringset.Data {
+	secrets := stringset.New()
+	for _, source := range cluster.Spec.PostgresConfiguration.ParametersFrom {
+		if source.SecretKeyRef != nil {
+			secrets.Put(source.SecretKeyRef.Name)
+		}
+	}
+	return secrets
+}
+
+// GetParametersFromConfigMaps gets the names of the config maps containing
+// the values of PostgreSQL configuration options
+func (cluster *Cluster) GetParametersFromConfigMaps() *stringset.Data {
+	configMaps := stringset.New()
+	for _, source := range cluster.Spec.PostgresConfiguration.ParametersFrom {
+		if source.ConfigMapKeyRef != nil {
+			configMaps.Put(source.ConfigMapKeyRef.Name)
+		}
+	}
+	return configMaps
+}
+
 // UsesSecretInManagedRoles checks if the given secret name is used in a managed role
 func (cluster *Cluster) UsesSecretInManagedRoles(secretName string) bool {
 	if !cluster.ContainsManagedRolesConfiguration() {
 		return false
 	}
 	for _, role := range cluster.Spec.Managed.Roles {
-		if role.PasswordSecret != nil && role.PasswordSecret.Name == secretName {
+		if slices.Contains(role.GetPasswordSecretNames(), secretName) {
 			return true
 		}
 	}
@@ -703,6 +822,66 @@ func (cluster *Cluster) GetReplicationSecretName() string {
 	return fmt.Sprintf("%v%v", cluster.Name, ReplicationSecretSuffix)
 }
 
+// GetCloneSourceSecretName get the name of the secret containing the TLS
+// credentials used to connect to the Cluster being cloned
+func (cluster *Cluster) GetCloneSourceSecretName() string {
+	return fmt.Sprintf("%v%v", cluster.Name, CloneSourceSecretSuffix)
+}
+
+// GetCloneSource returns the reference to the Cluster being cloned, if the
+// cluster is bootstrapping via clone, with the namespace filled in
+func (cluster *Cluster) GetCloneSource() (ClusterReference, bool) {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Clone == nil {
+		return ClusterReference{}, false
+	}
+
+	source := cluster.Spec.Bootstrap.Clone.Cluster
+	if source.Namespace == "" {
+		source.Namespace = cluster.Namespace
+	}
+	return source, true
+}
+
+// IsCloningViaVolumeSnapshot returns true if the cluster is bootstrapping
+// cloning another Cluster from its volume snapshots
+func (cluster *Cluster) IsCloningViaVolumeSnapshot() bool {
+	return cluster.Spec.Bootstrap != nil &&
+		cluster.Spec.Bootstrap.Clone != nil &&
+		cluster.Spec.Bootstrap.Clone.Method == CloneMethodVolumeSnapshot
+}
+
+// GetCloneSourceExternalCluster builds the external cluster definition used
+// to stream the data directory from the primary of the Cluster being cloned,
+// authenticating as the streaming replication user with the credentials
+// stored in the clone source secret
+func (cluster *Cluster) GetCloneSourceExternalCluster() (ExternalCluster, bool) {
+	source, ok := cluster.GetCloneSource()
+	if !ok {
+		return ExternalCluster{}, false
+	}
+
+	secretName := cluster.GetCloneSourceSecretName()
+	secretKey := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		}
+	}
+
+	return ExternalCluster{
+		Name: fmt.Sprintf("%v%v", source.Name, CloneSourceSecretSuffix),
+		ConnectionParameters: map[string]string{
+			"host":    fmt.Sprintf("%v%v.%v.svc", source.Name, ServiceReadWriteSuffix, source.Namespace),
+			"user":    StreamingReplicationUser,
+			"dbname":  "postgres",
+			"sslmode": "verify-full",
+		},
+		SSLCert:     secretKey(corev1.TLSCertKey),
+		SSLKey:      secretKey(corev1.TLSPrivateKeyKey),
+		SSLRootCert: secretKey("ca.crt"),
+	}, true
+}
+
 // GetServiceAnyName return the name of the service that is used as DNS
 // domain for all the nodes, even if they are not ready
 func (cluster *Cluster) GetServiceAnyName() string {
@@ -973,6 +1152,35 @@ func (cluster *Cluster) ShouldInitDBRunPostInitSQLRefs() bool {
 	return cluster.Spec.Bootstrap.InitDB.PostInitSQLRefs.HasElements()
 }
 
+// GetPostRecoverySQL gets the queries to be executed after the recovery
+// or the pg_basebackup bootstrap, and the references to the SQL files
+// to be executed after them
+func (cluster *Cluster) GetPostRecoverySQL() ([]string, []PostRecoverySQLRefs) {
+	bootstrap := cluster.Spec.Bootstrap
+	switch {
+	case bootstrap == nil:
+		return nil, nil
+	case bootstrap.Recovery != nil:
+		return bootstrap.Recovery.PostRecoverySQL, bootstrap.Recovery.PostRecoverySQLRefs
+	case bootstrap.PgBaseBackup != nil:
+		return bootstrap.PgBaseBackup.PostRecoverySQL, bootstrap.PgBaseBackup.PostRecoverySQLRefs
+	default:
+		return nil, nil
+	}
+}
+
+// ShouldRunPostRecoverySQL returns true if for this cluster, during the
+// bootstrap phase using recovery or pg_basebackup, we need to run SQL
+// queries or files after the promotion
+func (cluster *Cluster) ShouldRunPostRecoverySQL() bool {
+	if cluster.IsReplica() {
+		return false
+	}
+
+	queries, refs := cluster.GetPostRecoverySQL()
+	return len(queries) > 0 || len(refs) > 0
+}
+
 // ShouldInitDBCreateApplicationDatabase returns true if the application database needs to be created during initdb
 // job
 func (cluster *Cluster) ShouldInitDBCreateApplicationDatabase() bool {
@@ -988,6 +1196,220 @@ func (cluster *Cluster) ShouldInitDBCreateApplicationDatabase() bool {
 	return initDBParameters.Owner != "" && initDBParameters.Database != ""
 }
 
+// tuningCoefficients maps each workload profile to the coefficients
+// used by the automatic tuning
+var tuningCoefficients = map[TuningProfile]postgres.TuningCoefficients{
+	TuningProfileOLTP:  {WorkMemDivisor: 4, MaintenanceWorkMemDivisor: 16, MaxParallelWorkersPerGather: 2},
+	TuningProfileMixed: {WorkMemDivisor: 2, MaintenanceWorkMemDivisor: 16, MaxParallelWorkersPerGather: 4},
+	TuningProfileOLAP:  {WorkMemDivisor: 1, MaintenanceWorkMemDivisor: 8, MaxParallelWorkersPerGather: math.MaxInt64},
+}
+
+// GetTunedParameters computes the PostgreSQL parameters derived from the
+// `tuning` profile and the resources of the instances, excluding the ones
+// explicitly set by the user, inline or via `parametersFrom`. The memory
+// is taken from the requests, falling back to the limits, while the CPU
+// count is taken from the limits, falling back to the requests
+func (cluster *Cluster) GetTunedParameters() map[string]string {
+	coefficients, ok := tuningCoefficients[cluster.Spec.PostgresConfiguration.Tuning]
+	if !ok {
+		return nil
+	}
+
+	info := postgres.TuningInfo{
+		Profile: &coefficients,
+	}
+
+	resources := cluster.Spec.Resources
+	if memory := resources.Requests.Memory(); !memory.IsZero() {
+		info.Memory = memory.Value()
+	} else if memory := resources.Limits.Memory(); !memory.IsZero() {
+		info.Memory = memory.Value()
+	}
+
+	if cpu := resources.Limits.Cpu(); !cpu.IsZero() {
+		info.CPUCount = (cpu.MilliValue() + 999) / 1000
+	} else if cpu := resources.Requests.Cpu(); !cpu.IsZero() {
+		info.CPUCount = (cpu.MilliValue() + 999) / 1000
+	}
+
+	if shm := cluster.Spec.EphemeralVolumesSizeLimit.GetShmLimit(); shm != nil {
+		info.SharedMemory = shm.Value()
+	}
+
+	userSettings := cluster.GetPostgresParameters()
+	if maxConnections, err := strconv.ParseInt(userSettings["max_connections"], 10, 64); err == nil {
+		info.MaxConnections = maxConnections
+	}
+	if maxWorkerProcesses, err := strconv.ParseInt(userSettings["max_worker_processes"], 10, 64); err == nil {
+		info.MaxWorkerProcesses = maxWorkerProcesses
+	}
+
+	result := postgres.CreateTunedConfiguration(info)
+	for key := range userSettings {
+		delete(result, key)
+	}
+	for _, source := range cluster.Spec.PostgresConfiguration.ParametersFrom {
+		delete(result, source.Name)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// getConfigurationProfile returns the specification of the configuration
+// profile applied to the cluster, if any
+func (cluster *Cluster) getConfigurationProfile() *PostgresConfigurationProfileSpec {
+	if cluster.Status.ConfigurationProfile == nil {
+		return nil
+	}
+	return &cluster.Status.ConfigurationProfile.Spec
+}
+
+// GetPostgresParameters returns the PostgreSQL parameters of the cluster,
+// layered over the ones of the applied configuration profile. Every
+// parameter set in the cluster takes precedence over the profile: the
+// defaulting webhook doesn't store the global defaults in the spec of a
+// cluster referencing a profile
+func (cluster *Cluster) GetPostgresParameters() map[string]string {
+	parameters := cluster.Spec.PostgresConfiguration.Parameters
+	profile := cluster.getConfigurationProfile()
+	if profile == nil || len(profile.Parameters) == 0 {
+		return parameters
+	}
+
+	result := make(map[string]string, len(parameters)+len(profile.Parameters))
+	maps.Copy(result, profile.Parameters)
+	maps.Copy(result, parameters)
+
+	return result
+}
+
+// GetPgHBA returns the pg_hba rules of the cluster in the order they
+// are applied: the rules of the active break-glass accesses, the `pg_hba`
+// lines, the `hbaRules`, the rules declared by the DatabaseRole objects
+// and the ones of the applied configuration profile.
+// The `hbaRules` that cannot be rendered as a valid line are skipped
+func (cluster *Cluster) GetPgHBA() []string {
+	var rules []string
+	if cluster.Status.HBA != nil {
+		rules = append(rules, cluster.Status.HBA.BreakGlassRules...)
+	}
+
+	rules = append(rules, cluster.Spec.PostgresConfiguration.PgHBA...)
+	for i := range cluster.Spec.PostgresConfiguration.HBARules {
+		rule := &cluster.Spec.PostgresConfiguration.HBARules[i]
+		if rule.Validate() != nil {
+			continue
+		}
+		rules = append(rules, rule.String())
+	}
+
+	if cluster.Status.HBA != nil {
+		rules = append(rules, cluster.Status.HBA.RoleRules...)
+	}
+
+	if profile := cluster.getConfigurationProfile(); profile != nil {
+		rules = append(rules, profile.PgHBA...)
+	}
+
+	return rules
+}
+
+// GetAdditionalLibraries returns the shared preload libraries of the
+// applied configuration profile, followed by the ones of the cluster
+func (cluster *Cluster) GetAdditionalLibraries() []string {
+	libraries := cluster.Spec.PostgresConfiguration.AdditionalLibraries
+	profile := cluster.getConfigurationProfile()
+	if profile == nil || len(profile.AdditionalLibraries) == 0 {
+		return libraries
+	}
+
+	result := slices.Clone(profile.AdditionalLibraries)
+	for _, library := range libraries {
+		if !slices.Contains(result, library) {
+			result = append(result, library)
+		}
+	}
+	return result
+}
+
+// GetCustomQueriesConfigMaps returns the config maps containing the
+// custom monitoring queries of the applied configuration profile,
+// followed by the ones of the cluster
+func (cluster *Cluster) GetCustomQueriesConfigMaps() []ConfigMapKeySelector {
+	var result []ConfigMapKeySelector
+	if profile := cluster.getConfigurationProfile(); profile != nil && profile.Monitoring != nil {
+		result = append(result, profile.Monitoring.CustomQueriesConfigMap...)
+	}
+	if cluster.Spec.Monitoring != nil {
+		result = append(result, cluster.Spec.Monitoring.CustomQueriesConfigMap...)
+	}
+	return result
+}
+
+// GetCustomQueriesSecrets returns the secrets containing the custom
+// monitoring queries of the applied configuration profile, followed by
+// the ones of the cluster
+func (cluster *Cluster) GetCustomQueriesSecrets() []SecretKeySelector {
+	var result []SecretKeySelector
+	if profile := cluster.getConfigurationProfile(); profile != nil && profile.Monitoring != nil {
+		result = append(result, profile.Monitoring.CustomQueriesSecret...)
+	}
+	if cluster.Spec.Monitoring != nil {
+		result = append(result, cluster.Spec.Monitoring.CustomQueriesSecret...)
+	}
+	return result
+}
+
+// getProfileMonitoring returns the monitoring configuration of the
+// applied configuration profile, if any
+func (cluster *Cluster) getProfileMonitoring() *ConfigurationProfileMonitoring {
+	if profile := cluster.getConfigurationProfile(); profile != nil {
+		return profile.Monitoring
+	}
+	return nil
+}
+
+// GetInstanceParameterOverrides merges the parameters of the
+// `instanceOverrides` matching the instance with the passed name and
+// labels. Parameters that cannot be overridden are ignored
+func (cluster *Cluster) GetInstanceParameterOverrides(
+	instanceName string,
+	instanceLabels map[string]string,
+) map[string]string {
+	var result map[string]string
+	for _, override := range cluster.Spec.PostgresConfiguration.InstanceOverrides {
+		if !override.Matches(instanceName, instanceLabels) {
+			continue
+		}
+
+		for key, value := range override.Parameters {
+			if _, isFixed := postgres.FixedConfigurationParameters[key]; isFixed ||
+				postgres.HotStandbySensitiveParameters[key] {
+				continue
+			}
+			if result == nil {
+				result = make(map[string]string)
+			}
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// GetBootstrapPgBaseBackupOptions gets the options of the pg_basebackup
+// bootstrapping the cluster, if any
+func (cluster *Cluster) GetBootstrapPgBaseBackupOptions() *PgBaseBackupOptions {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.PgBaseBackup == nil {
+		return nil
+	}
+
+	return &cluster.Spec.Bootstrap.PgBaseBackup.PgBaseBackupOptions
+}
+
 // ShouldPgBaseBackupCreateApplicationDatabase returns true if the application database needs to be created during the
 // pg_basebackup job
 func (cluster *Cluster) ShouldPgBaseBackupCreateApplicationDatabase() bool {
@@ -1184,6 +1606,9 @@ func (cluster *Cluster) UsesSecret(secret string) bool {
 	if _, ok := cluster.Status.SecretsResourceVersion.Metrics[secret]; ok {
 		return true
 	}
+	if _, ok := cluster.Status.SecretsResourceVersion.Parameters[secret]; ok {
+		return true
+	}
 	certificates := cluster.Status.Certificates
 	switch secret {
 	case cluster.GetSuperuserSecretName(),
@@ -1221,6 +1646,9 @@ func (cluster *Cluster) UsesConfigMap(config string) (ok bool) {
 	if _, ok := cluster.Status.ConfigMapResourceVersion.Metrics[config]; ok {
 		return true
 	}
+	if _, ok := cluster.Status.ConfigMapResourceVersion.Parameters[config]; ok {
+		return true
+	}
 	return false
 }
 
@@ -1239,6 +1667,10 @@ func (cluster *Cluster) IsMetricsTLSEnabled() bool {
 		return cluster.Spec.Monitoring.TLSConfig.Enabled
 	}
 
+	if profile := cluster.getProfileMonitoring(); profile != nil && profile.TLSConfig != nil {
+		return profile.TLSConfig.Enabled
+	}
+
 	return false
 }
 
@@ -1250,6 +1682,10 @@ func (cluster *Cluster) GetMetricsQueriesTTL() metav1.Duration {
 		return *cluster.Spec.Monitoring.MetricsQueriesTTL
 	}
 
+	if profile := cluster.getProfileMonitoring(); profile != nil && profile.MetricsQueriesTTL != nil {
+		return *profile.MetricsQueriesTTL
+	}
+
 	return metav1.Duration{
 		Duration: 30 * time.Second,
 	}
@@ -1342,6 +1778,19 @@ func (cluster *Cluster) ShouldForceLegacyBackup() bool {
 	return cluster.Annotations[utils.LegacyBackupAnnotationName] == "true"
 }
 
+// IsMajorUpgradeRollbackPoint checks if the Backup with the passed name
+// is the one taken before the latest in-place major upgrade
+func (cluster *Cluster) IsMajorUpgradeRollbackPoint(backupName string) bool {
+	return cluster.Status.MajorUpgradeRollbackPoint != nil &&
+		cluster.Status.MajorUpgradeRollbackPoint.BackupName == backupName
+}
+
+// IsMajorUpgradeRollbackRequested checks if the user requested to restore
+// the cluster from the backup taken before the latest in-place major upgrade
+func (cluster *Cluster) IsMajorUpgradeRollbackRequested() bool {
+	return cluster.Annotations[utils.MajorUpgradeRollbackAnnotationName] != ""
+}
+
 // GetSeccompProfile return the proper SeccompProfile set in the cluster for Pods and Containers
 func (cluster *Cluster) GetSeccompProfile() *corev1.SeccompProfile {
 	if cluster.Spec.SeccompProfile != nil {
@@ -1599,6 +2048,17 @@ func (p *ProbeWithStrategy) ApplyInto(k8sProbe *corev1.Probe) {
 	p.Probe.ApplyInto(k8sProbe)
 }
 
+// IsArchivingWAL checks if the cluster archives its WAL files, either
+// through Barman Cloud or through a plugin
+func (cluster *Cluster) IsArchivingWAL() bool {
+	if utils.IsWalArchivingDisabled(&cluster.ObjectMeta) {
+		return false
+	}
+
+	return (cluster.Spec.Backup != nil && cluster.Spec.Backup.BarmanObjectStore != nil) ||
+		cluster.GetEnabledWALArchivePluginName() != ""
+}
+
 // GetEnabledWALArchivePluginName returns the name of the enabled backup plugin or an empty string
 // if no backup plugin is enabled
 func (cluster *Cluster) GetEnabledWALArchivePluginName() string {
@@ -1658,6 +2118,40 @@ func (cluster *Cluster) IsInitialized() bool {
 	return c.Status == metav1.ConditionTrue
 }
 
+// IsRefreshInProgress checks if the data of the cluster is being
+// refreshed from a backup
+func (cluster *Cluster) IsRefreshInProgress() bool {
+	return cluster.Status.Refresh != nil
+}
+
+// GetBootstrapCluster returns the cluster definition to be used to
+// bootstrap the first instance. While a refresh is in progress, the
+// bootstrap section is replaced with the recovery from the backup
+// chosen for the refresh, keeping the application database and owner.
+func (cluster *Cluster) GetBootstrapCluster() *Cluster {
+	refresh := cluster.Status.Refresh
+	if refresh == nil {
+		return cluster
+	}
+
+	recovery := &BootstrapRecovery{
+		Backup: &BackupSource{
+			LocalObjectReference: LocalObjectReference{Name: refresh.BackupName},
+		},
+		Database:            cluster.GetApplicationDatabaseName(),
+		Owner:               cluster.GetApplicationDatabaseOwner(),
+		PostRecoverySQL:     refresh.PostRecoverySQL,
+		PostRecoverySQLRefs: refresh.PostRecoverySQLRefs,
+	}
+	if secretName := cluster.GetApplicationSecretName(); secretName != cluster.Name+ApplicationUserSecretSuffix {
+		recovery.Secret = &LocalObjectReference{Name: secretName}
+	}
+
+	result := cluster.DeepCopy()
+	result.Spec.Bootstrap = &BootstrapConfiguration{Recovery: recovery}
+	return result
+}
+
 // SetAdmissionError sets the admission error status on the Cluster resource
 func (cluster *Cluster) SetAdmissionError(msg string) {
 	if len(msg) > 0 {
@@ -307,6 +307,312 @@ var _ = Describe("Bootstrap via pg_basebackup", func() {
 	})
 })
 
+var _ = Describe("post-recovery SQL", func() {
+	It("is not run when no bootstrap is defined", func() {
+		cluster := Cluster{}
+		queries, refs := cluster.GetPostRecoverySQL()
+		Expect(queries).To(BeEmpty())
+		Expect(refs).To(BeEmpty())
+		Expect(cluster.ShouldRunPostRecoverySQL()).To(BeFalse())
+	})
+
+	It("is taken from the recovery section", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					Recovery: &BootstrapRecovery{
+						PostRecoverySQL: []string{"SELECT 1"},
+					},
+				},
+			},
+		}
+		queries, refs := cluster.GetPostRecoverySQL()
+		Expect(queries).To(ConsistOf("SELECT 1"))
+		Expect(refs).To(BeEmpty())
+		Expect(cluster.ShouldRunPostRecoverySQL()).To(BeTrue())
+	})
+
+	It("is taken from the pg_basebackup section", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					PgBaseBackup: &BootstrapPgBaseBackup{
+						PostRecoverySQLRefs: []PostRecoverySQLRefs{
+							{
+								Database: "app",
+								SQLRefs: SQLRefs{
+									SecretRefs: []SecretKeySelector{
+										{LocalObjectReference: LocalObjectReference{Name: "mask"}, Key: "mask.sql"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		queries, refs := cluster.GetPostRecoverySQL()
+		Expect(queries).To(BeEmpty())
+		Expect(refs).To(HaveLen(1))
+		Expect(cluster.ShouldRunPostRecoverySQL()).To(BeTrue())
+	})
+
+	It("is not run in replica clusters", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				ReplicaCluster: &ReplicaClusterConfiguration{
+					Enabled: ptr.To(true),
+					Source:  "origin",
+				},
+				Bootstrap: &BootstrapConfiguration{
+					Recovery: &BootstrapRecovery{
+						Source:          "origin",
+						PostRecoverySQL: []string{"SELECT 1"},
+					},
+				},
+			},
+		}
+		Expect(cluster.ShouldRunPostRecoverySQL()).To(BeFalse())
+	})
+})
+
+var _ = Describe("GetTunedParameters", func() {
+	It("returns nil without a tuning profile", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		}
+		Expect(cluster.GetTunedParameters()).To(BeNil())
+	})
+
+	It("computes the parameters from the resources", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLTP,
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourceCPU:    resource.MustParse("2"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+						corev1.ResourceCPU:    resource.MustParse("3500m"),
+					},
+				},
+			},
+		}
+		parameters := cluster.GetTunedParameters()
+		Expect(parameters).To(HaveKeyWithValue("shared_buffers", "1024MB"))
+		Expect(parameters).To(HaveKeyWithValue("effective_cache_size", "3072MB"))
+		Expect(parameters).To(HaveKeyWithValue("max_parallel_workers", "4"))
+	})
+
+	It("uses max_connections and the shared memory limit", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLAP,
+					Parameters: map[string]string{
+						"max_connections": "10",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+						corev1.ResourceCPU:    resource.MustParse("2"),
+					},
+				},
+				EphemeralVolumesSizeLimit: &EphemeralVolumesSizeLimitConfiguration{
+					Shm: ptr.To(resource.MustParse("64Mi")),
+				},
+			},
+		}
+		parameters := cluster.GetTunedParameters()
+		Expect(parameters).To(HaveKeyWithValue("max_parallel_workers_per_gather", "1"))
+		Expect(parameters).To(HaveKeyWithValue("work_mem", "16384kB"))
+	})
+
+	It("excludes the parameters set by the user", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileMixed,
+					Parameters: map[string]string{
+						"shared_buffers": "512MB",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		}
+		parameters := cluster.GetTunedParameters()
+		Expect(parameters).ToNot(HaveKey("shared_buffers"))
+		Expect(parameters).To(HaveKey("effective_cache_size"))
+	})
+
+	It("keeps the explicit values matching the CloudNativePG defaults", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Tuning: TuningProfileOLTP,
+					Parameters: map[string]string{
+						"max_parallel_workers": "32",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+				},
+			},
+		}
+		Expect(cluster.GetTunedParameters()).ToNot(HaveKey("max_parallel_workers"))
+	})
+})
+
+var _ = Describe("GetInstanceParameterOverrides", func() {
+	cluster := Cluster{
+		Spec: ClusterSpec{
+			PostgresConfiguration: PostgresConfiguration{
+				InstanceOverrides: []InstanceParametersOverride{
+					{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"workload": "reporting"},
+						},
+						Parameters: map[string]string{
+							"work_mem":        "64MB",
+							"max_connections": "500",
+						},
+					},
+					{
+						InstanceName: "cluster-example-3",
+						Parameters: map[string]string{
+							"work_mem":                   "128MB",
+							"log_min_duration_statement": "0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	It("returns nil when no override matches", func() {
+		Expect(cluster.GetInstanceParameterOverrides("cluster-example-1", nil)).To(BeNil())
+	})
+
+	It("applies the overrides matching the labels", func() {
+		Expect(cluster.GetInstanceParameterOverrides(
+			"cluster-example-2",
+			map[string]string{"workload": "reporting"},
+		)).To(Equal(map[string]string{"work_mem": "64MB"}))
+	})
+
+	It("gives precedence to the later overrides", func() {
+		Expect(cluster.GetInstanceParameterOverrides(
+			"cluster-example-3",
+			map[string]string{"workload": "reporting"},
+		)).To(Equal(map[string]string{
+			"work_mem":                   "128MB",
+			"log_min_duration_statement": "0",
+		}))
+	})
+})
+
+var _ = Describe("GetBootstrapPgBaseBackupOptions", func() {
+	It("returns nil when the cluster is not bootstrapped via pg_basebackup", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					InitDB: &BootstrapInitDB{},
+				},
+			},
+		}
+		Expect(cluster.GetBootstrapPgBaseBackupOptions()).To(BeNil())
+		Expect((&Cluster{}).GetBootstrapPgBaseBackupOptions()).To(BeNil())
+	})
+
+	It("returns the options of the pg_basebackup bootstrap", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					PgBaseBackup: &BootstrapPgBaseBackup{
+						Source: "origin",
+						PgBaseBackupOptions: PgBaseBackupOptions{
+							MaxRate:         "100M",
+							VerifyChecksums: ptr.To(false),
+						},
+					},
+				},
+			},
+		}
+		options := cluster.GetBootstrapPgBaseBackupOptions()
+		Expect(options).ToNot(BeNil())
+		Expect(options.MaxRate).To(Equal("100M"))
+		Expect(options.VerifyChecksums).To(HaveValue(BeFalse()))
+	})
+})
+
+var _ = Describe("GetBootstrapCluster", func() {
+	var cluster *Cluster
+
+	BeforeEach(func() {
+		cluster = &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+			Spec: ClusterSpec{
+				Bootstrap: &BootstrapConfiguration{
+					InitDB: &BootstrapInitDB{Database: "app", Owner: "app"},
+				},
+			},
+		}
+	})
+
+	It("returns the cluster itself when no refresh is in progress", func() {
+		Expect(cluster.IsRefreshInProgress()).To(BeFalse())
+		Expect(cluster.GetBootstrapCluster()).To(BeIdenticalTo(cluster))
+	})
+
+	It("bootstraps from the refresh backup while a refresh is in progress", func() {
+		cluster.Status.Refresh = &ClusterRefreshRequest{
+			Name:            "nightly",
+			BackupName:      "production-backup",
+			PostRecoverySQL: []string{"SELECT 1"},
+		}
+
+		bootstrapCluster := cluster.GetBootstrapCluster()
+		Expect(cluster.IsRefreshInProgress()).To(BeTrue())
+		Expect(cluster.Spec.Bootstrap.InitDB).ToNot(BeNil())
+		Expect(bootstrapCluster.Spec.Bootstrap.InitDB).To(BeNil())
+		Expect(utils.IsEmptyWalArchiveCheckEnabled(&bootstrapCluster.ObjectMeta)).To(BeTrue())
+		Expect(bootstrapCluster.Spec.Bootstrap.Recovery).To(Equal(&BootstrapRecovery{
+			Backup: &BackupSource{
+				LocalObjectReference: LocalObjectReference{Name: "production-backup"},
+			},
+			Database:        "app",
+			Owner:           "app",
+			PostRecoverySQL: []string{"SELECT 1"},
+		}))
+	})
+
+	It("keeps the application secret provided by the user", func() {
+		cluster.Spec.Bootstrap.InitDB.Secret = &LocalObjectReference{Name: "my-app-secret"}
+		cluster.Status.Refresh = &ClusterRefreshRequest{Name: "nightly", BackupName: "production-backup"}
+
+		bootstrapCluster := cluster.GetBootstrapCluster()
+		Expect(bootstrapCluster.Spec.Bootstrap.Recovery.Secret).To(Equal(&LocalObjectReference{Name: "my-app-secret"}))
+		Expect(bootstrapCluster.GetApplicationSecretName()).To(Equal("my-app-secret"))
+	})
+})
+
 var _ = Describe("default UID/GID", func() {
 	It("will use 26/26 if not specified", func() {
 		cluster := Cluster{}
@@ -774,6 +1080,63 @@ var _ = Describe("A config map resource version", func() {
 		found := cluster.UsesConfigMap("a-configmap")
 		Expect(found).To(BeTrue())
 	})
+
+	It("contains the configmap holding configuration parameters", func() {
+		cluster := Cluster{
+			Status: ClusterStatus{
+				ConfigMapResourceVersion: ConfigMapResourceVersion{
+					Parameters: map[string]string{"tuning": "test-version"},
+				},
+			},
+		}
+		Expect(cluster.UsesConfigMap("tuning")).To(BeTrue())
+	})
+})
+
+var _ = Describe("parametersFrom references", func() {
+	cluster := Cluster{
+		Spec: ClusterSpec{
+			PostgresConfiguration: PostgresConfiguration{
+				ParametersFrom: []ParameterSource{
+					{
+						Name: "pgaudit.role",
+						SecretKeyRef: &SecretKeySelector{
+							LocalObjectReference: LocalObjectReference{Name: "audit"},
+							Key:                  "role",
+						},
+					},
+					{
+						Name: "work_mem",
+						ConfigMapKeyRef: &ConfigMapKeySelector{
+							LocalObjectReference: LocalObjectReference{Name: "tuning"},
+							Key:                  "work_mem",
+						},
+					},
+					{
+						Name: "maintenance_work_mem",
+						ConfigMapKeyRef: &ConfigMapKeySelector{
+							LocalObjectReference: LocalObjectReference{Name: "tuning"},
+							Key:                  "maintenance_work_mem",
+						},
+					},
+				},
+			},
+		},
+		Status: ClusterStatus{
+			SecretsResourceVersion: SecretsResourceVersion{
+				Parameters: map[string]string{"audit": "1"},
+			},
+		},
+	}
+
+	It("lists the referenced secrets and config maps", func() {
+		Expect(cluster.GetParametersFromSecrets().ToList()).To(ConsistOf("audit"))
+		Expect(cluster.GetParametersFromConfigMaps().ToList()).To(ConsistOf("tuning"))
+	})
+
+	It("detects the usage of the referenced secrets", func() {
+		Expect(cluster.UsesSecret("audit")).To(BeTrue())
+	})
 })
 
 var _ = Describe("PostgreSQL version detection", func() {
@@ -2039,3 +2402,62 @@ var _ = Describe("FailureDomainKeys", func() {
 		Expect(sync.FailureDomainKeys()).To(Equal([]string{"topology.kubernetes.io/zone"}))
 	})
 })
+
+var _ = Describe("Clone bootstrap", func() {
+	cluster := Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "staging"},
+		Spec: ClusterSpec{
+			Bootstrap: &BootstrapConfiguration{
+				Clone: &BootstrapClone{
+					Cluster: ClusterReference{Name: "source"},
+				},
+			},
+		},
+	}
+
+	It("defaults the namespace of the source to the one of the cluster", func() {
+		source, ok := cluster.GetCloneSource()
+		Expect(ok).To(BeTrue())
+		Expect(source).To(Equal(ClusterReference{Name: "source", Namespace: "staging"}))
+	})
+
+	It("connects to the primary of the source with the clone source credentials", func() {
+		server, ok := cluster.GetCloneSourceExternalCluster()
+		Expect(ok).To(BeTrue())
+		Expect(server.ConnectionParameters).To(HaveKeyWithValue("host", "source-rw.staging.svc"))
+		Expect(server.ConnectionParameters).To(HaveKeyWithValue("user", StreamingReplicationUser))
+		Expect(server.ConnectionParameters).To(HaveKeyWithValue("sslmode", "verify-full"))
+		Expect(server.SSLCert.Name).To(Equal("clone-clone-source"))
+		Expect(server.SSLKey.Key).To(Equal(corev1.TLSPrivateKeyKey))
+		Expect(server.SSLRootCert.Key).To(Equal("ca.crt"))
+	})
+
+	It("is not defined when the cluster is not cloning", func() {
+		_, ok := (&Cluster{}).GetCloneSourceExternalCluster()
+		Expect(ok).To(BeFalse())
+		Expect((&Cluster{}).IsCloningViaVolumeSnapshot()).To(BeFalse())
+	})
+})
+
+var _ = Describe("ClusterCloneGrant", func() {
+	grants := ClusterCloneGrantList{
+		Items: []ClusterCloneGrant{
+			{
+				Spec: ClusterCloneGrantSpec{
+					Cluster:    LocalObjectReference{Name: "source"},
+					Namespaces: []string{"staging", "development"},
+				},
+			},
+		},
+	}
+
+	It("allows the listed namespaces to clone the referenced cluster", func() {
+		Expect(grants.Allows("source", "staging")).To(BeTrue())
+		Expect(grants.Allows("source", "development")).To(BeTrue())
+	})
+
+	It("doesn't allow other namespaces or other clusters", func() {
+		Expect(grants.Allows("source", "production")).To(BeFalse())
+		Expect(grants.Allows("other", "staging")).To(BeFalse())
+	})
+})
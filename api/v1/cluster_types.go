@@ -36,6 +36,11 @@ const (
 	// get the name of the generated replication secret for PostgreSQL
 	ReplicationSecretSuffix = "-replication" // #nosec
 
+	// CloneSourceSecretSuffix is the suffix appended to the cluster name to
+	// get the name of the secret containing the credentials used to clone
+	// the Cluster referenced in the clone bootstrap section
+	CloneSourceSecretSuffix = "-clone-source" // #nosec
+
 	// SuperUserSecretSuffix is the suffix appended to the cluster name to
 	// get the name of the PostgreSQL superuser secret
 	SuperUserSecretSuffix = "-superuser"
@@ -205,6 +210,14 @@ type ImageCatalogRef struct {
 	Major int `json:"major"`
 }
 
+// ConfigurationProfileRef defines the reference to a PostgreSQL
+// configuration profile
+type ConfigurationProfileRef struct {
+	// +kubebuilder:validation:XValidation:rule="self.kind == 'PostgresConfigurationProfile' || self.kind == 'ClusterPostgresConfigurationProfile'",message="Only configuration profiles are supported"
+	// +kubebuilder:validation:XValidation:rule="self.apiGroup == 'postgresql.cnpg.io'",message="Only configuration profiles are supported"
+	corev1.TypedLocalObjectReference `json:",inline"`
+}
+
 // +kubebuilder:validation:XValidation:rule="!(has(self.imageCatalogRef) && has(self.imageName))",message="imageName and imageCatalogRef are mutually exclusive"
 
 // ClusterSpec defines the desired state of a PostgreSQL cluster managed by
@@ -278,6 +291,12 @@ type ClusterSpec struct {
 	// +optional
 	PostgresConfiguration PostgresConfiguration `json:"postgresql,omitempty"`
 
+	// The configuration profile providing the baseline PostgreSQL
+	// configuration of the cluster, which is layered under the one
+	// defined in the `postgresql` section
+	// +optional
+	ConfigurationProfileRef *ConfigurationProfileRef `json:"configurationProfileRef,omitempty"`
+
 	// PodSelectorRefs defines named pod label selectors that can be referenced
 	// in pg_hba rules using the ${podselector:NAME} syntax in the address field.
 	// The operator resolves matching pod IPs and the instance manager expands
@@ -292,6 +311,11 @@ type ClusterSpec struct {
 	// +optional
 	ReplicationSlots *ReplicationSlotsConfiguration `json:"replicationSlots,omitempty"`
 
+	// The options of the `pg_basebackup` copying the data directory of
+	// the primary when a new replica joins the cluster
+	// +optional
+	Join *PgBaseBackupOptions `json:"join,omitempty"`
+
 	// Instructions to bootstrap this cluster
 	// +optional
 	Bootstrap *BootstrapConfiguration `json:"bootstrap,omitempty"`
@@ -713,6 +737,10 @@ const (
 	// PhaseMajorUpgrade major version upgrade in process
 	PhaseMajorUpgrade = "Upgrading Postgres major version"
 
+	// PhaseMajorUpgradeRollback major version upgrade being rolled back
+	// from the pre-upgrade volume snapshot backup
+	PhaseMajorUpgradeRollback = "Rolling back Postgres major version upgrade"
+
 	// PhaseUpgradeDelayed is set when a cluster needs to be upgraded,
 	// but the operation is being delayed by the operator configuration
 	PhaseUpgradeDelayed = "Cluster upgrade delayed"
@@ -740,6 +768,11 @@ const (
 	// apply because of an invalid or incomplete catalog
 	PhaseImageCatalogError = "Cluster has incomplete or invalid image catalog"
 
+	// PhaseConfigurationProfileError is triggered when the cluster cannot
+	// apply the referenced configuration profile because it is missing
+	// or invalid
+	PhaseConfigurationProfileError = "Cluster has a missing or invalid configuration profile"
+
 	// PhaseUnrecoverable for an unrecoverable cluster
 	PhaseUnrecoverable = "Cluster is unrecoverable and needs manual intervention"
 
@@ -764,6 +797,15 @@ const (
 
 	// PhaseDefinitionInvalid is set when the cluster definition is invalid
 	PhaseDefinitionInvalid = "Invalid cluster definition"
+
+	// PhaseWaitingForCloneSource is set by the operator when the Cluster
+	// referenced in the clone bootstrap section cannot be cloned yet
+	PhaseWaitingForCloneSource = "Waiting for the clone source"
+
+	// PhaseRefreshing is set by the operator while the data of the cluster
+	// is being replaced with the content of a backup, as requested by a
+	// ClusterRefresh
+	PhaseRefreshing = "Refreshing the data from a backup"
 )
 
 // EphemeralVolumesSizeLimitConfiguration contains the configuration of the ephemeral
@@ -833,6 +875,75 @@ type PasswordState struct {
 	// the resource version of the password secret
 	// +optional
 	SecretResourceVersion string `json:"resourceVersion,omitempty"`
+	// the state of the automatic password rotation, when enabled
+	// +optional
+	Rotation *PasswordRotationState `json:"rotation,omitempty"`
+}
+
+// PasswordRotationStrategy is the way the operator keeps two valid
+// credentials of a role during a password rotation
+// +enum
+type PasswordRotationStrategy string
+
+const (
+	// PasswordRotationStrategyNextRole stores the credentials of the login in
+	// use in the password secret, alternating the role and its `<role>_next`
+	// login at every rotation
+	PasswordRotationStrategyNextRole PasswordRotationStrategy = "nextRole"
+
+	// PasswordRotationStrategyAlternateSecrets stores the credentials of the
+	// role in the password secret and the ones of its `<role>_next` login in
+	// the alternate password secret, rotating them in turn
+	PasswordRotationStrategyAlternateSecrets PasswordRotationStrategy = "alternateSecrets"
+)
+
+// PasswordRotationNextLoginSuffix is the suffix of the name of the login
+// created next to a role whose password is rotated
+const PasswordRotationNextLoginSuffix = "_next"
+
+// PasswordRotationConfiguration is the policy to automatically rotate the
+// password of a role
+type PasswordRotationConfiguration struct {
+	// Interval between two password rotations, e.g. `720h`
+	Interval metav1.Duration `json:"interval"`
+
+	// How long the credentials in use before a rotation stay valid after
+	// it. Defaults to `24h` and must be shorter than the interval
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// The way the two credentials of the role are exposed during the
+	// rotation: `nextRole` (default) or `alternateSecrets`
+	// +kubebuilder:validation:Enum=nextRole;alternateSecrets
+	// +kubebuilder:default:=nextRole
+	// +optional
+	Strategy PasswordRotationStrategy `json:"strategy,omitempty"`
+
+	// Secret containing the credentials of the `<role>_next` login, required
+	// by the `alternateSecrets` strategy
+	// +optional
+	AlternatePasswordSecret *LocalObjectReference `json:"alternatePasswordSecret,omitempty"`
+}
+
+// PasswordRotationState is the state of the automatic password rotation of a role
+type PasswordRotationState struct {
+	// The login whose credentials are currently in use, either the
+	// role or its `<role>_next` login
+	// +optional
+	ActiveLogin string `json:"activeLogin,omitempty"`
+
+	// The time of the last password rotation
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// The time of the next password rotation
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// The time until which the credentials in use before the last
+	// rotation stay valid
+	// +optional
+	GracePeriodEnd *metav1.Time `json:"gracePeriodEnd,omitempty"`
 }
 
 // ManagedRoles tracks the status of a cluster's managed roles
@@ -851,6 +962,11 @@ type ManagedRoles struct {
 	// PasswordStatus gives the last transaction id and password secret version for each managed role
 	// +optional
 	PasswordStatus map[string]PasswordState `json:"passwordStatus,omitempty"`
+
+	// Parameters gives the role-level configuration parameters applied
+	// to each managed role
+	// +optional
+	Parameters map[string]map[string]string `json:"parameters,omitempty"`
 }
 
 // TablespaceState represents the state of a tablespace in a cluster
@@ -931,6 +1047,11 @@ type ClusterStatus struct {
 	// +listMapKey=name
 	PodSelectorRefs []PodSelectorRefStatus `json:"podSelectorRefs,omitempty"`
 
+	// HBA reports the effective pg_hba.conf of the cluster, together with
+	// the rules declared by the DatabaseRole objects and the rejected ones
+	// +optional
+	HBA *HBAStatus `json:"hba,omitempty"`
+
 	// The timeline of the Postgres cluster
 	// +optional
 	TimelineID int `json:"timelineID,omitempty"`
@@ -1115,6 +1236,43 @@ type ClusterStatus struct {
 	// +optional
 	TargetPGDataImageInfo *ImageInfo `json:"targetPgDataImageInfo,omitempty"`
 
+	// MajorUpgradeRollbackPoint contains the details of the volume snapshot
+	// backup taken before the latest in-place major upgrade, which can be
+	// used to restore the data directory of the previous major version.
+	// +optional
+	MajorUpgradeRollbackPoint *MajorUpgradeRollbackPoint `json:"majorUpgradeRollbackPoint,omitempty"`
+
+	// MajorUpgradeFinalization contains the progress of the maintenance
+	// operations executed on the primary instance after the latest
+	// in-place major upgrade
+	// +optional
+	MajorUpgradeFinalization *MajorUpgradeFinalizationStatus `json:"majorUpgradeFinalization,omitempty"`
+
+	// Refresh contains the details of the refresh of the data of the
+	// cluster in progress, as requested by a ClusterRefresh
+	// +optional
+	Refresh *ClusterRefreshRequest `json:"refresh,omitempty"`
+
+	// InstanceParameters contains the PostgreSQL parameters overridden
+	// on each instance by `instanceOverrides`
+	// +optional
+	InstanceParameters map[PodName]map[string]string `json:"instanceParameters,omitempty"`
+
+	// TunedParameters contains the PostgreSQL parameters computed by
+	// the `tuning` profile and not overridden in `parameters`
+	// +optional
+	TunedParameters map[string]string `json:"tunedParameters,omitempty"`
+
+	// ConfigurationProfile contains the version of the configuration
+	// profile applied to the cluster
+	// +optional
+	ConfigurationProfile *AppliedConfigurationProfile `json:"configurationProfile,omitempty"`
+
+	// BaseBackupProgress contains the progress of the `pg_basebackup`
+	// copying the data directory of the instances being created
+	// +optional
+	BaseBackupProgress map[PodName]BaseBackupProgress `json:"baseBackupProgress,omitempty"`
+
 	// PluginStatus is the status of the loaded plugins
 	// +optional
 	PluginStatus []PluginStatus `json:"pluginStatus,omitempty"`
@@ -1146,6 +1304,94 @@ type ImageInfo struct {
 	Extensions []ExtensionConfiguration `json:"extensions,omitempty"`
 }
 
+// MajorUpgradeRollbackPoint contains the information about the volume
+// snapshot backup taken before an in-place major upgrade
+type MajorUpgradeRollbackPoint struct {
+	// BackupName is the name of the Backup taken before the upgrade
+	BackupName string `json:"backupName"`
+
+	// PGDataImageInfo contains the details of the image that was running
+	// on the data directory when the backup was taken
+	PGDataImageInfo ImageInfo `json:"pgDataImageInfo"`
+
+	// TargetMajorVersion is the major version the cluster was being
+	// upgraded to when the backup was taken
+	TargetMajorVersion int `json:"targetMajorVersion"`
+}
+
+// AppliedConfigurationProfile contains the version of a configuration
+// profile applied to a cluster
+type AppliedConfigurationProfile struct {
+	// Kind is the kind of the configuration profile
+	Kind string `json:"kind"`
+
+	// Name is the name of the configuration profile
+	Name string `json:"name"`
+
+	// Generation is the generation of the configuration profile
+	// applied to the cluster
+	Generation int64 `json:"generation"`
+
+	// Spec is the applied specification of the configuration profile
+	Spec PostgresConfigurationProfileSpec `json:"spec"`
+}
+
+// ClusterRefreshRequest contains the information about a refresh of the
+// data of a cluster from a backup
+type ClusterRefreshRequest struct {
+	// Name is the name of the ClusterRefresh that requested the refresh
+	Name string `json:"name"`
+
+	// BackupName is the name of the Backup the data is restored from
+	BackupName string `json:"backupName"`
+
+	// ApplicationCredentials tells whether the password of the
+	// application user needs to be rotated
+	// +optional
+	ApplicationCredentials ClusterRefreshCredentialsPolicy `json:"applicationCredentials,omitempty"`
+
+	// PostRecoverySQL is the list of SQL queries to be executed after
+	// the data has been restored
+	// +optional
+	PostRecoverySQL []string `json:"postRecoverySQL,omitempty"`
+
+	// PostRecoverySQLRefs is the list of references to SQL files to be
+	// executed after the data has been restored
+	// +optional
+	PostRecoverySQLRefs []PostRecoverySQLRefs `json:"postRecoverySQLRefs,omitempty"`
+
+	// DataRemoved is true once the instances and the volumes holding
+	// the previous data have been removed
+	// +optional
+	DataRemoved bool `json:"dataRemoved,omitempty"`
+}
+
+// MajorUpgradeFinalizationStatus contains the progress of the maintenance
+// operations executed after an in-place major upgrade, that is the
+// statistics rebuild with `vacuumdb --analyze-in-stages` and the update
+// of the installed extensions in every database
+type MajorUpgradeFinalizationStatus struct {
+	// MajorVersion is the major version the cluster has been upgraded to
+	MajorVersion int `json:"majorVersion"`
+
+	// TotalDatabases is the number of databases to be processed
+	// +optional
+	TotalDatabases int `json:"totalDatabases,omitempty"`
+
+	// CompletedDatabases is the list of databases that have
+	// already been processed
+	// +optional
+	CompletedDatabases []string `json:"completedDatabases,omitempty"`
+
+	// CurrentDatabase is the database being processed
+	// +optional
+	CurrentDatabase string `json:"currentDatabase,omitempty"`
+
+	// CompletedAt is the time when the maintenance operations completed
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
 // SwitchReplicaClusterStatus contains all the statuses regarding the switch of a cluster to a replica cluster
 type SwitchReplicaClusterStatus struct {
 	// InProgress indicates if there is an ongoing procedure of switching a cluster to a replica cluster.
@@ -1190,6 +1436,21 @@ const (
 	// or .spec.postgresql.synchronous.nodeFailureDomainKeys.
 	// Only set when one of those fields is configured.
 	ConditionSyncReplicationTopologySatisfied ClusterConditionType = "SyncReplicationTopologySatisfied"
+
+	// ConditionUpgradeFinalizing is True from the completion of an in-place
+	// major upgrade until the post-upgrade maintenance operations have been
+	// executed on every database.
+	ConditionUpgradeFinalizing ClusterConditionType = "UpgradeFinalizing"
+
+	// ConditionConfigurationApplied is True when every instance is running
+	// the PostgreSQL configuration declared in the cluster, without
+	// parameters pending restart or overridden via ALTER SYSTEM.
+	ConditionConfigurationApplied ClusterConditionType = "ConfigurationApplied"
+
+	// ConditionParametersFromAvailable is True when every ConfigMap and
+	// Secret key referenced by .spec.postgresql.parametersFrom exists.
+	// Only set when parametersFrom is configured.
+	ConditionParametersFromAvailable ClusterConditionType = "ParametersFromAvailable"
 )
 
 // ConditionStatus defines conditions of resources
@@ -1259,6 +1520,46 @@ const (
 	// are set but no synchronous replica in a different failure domain than
 	// the primary exists.
 	ConditionReasonInsufficientCrossDomainReplicas ConditionReason = "InsufficientCrossDomainReplicas"
+
+	// ConditionReasonUpgradeFinalizationInProgress means the post-upgrade
+	// maintenance operations are pending or running
+	ConditionReasonUpgradeFinalizationInProgress ConditionReason = "UpgradeFinalizationInProgress"
+
+	// ConditionReasonUpgradeFinalizationFailed means the post-upgrade
+	// maintenance operations failed, and will be retried
+	ConditionReasonUpgradeFinalizationFailed ConditionReason = "UpgradeFinalizationFailed"
+
+	// ConditionReasonUpgradeFinalizationCompleted means the post-upgrade
+	// maintenance operations have been executed on every database
+	ConditionReasonUpgradeFinalizationCompleted ConditionReason = "UpgradeFinalizationCompleted"
+
+	// ConditionReasonConfigurationApplied means every instance is running
+	// the declared configuration
+	ConditionReasonConfigurationApplied ConditionReason = "ConfigurationApplied"
+
+	// ConditionReasonConfigurationNotReported means at least one instance
+	// didn't report the configuration it is running
+	ConditionReasonConfigurationNotReported ConditionReason = "ConfigurationNotReported"
+
+	// ConditionReasonConfigurationNotUniform means the instances are running
+	// different configurations
+	ConditionReasonConfigurationNotUniform ConditionReason = "ConfigurationNotUniform"
+
+	// ConditionReasonPendingRestart means at least one instance has
+	// parameters that will be applied only after a restart
+	ConditionReasonPendingRestart ConditionReason = "PendingRestart"
+
+	// ConditionReasonAlterSystemOverride means at least one instance has
+	// parameters set via ALTER SYSTEM differing from the declared ones
+	ConditionReasonAlterSystemOverride ConditionReason = "AlterSystemOverride"
+
+	// ConditionReasonParametersFromAvailable means every parameter source
+	// referenced by parametersFrom can be read
+	ConditionReasonParametersFromAvailable ConditionReason = "ParametersFromAvailable"
+
+	// ConditionReasonParametersFromNotAvailable means a ConfigMap, a Secret,
+	// or one of their keys referenced by parametersFrom is missing
+	ConditionReasonParametersFromNotAvailable ConditionReason = "ParametersFromNotAvailable"
 )
 
 // EmbeddedObjectMetadata contains metadata to be inherited by all resources related to a Cluster
@@ -1639,6 +1940,114 @@ type PodSelectorRefStatus struct {
 	IPs []string `json:"ips,omitempty"`
 }
 
+// HBARuleType is the type of connection matched by a pg_hba rule
+// +enum
+type HBARuleType string
+
+const (
+	// HBARuleTypeLocal matches the connections using Unix-domain sockets
+	HBARuleTypeLocal HBARuleType = "local"
+
+	// HBARuleTypeHost matches the TCP/IP connections, with or without SSL
+	// or GSSAPI encryption
+	HBARuleTypeHost HBARuleType = "host"
+
+	// HBARuleTypeHostSSL matches the TCP/IP connections using SSL
+	HBARuleTypeHostSSL HBARuleType = "hostssl"
+
+	// HBARuleTypeHostNoSSL matches the TCP/IP connections not using SSL
+	HBARuleTypeHostNoSSL HBARuleType = "hostnossl"
+
+	// HBARuleTypeHostGSSEnc matches the TCP/IP connections using GSSAPI
+	// encryption
+	HBARuleTypeHostGSSEnc HBARuleType = "hostgssenc"
+
+	// HBARuleTypeHostNoGSSEnc matches the TCP/IP connections not using
+	// GSSAPI encryption
+	HBARuleTypeHostNoGSSEnc HBARuleType = "hostnogssenc"
+)
+
+// HBARule is a structured PostgreSQL Host Based Authentication rule,
+// rendered as a line of the pg_hba.conf file
+// +kubebuilder:validation:XValidation:rule="!has(self.address) || !has(self.podSelectorRef)",message="address and podSelectorRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'local' || (!has(self.address) && !has(self.podSelectorRef))",message="local rules cannot have an address or a podSelectorRef"
+// +kubebuilder:validation:XValidation:rule="(has(self.type) && self.type == 'local') || has(self.address) || has(self.podSelectorRef)",message="one of address and podSelectorRef is required"
+type HBARule struct {
+	// The type of connection matched by the rule
+	// +kubebuilder:validation:Enum=local;host;hostssl;hostnossl;hostgssenc;hostnogssenc
+	// +kubebuilder:default:=host
+	// +optional
+	Type HBARuleType `json:"type,omitempty"`
+
+	// The databases matched by the rule, defaults to `all`
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// The users matched by the rule, defaults to `all`. Prefix a role
+	// name with `+` to match the members of that role
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// The client addresses matched by the rule: an IP address range in
+	// CIDR notation, a host name, or one of `all`, `samehost`, `samenet`
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// The name of an entry of `podSelectorRefs`, matching the IP
+	// addresses of the selected pods
+	// +optional
+	PodSelectorRef string `json:"podSelectorRef,omitempty"`
+
+	// The authentication method
+	// +kubebuilder:validation:Enum=trust;reject;scram-sha-256;md5;password;gss;sspi;ident;peer;ldap;radius;cert;pam;bsd;oauth
+	Method string `json:"method"`
+
+	// The options of the authentication method, e.g. `clientcert`
+	// or `map`
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// HBAStatus reports the effective host based authentication configuration
+type HBAStatus struct {
+	// The pg_hba rules granted by the active BreakGlassAccess objects of
+	// the cluster, which are applied before any other user-defined rule
+	// +optional
+	BreakGlassRules []string `json:"breakGlassRules,omitempty"`
+
+	// The time when the first of the BreakGlassRules expires, and the
+	// pg_hba rules have to be updated
+	// +optional
+	BreakGlassExpirationTime *metav1.Time `json:"breakGlassExpirationTime,omitempty"`
+
+	// The pg_hba rules declared by the DatabaseRole objects of the cluster,
+	// in the order they are applied
+	// +optional
+	RoleRules []string `json:"roleRules,omitempty"`
+
+	// The effective content of the pg_hba.conf file, with the LDAP bind
+	// password redacted
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// The rules that have not been applied
+	// +optional
+	RejectedRules []RejectedHBARule `json:"rejectedRules,omitempty"`
+}
+
+// RejectedHBARule is a pg_hba rule that has not been applied
+type RejectedHBARule struct {
+	// Where the rule has been declared, e.g. `spec.postgresql.pg_hba[0]`
+	// or `DatabaseRole/app: spec.hbaRules[1]`
+	Source string `json:"source"`
+
+	// The rule
+	Rule string `json:"rule"`
+
+	// Why the rule has not been applied
+	Reason string `json:"reason"`
+}
+
 // PostgresConfiguration defines the PostgreSQL configuration
 // +kubebuilder:validation:XValidation:rule="!(has(self.syncReplicaElectionConstraint) && self.syncReplicaElectionConstraint.enabled && has(self.synchronous) && ((has(self.synchronous.podFailureDomainKeys) && self.synchronous.podFailureDomainKeys.size() > 0) || (has(self.synchronous.nodeFailureDomainKeys) && self.synchronous.nodeFailureDomainKeys.size() > 0)))",message="syncReplicaElectionConstraint and synchronous failure domain keys are mutually exclusive"
 type PostgresConfiguration struct {
@@ -1646,6 +2055,28 @@ type PostgresConfiguration struct {
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
 
+	// PostgreSQL configuration options whose values are read from
+	// ConfigMaps or Secrets. A parameter cannot be set both here and
+	// in `parameters`
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ParametersFrom []ParameterSource `json:"parametersFrom,omitempty"`
+
+	// The workload profile used to compute the memory and parallelism
+	// parameters from the resources of the instances. Parameters set
+	// in `parameters` take precedence over the computed ones
+	// +optional
+	Tuning TuningProfile `json:"tuning,omitempty"`
+
+	// PostgreSQL configuration options overriding the ones in `parameters`
+	// on specific instances. When more than one override matches an
+	// instance, the later ones in the list take precedence. Parameters
+	// that must be consistent between the primary and the standbys, like
+	// `max_connections`, cannot be overridden
+	// +optional
+	InstanceOverrides []InstanceParametersOverride `json:"instanceOverrides,omitempty"`
+
 	// Configuration of the PostgreSQL synchronous replication feature
 	// +optional
 	Synchronous *SynchronousReplicaConfiguration `json:"synchronous,omitempty"`
@@ -1657,6 +2088,12 @@ type PostgresConfiguration struct {
 	// +optional
 	PgHBA []string `json:"pg_hba,omitempty"`
 
+	// Structured PostgreSQL Host Based Authentication rules, appended
+	// to the pg_hba.conf file after the ones in `pg_hba`
+	// +optional
+	// +kubebuilder:validation:MaxItems=256
+	HBARules []HBARule `json:"hbaRules,omitempty"`
+
 	// PostgreSQL User Name Maps rules (lines to be appended
 	// to the pg_ident.conf file)
 	// +optional
@@ -1695,6 +2132,60 @@ type PostgresConfiguration struct {
 	Extensions []ExtensionConfiguration `json:"extensions,omitempty"`
 }
 
+// ParameterSource is a PostgreSQL configuration option whose value
+// is read from a ConfigMap or a Secret
+type ParameterSource struct {
+	// The name of the PostgreSQL configuration option
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The key of a ConfigMap containing the value.
+	// Mutually exclusive with `secretKeyRef`
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// The key of a Secret containing the value. Only allowed for the
+	// options of the extensions and the custom parameters.
+	// Mutually exclusive with `configMapKeyRef`
+	// +optional
+	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// InstanceParametersOverride contains the PostgreSQL configuration
+// options applied only to the matching instances
+type InstanceParametersOverride struct {
+	// The name of the instance the parameters are applied to, such as
+	// `cluster-example-2`. Mutually exclusive with `selector`
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// The label selector matching the Pods of the instances the
+	// parameters are applied to. Mutually exclusive with `instanceName`
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// PostgreSQL configuration options (postgresql.conf)
+	// +kubebuilder:validation:MinProperties=1
+	Parameters map[string]string `json:"parameters"`
+}
+
+// TuningProfile is the workload profile used to compute the memory
+// and parallelism parameters of PostgreSQL
+// +kubebuilder:validation:Enum=oltp;olap;mixed
+type TuningProfile string
+
+const (
+	// TuningProfileOLTP is meant for many short concurrent transactions
+	TuningProfileOLTP TuningProfile = "oltp"
+
+	// TuningProfileOLAP is meant for few long analytical queries
+	TuningProfileOLAP TuningProfile = "olap"
+
+	// TuningProfileMixed is meant for a mix of transactional and
+	// analytical queries
+	TuningProfileMixed TuningProfile = "mixed"
+)
+
 // ExtensionConfiguration is the configuration used to add
 // PostgreSQL extensions to the Cluster.
 type ExtensionConfiguration struct {
@@ -1780,6 +2271,11 @@ type BootstrapConfiguration struct {
 	// PostgreSQL instance
 	// +optional
 	PgBaseBackup *BootstrapPgBaseBackup `json:"pg_basebackup,omitempty"`
+
+	// Bootstrap the cluster cloning another Cluster managed by the operator
+	// in the same Kubernetes cluster
+	// +optional
+	Clone *BootstrapClone `json:"clone,omitempty"`
 }
 
 // LDAPScheme defines the possible schemes for LDAP
@@ -2153,6 +2649,17 @@ type SQLRefs struct {
 	ConfigMapRefs []ConfigMapKeySelector `json:"configMapRefs,omitempty"`
 }
 
+// PostRecoverySQLRefs references SQL files to be executed in a database
+// after recovery
+type PostRecoverySQLRefs struct {
+	// The database where the SQL files are executed. Defaults to the
+	// application database, or to `postgres` if there is none
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	SQLRefs `json:",inline"`
+}
+
 // BootstrapRecovery contains the configuration required to restore
 // from an existing cluster using 3 methodologies: external cluster,
 // volume snapshots or backup objects. Full recovery and Point-In-Time
@@ -2210,6 +2717,22 @@ type BootstrapRecovery struct {
 	// created from scratch
 	// +optional
 	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// List of SQL queries to be executed as a superuser in the application
+	// database, or in the `postgres` database if there is none, right
+	// after the promotion and before the instance is started. Any failure
+	// prevents the cluster from becoming ready, and the bootstrap is
+	// retried: the queries should be idempotent. Use `postRecoverySQLRefs`
+	// to execute queries in a different database
+	// +optional
+	PostRecoverySQL []string `json:"postRecoverySQL,omitempty"`
+
+	// List of references to ConfigMaps or Secrets containing SQL files
+	// to be executed as a superuser right after `postRecoverySQL`, in
+	// the database specified by each entry. The same failure handling
+	// of `postRecoverySQL` applies
+	// +optional
+	PostRecoverySQLRefs []PostRecoverySQLRefs `json:"postRecoverySQLRefs,omitempty"`
 }
 
 // DataSource contains the configuration required to bootstrap a
@@ -2259,6 +2782,127 @@ type BootstrapPgBaseBackup struct {
 	// created from scratch
 	// +optional
 	Secret *LocalObjectReference `json:"secret,omitempty"`
+
+	// List of SQL queries to be executed as a superuser in the application
+	// database, or in the `postgres` database if there is none, right
+	// after the promotion and before the instance is started. Any failure
+	// prevents the cluster from becoming ready, and the bootstrap is
+	// retried: the queries should be idempotent. Use `postRecoverySQLRefs`
+	// to execute queries in a different database
+	// +optional
+	PostRecoverySQL []string `json:"postRecoverySQL,omitempty"`
+
+	// List of references to ConfigMaps or Secrets containing SQL files
+	// to be executed as a superuser right after `postRecoverySQL`, in
+	// the database specified by each entry. The same failure handling
+	// of `postRecoverySQL` applies
+	// +optional
+	PostRecoverySQLRefs []PostRecoverySQLRefs `json:"postRecoverySQLRefs,omitempty"`
+
+	// The options of the `pg_basebackup` copying the data directory
+	// of the source server
+	PgBaseBackupOptions `json:",inline"`
+}
+
+// PgBaseBackupOptions are the options of a `pg_basebackup` copying
+// the data directory of another server
+type PgBaseBackupOptions struct {
+	// The maximum rate at which the data directory is transferred, in
+	// kilobytes per second, or with the `k` (kilobytes) or `M`
+	// (megabytes) suffix. PostgreSQL accepts values from 32 kilobytes
+	// to 1024 megabytes per second. If empty, the rate is not limited
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kM]?$`
+	// +optional
+	MaxRate string `json:"maxRate,omitempty"`
+
+	// Whether to verify the data checksums of the source server while
+	// copying the data directory. This has no effect when data checksums
+	// are not enabled in the source server. Default: `true`
+	// +optional
+	VerifyChecksums *bool `json:"verifyChecksums,omitempty"`
+
+	// The checksum algorithm used for the files listed in the backup
+	// manifest. When empty, the PostgreSQL default (`CRC32C`) is used
+	// +kubebuilder:validation:Enum=NONE;CRC32C;SHA224;SHA256;SHA384;SHA512
+	// +optional
+	ManifestChecksums string `json:"manifestChecksums,omitempty"`
+}
+
+// BaseBackupProgress is the progress of the `pg_basebackup` copying the
+// data directory of a new instance, as reported by the
+// `pg_stat_progress_basebackup` view of the source server
+type BaseBackupProgress struct {
+	// The phase of the copy, such as `streaming database files`
+	Phase string `json:"phase"`
+
+	// The estimated amount of data to be streamed, in bytes. Not set
+	// when the source server doesn't estimate it
+	// +optional
+	BackupTotal *int64 `json:"backupTotal,omitempty"`
+
+	// The amount of data streamed, in bytes
+	BackupStreamed int64 `json:"backupStreamed"`
+
+	// The number of tablespaces to be streamed
+	TablespacesTotal int64 `json:"tablespacesTotal"`
+
+	// The number of tablespaces streamed
+	TablespacesStreamed int64 `json:"tablespacesStreamed"`
+
+	// The time when the progress has been reported
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// CloneMethod is the method used to clone another Cluster
+// +kubebuilder:validation:Enum=pg_basebackup;volumeSnapshot
+type CloneMethod string
+
+const (
+	// CloneMethodPgBaseBackup means the data directory is copied from the
+	// primary of the source Cluster via streaming replication
+	CloneMethodPgBaseBackup CloneMethod = "pg_basebackup"
+
+	// CloneMethodVolumeSnapshot means the volumes are created from volume
+	// snapshots of the source Cluster
+	CloneMethodVolumeSnapshot CloneMethod = "volumeSnapshot"
+)
+
+// ClusterReference is a reference to a Cluster, possibly living in a
+// different namespace
+type ClusterReference struct {
+	// The name of the Cluster
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The namespace of the Cluster. Defaults to the namespace of the
+	// cluster being created. A ClusterCloneGrant in the source namespace
+	// is required when the two namespaces are different and the
+	// `pg_basebackup` method is used
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BootstrapClone contains the configuration required to clone
+// another Cluster managed by the operator
+// +kubebuilder:validation:XValidation:rule="has(self.volumeSnapshots) == (has(self.method) && self.method == 'volumeSnapshot')",message="volumeSnapshots must be set if and only if the method is volumeSnapshot"
+type BootstrapClone struct {
+	// The Cluster to be cloned
+	Cluster ClusterReference `json:"cluster"`
+
+	// The cloning method, either `pg_basebackup` (default), which streams
+	// the data directory from the primary of the source Cluster using
+	// TLS credentials managed by the operator, or `volumeSnapshot`, which
+	// creates the volumes from snapshots of the source Cluster
+	// +kubebuilder:default:=pg_basebackup
+	// +optional
+	Method CloneMethod `json:"method,omitempty"`
+
+	// The volume snapshots of the source Cluster used to create the
+	// volumes of the first instance. Required with the `volumeSnapshot`
+	// method. The snapshots must be available in the namespace of the
+	// cluster being created
+	// +optional
+	VolumeSnapshots *DataSource `json:"volumeSnapshots,omitempty"`
 }
 
 // RecoveryTarget allows to configure the moment where the recovery process
@@ -2806,6 +3450,41 @@ type RoleConfiguration struct {
 	// Default is `false`.
 	// +optional
 	BypassRLS bool `json:"bypassrls,omitempty"` // Row-Level Security
+
+	// Role-level defaults of configuration parameters, set with
+	// `ALTER ROLE ... SET` and applied whenever the role starts a session.
+	// Parameters removed from the map are reset with `ALTER ROLE ... RESET`.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// The policy to automatically rotate the password of the role, which
+	// the operator generates into the password secret. Requires
+	// `passwordSecret` and `login`.
+	// +optional
+	PasswordRotation *PasswordRotationConfiguration `json:"passwordRotation,omitempty"`
+
+	// The Secrets with the material an application needs to connect to
+	// a database as this role, generated by the operator following the
+	// layout of the Kubernetes Service Binding specification
+	// +optional
+	// +listType=map
+	// +listMapKey=database
+	ServiceBindings []ServiceBinding `json:"serviceBindings,omitempty"`
+}
+
+// ServiceBinding requests a Secret with the material needed to connect to
+// a database as a role
+type ServiceBinding struct {
+	// The name of the database
+	// +kubebuilder:validation:MinLength=1
+	Database string `json:"database"`
+
+	// The name of the Secret. Defaults to `<cluster>-<role>-<database>`,
+	// lowercase and with the underscores replaced by dashes
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // +genclient
@@ -2896,6 +3575,12 @@ type SecretsResourceVersion struct {
 	// Map keys are the secret names, map values are the versions
 	// +optional
 	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// A map with the versions of all the secrets used to pass
+	// PostgreSQL configuration options.
+	// Map keys are the secret names, map values are the versions
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // ConfigMapResourceVersion is the resource versions of the secrets
@@ -2905,4 +3590,10 @@ type ConfigMapResourceVersion struct {
 	// Map keys are the config map names, map values are the versions
 	// +optional
 	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// A map with the versions of all the config maps used to pass
+	// PostgreSQL configuration options.
+	// Map keys are the config map names, map values are the versions
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
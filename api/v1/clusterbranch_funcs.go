@@ -0,0 +1,146 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// GetTypeMeta gets the type metadata of the ClusterBranch, that is not
+// included by the informers
+func (branch *ClusterBranch) GetTypeMeta() metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       ClusterBranchKind,
+	}
+}
+
+// SetAsOwnerOf sets the ClusterBranch as the owner of the passed object
+func (branch *ClusterBranch) SetAsOwnerOf(obj *metav1.ObjectMeta) {
+	utils.SetAsOwnedBy(obj, branch.ObjectMeta, branch.GetTypeMeta())
+}
+
+// IsOwnerOf checks if the ClusterBranch is the controller of the passed object
+func (branch *ClusterBranch) IsOwnerOf(obj metav1.ObjectMeta) bool {
+	owner := metav1.GetControllerOfNoCopy(&obj)
+	return owner != nil && owner.Kind == ClusterBranchKind && owner.UID == branch.UID
+}
+
+// CreateBackup creates the online volume snapshot backup of the source
+// cluster the branch is created from
+func (branch *ClusterBranch) CreateBackup() *Backup {
+	backup := Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      branch.Name,
+			Namespace: branch.Namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName: branch.Spec.Cluster.Name,
+			},
+		},
+		Spec: BackupSpec{
+			Cluster:  branch.Spec.Cluster,
+			Method:   BackupMethodVolumeSnapshot,
+			Online:   ptr.To(true),
+			Instance: branch.Spec.Instance,
+		},
+	}
+	branch.SetAsOwnerOf(&backup.ObjectMeta)
+
+	return &backup
+}
+
+// CreateCluster creates the single-instance branch cluster, bootstrapping
+// it from the volume snapshot backup of the source cluster
+func (branch *ClusterBranch) CreateCluster(source *Cluster, backup *Backup) *Cluster {
+	postgresConfiguration := source.Spec.PostgresConfiguration.DeepCopy()
+	// A single instance can't honor synchronous replication
+	postgresConfiguration.Synchronous = nil
+
+	cluster := Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      branch.Name,
+			Namespace: branch.Namespace,
+		},
+		Spec: ClusterSpec{
+			Description:           fmt.Sprintf("Branch of cluster %s", source.Name),
+			ImageName:             source.Spec.ImageName,
+			ImageCatalogRef:       source.Spec.ImageCatalogRef.DeepCopy(),
+			ImagePullPolicy:       source.Spec.ImagePullPolicy,
+			ImagePullSecrets:      slices.Clone(source.Spec.ImagePullSecrets),
+			PostgresUID:           source.Spec.PostgresUID,
+			PostgresGID:           source.Spec.PostgresGID,
+			Instances:             1,
+			PostgresConfiguration: *postgresConfiguration,
+			EnableSuperuserAccess: source.Spec.EnableSuperuserAccess,
+			Bootstrap: &BootstrapConfiguration{
+				Recovery: &BootstrapRecovery{
+					Backup: &BackupSource{
+						LocalObjectReference: LocalObjectReference{Name: backup.Name},
+					},
+					Database: source.GetApplicationDatabaseName(),
+					Owner:    source.GetApplicationDatabaseOwner(),
+				},
+			},
+			StorageConfiguration: *source.Spec.StorageConfiguration.DeepCopy(),
+			WalStorage:           source.Spec.WalStorage.DeepCopy(),
+			Resources:            *source.Spec.Resources.DeepCopy(),
+		},
+	}
+	for i := range source.Spec.Tablespaces {
+		cluster.Spec.Tablespaces = append(cluster.Spec.Tablespaces, *source.Spec.Tablespaces[i].DeepCopy())
+	}
+	branch.SetAsOwnerOf(&cluster.ObjectMeta)
+
+	return &cluster
+}
+
+// NewLineage builds the lineage of the branch, given the lineage of the
+// source cluster and the backup the branch is created from
+func (branch *ClusterBranch) NewLineage(
+	sourceLineage []ClusterBranchLineage,
+	backup *Backup,
+) []ClusterBranchLineage {
+	entry := ClusterBranchLineage{
+		Cluster:      branch.Spec.Cluster.Name,
+		Backup:       backup.Name,
+		SnapshotTime: backup.Status.StoppedAt.DeepCopy(),
+	}
+	if backup.Status.InstanceID != nil {
+		entry.Instance = backup.Status.InstanceID.PodName
+	}
+
+	return append(slices.Clone(sourceLineage), entry)
+}
+
+// GetExpirationTime gets the time when the branch expires, which is
+// nil when the branch is not ready or doesn't have a time to live
+func (branch *ClusterBranch) GetExpirationTime() *metav1.Time {
+	if branch.Spec.TTL == nil || branch.Status.ReadyTime == nil {
+		return nil
+	}
+
+	return &metav1.Time{Time: branch.Status.ReadyTime.Add(branch.Spec.TTL.Duration)}
+}
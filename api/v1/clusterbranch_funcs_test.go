@@ -0,0 +1,104 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterBranch", func() {
+	var branch *ClusterBranch
+
+	BeforeEach(func() {
+		branch = &ClusterBranch{
+			ObjectMeta: metav1.ObjectMeta{Name: "migration-test", Namespace: "default", UID: "branch-uid"},
+			Spec: ClusterBranchSpec{
+				Cluster: LocalObjectReference{Name: "production"},
+			},
+		}
+	})
+
+	It("creates a single-instance cluster from the backup", func() {
+		source := &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "default"},
+			Spec: ClusterSpec{
+				Instances: 3,
+				ImageName: "postgres:18",
+				PostgresConfiguration: PostgresConfiguration{
+					Parameters:  map[string]string{"work_mem": "8MB"},
+					Synchronous: &SynchronousReplicaConfiguration{Number: 1},
+				},
+				Bootstrap: &BootstrapConfiguration{
+					InitDB: &BootstrapInitDB{Database: "shop", Owner: "shop"},
+				},
+				StorageConfiguration: StorageConfiguration{Size: "10Gi"},
+				Tablespaces: []TablespaceConfiguration{
+					{Name: "archive", Storage: StorageConfiguration{Size: "1Gi"}},
+				},
+			},
+		}
+		backup := branch.CreateBackup()
+
+		cluster := branch.CreateCluster(source, backup)
+		Expect(cluster.Name).To(Equal("migration-test"))
+		Expect(cluster.Spec.Instances).To(Equal(1))
+		Expect(cluster.Spec.ImageName).To(Equal("postgres:18"))
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("work_mem", "8MB"))
+		Expect(cluster.Spec.PostgresConfiguration.Synchronous).To(BeNil())
+		Expect(source.Spec.PostgresConfiguration.Synchronous).ToNot(BeNil())
+		Expect(cluster.Spec.Bootstrap.Recovery.Backup.Name).To(Equal(backup.Name))
+		Expect(cluster.Spec.Bootstrap.Recovery.Database).To(Equal("shop"))
+		Expect(cluster.Spec.Bootstrap.Recovery.Owner).To(Equal("shop"))
+		Expect(cluster.Spec.Tablespaces).To(HaveLen(1))
+		Expect(cluster.Spec.Backup).To(BeNil())
+		Expect(branch.IsOwnerOf(cluster.ObjectMeta)).To(BeTrue())
+	})
+
+	It("appends the source cluster to the lineage", func() {
+		stoppedAt := metav1.Now()
+		backup := branch.CreateBackup()
+		backup.Status.InstanceID = &InstanceID{PodName: "production-2"}
+		backup.Status.StoppedAt = &stoppedAt
+
+		sourceLineage := []ClusterBranchLineage{{Cluster: "origin", Backup: "production"}}
+		lineage := branch.NewLineage(sourceLineage, backup)
+		Expect(lineage).To(Equal([]ClusterBranchLineage{
+			{Cluster: "origin", Backup: "production"},
+			{Cluster: "production", Backup: "migration-test", Instance: "production-2", SnapshotTime: &stoppedAt},
+		}))
+		Expect(sourceLineage).To(HaveLen(1))
+	})
+
+	It("expires only when ready and with a time to live", func() {
+		Expect(branch.GetExpirationTime()).To(BeNil())
+
+		readyTime := metav1.Now()
+		branch.Status.ReadyTime = &readyTime
+		Expect(branch.GetExpirationTime()).To(BeNil())
+
+		branch.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+		Expect(branch.GetExpirationTime().Time).To(Equal(readyTime.Add(time.Hour)))
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterBranchPhase is the phase of a ClusterBranch
+type ClusterBranchPhase string
+
+const (
+	// ClusterBranchPhaseSnapshotting means that the volumes of the source
+	// instance are being snapshotted
+	ClusterBranchPhaseSnapshotting ClusterBranchPhase = "snapshotting"
+
+	// ClusterBranchPhaseProvisioning means that the branch cluster is
+	// being created from the snapshots
+	ClusterBranchPhaseProvisioning ClusterBranchPhase = "provisioning"
+
+	// ClusterBranchPhaseReady means that the branch cluster is ready
+	// to be used
+	ClusterBranchPhaseReady ClusterBranchPhase = "ready"
+
+	// ClusterBranchPhaseFailed means that the branch could not be created
+	ClusterBranchPhaseFailed ClusterBranchPhase = "failed"
+)
+
+// ClusterBranchSpec defines the desired state of ClusterBranch
+type ClusterBranchSpec struct {
+	// The cluster to branch from. The cluster must live in the same
+	// namespace of the ClusterBranch and have the volume snapshot backups
+	// configured.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster reference is immutable after creation"
+	Cluster LocalObjectReference `json:"cluster"`
+
+	// The name of the instance whose volumes are snapshotted. If empty,
+	// the instance is chosen following the backup target policy of the
+	// source cluster.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="instance is immutable after creation"
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
+	// The time to live of the branch, counted from when it becomes ready.
+	// When expired, the ClusterBranch is deleted together with the branch
+	// cluster and its snapshots. If empty, the branch never expires.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// ClusterBranchLineage is an ancestor of a branch
+type ClusterBranchLineage struct {
+	// The name of the cluster that has been branched
+	Cluster string `json:"cluster"`
+
+	// The name of the volume snapshot backup the branch was created from
+	Backup string `json:"backup"`
+
+	// The name of the instance whose volumes have been snapshotted
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
+	// When the snapshots have been taken
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+}
+
+// ClusterBranchStatus defines the observed state of ClusterBranch
+type ClusterBranchStatus struct {
+	// The phase of the branch
+	// +optional
+	Phase ClusterBranchPhase `json:"phase,omitempty"`
+
+	// The name of the volume snapshot backup of the source cluster
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The time when the branch cluster became ready
+	// +optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
+	// The time when the branch will be deleted
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// The ancestors of the branch, starting from the original cluster
+	// and ending with the cluster this branch has been created from
+	// +optional
+	Lineage []ClusterBranchLineage `json:"lineage,omitempty"`
+
+	// A message describing the reason of the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".status.expirationTime"
+
+// ClusterBranch creates a single-instance Cluster, named after the
+// ClusterBranch, from a volume snapshot of an instance of another Cluster
+type ClusterBranch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired behavior of the ClusterBranch.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ClusterBranchSpec `json:"spec"`
+	// Most recently observed status of the ClusterBranch. This data may not be up
+	// to date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status ClusterBranchStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterBranchList contains a list of ClusterBranch
+type ClusterBranchList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of cluster branches
+	Items []ClusterBranch `json:"items"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"slices"
+)
+
+// Allows checks if the grant allows the Cluster with the passed name,
+// living in the namespace of the grant, to be cloned in the passed
+// target namespace
+func (grant *ClusterCloneGrant) Allows(clusterName, targetNamespace string) bool {
+	return grant.Spec.Cluster.Name == clusterName &&
+		slices.Contains(grant.Spec.Namespaces, targetNamespace)
+}
+
+// Allows checks if any of the grants in the list allows the Cluster
+// with the passed name to be cloned in the passed target namespace
+func (list *ClusterCloneGrantList) Allows(clusterName, targetNamespace string) bool {
+	return slices.ContainsFunc(list.Items, func(grant ClusterCloneGrant) bool {
+		return grant.Allows(clusterName, targetNamespace)
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCloneGrantSpec defines which namespaces are allowed to clone
+// a Cluster
+type ClusterCloneGrantSpec struct {
+	// The Cluster, in the same namespace of the grant, that can be cloned
+	Cluster LocalObjectReference `json:"cluster"`
+
+	// The namespaces where Clusters are allowed to bootstrap cloning
+	// the referenced Cluster
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Namespaces []string `json:"namespaces"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+
+// ClusterCloneGrant allows Clusters living in other namespaces to
+// bootstrap cloning a Cluster of the namespace of the grant
+type ClusterCloneGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired behavior of the ClusterCloneGrant.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ClusterCloneGrantSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterCloneGrantList contains a list of ClusterCloneGrant
+type ClusterCloneGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of cluster clone grants
+	Items []ClusterCloneGrant `json:"items"`
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+// IsSuspended check if a cluster refresh has been suspended or not
+func (refresh *ClusterRefresh) IsSuspended() bool {
+	if refresh.Spec.Suspend == nil {
+		return false
+	}
+
+	return *refresh.Spec.Suspend
+}
+
+// IsImmediate check if a refresh has to be issued immediately upon creation or not
+func (refresh *ClusterRefresh) IsImmediate() bool {
+	if refresh.Spec.Immediate == nil {
+		return false
+	}
+
+	return *refresh.Spec.Immediate
+}
+
+// GetApplicationCredentialsPolicy gets the policy to be applied to the
+// credentials of the application user, defaulting to `keep`
+func (refresh *ClusterRefresh) GetApplicationCredentialsPolicy() ClusterRefreshCredentialsPolicy {
+	if refresh.Spec.ApplicationCredentials == "" {
+		return ClusterRefreshCredentialsKeep
+	}
+
+	return refresh.Spec.ApplicationCredentials
+}
+
+// IsRunning checks if the latest refresh is still in progress
+func (refresh *ClusterRefresh) IsRunning() bool {
+	return refresh.Status.Phase == ClusterRefreshPhaseRunning
+}
+
+// NewRequest creates the request to refresh the data of the
+// target cluster from the passed backup
+func (refresh *ClusterRefresh) NewRequest(backupName string) *ClusterRefreshRequest {
+	return &ClusterRefreshRequest{
+		Name:                   refresh.Name,
+		BackupName:             backupName,
+		ApplicationCredentials: refresh.GetApplicationCredentialsPolicy(),
+		PostRecoverySQL:        refresh.Spec.PostRecoverySQL,
+		PostRecoverySQLRefs:    refresh.Spec.PostRecoverySQLRefs,
+	}
+}
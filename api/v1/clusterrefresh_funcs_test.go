@@ -0,0 +1,69 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterRefresh", func() {
+	It("is not suspended nor immediate by default", func() {
+		refresh := ClusterRefresh{}
+		Expect(refresh.IsSuspended()).To(BeFalse())
+		Expect(refresh.IsImmediate()).To(BeFalse())
+		Expect(refresh.IsRunning()).To(BeFalse())
+	})
+
+	It("can be suspended and immediate", func() {
+		refresh := ClusterRefresh{
+			Spec: ClusterRefreshSpec{
+				Suspend:   ptr.To(true),
+				Immediate: ptr.To(true),
+			},
+		}
+		Expect(refresh.IsSuspended()).To(BeTrue())
+		Expect(refresh.IsImmediate()).To(BeTrue())
+	})
+
+	It("keeps the application credentials by default", func() {
+		refresh := ClusterRefresh{}
+		Expect(refresh.GetApplicationCredentialsPolicy()).To(Equal(ClusterRefreshCredentialsKeep))
+	})
+
+	It("creates the refresh request", func() {
+		refresh := ClusterRefresh{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+			Spec: ClusterRefreshSpec{
+				ApplicationCredentials: ClusterRefreshCredentialsRotate,
+				PostRecoverySQL:        []string{"SELECT 1"},
+			},
+		}
+		Expect(refresh.NewRequest("production-backup")).To(Equal(&ClusterRefreshRequest{
+			Name:                   "nightly",
+			BackupName:             "production-backup",
+			ApplicationCredentials: ClusterRefreshCredentialsRotate,
+			PostRecoverySQL:        []string{"SELECT 1"},
+		}))
+	})
+})
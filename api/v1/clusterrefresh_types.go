@@ -0,0 +1,188 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterRefreshCredentialsPolicy defines what happens to the credentials
+// of the application user when the data of a cluster is refreshed
+// +kubebuilder:validation:Enum=keep;rotate
+type ClusterRefreshCredentialsPolicy string
+
+const (
+	// ClusterRefreshCredentialsKeep means that the application user keeps
+	// the password stored in the application secret
+	ClusterRefreshCredentialsKeep ClusterRefreshCredentialsPolicy = "keep"
+
+	// ClusterRefreshCredentialsRotate means that a new password is generated
+	// for the application user. This only applies when the application
+	// secret is generated by the operator.
+	ClusterRefreshCredentialsRotate ClusterRefreshCredentialsPolicy = "rotate"
+)
+
+// ClusterRefreshPhase is the phase of the latest refresh
+type ClusterRefreshPhase string
+
+const (
+	// ClusterRefreshPhaseRunning means that the data of the cluster
+	// is being refreshed
+	ClusterRefreshPhaseRunning ClusterRefreshPhase = "running"
+
+	// ClusterRefreshPhaseCompleted means that the latest refresh
+	// has been completed
+	ClusterRefreshPhaseCompleted ClusterRefreshPhase = "completed"
+
+	// ClusterRefreshPhaseFailed means that the latest refresh has failed
+	// and the cluster is unrecoverable until the next refresh
+	ClusterRefreshPhaseFailed ClusterRefreshPhase = "failed"
+)
+
+// ClusterRefreshSpec defines the desired state of ClusterRefresh
+// +kubebuilder:validation:XValidation:rule="!has(self.source.cluster) || self.source.cluster.name != self.cluster.name",message="a cluster cannot be refreshed from its own backups"
+type ClusterRefreshSpec struct {
+	// If this refresh is suspended or not
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// If the first refresh has to be immediately start after creation or not
+	// +optional
+	Immediate *bool `json:"immediate,omitempty"`
+
+	// The schedule does not follow the same format used in Kubernetes CronJobs
+	// as it includes an additional seconds specifier,
+	// see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format
+	Schedule string `json:"schedule"`
+
+	// The cluster whose data will be refreshed
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster reference is immutable after creation"
+	Cluster LocalObjectReference `json:"cluster"`
+
+	// Where the data will be refreshed from
+	Source ClusterRefreshSource `json:"source"`
+
+	// What happens to the credentials of the application user: `keep`
+	// (default) preserves the password stored in the application secret,
+	// while `rotate` generates a new one. Passwords stored in secrets
+	// provided by the user are never changed.
+	// +kubebuilder:default:=keep
+	// +optional
+	ApplicationCredentials ClusterRefreshCredentialsPolicy `json:"applicationCredentials,omitempty"`
+
+	// List of SQL queries to be executed as a superuser in the application
+	// database, or in the `postgres` database if there is none, right after
+	// the data has been restored and before the cluster becomes ready. See
+	// the `postRecoverySQL` option of the `recovery` bootstrap method.
+	// +optional
+	PostRecoverySQL []string `json:"postRecoverySQL,omitempty"`
+
+	// List of references to ConfigMaps or Secrets containing SQL files
+	// to be executed as a superuser right after the data has been restored
+	// and before the cluster becomes ready. See the `postRecoverySQLRefs`
+	// option of the `recovery` bootstrap method.
+	// +optional
+	PostRecoverySQLRefs []PostRecoverySQLRefs `json:"postRecoverySQLRefs,omitempty"`
+}
+
+// ClusterRefreshSource is the origin of the data of a refresh.
+// Exactly one of the fields must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.cluster) != has(self.backup)",message="exactly one of cluster and backup must be specified"
+type ClusterRefreshSource struct {
+	// The cluster whose latest completed backup will be used. The cluster
+	// must live in the same namespace of the ClusterRefresh.
+	// +optional
+	Cluster *LocalObjectReference `json:"cluster,omitempty"`
+
+	// The backup to be used
+	// +optional
+	Backup *LocalObjectReference `json:"backup,omitempty"`
+}
+
+// ClusterRefreshStatus defines the observed state of ClusterRefresh
+type ClusterRefreshStatus struct {
+	// The latest time the schedule
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Information when was the last time that a refresh was successfully scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Next time we will run a refresh
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// The time when the latest refresh has been completed
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// The phase of the latest refresh
+	// +optional
+	Phase ClusterRefreshPhase `json:"phase,omitempty"`
+
+	// The name of the backup used by the latest refresh
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// A message describing why the latest scheduled refresh has been skipped
+	// or has failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Last Refresh",type="date",JSONPath=".status.lastRefreshTime"
+
+// ClusterRefresh periodically replaces the data of a Cluster with the
+// content of a backup, keeping the Cluster object and its dependent
+// resources in place
+type ClusterRefresh struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Specification of the desired behavior of the ClusterRefresh.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec ClusterRefreshSpec `json:"spec"`
+	// Most recently observed status of the ClusterRefresh. This data may not be up
+	// to date. Populated by the system. Read-only.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status ClusterRefreshStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterRefreshList contains a list of ClusterRefresh
+type ClusterRefreshList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of cluster refreshes
+	Items []ClusterRefresh `json:"items"`
+}
@@ -20,8 +20,13 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"fmt"
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // SetAsFailed sets the database as failed with the given error
@@ -53,6 +58,21 @@ func (db *Database) GetStatusApplied() *bool {
 	return db.Status.Applied
 }
 
+// GetDriftDetection returns the drift detection configuration of the database
+func (db *Database) GetDriftDetection() DriftDetectionConfiguration {
+	return db.Spec.DriftDetectionConfiguration
+}
+
+// GetDriftStatus returns the outcome of the last drift check of the database
+func (db *Database) GetDriftStatus() *DriftStatus {
+	return db.Status.Drift
+}
+
+// SetDriftStatus sets the outcome of the last drift check of the database
+func (db *Database) SetDriftStatus(status *DriftStatus) {
+	db.Status.Drift = status
+}
+
 // GetClusterRef returns the cluster reference of the database
 func (db *Database) GetClusterRef() corev1.LocalObjectReference {
 	return db.Spec.ClusterRef
@@ -94,6 +114,80 @@ func (dbObject DatabaseObjectSpec) GetName() string {
 	return dbObject.Name
 }
 
+// GetPrivileges returns the privileges that can be granted on the objects
+// of this type. `MAINTAIN` is only available since PostgreSQL 17.
+func (t PrivilegeObjectType) GetPrivileges() []string {
+	switch t {
+	case PrivilegeObjectTypeDatabase:
+		return []string{"CREATE", "CONNECT", "TEMPORARY"}
+	case PrivilegeObjectTypeSchema:
+		return []string{"USAGE", "CREATE"}
+	case PrivilegeObjectTypeTables:
+		return []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "MAINTAIN"}
+	case PrivilegeObjectTypeSequences:
+		return []string{"USAGE", "SELECT", "UPDATE"}
+	case PrivilegeObjectTypeFunctions:
+		return []string{"EXECUTE"}
+	case PrivilegeObjectTypeTypes:
+		return []string{"USAGE"}
+	default:
+		return nil
+	}
+}
+
+// GetEnsure gets the ensure status of the privileges
+func (privilege PrivilegeSpec) GetEnsure() EnsureOption {
+	return privilege.Ensure
+}
+
+// GetName gets a description of the privileges, identifying them in the
+// status of the database
+func (privilege PrivilegeSpec) GetName() string {
+	switch privilege.ObjectType {
+	case PrivilegeObjectTypeDatabase:
+		return fmt.Sprintf("database to %s", privilege.Role)
+	case PrivilegeObjectTypeSchema:
+		return fmt.Sprintf("schema %s to %s", privilege.Schema, privilege.Role)
+	default:
+		return fmt.Sprintf("%s in schema %s to %s", privilege.ObjectType, privilege.Schema, privilege.Role)
+	}
+}
+
+// GetEnsure gets the ensure status of the default privileges
+func (privilege DefaultPrivilegeSpec) GetEnsure() EnsureOption {
+	return privilege.Ensure
+}
+
+// GetName gets a description of the default privileges, identifying them
+// in the status of the database
+func (privilege DefaultPrivilegeSpec) GetName() string {
+	return fmt.Sprintf("%s in schema %s for %s to %s",
+		privilege.ObjectType, privilege.Schema, privilege.ForRole, privilege.Role)
+}
+
+// GetUserMappingSecretNames gets the names of the Secrets used by the user
+// mappings of the foreign servers
+func (db *Database) GetUserMappingSecretNames() []string {
+	var result []string
+	for i := range db.Spec.Servers {
+		for j := range db.Spec.Servers[i].UserMappings {
+			if secret := db.Spec.Servers[i].UserMappings[j].PasswordSecret; secret != nil &&
+				!slices.Contains(result, secret.Name) {
+				result = append(result, secret.Name)
+			}
+		}
+	}
+	return result
+}
+
+// IsUserMappingSecret checks whether the passed Secret has been labeled
+// to be used as the password of a user mapping. The label prevents the
+// users allowed to create a Database from reading any Secret of the
+// namespace through a foreign server.
+func IsUserMappingSecret(secret *corev1.Secret) bool {
+	return secret.Labels[utils.UserMappingSecretLabelName] == "true"
+}
+
 // SetAdmissionError sets the admission error status on the Database resource
 func (db *Database) SetAdmissionError(msg string) {
 	db.Status.Message = msg
@@ -52,6 +52,32 @@ const (
 	RevokeUsageSpecType UsageSpecType = "revoke"
 )
 
+// PrivilegeObjectType is the type of the database objects on which
+// privileges are granted or revoked
+// +enum
+type PrivilegeObjectType string
+
+const (
+	// PrivilegeObjectTypeDatabase refers to the database itself
+	PrivilegeObjectTypeDatabase PrivilegeObjectType = "database"
+
+	// PrivilegeObjectTypeSchema refers to a schema
+	PrivilegeObjectTypeSchema PrivilegeObjectType = "schema"
+
+	// PrivilegeObjectTypeTables refers to the tables, views, materialized
+	// views and foreign tables in a schema
+	PrivilegeObjectTypeTables PrivilegeObjectType = "tables"
+
+	// PrivilegeObjectTypeSequences refers to the sequences in a schema
+	PrivilegeObjectTypeSequences PrivilegeObjectType = "sequences"
+
+	// PrivilegeObjectTypeFunctions refers to the functions in a schema
+	PrivilegeObjectTypeFunctions PrivilegeObjectType = "functions"
+
+	// PrivilegeObjectTypeTypes refers to the types in a schema
+	PrivilegeObjectTypeTypes PrivilegeObjectType = "types"
+)
+
 // DatabaseSpec is the specification of a Postgresql Database, built around the
 // `CREATE DATABASE`, `ALTER DATABASE`, and `DROP DATABASE` SQL commands of
 // PostgreSQL.
@@ -175,6 +201,13 @@ type DatabaseSpec struct {
 	// +optional
 	Tablespace string `json:"tablespace,omitempty"`
 
+	// Database-level defaults of configuration parameters, set with
+	// `ALTER DATABASE ... SET` and applied whenever a session connects to
+	// the database. Parameters removed from the map are reset with
+	// `ALTER DATABASE ... RESET`.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
 	// The policy for end-of-life maintenance of this database.
 	// +kubebuilder:validation:Enum=delete;retain
 	// +kubebuilder:default:=retain
@@ -196,6 +229,20 @@ type DatabaseSpec struct {
 	// The list of foreign servers to be managed in the database
 	// +optional
 	Servers []ServerSpec `json:"servers,omitempty"`
+
+	// The list of privileges to be granted or revoked on the database
+	// and on the objects it contains
+	// +optional
+	Privileges []PrivilegeSpec `json:"privileges,omitempty"`
+
+	// The list of default privileges to be granted or revoked on the
+	// objects created in the future by a role in a schema
+	// +optional
+	DefaultPrivileges []DefaultPrivilegeSpec `json:"defaultPrivileges,omitempty"`
+
+	// The periodic detection of the drift of the database in PostgreSQL
+	// from this specification
+	DriftDetectionConfiguration `json:",inline"`
 }
 
 // DatabaseObjectSpec contains the fields which are common to every
@@ -291,6 +338,106 @@ type ServerSpec struct {
 	// List of roles for which `USAGE` privileges on the server are granted or revoked.
 	// +optional
 	Usages []UsageSpec `json:"usage,omitempty"`
+
+	// The list of user mappings of the server, mapping a role to the
+	// credentials used to connect to the foreign server
+	// +optional
+	UserMappings []UserMappingSpec `json:"userMappings,omitempty"`
+}
+
+// UserMappingSpec configures a user mapping of a foreign server, built
+// around the `CREATE USER MAPPING` SQL command
+// +kubebuilder:validation:XValidation:rule="!has(self.passwordSecret) || !has(self.options) || !self.options.exists(o, o.name == 'password')",message="the password option cannot be set together with passwordSecret"
+type UserMappingSpec struct {
+	// The role the user mapping is defined for. Use `PUBLIC` to define
+	// the mapping for all the roles without a specific one.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// Options specifies the configuration options for the user mapping,
+	// like the `user` to connect to the foreign server as.
+	// +optional
+	Options []OptionSpec `json:"options,omitempty"`
+
+	// The Secret key containing the value of the `password` option of the
+	// user mapping. The user mapping is updated when the Secret changes.
+	// +optional
+	PasswordSecret *SecretKeySelector `json:"passwordSecret,omitempty"`
+
+	// Specifies whether the user mapping should be present or absent in
+	// the server. A user mapping is always removed together with its server.
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+}
+
+// PrivilegeSpec configures the privileges of a role on the database, on a
+// schema, or on all the objects of a given type in a schema, built around
+// the `GRANT` and `REVOKE` SQL commands
+type PrivilegeSpec struct {
+	// The type of the objects the privileges refer to. For `tables`,
+	// `sequences` and `functions`, the privileges are applied to all the
+	// existing objects of that type in the schema.
+	// +kubebuilder:validation:Enum=database;schema;tables;sequences;functions
+	ObjectType PrivilegeObjectType `json:"objectType"`
+
+	// The name of the schema. Required unless `objectType` is `database`.
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// The role the privileges are granted to or revoked from. Use
+	// `PUBLIC` to refer to all the roles.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// The privileges to grant or revoke, like `SELECT` or `USAGE`.
+	// `ALL` stands for all the privileges of the object type.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=ALL;SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;MAINTAIN;USAGE;CREATE;CONNECT;TEMPORARY;EXECUTE
+	Privileges []string `json:"privileges"`
+
+	// Specifies whether the privileges should be granted (`present`) or
+	// revoked (`absent`).
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+}
+
+// DefaultPrivilegeSpec configures the privileges granted to a role on the
+// objects created in the future by another role in a schema, built around
+// the `ALTER DEFAULT PRIVILEGES FOR ROLE ... IN SCHEMA` SQL command
+type DefaultPrivilegeSpec struct {
+	// The role creating the objects the default privileges apply to
+	// +kubebuilder:validation:MinLength=1
+	ForRole string `json:"forRole"`
+
+	// The name of the schema where the objects are created
+	// +kubebuilder:validation:MinLength=1
+	Schema string `json:"schema"`
+
+	// The type of the objects the default privileges refer to
+	// +kubebuilder:validation:Enum=tables;sequences;functions;types
+	ObjectType PrivilegeObjectType `json:"objectType"`
+
+	// The role the privileges are granted to or revoked from. Use
+	// `PUBLIC` to refer to all the roles.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+
+	// The privileges to grant or revoke, like `SELECT` or `USAGE`.
+	// `ALL` stands for all the privileges of the object type.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=ALL;SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;MAINTAIN;USAGE;EXECUTE
+	Privileges []string `json:"privileges"`
+
+	// Specifies whether the default privileges should be granted
+	// (`present`) or revoked (`absent`).
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
 }
 
 // OptionSpec holds the name, value and the ensure field for an option
@@ -351,9 +498,31 @@ type DatabaseStatus struct {
 	// +optional
 	FDWs []DatabaseObjectStatus `json:"fdws,omitempty"`
 
-	// Servers is the status of the managed servers
+	// Servers is the status of the managed servers and of their user mappings
 	// +optional
 	Servers []DatabaseObjectStatus `json:"servers,omitempty"`
+
+	// Parameters is the status of the database-level configuration parameters
+	// +optional
+	Parameters []DatabaseObjectStatus `json:"parameters,omitempty"`
+
+	// Privileges is the status of the managed privileges
+	// +optional
+	Privileges []DatabaseObjectStatus `json:"privileges,omitempty"`
+
+	// DefaultPrivileges is the status of the managed default privileges
+	// +optional
+	DefaultPrivileges []DatabaseObjectStatus `json:"defaultPrivileges,omitempty"`
+
+	// SecretResourceVersions are the resource versions of the Secrets used
+	// by the user mappings, as of the last reconciliation
+	// +optional
+	SecretResourceVersions map[string]string `json:"secretResourceVersions,omitempty"`
+
+	// Drift is the outcome of the last comparison of the state of the
+	// database in PostgreSQL with its specification
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
 }
 
 // DatabaseObjectStatus is the status of the managed database objects
@@ -63,6 +63,10 @@ const (
 // +kubebuilder:validation:XValidation:rule="self.name.size() != 0",message="role name must not be empty"
 // +kubebuilder:validation:XValidation:rule="!has(self.passwordSecret) || !has(self.disablePassword) || !self.disablePassword",message="passwordSecret and disablePassword are mutually exclusive"
 // +kubebuilder:validation:XValidation:rule="!has(self.clientCertificate) || !self.clientCertificate.enabled || self.login",message="clientCertificate requires the role to have login enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.passwordRotation) || (has(self.passwordSecret) && has(self.login) && self.login)",message="passwordRotation requires passwordSecret and the role to have login enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.passwordRotation) || duration(self.passwordRotation.interval) >= duration('1h')",message="the password rotation interval must be at least one hour"
+// +kubebuilder:validation:XValidation:rule="!has(self.passwordRotation) || !has(self.passwordRotation.gracePeriod) || (duration(self.passwordRotation.gracePeriod) > duration('0s') && duration(self.passwordRotation.gracePeriod) < duration(self.passwordRotation.interval))",message="the password rotation grace period must be positive and shorter than the interval"
+// +kubebuilder:validation:XValidation:rule="!has(self.passwordRotation) || ((has(self.passwordRotation.strategy) && self.passwordRotation.strategy == 'alternateSecrets') == has(self.passwordRotation.alternatePasswordSecret))",message="alternatePasswordSecret is required by, and only allowed with, the alternateSecrets strategy"
 type DatabaseRoleSpec struct {
 	// The Kubernetes representation of a PostgreSQL role
 	// in the `cluster.spec.managed.roles` definition.
@@ -84,6 +88,14 @@ type DatabaseRoleSpec struct {
 	// Requires login to be true.
 	// +optional
 	ClientCertificate *ClientCertificateConfiguration `json:"clientCertificate,omitempty"`
+
+	// The pg_hba rules allowing this role to connect to the cluster,
+	// which are applied after the ones declared in the cluster. The
+	// `users` field is set to the name of the role
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:XValidation:rule="self.all(r, !has(r.users))",message="users cannot be set in the hbaRules of a DatabaseRole"
+	HBARules []HBARule `json:"hbaRules,omitempty"`
 }
 
 // ClientCertificateConfiguration configures operator-managed issuance of a TLS
@@ -128,11 +140,21 @@ type DatabaseRoleStatus struct {
 	// +optional
 	SecretResourceVersion string `json:"secretResourceVersion,omitempty"`
 
+	// Parameters are the role-level configuration parameters last applied
+	// to the role
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
 	// ClientCertificate holds the observed state of the generated TLS client
 	// certificate, when client certificate issuance is enabled.
 	// +optional
 	ClientCertificate *ClientCertificateState `json:"clientCertificate,omitempty"`
 
+	// PasswordRotation holds the state of the automatic password rotation,
+	// when enabled.
+	// +optional
+	PasswordRotation *PasswordRotationState `json:"passwordRotation,omitempty"`
+
 	// Conditions for the DatabaseRole object
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
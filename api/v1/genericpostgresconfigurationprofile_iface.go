@@ -0,0 +1,37 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +kubebuilder:object:generate=false
+
+// GenericPostgresConfigurationProfile is an interface used to manage
+// ClusterPostgresConfigurationProfile and PostgresConfigurationProfile in the same way
+type GenericPostgresConfigurationProfile interface {
+	runtime.Object
+	metav1.Object
+
+	// GetSpec returns the Spec of the GenericPostgresConfigurationProfile
+	GetSpec() *PostgresConfigurationProfileSpec
+}
@@ -47,8 +47,31 @@ const (
 	// SubscriptionKind is the kind name of subscriptions
 	SubscriptionKind = "Subscription"
 
+	// ReplicationSlotKind is the kind name of replication slots
+	ReplicationSlotKind = "ReplicationSlot"
+
 	// DatabaseKind is the kind name of databases
 	DatabaseKind = "Database"
+
+	// ClusterCloneGrantKind is the kind name of cluster clone grants
+	ClusterCloneGrantKind = "ClusterCloneGrant"
+
+	// ClusterRefreshKind is the kind name of cluster refreshes
+	ClusterRefreshKind = "ClusterRefresh"
+
+	// ClusterBranchKind is the kind name of cluster branches
+	ClusterBranchKind = "ClusterBranch"
+
+	// BreakGlassAccessKind is the kind name of break-glass accesses
+	BreakGlassAccessKind = "BreakGlassAccess"
+
+	// PostgresConfigurationProfileKind is the kind name of namespaced
+	// configuration profiles
+	PostgresConfigurationProfileKind = "PostgresConfigurationProfile"
+
+	// ClusterPostgresConfigurationProfileKind is the kind name of the
+	// cluster-wide configuration profiles
+	ClusterPostgresConfigurationProfileKind = "ClusterPostgresConfigurationProfile"
 )
 
 var (
@@ -69,15 +92,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&Cluster{}, &ClusterList{},
 
 		// Helper types
+		&BreakGlassAccess{}, &BreakGlassAccessList{},
+		&ClusterBranch{}, &ClusterBranchList{},
+		&ClusterCloneGrant{}, &ClusterCloneGrantList{},
 		&ClusterImageCatalog{}, &ClusterImageCatalogList{},
+		&ClusterPostgresConfigurationProfile{}, &ClusterPostgresConfigurationProfileList{},
+		&ClusterRefresh{}, &ClusterRefreshList{},
 		&Database{}, &DatabaseList{},
 		&FailoverQuorum{}, &FailoverQuorumList{},
 		&ImageCatalog{}, &ImageCatalogList{},
+		&PostgresConfigurationProfile{}, &PostgresConfigurationProfileList{},
 		&DatabaseRole{}, &DatabaseRoleList{},
 
 		// Util types
 		&Pooler{}, &PoolerList{},
 		&Publication{}, &PublicationList{},
+		&ReplicationSlot{}, &ReplicationSlotList{},
 		&ScheduledBackup{}, &ScheduledBackupList{},
 		&Subscription{}, &SubscriptionList{},
 	)
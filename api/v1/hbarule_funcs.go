@@ -0,0 +1,190 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
+)
+
+var (
+	// hbaUnquotedTokenRegex matches the tokens that can be written in
+	// pg_hba.conf without being quoted
+	hbaUnquotedTokenRegex = regexp.MustCompile(`^[A-Za-z0-9_.$\-]+$`)
+
+	// hbaHostNameRegex matches the host names, and the host name suffixes
+	// starting with a dot, accepted in the address field
+	hbaHostNameRegex = regexp.MustCompile(`^\.?[A-Za-z0-9]([A-Za-z0-9.\-]*[A-Za-z0-9])?$`)
+
+	// hbaOptionNameRegex matches the names of the authentication options
+	hbaOptionNameRegex = regexp.MustCompile(`^[a-z_]+$`)
+)
+
+// hbaAddressKeywords are the keywords accepted in the address field
+var hbaAddressKeywords = []string{"all", "samehost", "samenet"}
+
+// GetType returns the type of connection matched by the rule,
+// defaulting to host
+func (rule *HBARule) GetType() HBARuleType {
+	if rule.Type == "" {
+		return HBARuleTypeHost
+	}
+	return rule.Type
+}
+
+// String renders the rule as a pg_hba.conf line. A rule referencing a
+// pod selector uses the ${podselector:NAME} syntax in the address field,
+// that is expanded in the same way as the pg_hba lines
+func (rule *HBARule) String() string {
+	fields := []string{
+		string(rule.GetType()),
+		renderHBANames(rule.Databases),
+		renderHBANames(rule.Users),
+	}
+
+	if rule.GetType() != HBARuleTypeLocal {
+		address := rule.Address
+		if rule.PodSelectorRef != "" {
+			address = fmt.Sprintf("${%s:%s}", hba.PodSelectorReference, rule.PodSelectorRef)
+		}
+		fields = append(fields, address)
+	}
+
+	fields = append(fields, rule.Method)
+	for _, name := range slices.Sorted(maps.Keys(rule.Options)) {
+		fields = append(fields, name+"="+quoteHBAToken(rule.Options[name]))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// Validate checks that the rule can be rendered as a valid pg_hba.conf
+// line. The existence of the referenced pod selector is not checked
+func (rule *HBARule) Validate() error {
+	var errs []error
+
+	for _, name := range rule.Databases {
+		if err := validateHBAName(name); err != nil {
+			errs = append(errs, fmt.Errorf("invalid database %q: %w", name, err))
+		}
+	}
+	for _, name := range rule.Users {
+		if err := validateHBAName(strings.TrimPrefix(name, "+")); err != nil {
+			errs = append(errs, fmt.Errorf("invalid user %q: %w", name, err))
+		}
+	}
+
+	switch {
+	case rule.GetType() == HBARuleTypeLocal:
+		if rule.Address != "" || rule.PodSelectorRef != "" {
+			errs = append(errs, errors.New("local rules cannot have an address or a podSelectorRef"))
+		}
+	case rule.Address != "" && rule.PodSelectorRef != "":
+		errs = append(errs, errors.New("address and podSelectorRef are mutually exclusive"))
+	case rule.Address == "" && rule.PodSelectorRef == "":
+		errs = append(errs, errors.New("one of address and podSelectorRef is required"))
+	case rule.Address != "":
+		if err := validateHBAAddress(rule.Address); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for name, value := range rule.Options {
+		if !hbaOptionNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("invalid option name %q", name))
+		}
+		if strings.ContainsAny(value, "\"\n") {
+			errs = append(errs, fmt.Errorf("the value of option %q cannot contain double quotes or newlines", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateHBAName checks a database or user name of a pg_hba rule
+func validateHBAName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("cannot be empty")
+	case strings.HasPrefix(name, "@"):
+		return errors.New("file inclusions are not supported")
+	case strings.ContainsAny(name, "\"\n"):
+		return errors.New("cannot contain double quotes or newlines")
+	}
+	return nil
+}
+
+// validateHBAAddress checks the address field of a pg_hba rule
+func validateHBAAddress(address string) error {
+	if slices.Contains(hbaAddressKeywords, address) {
+		return nil
+	}
+
+	if strings.Contains(address, "/") {
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			return fmt.Errorf("invalid address %q: %w", address, err)
+		}
+		return nil
+	}
+
+	if net.ParseIP(address) != nil {
+		return fmt.Errorf("invalid address %q: IP addresses must be written in CIDR notation", address)
+	}
+
+	if !hbaHostNameRegex.MatchString(address) {
+		return fmt.Errorf("invalid address %q: not an IP address range, a host name or a keyword", address)
+	}
+
+	return nil
+}
+
+// renderHBANames renders a list of databases or users of a pg_hba rule,
+// defaulting to all
+func renderHBANames(names []string) string {
+	if len(names) == 0 {
+		return "all"
+	}
+
+	tokens := make([]string, len(names))
+	for i, name := range names {
+		if group, isGroup := strings.CutPrefix(name, "+"); isGroup {
+			tokens[i] = "+" + quoteHBAToken(group)
+			continue
+		}
+		tokens[i] = quoteHBAToken(name)
+	}
+	return strings.Join(tokens, ",")
+}
+
+// quoteHBAToken quotes a pg_hba.conf token when it contains characters
+// having a special meaning in that file
+func quoteHBAToken(token string) string {
+	if hbaUnquotedTokenRegex.MatchString(token) {
+		return token
+	}
+	return `"` + token + `"`
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HBA rules", func() {
+	DescribeTable("rendering",
+		func(rule HBARule, expected string) {
+			Expect(rule.String()).To(Equal(expected))
+		},
+		Entry("with the default values",
+			HBARule{Address: "10.0.0.0/8", Method: "scram-sha-256"},
+			"host all all 10.0.0.0/8 scram-sha-256"),
+		Entry("with databases, users and options",
+			HBARule{
+				Type:      HBARuleTypeHostSSL,
+				Databases: []string{"app", "Other DB"},
+				Users:     []string{"+readers", "+My Group", "app"},
+				Address:   "all",
+				Method:    "cert",
+				Options:   map[string]string{"map": "users", "clientcert": "verify-full"},
+			},
+			`hostssl app,"Other DB" +readers,+"My Group",app all cert clientcert=verify-full map=users`),
+		Entry("with a pod selector",
+			HBARule{PodSelectorRef: "app-pods", Method: "md5"},
+			"host all all ${podselector:app-pods} md5"),
+		Entry("with a local rule",
+			HBARule{Type: HBARuleTypeLocal, Method: "peer"},
+			"local all all peer"),
+		Entry("with an option value to be quoted",
+			HBARule{Address: "all", Method: "ldap", Options: map[string]string{"ldapprefix": "cn="}},
+			`host all all all ldap ldapprefix="cn="`),
+	)
+
+	DescribeTable("validation",
+		func(rule HBARule, expectedError string) {
+			err := rule.Validate()
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(expectedError)))
+		},
+		Entry("accepts a CIDR", HBARule{Address: "fd00::/8", Method: "md5"}, ""),
+		Entry("accepts a host name", HBARule{Address: "db.example.com", Method: "md5"}, ""),
+		Entry("accepts a host name suffix", HBARule{Address: ".example.com", Method: "md5"}, ""),
+		Entry("accepts a keyword", HBARule{Address: "samenet", Method: "md5"}, ""),
+		Entry("rejects an invalid CIDR",
+			HBARule{Address: "10.0.0.0/33", Method: "md5"}, "invalid address"),
+		Entry("rejects an IP address without mask",
+			HBARule{Address: "10.0.0.1", Method: "md5"}, "CIDR notation"),
+		Entry("rejects an invalid host name",
+			HBARule{Address: "db example", Method: "md5"}, "not an IP address range"),
+		Entry("rejects a missing address",
+			HBARule{Method: "md5"}, "one of address and podSelectorRef is required"),
+		Entry("rejects both an address and a pod selector",
+			HBARule{Address: "all", PodSelectorRef: "app", Method: "md5"}, "mutually exclusive"),
+		Entry("rejects an address in a local rule",
+			HBARule{Type: HBARuleTypeLocal, Address: "all", Method: "peer"}, "local rules"),
+		Entry("rejects an empty user",
+			HBARule{Users: []string{""}, Address: "all", Method: "md5"}, "cannot be empty"),
+		Entry("rejects quotes in database names",
+			HBARule{Databases: []string{`a"b`}, Address: "all", Method: "md5"}, "double quotes"),
+		Entry("rejects invalid option names",
+			HBARule{Address: "all", Method: "md5", Options: map[string]string{"Bad Name": "x"}}, "invalid option name"),
+	)
+
+	It("applies the rules in a deterministic order", func() {
+		cluster := &Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					PgHBA: []string{"host all all 10.0.0.0/8 md5"},
+					HBARules: []HBARule{
+						{Address: "192.168.0.0/16", Method: "scram-sha-256"},
+						{Address: "10.0.0.1", Method: "scram-sha-256"},
+					},
+				},
+			},
+			Status: ClusterStatus{
+				HBA: &HBAStatus{
+					RoleRules: []string{"hostssl all app all cert"},
+				},
+				ConfigurationProfile: &AppliedConfigurationProfile{
+					Spec: PostgresConfigurationProfileSpec{
+						PgHBA: []string{"host all all 172.16.0.0/12 reject"},
+					},
+				},
+			},
+		}
+
+		Expect(cluster.GetPgHBA()).To(Equal([]string{
+			"host all all 10.0.0.0/8 md5",
+			"host all all 192.168.0.0/16 scram-sha-256",
+			"hostssl all app all cert",
+			"host all all 172.16.0.0/12 reject",
+		}))
+	})
+})
@@ -0,0 +1,74 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// GetSpec returns the Spec of the PostgresConfigurationProfile
+func (p *PostgresConfigurationProfile) GetSpec() *PostgresConfigurationProfileSpec {
+	return &p.Spec
+}
+
+// GetSpec returns the Spec of the ClusterPostgresConfigurationProfile
+func (p *ClusterPostgresConfigurationProfile) GetSpec() *PostgresConfigurationProfileSpec {
+	return &p.Spec
+}
+
+// ConfigurationProfileIdentifier returns a human-readable Kind/Name
+// identifier for a configuration profile, e.g.
+// "PostgresConfigurationProfile/baseline".
+func ConfigurationProfileIdentifier(profile GenericPostgresConfigurationProfile) string {
+	var kind string
+	switch profile.(type) {
+	case *PostgresConfigurationProfile:
+		kind = PostgresConfigurationProfileKind
+	case *ClusterPostgresConfigurationProfile:
+		kind = ClusterPostgresConfigurationProfileKind
+	default:
+		kind = "UnknownConfigurationProfile"
+	}
+	return kind + "/" + profile.GetName()
+}
+
+// ValidateParameters checks that the profile doesn't set parameters
+// which are validated or managed by the operator, as they can only be
+// set in the cluster
+func (spec *PostgresConfigurationProfileSpec) ValidateParameters() error {
+	var forbidden []string
+	for name := range spec.Parameters {
+		if _, isFixed := postgres.FixedConfigurationParameters[name]; isFixed ||
+			name == postgres.ParameterWalLevel {
+			forbidden = append(forbidden, name)
+		}
+	}
+	if len(forbidden) == 0 {
+		return nil
+	}
+
+	slices.Sort(forbidden)
+	return fmt.Errorf("parameters validated or managed by the operator cannot be set "+
+		"in a configuration profile: %s", strings.Join(forbidden, ", "))
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration profiles", func() {
+	It("identifies the kind of the profile", func() {
+		namespaced := &PostgresConfigurationProfile{ObjectMeta: metav1.ObjectMeta{Name: "baseline"}}
+		clusterWide := &ClusterPostgresConfigurationProfile{ObjectMeta: metav1.ObjectMeta{Name: "baseline"}}
+		Expect(ConfigurationProfileIdentifier(namespaced)).To(Equal("PostgresConfigurationProfile/baseline"))
+		Expect(ConfigurationProfileIdentifier(clusterWide)).To(Equal("ClusterPostgresConfigurationProfile/baseline"))
+	})
+
+	It("rejects the parameters managed by the operator", func() {
+		spec := PostgresConfigurationProfileSpec{
+			Parameters: map[string]string{
+				"work_mem":  "16MB",
+				"wal_level": "replica",
+				"port":      "5433",
+			},
+		}
+		err := spec.ValidateParameters()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HaveSuffix("port, wal_level"))
+
+		delete(spec.Parameters, "wal_level")
+		delete(spec.Parameters, "port")
+		Expect(spec.ValidateParameters()).To(Succeed())
+	})
+})
+
+var _ = Describe("cluster layered over a configuration profile", func() {
+	var cluster *Cluster
+
+	BeforeEach(func() {
+		cluster = &Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					Parameters: map[string]string{
+						"work_mem":        "64MB",
+						"wal_keep_size":   "512MB",
+						"max_wal_senders": "10",
+						"archive_timeout": "10min",
+					},
+					PgHBA:               []string{"host all app 10.0.0.0/8 scram-sha-256"},
+					AdditionalLibraries: []string{"pg_cron", "auto_explain"},
+				},
+				Monitoring: &MonitoringConfiguration{
+					CustomQueriesConfigMap: []ConfigMapKeySelector{
+						{LocalObjectReference: LocalObjectReference{Name: "cluster-queries"}, Key: "queries"},
+					},
+				},
+			},
+			Status: ClusterStatus{
+				ConfigurationProfile: &AppliedConfigurationProfile{
+					Kind:       PostgresConfigurationProfileKind,
+					Name:       "baseline",
+					Generation: 2,
+					Spec: PostgresConfigurationProfileSpec{
+						Parameters: map[string]string{
+							"work_mem":                   "16MB",
+							"wal_keep_size":              "1GB",
+							"archive_timeout":            "5min",
+							"log_min_duration_statement": "1s",
+						},
+						PgHBA:               []string{"host all all 0.0.0.0/0 reject"},
+						AdditionalLibraries: []string{"auto_explain", "pg_stat_statements"},
+						Monitoring: &ConfigurationProfileMonitoring{
+							CustomQueriesConfigMap: []ConfigMapKeySelector{
+								{LocalObjectReference: LocalObjectReference{Name: "shared-queries"}, Key: "queries"},
+							},
+							CustomQueriesSecret: []SecretKeySelector{
+								{LocalObjectReference: LocalObjectReference{Name: "shared-secret"}, Key: "queries"},
+							},
+							TLSConfig:         &ClusterMonitoringTLSConfiguration{Enabled: true},
+							MetricsQueriesTTL: &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("uses the parameters of the cluster, or of the profile when not set", func() {
+		Expect(cluster.GetPostgresParameters()).To(Equal(map[string]string{
+			"work_mem":                   "64MB",
+			"wal_keep_size":              "512MB",
+			"max_wal_senders":            "10",
+			"archive_timeout":            "10min",
+			"log_min_duration_statement": "1s",
+		}))
+	})
+
+	It("doesn't store the global defaults in a cluster referencing a profile", func() {
+		cluster.Spec.ImageName = "postgres:17"
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{
+			"work_mem":        "64MB",
+			"archive_timeout": "5min",
+		}
+		cluster.Spec.ConfigurationProfileRef = &ConfigurationProfileRef{
+			TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+				Kind: PostgresConfigurationProfileKind,
+				Name: "baseline",
+			},
+		}
+		cluster.SetDefaults()
+
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(Equal(map[string]string{
+			"work_mem":        "64MB",
+			"archive_timeout": "5min",
+		}))
+		parameters := cluster.GetPostgresParameters()
+		Expect(parameters).To(HaveKeyWithValue("archive_timeout", "5min"))
+		Expect(parameters).To(HaveKeyWithValue("wal_keep_size", "1GB"))
+	})
+
+	It("evaluates the pg_hba rules of the cluster first", func() {
+		Expect(cluster.GetPgHBA()).To(Equal([]string{
+			"host all app 10.0.0.0/8 scram-sha-256",
+			"host all all 0.0.0.0/0 reject",
+		}))
+	})
+
+	It("merges the shared preload libraries", func() {
+		Expect(cluster.GetAdditionalLibraries()).To(Equal([]string{
+			"auto_explain", "pg_stat_statements", "pg_cron",
+		}))
+	})
+
+	It("loads the custom queries of the profile first", func() {
+		Expect(cluster.GetCustomQueriesConfigMaps()).To(HaveLen(2))
+		Expect(cluster.GetCustomQueriesConfigMaps()[0].Name).To(Equal("shared-queries"))
+		Expect(cluster.GetCustomQueriesSecrets()).To(HaveLen(1))
+	})
+
+	It("uses the monitoring settings of the profile when not set in the cluster", func() {
+		Expect(cluster.IsMetricsTLSEnabled()).To(BeTrue())
+		Expect(cluster.GetMetricsQueriesTTL().Duration).To(Equal(time.Minute))
+
+		cluster.Spec.Monitoring.TLSConfig = &ClusterMonitoringTLSConfiguration{Enabled: false}
+		Expect(cluster.IsMetricsTLSEnabled()).To(BeFalse())
+	})
+
+	It("uses the configuration of the cluster when no profile is applied", func() {
+		cluster.Status.ConfigurationProfile = nil
+		Expect(cluster.GetPostgresParameters()).To(Equal(cluster.Spec.PostgresConfiguration.Parameters))
+		Expect(cluster.GetPgHBA()).To(Equal(cluster.Spec.PostgresConfiguration.PgHBA))
+		Expect(cluster.GetAdditionalLibraries()).To(Equal(cluster.Spec.PostgresConfiguration.AdditionalLibraries))
+		Expect(cluster.GetCustomQueriesSecrets()).To(BeEmpty())
+		Expect(cluster.IsMetricsTLSEnabled()).To(BeFalse())
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostgresConfigurationProfileSpec defines the PostgreSQL configuration
+// shared by the clusters referencing the profile
+type PostgresConfigurationProfileSpec struct {
+	// PostgreSQL configuration options (postgresql.conf), overridden by
+	// the ones set in the cluster
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// PostgreSQL Host Based Authentication rules (lines to be appended
+	// to the pg_hba.conf file), evaluated after the ones set in the cluster
+	// +optional
+	PgHBA []string `json:"pg_hba,omitempty"`
+
+	// Lists of shared preload libraries to add to the default ones
+	// +optional
+	AdditionalLibraries []string `json:"shared_preload_libraries,omitempty"`
+
+	// The monitoring configuration shared by the clusters
+	// +optional
+	Monitoring *ConfigurationProfileMonitoring `json:"monitoring,omitempty"`
+}
+
+// ConfigurationProfileMonitoring contains the monitoring settings that
+// can be shared through a configuration profile
+type ConfigurationProfileMonitoring struct {
+	// The list of config maps containing the custom queries, read from
+	// the namespace of each cluster and loaded before the ones set in
+	// the cluster
+	// +optional
+	CustomQueriesConfigMap []ConfigMapKeySelector `json:"customQueriesConfigMap,omitempty"`
+
+	// The list of secrets containing the custom queries, read from
+	// the namespace of each cluster and loaded before the ones set in
+	// the cluster
+	// +optional
+	CustomQueriesSecret []SecretKeySelector `json:"customQueriesSecret,omitempty"`
+
+	// Configure TLS communication for the metrics endpoint, unless
+	// configured in the cluster
+	// +optional
+	TLSConfig *ClusterMonitoringTLSConfiguration `json:"tls,omitempty"`
+
+	// The interval during which metrics computed from queries are
+	// considered current, unless configured in the cluster
+	// +optional
+	MetricsQueriesTTL *metav1.Duration `json:"metricsQueriesTTL,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PostgresConfigurationProfile is the Schema for the postgresconfigurationprofiles API
+type PostgresConfigurationProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Specification of the desired behavior of the PostgresConfigurationProfile.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec PostgresConfigurationProfileSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// PostgresConfigurationProfileList contains a list of PostgresConfigurationProfile
+type PostgresConfigurationProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	metav1.ListMeta `json:"metadata"`
+	// List of PostgresConfigurationProfiles
+	Items []PostgresConfigurationProfile `json:"items"`
+}
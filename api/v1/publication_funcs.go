@@ -53,6 +53,21 @@ func (pub *Publication) GetStatusApplied() *bool {
 	return pub.Status.Applied
 }
 
+// GetDriftDetection returns the drift detection configuration of the publication
+func (pub *Publication) GetDriftDetection() DriftDetectionConfiguration {
+	return pub.Spec.DriftDetectionConfiguration
+}
+
+// GetDriftStatus returns the outcome of the last drift check of the publication
+func (pub *Publication) GetDriftStatus() *DriftStatus {
+	return pub.Status.Drift
+}
+
+// SetDriftStatus sets the outcome of the last drift check of the publication
+func (pub *Publication) SetDriftStatus(status *DriftStatus) {
+	pub.Status.Drift = status
+}
+
 // GetClusterRef returns the cluster reference of the publication
 func (pub *Publication) GetClusterRef() corev1.LocalObjectReference {
 	return pub.Spec.ClusterRef
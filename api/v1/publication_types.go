@@ -38,6 +38,35 @@ const (
 	PublicationReclaimRetain PublicationReclaimPolicy = "retain"
 )
 
+// PublicationOperation is a DML operation published by a publication
+// +kubebuilder:validation:Enum=insert;update;delete;truncate
+type PublicationOperation string
+
+const (
+	// PublicationOperationInsert publishes the INSERT operations
+	PublicationOperationInsert PublicationOperation = "insert"
+
+	// PublicationOperationUpdate publishes the UPDATE operations
+	PublicationOperationUpdate PublicationOperation = "update"
+
+	// PublicationOperationDelete publishes the DELETE operations
+	PublicationOperationDelete PublicationOperation = "delete"
+
+	// PublicationOperationTruncate publishes the TRUNCATE operations
+	PublicationOperationTruncate PublicationOperation = "truncate"
+)
+
+// PublicationGeneratedColumns defines which generated columns are published
+type PublicationGeneratedColumns string
+
+const (
+	// PublicationGeneratedColumnsNone does not publish the generated columns
+	PublicationGeneratedColumnsNone PublicationGeneratedColumns = "none"
+
+	// PublicationGeneratedColumnsStored publishes the stored generated columns
+	PublicationGeneratedColumnsStored PublicationGeneratedColumns = "stored"
+)
+
 // PublicationSpec defines the desired state of Publication
 type PublicationSpec struct {
 	// The name of the PostgreSQL cluster that identifies the "publisher"
@@ -58,6 +87,28 @@ type PublicationSpec struct {
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
 
+	// The DML operations published by the publication. It maps to the
+	// `publish` parameter. If not specified, all the operations are
+	// published.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Publish []PublicationOperation `json:"publish,omitempty"`
+
+	// Whether the changes of the partitions of a partitioned table are
+	// published using the identity and schema of the partitioned table
+	// rather than the ones of the partitions. It maps to the
+	// `publish_via_partition_root` parameter.
+	// +optional
+	PublishViaPartitionRoot *bool `json:"publishViaPartitionRoot,omitempty"`
+
+	// Which generated columns are published when no column list is
+	// specified. It maps to the `publish_generated_columns` parameter,
+	// available since PostgreSQL 18.
+	// +kubebuilder:validation:Enum=none;stored
+	// +optional
+	PublishGeneratedColumns PublicationGeneratedColumns `json:"publishGeneratedColumns,omitempty"`
+
 	// Target of the publication as expected by PostgreSQL `CREATE PUBLICATION` command
 	Target PublicationTarget `json:"target"`
 
@@ -66,6 +117,10 @@ type PublicationSpec struct {
 	// +kubebuilder:default:=retain
 	// +optional
 	ReclaimPolicy PublicationReclaimPolicy `json:"publicationReclaimPolicy,omitempty"`
+
+	// The periodic detection of the drift of the publication in PostgreSQL
+	// from this specification
+	DriftDetectionConfiguration `json:",inline"`
 }
 
 // PublicationTarget is what this publication should publish
@@ -116,6 +171,16 @@ type PublicationTargetTable struct {
 	// The columns to publish
 	// +optional
 	Columns []string `json:"columns,omitempty"`
+
+	// The row filter: only the rows for which this boolean SQL expression
+	// evaluates to true are published. It maps to the `WHERE` clause of
+	// the table, available since PostgreSQL 15. Outside of its string
+	// constants, including the dollar-quoted ones, and of its quoted
+	// identifiers, it cannot contain `;` or comments, and its parentheses
+	// must be balanced. Backslashes are only allowed in the escape string
+	// constants (`E'...'`).
+	// +optional
+	Where string `json:"where,omitempty"`
 }
 
 // PublicationStatus defines the observed state of Publication
@@ -132,6 +197,11 @@ type PublicationStatus struct {
 	// Message is the reconciliation output message
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Drift is the outcome of the last comparison of the state of the
+	// publication in PostgreSQL with its specification
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
 }
 
 // +genclient
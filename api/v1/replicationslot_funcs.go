@@ -0,0 +1,94 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// SetAsFailed sets the replication slot as failed with the given error
+func (slot *ReplicationSlot) SetAsFailed(err error) {
+	slot.Status.Applied = ptr.To(false)
+	slot.Status.Message = err.Error()
+}
+
+// SetAsUnknown sets the replication slot as unknown with the given error
+func (slot *ReplicationSlot) SetAsUnknown(err error) {
+	slot.Status.Applied = nil
+	slot.Status.Message = err.Error()
+}
+
+// SetAsReady sets the replication slot as working correctly
+func (slot *ReplicationSlot) SetAsReady() {
+	slot.Status.Applied = ptr.To(true)
+	slot.Status.Message = ""
+	slot.Status.ObservedGeneration = slot.Generation
+}
+
+// GetStatusMessage returns the status message of the replication slot
+func (slot *ReplicationSlot) GetStatusMessage() string {
+	return slot.Status.Message
+}
+
+// GetStatusApplied returns the applied status of the replication slot
+func (slot *ReplicationSlot) GetStatusApplied() *bool {
+	return slot.Status.Applied
+}
+
+// GetClusterRef returns the cluster reference of the replication slot
+func (slot *ReplicationSlot) GetClusterRef() corev1.LocalObjectReference {
+	return slot.Spec.ClusterRef
+}
+
+// GetName returns the replication slot object name
+func (slot *ReplicationSlot) GetName() string {
+	return slot.Name
+}
+
+// GetManagedObjectName returns the name of the managed replication slot
+func (slot *ReplicationSlot) GetManagedObjectName() string {
+	return slot.Spec.Name
+}
+
+// HasReconciliations returns true if the replication slot has been reconciled at least once
+func (slot *ReplicationSlot) HasReconciliations() bool {
+	return slot.Status.ObservedGeneration > 0
+}
+
+// SetStatusObservedGeneration sets the observed generation of the replication slot
+func (slot *ReplicationSlot) SetStatusObservedGeneration(obsGeneration int64) {
+	slot.Status.ObservedGeneration = obsGeneration
+}
+
+// GetRetentionAction returns the action taken when the replication slot
+// retains more WAL than allowed
+func (slot *ReplicationSlot) GetRetentionAction() ReplicationSlotRetentionAction {
+	if slot.Spec.RetentionAction == "" {
+		return ReplicationSlotRetentionAlert
+	}
+	return slot.Spec.RetentionAction
+}
+
+// MustHaveManagedResourceExclusivity detects conflicting replication slots
+func (list *ReplicationSlotList) MustHaveManagedResourceExclusivity(reference *ReplicationSlot) error {
+	pointers := toSliceWithPointers(list.Items)
+	return ensureManagedResourceExclusivity(reference, pointers)
+}
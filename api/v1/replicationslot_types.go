@@ -0,0 +1,214 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicationSlotType is the type of a replication slot
+// +kubebuilder:validation:Enum=physical;logical
+type ReplicationSlotType string
+
+const (
+	// ReplicationSlotTypePhysical is a slot used by streaming replication
+	// clients, such as `pg_receivewal`
+	ReplicationSlotTypePhysical ReplicationSlotType = "physical"
+
+	// ReplicationSlotTypeLogical is a slot used by logical decoding
+	// clients, such as change data capture tools
+	ReplicationSlotTypeLogical ReplicationSlotType = "logical"
+)
+
+// ReplicationSlotReclaimPolicy describes a policy for end-of-life maintenance of replication slots.
+// +enum
+type ReplicationSlotReclaimPolicy string
+
+const (
+	// ReplicationSlotReclaimDelete means the replication slot will be dropped
+	// when the ReplicationSlot object is deleted
+	ReplicationSlotReclaimDelete ReplicationSlotReclaimPolicy = "delete"
+
+	// ReplicationSlotReclaimRetain means the replication slot will be left in
+	// PostgreSQL for manual reclamation by the administrator. The default
+	// policy is Retain.
+	ReplicationSlotReclaimRetain ReplicationSlotReclaimPolicy = "retain"
+)
+
+// ReplicationSlotRetentionAction is the action taken when a replication
+// slot retains more WAL than allowed
+// +kubebuilder:validation:Enum=alert;drop
+type ReplicationSlotRetentionAction string
+
+const (
+	// ReplicationSlotRetentionAlert reports the excess of retained WAL in the
+	// status and with a warning event
+	ReplicationSlotRetentionAlert ReplicationSlotRetentionAction = "alert"
+
+	// ReplicationSlotRetentionDrop drops the replication slot, terminating
+	// its consumer if connected
+	ReplicationSlotRetentionDrop ReplicationSlotRetentionAction = "drop"
+)
+
+// ReplicationSlotSpec defines the desired state of ReplicationSlot
+// +kubebuilder:validation:XValidation:rule="self.type != 'logical' || (has(self.database) && has(self.plugin))",message="database and plugin are required for logical replication slots"
+// +kubebuilder:validation:XValidation:rule="self.type == 'logical' || (!has(self.database) && !has(self.plugin) && !has(self.failover))",message="database, plugin and failover are only allowed for logical replication slots"
+type ReplicationSlotSpec struct {
+	// The name of the PostgreSQL cluster hosting the replication slot
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster reference is immutable after creation"
+	ClusterRef corev1.LocalObjectReference `json:"cluster"`
+
+	// The name of the replication slot inside PostgreSQL. It may only
+	// contain lower case letters, numbers, and the underscore character.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	// +kubebuilder:validation:Pattern=^[0-9a-z_]+$
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// The type of the replication slot
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type ReplicationSlotType `json:"type"`
+
+	// The database of a logical replication slot
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="database is immutable"
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// The output plugin of a logical replication slot, such as `pgoutput`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="plugin is immutable"
+	// +optional
+	Plugin string `json:"plugin,omitempty"`
+
+	// Whether the logical replication slot is synchronized to the standby
+	// servers, so that its consumer can continue after a failover. It
+	// requires PostgreSQL 17 or later and is only applied when the slot is
+	// created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="failover is immutable"
+	// +optional
+	Failover bool `json:"failover,omitempty"`
+
+	// The maximum size of the WAL files the replication slot can retain
+	// before the retention action is taken. No limit if not specified.
+	// +optional
+	MaxRetainedWAL *resource.Quantity `json:"maxRetainedWAL,omitempty"`
+
+	// The action taken when the replication slot retains more WAL than
+	// `maxRetainedWAL`: `alert` (default) or `drop`
+	// +kubebuilder:default:=alert
+	// +optional
+	RetentionAction ReplicationSlotRetentionAction `json:"retentionAction,omitempty"`
+
+	// The policy for end-of-life maintenance of this replication slot
+	// +kubebuilder:validation:Enum=delete;retain
+	// +kubebuilder:default:=retain
+	// +optional
+	ReclaimPolicy ReplicationSlotReclaimPolicy `json:"replicationSlotReclaimPolicy,omitempty"`
+}
+
+// ReplicationSlotStatus defines the observed state of ReplicationSlot
+type ReplicationSlotStatus struct {
+	// A sequence number representing the latest
+	// desired state that was synchronized
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Applied is true if the replication slot was reconciled correctly
+	// +optional
+	Applied *bool `json:"applied,omitempty"`
+
+	// Message is the reconciliation output message
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// When the state of the replication slot was last refreshed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// Whether a consumer is connected to the replication slot
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// The oldest write-ahead log location required by the consumer
+	// +optional
+	RestartLSN string `json:"restartLSN,omitempty"`
+
+	// The write-ahead log location up to which the consumer of a logical
+	// replication slot confirmed receiving the changes
+	// +optional
+	ConfirmedFlushLSN string `json:"confirmedFlushLSN,omitempty"`
+
+	// The availability of the WAL files required by the replication slot,
+	// from `pg_replication_slots`: `reserved`, `extended`, `unreserved` or
+	// `lost`
+	// +optional
+	WALStatus string `json:"walStatus,omitempty"`
+
+	// The size in bytes of the WAL retained by the replication slot
+	// +optional
+	RetainedWALBytes int64 `json:"retainedWALBytes,omitempty"`
+
+	// The distance in bytes between the current write-ahead log location
+	// and the position confirmed by the consumer
+	// +optional
+	LagBytes int64 `json:"lagBytes,omitempty"`
+
+	// Since when the replication slot retains more WAL than
+	// `maxRetainedWAL`, empty if it does not
+	// +optional
+	RetentionExceededTime *metav1.Time `json:"retentionExceededTime,omitempty"`
+
+	// When the replication slot was dropped because it retained more WAL
+	// than `maxRetainedWAL`. The slot is not created again until the
+	// specification changes.
+	// +optional
+	DroppedTime *metav1.Time `json:"droppedTime,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster.name"
+// +kubebuilder:printcolumn:name="PG Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.active"
+// +kubebuilder:printcolumn:name="Retained WAL",type="integer",JSONPath=".status.retainedWALBytes"
+// +kubebuilder:printcolumn:name="Applied",type="boolean",JSONPath=".status.applied"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Latest reconciliation message"
+
+// ReplicationSlot is the Schema for the replicationslots API
+type ReplicationSlot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   ReplicationSlotSpec   `json:"spec"`
+	Status ReplicationSlotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReplicationSlotList contains a list of ReplicationSlot
+type ReplicationSlotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReplicationSlot `json:"items"`
+}
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
@@ -53,6 +55,21 @@ func (sub *Subscription) GetStatusApplied() *bool {
 	return sub.Status.Applied
 }
 
+// GetDriftDetection returns the drift detection configuration of the subscription
+func (sub *Subscription) GetDriftDetection() DriftDetectionConfiguration {
+	return sub.Spec.DriftDetectionConfiguration
+}
+
+// GetDriftStatus returns the outcome of the last drift check of the subscription
+func (sub *Subscription) GetDriftStatus() *DriftStatus {
+	return sub.Status.Drift
+}
+
+// SetDriftStatus sets the outcome of the last drift check of the subscription
+func (sub *Subscription) SetDriftStatus(status *DriftStatus) {
+	sub.Status.Drift = status
+}
+
 // GetClusterRef returns the cluster reference of the subscription
 func (sub *Subscription) GetClusterRef() corev1.LocalObjectReference {
 	return sub.Spec.ClusterRef
@@ -78,6 +95,23 @@ func (sub *Subscription) SetStatusObservedGeneration(obsGeneration int64) {
 	sub.Status.ObservedGeneration = obsGeneration
 }
 
+// GetMode returns the mode of the synchronization of the sequences
+func (configuration *SubscriptionSequenceSyncConfiguration) GetMode() SubscriptionSequenceSyncMode {
+	if configuration.Mode == "" {
+		return SubscriptionSequenceSyncModePeriodic
+	}
+	return configuration.Mode
+}
+
+// GetInterval returns the interval between two synchronizations of the
+// sequences in the periodic mode
+func (configuration *SubscriptionSequenceSyncConfiguration) GetInterval() time.Duration {
+	if configuration.Interval == nil || configuration.Interval.Duration <= 0 {
+		return DefaultSubscriptionSequenceSyncInterval
+	}
+	return configuration.Interval.Duration
+}
+
 // MustHaveManagedResourceExclusivity detects conflicting subscriptions
 func (pub *SubscriptionList) MustHaveManagedResourceExclusivity(reference *Subscription) error {
 	pointers := toSliceWithPointers(pub.Items)
@@ -20,6 +20,8 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -78,6 +80,82 @@ type SubscriptionSpec struct {
 	// +kubebuilder:default:=retain
 	// +optional
 	ReclaimPolicy SubscriptionReclaimPolicy `json:"subscriptionReclaimPolicy,omitempty"`
+
+	// The periodic detection of the drift of the subscription in PostgreSQL
+	// from this specification
+	DriftDetectionConfiguration `json:",inline"`
+
+	// The synchronization of the values of the sequences from the
+	// publisher, which are not replicated by logical replication
+	// +optional
+	SequenceSync *SubscriptionSequenceSyncConfiguration `json:"sequenceSync,omitempty"`
+}
+
+// SubscriptionSequenceSyncMode is the way the sequences of a subscription
+// are synchronized from the publisher
+// +kubebuilder:validation:Enum=periodic;onDemand
+type SubscriptionSequenceSyncMode string
+
+const (
+	// SubscriptionSequenceSyncModePeriodic means that the sequences are
+	// synchronized at every interval, as well as on demand
+	SubscriptionSequenceSyncModePeriodic SubscriptionSequenceSyncMode = "periodic"
+
+	// SubscriptionSequenceSyncModeOnDemand means that the sequences are
+	// synchronized only when requested with the `cnpg.io/syncSequences`
+	// annotation
+	SubscriptionSequenceSyncModeOnDemand SubscriptionSequenceSyncMode = "onDemand"
+)
+
+// DefaultSubscriptionSequenceSyncInterval is the interval between two
+// synchronizations of the sequences in the `periodic` mode
+const DefaultSubscriptionSequenceSyncInterval = 5 * time.Minute
+
+// SubscriptionSequenceSyncConfiguration configures the synchronization of
+// the sequences of a subscription from the publisher
+type SubscriptionSequenceSyncConfiguration struct {
+	// The synchronization mode: `periodic` (default) synchronizes the
+	// sequences at every interval, `onDemand` only when the
+	// `cnpg.io/syncSequences` annotation changes
+	// +kubebuilder:default:=periodic
+	// +optional
+	Mode SubscriptionSequenceSyncMode `json:"mode,omitempty"`
+
+	// The interval between two synchronizations in the `periodic` mode.
+	// Defaults to 5 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// The number by which the values of the sequences of the publisher
+	// are moved forward, in the direction of each sequence, before
+	// setting them in the subscriber
+	// +optional
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// SubscriptionSequenceSyncStatus is the outcome of the synchronization of
+// the sequences of a subscription from the publisher
+type SubscriptionSequenceSyncStatus struct {
+	// When the sequences were last synchronized successfully
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// When the sequences were last synchronized, successfully or not
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// The number of sequences set by the last successful synchronization
+	// +optional
+	SynchronizedSequences int `json:"synchronizedSequences,omitempty"`
+
+	// The value of the `cnpg.io/syncSequences` annotation handled by the
+	// last synchronization
+	// +optional
+	LastRequest string `json:"lastRequest,omitempty"`
+
+	// The error of the last synchronization, empty if it succeeded
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // SubscriptionStatus defines the observed state of Subscription
@@ -94,6 +172,130 @@ type SubscriptionStatus struct {
 	// Message is the reconciliation output message
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Drift is the outcome of the last comparison of the state of the
+	// subscription in PostgreSQL with its specification
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
+
+	// Replication is the state of the logical replication of the
+	// subscription, periodically refreshed by the primary instance
+	// +optional
+	Replication *SubscriptionReplicationStatus `json:"replication,omitempty"`
+
+	// SequenceSync is the outcome of the last synchronization of the
+	// sequences from the publisher
+	// +optional
+	SequenceSync *SubscriptionSequenceSyncStatus `json:"sequenceSync,omitempty"`
+}
+
+// SubscriptionTableState is the synchronization state of a table of a
+// subscription
+// +kubebuilder:validation:Enum=init;dataCopy;finishedCopy;synchronized;ready;unknown
+type SubscriptionTableState string
+
+const (
+	// SubscriptionTableStateInit means that the synchronization of the
+	// table is being initialized
+	SubscriptionTableStateInit SubscriptionTableState = "init"
+
+	// SubscriptionTableStateDataCopy means that the data of the table is
+	// being copied
+	SubscriptionTableStateDataCopy SubscriptionTableState = "dataCopy"
+
+	// SubscriptionTableStateFinishedCopy means that the data of the table
+	// has been copied
+	SubscriptionTableStateFinishedCopy SubscriptionTableState = "finishedCopy"
+
+	// SubscriptionTableStateSynchronized means that the table is
+	// synchronized with the apply worker
+	SubscriptionTableStateSynchronized SubscriptionTableState = "synchronized"
+
+	// SubscriptionTableStateReady means that the changes of the table are
+	// applied by the apply worker
+	SubscriptionTableStateReady SubscriptionTableState = "ready"
+
+	// SubscriptionTableStateUnknown is a state not known by the operator
+	SubscriptionTableStateUnknown SubscriptionTableState = "unknown"
+)
+
+// SubscriptionTableStatus is the synchronization state of a table of a
+// subscription
+type SubscriptionTableStatus struct {
+	// The schema-qualified name of the table
+	Name string `json:"name"`
+
+	// The synchronization state of the table, from `pg_subscription_rel`
+	State SubscriptionTableState `json:"state"`
+}
+
+// SubscriptionWorkerStatus is a running worker of a subscription
+type SubscriptionWorkerStatus struct {
+	// The type of the worker: `apply`, `parallel apply` or
+	// `table synchronization`
+	Type string `json:"type"`
+
+	// The process ID of the worker
+	PID int64 `json:"pid"`
+
+	// The schema-qualified name of the table being synchronized
+	// +optional
+	Table string `json:"table,omitempty"`
+
+	// The last write-ahead log location received
+	// +optional
+	ReceivedLSN string `json:"receivedLSN,omitempty"`
+
+	// The last write-ahead log location reported to the publisher
+	// +optional
+	LatestEndLSN string `json:"latestEndLSN,omitempty"`
+
+	// When the last write-ahead log location was reported to the publisher
+	// +optional
+	LatestEndTime *metav1.Time `json:"latestEndTime,omitempty"`
+}
+
+// SubscriptionReplicationStatus is the state of the logical replication of
+// a subscription
+type SubscriptionReplicationStatus struct {
+	// When the state was last refreshed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// The number of tables of the subscription in each synchronization
+	// state
+	// +optional
+	TableStates map[SubscriptionTableState]int `json:"tableStates,omitempty"`
+
+	// The synchronization state of the tables of the subscription,
+	// listing first the ones not ready. Only the first 1000 tables are
+	// reported.
+	// +optional
+	Tables []SubscriptionTableStatus `json:"tables,omitempty"`
+
+	// The running workers of the subscription, from `pg_stat_subscription`
+	// +optional
+	Workers []SubscriptionWorkerStatus `json:"workers,omitempty"`
+
+	// The time elapsed since the apply worker last reported its position
+	// to the publisher
+	// +optional
+	ApplyLag *metav1.Duration `json:"applyLag,omitempty"`
+
+	// The number of errors occurred while applying changes, from
+	// `pg_stat_subscription_stats` (PostgreSQL 15+)
+	// +optional
+	ApplyErrorCount int64 `json:"applyErrorCount,omitempty"`
+
+	// The number of errors occurred during the initial table
+	// synchronization, from `pg_stat_subscription_stats` (PostgreSQL 15+)
+	// +optional
+	SyncErrorCount int64 `json:"syncErrorCount,omitempty"`
+
+	// When an increase of the error counters was last observed. The error
+	// messages are only available in the PostgreSQL logs.
+	// +optional
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
 }
 
 // +genclient
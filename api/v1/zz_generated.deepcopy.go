@@ -80,6 +80,22 @@ func (in *AffinityConfiguration) DeepCopy() *AffinityConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedConfigurationProfile) DeepCopyInto(out *AppliedConfigurationProfile) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedConfigurationProfile.
+func (in *AppliedConfigurationProfile) DeepCopy() *AppliedConfigurationProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedConfigurationProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AvailableArchitecture) DeepCopyInto(out *AvailableArchitecture) {
 	*out = *in
@@ -353,6 +369,48 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseBackupProgress) DeepCopyInto(out *BaseBackupProgress) {
+	*out = *in
+	if in.BackupTotal != nil {
+		in, out := &in.BackupTotal, &out.BackupTotal
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseBackupProgress.
+func (in *BaseBackupProgress) DeepCopy() *BaseBackupProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseBackupProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapClone) DeepCopyInto(out *BootstrapClone) {
+	*out = *in
+	out.Cluster = in.Cluster
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = new(DataSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapClone.
+func (in *BootstrapClone) DeepCopy() *BootstrapClone {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 	*out = *in
@@ -371,6 +429,11 @@ func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 		*out = new(BootstrapPgBaseBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(BootstrapClone)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapConfiguration.
@@ -456,6 +519,19 @@ func (in *BootstrapPgBaseBackup) DeepCopyInto(out *BootstrapPgBaseBackup) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.PostRecoverySQL != nil {
+		in, out := &in.PostRecoverySQL, &out.PostRecoverySQL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostRecoverySQLRefs != nil {
+		in, out := &in.PostRecoverySQLRefs, &out.PostRecoverySQLRefs
+		*out = make([]PostRecoverySQLRefs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PgBaseBackupOptions.DeepCopyInto(&out.PgBaseBackupOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapPgBaseBackup.
@@ -491,6 +567,18 @@ func (in *BootstrapRecovery) DeepCopyInto(out *BootstrapRecovery) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.PostRecoverySQL != nil {
+		in, out := &in.PostRecoverySQL, &out.PostRecoverySQL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostRecoverySQLRefs != nil {
+		in, out := &in.PostRecoverySQLRefs, &out.PostRecoverySQLRefs
+		*out = make([]PostRecoverySQLRefs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapRecovery.
@@ -503,6 +591,125 @@ func (in *BootstrapRecovery) DeepCopy() *BootstrapRecovery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassAccess) DeepCopyInto(out *BreakGlassAccess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassAccess.
+func (in *BreakGlassAccess) DeepCopy() *BreakGlassAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakGlassAccess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassAccessList) DeepCopyInto(out *BreakGlassAccessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BreakGlassAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassAccessList.
+func (in *BreakGlassAccessList) DeepCopy() *BreakGlassAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BreakGlassAccessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassAccessSpec) DeepCopyInto(out *BreakGlassAccessSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.HBARule != nil {
+		in, out := &in.HBARule, &out.HBARule
+		*out = new(HBARule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InRoles != nil {
+		in, out := &in.InRoles, &out.InRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassAccessSpec.
+func (in *BreakGlassAccessSpec) DeepCopy() *BreakGlassAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassAccessStatus) DeepCopyInto(out *BreakGlassAccessStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GrantTime != nil {
+		in, out := &in.GrantTime, &out.GrantTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GrantedRoles != nil {
+		in, out := &in.GrantedRoles, &out.GrantedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassAccessStatus.
+func (in *BreakGlassAccessStatus) DeepCopy() *BreakGlassAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogComponentImage) DeepCopyInto(out *CatalogComponentImage) {
 	*out = *in
@@ -645,6 +852,214 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBranch) DeepCopyInto(out *ClusterBranch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBranch.
+func (in *ClusterBranch) DeepCopy() *ClusterBranch {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBranch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBranch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBranchLineage) DeepCopyInto(out *ClusterBranchLineage) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBranchLineage.
+func (in *ClusterBranchLineage) DeepCopy() *ClusterBranchLineage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBranchLineage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBranchList) DeepCopyInto(out *ClusterBranchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterBranch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBranchList.
+func (in *ClusterBranchList) DeepCopy() *ClusterBranchList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBranchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBranchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBranchSpec) DeepCopyInto(out *ClusterBranchSpec) {
+	*out = *in
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBranchSpec.
+func (in *ClusterBranchSpec) DeepCopy() *ClusterBranchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBranchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBranchStatus) DeepCopyInto(out *ClusterBranchStatus) {
+	*out = *in
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Lineage != nil {
+		in, out := &in.Lineage, &out.Lineage
+		*out = make([]ClusterBranchLineage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBranchStatus.
+func (in *ClusterBranchStatus) DeepCopy() *ClusterBranchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBranchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloneGrant) DeepCopyInto(out *ClusterCloneGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloneGrant.
+func (in *ClusterCloneGrant) DeepCopy() *ClusterCloneGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloneGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCloneGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloneGrantList) DeepCopyInto(out *ClusterCloneGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCloneGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloneGrantList.
+func (in *ClusterCloneGrantList) DeepCopy() *ClusterCloneGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloneGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCloneGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloneGrantSpec) DeepCopyInto(out *ClusterCloneGrantSpec) {
+	*out = *in
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloneGrantSpec.
+func (in *ClusterCloneGrantSpec) DeepCopy() *ClusterCloneGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloneGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImageCatalog) DeepCopyInto(out *ClusterImageCatalog) {
 	*out = *in
@@ -717,35 +1132,289 @@ func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
-func (in *ClusterList) DeepCopy() *ClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMonitoringTLSConfiguration) DeepCopyInto(out *ClusterMonitoringTLSConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitoringTLSConfiguration.
+func (in *ClusterMonitoringTLSConfiguration) DeepCopy() *ClusterMonitoringTLSConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMonitoringTLSConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPostgresConfigurationProfile) DeepCopyInto(out *ClusterPostgresConfigurationProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPostgresConfigurationProfile.
+func (in *ClusterPostgresConfigurationProfile) DeepCopy() *ClusterPostgresConfigurationProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPostgresConfigurationProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPostgresConfigurationProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPostgresConfigurationProfileList) DeepCopyInto(out *ClusterPostgresConfigurationProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPostgresConfigurationProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPostgresConfigurationProfileList.
+func (in *ClusterPostgresConfigurationProfileList) DeepCopy() *ClusterPostgresConfigurationProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPostgresConfigurationProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPostgresConfigurationProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRefresh) DeepCopyInto(out *ClusterRefresh) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefresh.
+func (in *ClusterRefresh) DeepCopy() *ClusterRefresh {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRefresh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRefresh) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRefreshList) DeepCopyInto(out *ClusterRefreshList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRefresh, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefreshList.
+func (in *ClusterRefreshList) DeepCopy() *ClusterRefreshList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRefreshList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRefreshList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRefreshRequest) DeepCopyInto(out *ClusterRefreshRequest) {
+	*out = *in
+	if in.PostRecoverySQL != nil {
+		in, out := &in.PostRecoverySQL, &out.PostRecoverySQL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostRecoverySQLRefs != nil {
+		in, out := &in.PostRecoverySQLRefs, &out.PostRecoverySQLRefs
+		*out = make([]PostRecoverySQLRefs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefreshRequest.
+func (in *ClusterRefreshRequest) DeepCopy() *ClusterRefreshRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRefreshRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRefreshSource) DeepCopyInto(out *ClusterRefreshSource) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefreshSource.
+func (in *ClusterRefreshSource) DeepCopy() *ClusterRefreshSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRefreshSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRefreshSpec) DeepCopyInto(out *ClusterRefreshSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Immediate != nil {
+		in, out := &in.Immediate, &out.Immediate
+		*out = new(bool)
+		**out = **in
+	}
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.Source.DeepCopyInto(&out.Source)
+	if in.PostRecoverySQL != nil {
+		in, out := &in.PostRecoverySQL, &out.PostRecoverySQL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostRecoverySQLRefs != nil {
+		in, out := &in.PostRecoverySQLRefs, &out.PostRecoverySQLRefs
+		*out = make([]PostRecoverySQLRefs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefreshSpec.
+func (in *ClusterRefreshSpec) DeepCopy() *ClusterRefreshSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterList)
+	out := new(ClusterRefreshSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMonitoringTLSConfiguration) DeepCopyInto(out *ClusterMonitoringTLSConfiguration) {
+func (in *ClusterRefreshStatus) DeepCopyInto(out *ClusterRefreshStatus) {
 	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitoringTLSConfiguration.
-func (in *ClusterMonitoringTLSConfiguration) DeepCopy() *ClusterMonitoringTLSConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRefreshStatus.
+func (in *ClusterRefreshStatus) DeepCopy() *ClusterRefreshStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMonitoringTLSConfiguration)
+	out := new(ClusterRefreshStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -764,6 +1433,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.PostgresConfiguration.DeepCopyInto(&out.PostgresConfiguration)
+	if in.ConfigurationProfileRef != nil {
+		in, out := &in.ConfigurationProfileRef, &out.ConfigurationProfileRef
+		*out = new(ConfigurationProfileRef)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodSelectorRefs != nil {
 		in, out := &in.PodSelectorRefs, &out.PodSelectorRefs
 		*out = make([]PodSelectorRef, len(*in))
@@ -776,6 +1450,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ReplicationSlotsConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Join != nil {
+		in, out := &in.Join, &out.Join
+		*out = new(PgBaseBackupOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Bootstrap != nil {
 		in, out := &in.Bootstrap, &out.Bootstrap
 		*out = new(BootstrapConfiguration)
@@ -987,6 +1666,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HBA != nil {
+		in, out := &in.HBA, &out.HBA
+		*out = new(HBAStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Topology.DeepCopyInto(&out.Topology)
 	if in.DanglingPVC != nil {
 		in, out := &in.DanglingPVC, &out.DanglingPVC
@@ -1062,6 +1746,58 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(ImageInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MajorUpgradeRollbackPoint != nil {
+		in, out := &in.MajorUpgradeRollbackPoint, &out.MajorUpgradeRollbackPoint
+		*out = new(MajorUpgradeRollbackPoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MajorUpgradeFinalization != nil {
+		in, out := &in.MajorUpgradeFinalization, &out.MajorUpgradeFinalization
+		*out = new(MajorUpgradeFinalizationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(ClusterRefreshRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceParameters != nil {
+		in, out := &in.InstanceParameters, &out.InstanceParameters
+		*out = make(map[PodName]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TunedParameters != nil {
+		in, out := &in.TunedParameters, &out.TunedParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigurationProfile != nil {
+		in, out := &in.ConfigurationProfile, &out.ConfigurationProfile
+		*out = new(AppliedConfigurationProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BaseBackupProgress != nil {
+		in, out := &in.BaseBackupProgress, &out.BaseBackupProgress
+		*out = make(map[PodName]BaseBackupProgress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.PluginStatus != nil {
 		in, out := &in.PluginStatus, &out.PluginStatus
 		*out = make([]PluginStatus, len(*in))
@@ -1092,6 +1828,13 @@ func (in *ConfigMapResourceVersion) DeepCopyInto(out *ConfigMapResourceVersion)
 			(*out)[key] = val
 		}
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapResourceVersion.
@@ -1104,6 +1847,57 @@ func (in *ConfigMapResourceVersion) DeepCopy() *ConfigMapResourceVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationProfileMonitoring) DeepCopyInto(out *ConfigurationProfileMonitoring) {
+	*out = *in
+	if in.CustomQueriesConfigMap != nil {
+		in, out := &in.CustomQueriesConfigMap, &out.CustomQueriesConfigMap
+		*out = make([]ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomQueriesSecret != nil {
+		in, out := &in.CustomQueriesSecret, &out.CustomQueriesSecret
+		*out = make([]SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(ClusterMonitoringTLSConfiguration)
+		**out = **in
+	}
+	if in.MetricsQueriesTTL != nil {
+		in, out := &in.MetricsQueriesTTL, &out.MetricsQueriesTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationProfileMonitoring.
+func (in *ConfigurationProfileMonitoring) DeepCopy() *ConfigurationProfileMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationProfileMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationProfileRef) DeepCopyInto(out *ConfigurationProfileRef) {
+	*out = *in
+	in.TypedLocalObjectReference.DeepCopyInto(&out.TypedLocalObjectReference)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationProfileRef.
+func (in *ConfigurationProfileRef) DeepCopy() *ConfigurationProfileRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationProfileRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
@@ -1305,6 +2099,13 @@ func (in *DatabaseRoleSpec) DeepCopyInto(out *DatabaseRoleSpec) {
 		*out = new(ClientCertificateConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HBARules != nil {
+		in, out := &in.HBARules, &out.HBARules
+		*out = make([]HBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRoleSpec.
@@ -1325,11 +2126,23 @@ func (in *DatabaseRoleStatus) DeepCopyInto(out *DatabaseRoleStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ClientCertificate != nil {
 		in, out := &in.ClientCertificate, &out.ClientCertificate
 		*out = new(ClientCertificateState)
 		**out = **in
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationState)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1368,6 +2181,13 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Schemas != nil {
 		in, out := &in.Schemas, &out.Schemas
 		*out = make([]SchemaSpec, len(*in))
@@ -1392,6 +2212,21 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]PrivilegeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultPrivileges != nil {
+		in, out := &in.DefaultPrivileges, &out.DefaultPrivileges
+		*out = make([]DefaultPrivilegeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.DriftDetectionConfiguration.DeepCopyInto(&out.DriftDetectionConfiguration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -1432,6 +2267,33 @@ func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
 		*out = make([]DatabaseObjectStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]DatabaseObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]DatabaseObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultPrivileges != nil {
+		in, out := &in.DefaultPrivileges, &out.DefaultPrivileges
+		*out = make([]DatabaseObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretResourceVersions != nil {
+		in, out := &in.SecretResourceVersions, &out.SecretResourceVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
@@ -1444,6 +2306,74 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultPrivilegeSpec) DeepCopyInto(out *DefaultPrivilegeSpec) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultPrivilegeSpec.
+func (in *DefaultPrivilegeSpec) DeepCopy() *DefaultPrivilegeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultPrivilegeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionConfiguration) DeepCopyInto(out *DriftDetectionConfiguration) {
+	*out = *in
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionConfiguration.
+func (in *DriftDetectionConfiguration) DeepCopy() *DriftDetectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftStatus) DeepCopyInto(out *DriftStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Differences != nil {
+		in, out := &in.Differences, &out.Differences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCorrectionTime != nil {
+		in, out := &in.LastCorrectionTime, &out.LastCorrectionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftStatus.
+func (in *DriftStatus) DeepCopy() *DriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -1707,21 +2637,87 @@ func (in *FailoverQuorumList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailoverQuorumStatus) DeepCopyInto(out *FailoverQuorumStatus) {
+func (in *FailoverQuorumStatus) DeepCopyInto(out *FailoverQuorumStatus) {
+	*out = *in
+	if in.StandbyNames != nil {
+		in, out := &in.StandbyNames, &out.StandbyNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverQuorumStatus.
+func (in *FailoverQuorumStatus) DeepCopy() *FailoverQuorumStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverQuorumStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HBARule) DeepCopyInto(out *HBARule) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HBARule.
+func (in *HBARule) DeepCopy() *HBARule {
+	if in == nil {
+		return nil
+	}
+	out := new(HBARule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HBAStatus) DeepCopyInto(out *HBAStatus) {
 	*out = *in
-	if in.StandbyNames != nil {
-		in, out := &in.StandbyNames, &out.StandbyNames
+	if in.BreakGlassRules != nil {
+		in, out := &in.BreakGlassRules, &out.BreakGlassRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BreakGlassExpirationTime != nil {
+		in, out := &in.BreakGlassExpirationTime, &out.BreakGlassExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RoleRules != nil {
+		in, out := &in.RoleRules, &out.RoleRules
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RejectedRules != nil {
+		in, out := &in.RejectedRules, &out.RejectedRules
+		*out = make([]RejectedHBARule, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverQuorumStatus.
-func (in *FailoverQuorumStatus) DeepCopy() *FailoverQuorumStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HBAStatus.
+func (in *HBAStatus) DeepCopy() *HBAStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FailoverQuorumStatus)
+	out := new(HBAStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1951,6 +2947,33 @@ func (in *InstanceID) DeepCopy() *InstanceID {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceParametersOverride) DeepCopyInto(out *InstanceParametersOverride) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceParametersOverride.
+func (in *InstanceParametersOverride) DeepCopy() *InstanceParametersOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceParametersOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceReportedState) DeepCopyInto(out *InstanceReportedState) {
 	*out = *in
@@ -2067,6 +3090,46 @@ func (in *LivenessProbe) DeepCopy() *LivenessProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MajorUpgradeFinalizationStatus) DeepCopyInto(out *MajorUpgradeFinalizationStatus) {
+	*out = *in
+	if in.CompletedDatabases != nil {
+		in, out := &in.CompletedDatabases, &out.CompletedDatabases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorUpgradeFinalizationStatus.
+func (in *MajorUpgradeFinalizationStatus) DeepCopy() *MajorUpgradeFinalizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MajorUpgradeFinalizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MajorUpgradeRollbackPoint) DeepCopyInto(out *MajorUpgradeRollbackPoint) {
+	*out = *in
+	in.PGDataImageInfo.DeepCopyInto(&out.PGDataImageInfo)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MajorUpgradeRollbackPoint.
+func (in *MajorUpgradeRollbackPoint) DeepCopy() *MajorUpgradeRollbackPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MajorUpgradeRollbackPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
 	*out = *in
@@ -2133,7 +3196,25 @@ func (in *ManagedRoles) DeepCopyInto(out *ManagedRoles) {
 		in, out := &in.PasswordStatus, &out.PasswordStatus
 		*out = make(map[string]PasswordState, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
 		}
 	}
 }
@@ -2334,9 +3415,92 @@ func (in *OptionSpec) DeepCopy() *OptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterSource) DeepCopyInto(out *ParameterSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterSource.
+func (in *ParameterSource) DeepCopy() *ParameterSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationConfiguration) DeepCopyInto(out *PasswordRotationConfiguration) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AlternatePasswordSecret != nil {
+		in, out := &in.AlternatePasswordSecret, &out.AlternatePasswordSecret
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationConfiguration.
+func (in *PasswordRotationConfiguration) DeepCopy() *PasswordRotationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationState) DeepCopyInto(out *PasswordRotationState) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GracePeriodEnd != nil {
+		in, out := &in.GracePeriodEnd, &out.GracePeriodEnd
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationState.
+func (in *PasswordRotationState) DeepCopy() *PasswordRotationState {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PasswordState) DeepCopyInto(out *PasswordState) {
 	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(PasswordRotationState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordState.
@@ -2349,6 +3513,26 @@ func (in *PasswordState) DeepCopy() *PasswordState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PgBaseBackupOptions) DeepCopyInto(out *PgBaseBackupOptions) {
+	*out = *in
+	if in.VerifyChecksums != nil {
+		in, out := &in.VerifyChecksums, &out.VerifyChecksums
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgBaseBackupOptions.
+func (in *PgBaseBackupOptions) DeepCopy() *PgBaseBackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PgBaseBackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PgBouncerIntegrationStatus) DeepCopyInto(out *PgBouncerIntegrationStatus) {
 	*out = *in
@@ -2807,6 +3991,22 @@ func (in *PoolerStatus) DeepCopy() *PoolerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostRecoverySQLRefs) DeepCopyInto(out *PostRecoverySQLRefs) {
+	*out = *in
+	in.SQLRefs.DeepCopyInto(&out.SQLRefs)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostRecoverySQLRefs.
+func (in *PostRecoverySQLRefs) DeepCopy() *PostRecoverySQLRefs {
+	if in == nil {
+		return nil
+	}
+	out := new(PostRecoverySQLRefs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 	*out = *in
@@ -2817,6 +4017,20 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	if in.ParametersFrom != nil {
+		in, out := &in.ParametersFrom, &out.ParametersFrom
+		*out = make([]ParameterSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstanceOverrides != nil {
+		in, out := &in.InstanceOverrides, &out.InstanceOverrides
+		*out = make([]InstanceParametersOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Synchronous != nil {
 		in, out := &in.Synchronous, &out.Synchronous
 		*out = new(SynchronousReplicaConfiguration)
@@ -2827,6 +4041,13 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HBARules != nil {
+		in, out := &in.HBARules, &out.HBARules
+		*out = make([]HBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PgIdent != nil {
 		in, out := &in.PgIdent, &out.PgIdent
 		*out = make([]string, len(*in))
@@ -2862,6 +4083,101 @@ func (in *PostgresConfiguration) DeepCopy() *PostgresConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresConfigurationProfile) DeepCopyInto(out *PostgresConfigurationProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfigurationProfile.
+func (in *PostgresConfigurationProfile) DeepCopy() *PostgresConfigurationProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresConfigurationProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresConfigurationProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresConfigurationProfileList) DeepCopyInto(out *PostgresConfigurationProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PostgresConfigurationProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfigurationProfileList.
+func (in *PostgresConfigurationProfileList) DeepCopy() *PostgresConfigurationProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresConfigurationProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PostgresConfigurationProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresConfigurationProfileSpec) DeepCopyInto(out *PostgresConfigurationProfileSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PgHBA != nil {
+		in, out := &in.PgHBA, &out.PgHBA
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalLibraries != nil {
+		in, out := &in.AdditionalLibraries, &out.AdditionalLibraries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ConfigurationProfileMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfigurationProfileSpec.
+func (in *PostgresConfigurationProfileSpec) DeepCopy() *PostgresConfigurationProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresConfigurationProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrimaryLeaseConfiguration) DeepCopyInto(out *PrimaryLeaseConfiguration) {
 	*out = *in
@@ -2887,12 +4203,32 @@ func (in *PrimaryLeaseConfiguration) DeepCopyInto(out *PrimaryLeaseConfiguration
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryLeaseConfiguration.
-func (in *PrimaryLeaseConfiguration) DeepCopy() *PrimaryLeaseConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryLeaseConfiguration.
+func (in *PrimaryLeaseConfiguration) DeepCopy() *PrimaryLeaseConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryLeaseConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeSpec) DeepCopyInto(out *PrivilegeSpec) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeSpec.
+func (in *PrivilegeSpec) DeepCopy() *PrivilegeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrimaryLeaseConfiguration)
+	out := new(PrivilegeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3038,7 +4374,18 @@ func (in *PublicationSpec) DeepCopyInto(out *PublicationSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = make([]PublicationOperation, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublishViaPartitionRoot != nil {
+		in, out := &in.PublishViaPartitionRoot, &out.PublishViaPartitionRoot
+		*out = new(bool)
+		**out = **in
+	}
 	in.Target.DeepCopyInto(&out.Target)
+	in.DriftDetectionConfiguration.DeepCopyInto(&out.DriftDetectionConfiguration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationSpec.
@@ -3059,6 +4406,11 @@ func (in *PublicationStatus) DeepCopyInto(out *PublicationStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicationStatus.
@@ -3158,6 +4510,21 @@ func (in *RecoveryTarget) DeepCopy() *RecoveryTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RejectedHBARule) DeepCopyInto(out *RejectedHBARule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RejectedHBARule.
+func (in *RejectedHBARule) DeepCopy() *RejectedHBARule {
+	if in == nil {
+		return nil
+	}
+	out := new(RejectedHBARule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaClusterConfiguration) DeepCopyInto(out *ReplicaClusterConfiguration) {
 	*out = *in
@@ -3183,6 +4550,118 @@ func (in *ReplicaClusterConfiguration) DeepCopy() *ReplicaClusterConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlot) DeepCopyInto(out *ReplicationSlot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlot.
+func (in *ReplicationSlot) DeepCopy() *ReplicationSlot {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicationSlot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotList) DeepCopyInto(out *ReplicationSlotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReplicationSlot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotList.
+func (in *ReplicationSlotList) DeepCopy() *ReplicationSlotList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicationSlotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotSpec) DeepCopyInto(out *ReplicationSlotSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.MaxRetainedWAL != nil {
+		in, out := &in.MaxRetainedWAL, &out.MaxRetainedWAL
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotSpec.
+func (in *ReplicationSlotSpec) DeepCopy() *ReplicationSlotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSlotStatus) DeepCopyInto(out *ReplicationSlotStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetentionExceededTime != nil {
+		in, out := &in.RetentionExceededTime, &out.RetentionExceededTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DroppedTime != nil {
+		in, out := &in.DroppedTime, &out.DroppedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSlotStatus.
+func (in *ReplicationSlotStatus) DeepCopy() *ReplicationSlotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSlotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationSlotsConfiguration) DeepCopyInto(out *ReplicationSlotsConfiguration) {
 	*out = *in
@@ -3250,6 +4729,23 @@ func (in *RoleConfiguration) DeepCopyInto(out *RoleConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PasswordRotationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceBindings != nil {
+		in, out := &in.ServiceBindings, &out.ServiceBindings
+		*out = make([]ServiceBinding, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleConfiguration.
@@ -3469,6 +4965,13 @@ func (in *SecretsResourceVersion) DeepCopyInto(out *SecretsResourceVersion) {
 			(*out)[key] = val
 		}
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsResourceVersion.
@@ -3495,6 +4998,13 @@ func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 		*out = make([]UsageSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.UserMappings != nil {
+		in, out := &in.UserMappings, &out.UserMappings
+		*out = make([]UserMappingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSpec.
@@ -3523,6 +5033,21 @@ func (in *ServiceAccountTemplate) DeepCopy() *ServiceAccountTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBinding) DeepCopyInto(out *ServiceBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBinding.
+func (in *ServiceBinding) DeepCopy() *ServiceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceTemplateSpec) DeepCopyInto(out *ServiceTemplateSpec) {
 	*out = *in
@@ -3629,6 +5154,96 @@ func (in *SubscriptionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionReplicationStatus) DeepCopyInto(out *SubscriptionReplicationStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TableStates != nil {
+		in, out := &in.TableStates, &out.TableStates
+		*out = make(map[SubscriptionTableState]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]SubscriptionTableStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = make([]SubscriptionWorkerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplyLag != nil {
+		in, out := &in.ApplyLag, &out.ApplyLag
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LastErrorTime != nil {
+		in, out := &in.LastErrorTime, &out.LastErrorTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionReplicationStatus.
+func (in *SubscriptionReplicationStatus) DeepCopy() *SubscriptionReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionSequenceSyncConfiguration) DeepCopyInto(out *SubscriptionSequenceSyncConfiguration) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSequenceSyncConfiguration.
+func (in *SubscriptionSequenceSyncConfiguration) DeepCopy() *SubscriptionSequenceSyncConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionSequenceSyncConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionSequenceSyncStatus) DeepCopyInto(out *SubscriptionSequenceSyncStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSequenceSyncStatus.
+func (in *SubscriptionSequenceSyncStatus) DeepCopy() *SubscriptionSequenceSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionSequenceSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 	*out = *in
@@ -3640,6 +5255,12 @@ func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.DriftDetectionConfiguration.DeepCopyInto(&out.DriftDetectionConfiguration)
+	if in.SequenceSync != nil {
+		in, out := &in.SequenceSync, &out.SequenceSync
+		*out = new(SubscriptionSequenceSyncConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSpec.
@@ -3660,6 +5281,21 @@ func (in *SubscriptionStatus) DeepCopyInto(out *SubscriptionStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(SubscriptionReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SequenceSync != nil {
+		in, out := &in.SequenceSync, &out.SequenceSync
+		*out = new(SubscriptionSequenceSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionStatus.
@@ -3672,6 +5308,40 @@ func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionTableStatus) DeepCopyInto(out *SubscriptionTableStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionTableStatus.
+func (in *SubscriptionTableStatus) DeepCopy() *SubscriptionTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionWorkerStatus) DeepCopyInto(out *SubscriptionWorkerStatus) {
+	*out = *in
+	if in.LatestEndTime != nil {
+		in, out := &in.LatestEndTime, &out.LatestEndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionWorkerStatus.
+func (in *SubscriptionWorkerStatus) DeepCopy() *SubscriptionWorkerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionWorkerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwitchReplicaClusterStatus) DeepCopyInto(out *SwitchReplicaClusterStatus) {
 	*out = *in
@@ -3852,6 +5522,31 @@ func (in *UsageSpec) DeepCopy() *UsageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserMappingSpec) DeepCopyInto(out *UserMappingSpec) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]OptionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserMappingSpec.
+func (in *UserMappingSpec) DeepCopy() *UserMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotConfiguration) DeepCopyInto(out *VolumeSnapshotConfiguration) {
 	*out = *in
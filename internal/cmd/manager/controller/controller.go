@@ -351,6 +351,24 @@ func setupReconcilers(
 		return err
 	}
 
+	if err := (&controller.ClusterRefreshReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cloudnative-pg-clusterrefresh"), //nolint:staticcheck
+	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterRefresh")
+		return err
+	}
+
+	if err := (&controller.ClusterBranchReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("cloudnative-pg-clusterbranch"), //nolint:staticcheck
+	}).SetupWithManager(mgr, maxConcurrentReconciles); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterBranch")
+		return err
+	}
+
 	if err := (&controller.PoolerReconciler{
 		Client:          mgr.GetClient(),
 		DiscoveryClient: discoveryClient,
@@ -400,6 +418,16 @@ func setupWebhooks(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := webhookv1.SetupPublicationWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Publication", "version", "v1")
+		return err
+	}
+
+	if err := webhookv1.SetupDatabaseRoleWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "DatabaseRole", "version", "v1")
+		return err
+	}
+
 	// Setup the handler used by the readiness and liveliness probe.
 	//
 	// Unfortunately the readiness of the probe is not sufficient for the operator to be
@@ -42,6 +42,7 @@ func NewCmd() *cobra.Command {
 	var pgWal string
 	var parentNode string
 	var podName string
+	var instanceName string
 	var clusterName string
 	var namespace string
 
@@ -70,7 +71,7 @@ func NewCmd() *cobra.Command {
 				PodName:    podName,
 			}
 
-			return joinSubCommand(ctx, instance, info)
+			return joinSubCommand(ctx, instance, info, instanceName)
 		},
 		PostRunE: func(cmd *cobra.Command, _ []string) error {
 			if err := istio.TryInvokeQuitEndpoint(cmd.Context()); err != nil {
@@ -86,6 +87,8 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().StringVar(&parentNode, "parent-node", "", "The origin node")
 	cmd.Flags().StringVar(&podName, "pod-name", os.Getenv("POD_NAME"), "The name of this pod, to "+
 		"be checked against the cluster state")
+	cmd.Flags().StringVar(&instanceName, "instance-name", "", "The name of the instance being "+
+		"created, used to report the progress of the copy in the cluster status")
 	cmd.Flags().StringVar(&namespace, "namespace", os.Getenv("NAMESPACE"), "The namespace of "+
 		"the cluster and of the Pod in k8s")
 	cmd.Flags().StringVar(&clusterName, "cluster-name", os.Getenv("CLUSTER_NAME"), "The name of "+
@@ -94,13 +97,14 @@ func NewCmd() *cobra.Command {
 	return cmd
 }
 
-func joinSubCommand(ctx context.Context, instance *postgres.Instance, info postgres.InitInfo) error {
+func joinSubCommand(
+	ctx context.Context,
+	instance *postgres.Instance,
+	info postgres.InitInfo,
+	instanceName string,
+) error {
 	contextLogger := log.FromContext(ctx)
 
-	if err := info.EnsureTargetDirectoriesDoNotExist(ctx); err != nil {
-		return err
-	}
-
 	client, err := management.NewControllerRuntimeClient()
 	if err != nil {
 		contextLogger.Error(err, "Error creating Kubernetes client")
@@ -123,8 +127,18 @@ func joinSubCommand(ctx context.Context, instance *postgres.Instance, info postg
 		return err
 	}
 
+	var reportProgress postgres.BaseBackupProgressReporter
+	if instanceName != "" {
+		reportProgress = postgres.NewBaseBackupProgressReporter(client, &cluster, instanceName)
+		defer func() {
+			if err := postgres.ClearBaseBackupProgress(ctx, client, &cluster, instanceName); err != nil {
+				contextLogger.Warning("Cannot clear the progress of pg_basebackup", "err", err)
+			}
+		}()
+	}
+
 	// Run "pg_basebackup" to download the data directory from the primary
-	if err := info.Join(ctx, &cluster); err != nil {
+	if err := info.Join(ctx, &cluster, reportProgress); err != nil {
 		contextLogger.Error(err, "Error joining node")
 		return err
 	}
@@ -44,8 +44,9 @@ import (
 // CloneInfo is the structure containing all the information needed
 // to clone an existing server
 type CloneInfo struct {
-	info   *postgres.InitInfo
-	client ctrl.Client
+	info         *postgres.InitInfo
+	client       ctrl.Client
+	instanceName string
 }
 
 // NewCmd creates the "pgbasebackup" subcommand
@@ -54,6 +55,8 @@ func NewCmd() *cobra.Command {
 	var namespace string
 	var pgData string
 	var pgWal string
+	var postRecoverySQLRefsFolder string
+	var instanceName string
 
 	cmd := &cobra.Command{
 		Use: "pgbasebackup",
@@ -78,12 +81,11 @@ func NewCmd() *cobra.Command {
 					Namespace:   namespace,
 					PgData:      pgData,
 					PgWal:       pgWal,
-				},
-				client: client,
-			}
 
-			if err := env.info.EnsureTargetDirectoriesDoNotExist(ctx); err != nil {
-				return err
+					PostRecoverySQLRefsFolder: postRecoverySQLRefsFolder,
+				},
+				client:       client,
+				instanceName: instanceName,
 			}
 
 			if err = env.bootstrapUsingPgbasebackup(ctx); err != nil {
@@ -106,6 +108,10 @@ func NewCmd() *cobra.Command {
 		"the cluster and of the Pod in k8s")
 	cmd.Flags().StringVar(&pgData, "pg-data", os.Getenv("PGDATA"), "The PGDATA to be created")
 	cmd.Flags().StringVar(&pgWal, "pg-wal", "", "the PGWAL to be created")
+	cmd.Flags().StringVar(&postRecoverySQLRefsFolder, "post-recovery-sql-refs-folder", "",
+		"The folder containing the SQL files to be executed after the promotion")
+	cmd.Flags().StringVar(&instanceName, "instance-name", "", "The name of the instance being "+
+		"created, used to report the progress of the copy in the cluster status")
 
 	return cmd
 }
@@ -128,7 +134,7 @@ func (env *CloneInfo) bootstrapUsingPgbasebackup(ctx context.Context) error {
 		env.info.ApplicationDatabase = cluster.GetApplicationDatabaseName()
 	}
 
-	server, ok := cluster.ExternalCluster(cluster.Spec.Bootstrap.PgBaseBackup.Source)
+	server, ok := getSourceServer(&cluster)
 	if !ok {
 		return fmt.Errorf("missing external cluster")
 	}
@@ -144,7 +150,20 @@ func (env *CloneInfo) bootstrapUsingPgbasebackup(ctx context.Context) error {
 	// like when the I/O is overloaded.
 	connectionString += " options='-c wal_sender_timeout=0s'"
 
-	if err := postgres.ClonePgData(ctx, connectionString, env.info.PgData, env.info.PgWal); err != nil {
+	cloneOptions := postgres.CloneOptions{
+		PgBaseBackup: cluster.GetBootstrapPgBaseBackupOptions(),
+	}
+	if env.instanceName != "" {
+		cloneOptions.ApplicationName = env.instanceName
+		cloneOptions.ReportProgress = postgres.NewBaseBackupProgressReporter(env.client, &cluster, env.instanceName)
+		defer func() {
+			if err := postgres.ClearBaseBackupProgress(ctx, env.client, &cluster, env.instanceName); err != nil {
+				log.FromContext(ctx).Warning("Cannot clear the progress of pg_basebackup", "err", err)
+			}
+		}()
+	}
+
+	if err := env.info.CloneDataDirectory(ctx, connectionString, cloneOptions); err != nil {
 		return fmt.Errorf("while cloning pgdata: %w", err)
 	}
 
@@ -157,17 +176,35 @@ func (env *CloneInfo) bootstrapUsingPgbasebackup(ctx context.Context) error {
 
 	if cluster.IsReplica() {
 		// TODO: Using a replication slot on replica cluster is not supported (yet?)
-		_, err = postgres.UpdateReplicaConfiguration(env.info.PgData, connectionString, "")
+		if _, err = postgres.UpdateReplicaConfiguration(env.info.PgData, connectionString, ""); err != nil {
+			return err
+		}
+		return env.info.ClearBaseBackupCompleted()
+	}
+
+	// From now on the data directory is modified, and cannot be
+	// reused by another execution of this command
+	if err := env.info.ClearBaseBackupCompleted(); err != nil {
 		return err
 	}
 
 	return env.configureInstanceAsNewPrimary(ctx, &cluster)
 }
 
+// getSourceServer gets the definition of the server to be cloned, either
+// from the pg_basebackup or from the clone bootstrap section
+func getSourceServer(cluster *apiv1.Cluster) (apiv1.ExternalCluster, bool) {
+	if cluster.Spec.Bootstrap.Clone != nil {
+		return cluster.GetCloneSourceExternalCluster()
+	}
+
+	return cluster.ExternalCluster(cluster.Spec.Bootstrap.PgBaseBackup.Source)
+}
+
 // configureInstanceAsNewPrimary sets up this instance as a new primary server, using
 // the configuration created by the user and setting up the global objects as needed
 func (env *CloneInfo) configureInstanceAsNewPrimary(ctx context.Context, cluster *apiv1.Cluster) error {
-	if err := env.info.WriteInitialPostgresqlConf(ctx, cluster); err != nil {
+	if err := env.info.WriteInitialPostgresqlConf(ctx, env.client, cluster); err != nil {
 		return err
 	}
 
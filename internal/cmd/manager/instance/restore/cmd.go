@@ -49,6 +49,8 @@ func NewCmd() *cobra.Command {
 		namespace   string
 		pgData      string
 		pgWal       string
+
+		postRecoverySQLRefsFolder string
 	)
 
 	cmd := &cobra.Command{
@@ -90,6 +92,8 @@ func NewCmd() *cobra.Command {
 				pgData:      pgData,
 				pgWal:       pgWal,
 				cancel:      cancel,
+
+				postRecoverySQLRefsFolder: postRecoverySQLRefsFolder,
 			}
 			if mgr.Add(&restoreProcess) != nil {
 				contextLogger.Error(err, "while building the restore process")
@@ -125,6 +129,8 @@ func NewCmd() *cobra.Command {
 		"the cluster and the Pod in k8s")
 	cmd.Flags().StringVar(&pgData, "pg-data", os.Getenv("PGDATA"), "The PGDATA to be restored")
 	cmd.Flags().StringVar(&pgWal, "pg-wal", "", "The PGWAL to be restored")
+	cmd.Flags().StringVar(&postRecoverySQLRefsFolder, "post-recovery-sql-refs-folder", "",
+		"The folder containing the SQL files to be executed after the promotion")
 
 	return cmd
 }
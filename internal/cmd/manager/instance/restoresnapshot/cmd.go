@@ -53,6 +53,8 @@ func NewCmd() *cobra.Command {
 		backupLabel   string
 		tablespaceMap string
 		immediate     bool
+
+		postRecoverySQLRefsFolder string
 	)
 
 	cmd := &cobra.Command{
@@ -95,6 +97,8 @@ func NewCmd() *cobra.Command {
 				pgWal:       pgWal,
 				immediate:   immediate,
 				cancel:      cancel,
+
+				postRecoverySQLRefsFolder: postRecoverySQLRefsFolder,
 			}
 			if mgr.Add(&restoreProcess) != nil {
 				contextLogger.Error(err, "while building the restore process")
@@ -149,6 +153,8 @@ func NewCmd() *cobra.Command {
 	cmd.Flags().StringVar(&backupLabel, "backuplabel", "", "The restore backup_label file content")
 	cmd.Flags().StringVar(&tablespaceMap, "tablespacemap", "", "The restore tablespace_map file content")
 	cmd.Flags().BoolVar(&immediate, "immediate", false, "Do not start PostgreSQL but just recover the snapshot")
+	cmd.Flags().StringVar(&postRecoverySQLRefsFolder, "post-recovery-sql-refs-folder", "",
+		"The folder containing the SQL files to be executed after the promotion")
 
 	return cmd
 }
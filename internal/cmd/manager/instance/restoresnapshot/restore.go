@@ -31,15 +31,16 @@ import (
 )
 
 type restoreRunnable struct {
-	cli               client.Client
-	clusterName       string
-	namespace         string
-	pgData            string
-	pgWal             string
-	backupLabelFile   []byte
-	tablespaceMapFile []byte
-	immediate         bool
-	cancel            context.CancelFunc
+	cli                       client.Client
+	clusterName               string
+	namespace                 string
+	pgData                    string
+	pgWal                     string
+	postRecoverySQLRefsFolder string
+	backupLabelFile           []byte
+	tablespaceMapFile         []byte
+	immediate                 bool
+	cancel                    context.CancelFunc
 }
 
 func (r *restoreRunnable) Start(ctx context.Context) error {
@@ -60,6 +61,8 @@ func (r *restoreRunnable) Start(ctx context.Context) error {
 		PgWal:             r.pgWal,
 		BackupLabelFile:   r.backupLabelFile,
 		TablespaceMapFile: r.tablespaceMapFile,
+
+		PostRecoverySQLRefsFolder: r.postRecoverySQLRefsFolder,
 	}
 
 	if err := info.RestoreSnapshot(ctx, r.cli, r.immediate); err != nil {
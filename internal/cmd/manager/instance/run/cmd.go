@@ -206,6 +206,16 @@ func runSubCommand( //nolint: gocyclo,gocognit
 						instance.GetNamespaceName(): {},
 					},
 				},
+				&apiv1.BreakGlassAccess{}: {
+					Namespaces: map[string]cache.Config{
+						instance.GetNamespaceName(): {},
+					},
+				},
+				&apiv1.ReplicationSlot{}: {
+					Namespaces: map[string]cache.Config{
+						instance.GetNamespaceName(): {},
+					},
+				},
 			},
 		},
 		// We don't need a cache for secrets and configmap, as all reloads
@@ -305,6 +315,20 @@ func runSubCommand( //nolint: gocyclo,gocognit
 		return err
 	}
 
+	// break-glass access reconciler
+	breakGlassAccessReconciler := controller.NewBreakGlassAccessReconciler(mgr, instance)
+	if err := breakGlassAccessReconciler.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create break-glass access controller")
+		return err
+	}
+
+	// replication slot reconciler
+	replicationSlotReconciler := controller.NewReplicationSlotReconciler(mgr, instance)
+	if err := replicationSlotReconciler.SetupWithManager(mgr); err != nil {
+		contextLogger.Error(err, "unable to create replication slot controller")
+		return err
+	}
+
 	// postgres CSV logs handler (PGAudit too)
 	postgresLogPipe := logpipe.NewLogPipe()
 	if err := mgr.Add(postgresLogPipe); err != nil {
@@ -235,7 +235,7 @@ func (ui upgradeInfo) upgradeSubCommand(ctx context.Context, instance *postgres.
 	}
 
 	contextLogger.Info("Preparing configuration files", "directory", newDataDir)
-	if err := prepareConfigurationFiles(ctx, cluster, newDataDir); err != nil {
+	if err := prepareConfigurationFiles(ctx, client, cluster, newDataDir); err != nil {
 		return err
 	}
 
@@ -315,7 +315,7 @@ func logExtensionUpdateScript(ctx context.Context, pgData, primaryPodName string
 	if exists {
 		contextLogger.Info(
 			"pg_upgrade emitted update_extensions.sql in PGDATA on the primary. "+
-				"Once the cluster is back online, review the script and apply extension updates as needed.",
+				"The extensions will be updated by the post-upgrade maintenance once the cluster is back online.",
 			"scriptPath", scriptPath,
 			"primaryPodName", primaryPodName,
 		)
@@ -413,7 +413,12 @@ func tryAddWalSegmentSize(pgControlData utils.PgControlData, options []string) (
 	return append(options, param), nil
 }
 
-func prepareConfigurationFiles(ctx context.Context, cluster apiv1.Cluster, destDir string) error {
+func prepareConfigurationFiles(
+	ctx context.Context,
+	cli ctrl.Reader,
+	cluster apiv1.Cluster,
+	destDir string,
+) error {
 	// Always read the custom and override configuration files created by the operator
 	_, err := configfile.EnsureIncludes(path.Join(destDir, "postgresql.conf"),
 		constants.PostgresqlCustomConfigurationFile,
@@ -427,6 +432,9 @@ func prepareConfigurationFiles(ctx context.Context, cluster apiv1.Cluster, destD
 	// during pg_upgrade in PostgreSQL 17 before 17.6. The bug has been fixed with the commit
 	// https://github.com/postgres/postgres/commit/f36e5774
 	tmpCluster := cluster.DeepCopy()
+	if tmpCluster.Spec.PostgresConfiguration.Parameters == nil {
+		tmpCluster.Spec.PostgresConfiguration.Parameters = make(map[string]string)
+	}
 	tmpCluster.Spec.PostgresConfiguration.Parameters["max_slot_wal_keep_size"] = "-1"
 
 	pgMajorVersion, err := postgresutils.GetMajorVersionFromPgData(destDir)
@@ -437,6 +445,11 @@ func prepareConfigurationFiles(ctx context.Context, cluster apiv1.Cluster, destD
 		tmpCluster.Spec.PostgresConfiguration.Parameters["idle_replication_slot_timeout"] = "0"
 	}
 
+	parametersFrom, err := postgres.ReadParametersFrom(ctx, cli, &cluster)
+	if err != nil {
+		return fmt.Errorf("error while reading the parameters from ConfigMaps and Secrets: %w", err)
+	}
+
 	enabledPluginNamesSet := stringset.From(cluster.GetJobEnabledPluginNames())
 	pluginCli, err := pluginClient.NewClient(ctx, enabledPluginNamesSet)
 	if err != nil {
@@ -458,6 +471,7 @@ func prepareConfigurationFiles(ctx context.Context, cluster apiv1.Cluster, destD
 	if _, err := newInstance.RefreshConfigurationFilesFromCluster(
 		ctx,
 		tmpCluster,
+		parametersFrom,
 		false,
 		cnpgiPostgres.OperationType_TYPE_UPGRADE,
 	); err != nil {
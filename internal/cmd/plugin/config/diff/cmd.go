@@ -0,0 +1,63 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd initializes the config diff command
+func NewCmd() *cobra.Command {
+	var (
+		fileName string
+		output   string
+		timeout  time.Duration
+	)
+
+	diffCmd := &cobra.Command{
+		Use:   "diff CLUSTER -f FILENAME",
+		Short: "Preview the PostgreSQL configuration changes of a cluster manifest",
+		Long: "Renders the PostgreSQL configuration of the passed cluster manifest, compares it " +
+			"with the one of each instance and reports whether every change requires a reload, " +
+			"a restart, or will be rejected. No change is applied to the cluster.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+			return Diff(cmd.Context(), clusterName, fileName, plugin.OutputFormat(output), timeout)
+		},
+	}
+
+	diffCmd.Flags().StringVarP(&fileName, "filename", "f", "",
+		"The file containing the new cluster manifest")
+	_ = diffCmd.MarkFlagRequired("filename")
+	diffCmd.Flags().StringVarP(&output, "output", "o", "text",
+		"Output format. One of text|json")
+	diffCmd.Flags().DurationVarP(&timeout, "timeout", "t", 10*time.Second,
+		"Timeout for the queries run on each instance")
+
+	return diffCmd
+}
@@ -0,0 +1,525 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	postgresClient "github.com/cloudnative-pg/cnpg-i/pkg/postgres"
+	"github.com/logrusorgru/aurora/v4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ChangeKind is the way a configuration change is applied
+type ChangeKind string
+
+const (
+	// ChangeKindReload means that the change is applied by reloading the configuration
+	ChangeKindReload ChangeKind = "reload"
+
+	// ChangeKindRestart means that the change requires a restart of the instance
+	ChangeKindRestart ChangeKind = "restart"
+
+	// ChangeKindRejected means that the change will not be applied
+	ChangeKindRejected ChangeKind = "rejected"
+)
+
+// maskedValue replaces the values of the parameters read from Secrets
+const maskedValue = "<secret>"
+
+// ParameterChange is the change of a PostgreSQL parameter
+type ParameterChange struct {
+	// Name is the name of the parameter
+	Name string `json:"name"`
+
+	// CurrentValue is the value currently in use, empty when the
+	// parameter is not set in the configuration. The values read from
+	// Secrets are masked
+	CurrentValue string `json:"currentValue,omitempty"`
+
+	// NewValue is the value in the new configuration, empty when the
+	// parameter will be removed from the configuration. The values read
+	// from Secrets are masked
+	NewValue string `json:"newValue,omitempty"`
+
+	// Kind is how the change is applied
+	Kind ChangeKind `json:"kind"`
+
+	// Reason explains the kind of a change, when needed
+	Reason string `json:"reason,omitempty"`
+}
+
+// InstanceDiff contains the configuration changes of an instance
+type InstanceDiff struct {
+	// Name is the name of the instance
+	Name string `json:"name"`
+
+	// Primary is true when the instance is the current primary
+	Primary bool `json:"primary"`
+
+	// Changes is the list of the parameters changing on this instance
+	Changes []ParameterChange `json:"changes,omitempty"`
+
+	// Error is the error raised while reading the configuration of the instance
+	Error string `json:"error,omitempty"`
+}
+
+// ConfigurationDiff is the outcome of the comparison between the
+// configuration of a cluster and the one of a new manifest
+type ConfigurationDiff struct {
+	// ClusterName is the name of the cluster
+	ClusterName string `json:"clusterName"`
+
+	// Rejected is the list of the parameters that the manifest sets
+	// but that can't be changed, as they're fixed or blocked
+	Rejected []ParameterChange `json:"rejected,omitempty"`
+
+	// Instances contains the changes for each instance
+	Instances []InstanceDiff `json:"instances"`
+
+	// Rollout describes how the changes will be rolled out
+	Rollout string `json:"rollout"`
+}
+
+// instanceSetting is a parameter as reported by an instance
+type instanceSetting struct {
+	// Value is the value set in the configuration files, if any
+	Value *string `json:"value"`
+
+	// Context is the pg_settings context of the parameter, if known
+	Context *string `json:"context"`
+}
+
+// Diff compares the PostgreSQL configuration of a cluster with the one
+// of the manifest in the passed file
+func Diff(
+	ctx context.Context,
+	clusterName string,
+	fileName string,
+	format plugin.OutputFormat,
+	timeout time.Duration,
+) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		types.NamespacedName{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	newCluster, err := readClusterManifest(fileName, &cluster)
+	if err != nil {
+		return err
+	}
+
+	var pods corev1.PodList
+	if err := plugin.Client.List(
+		ctx,
+		&pods,
+		client.InNamespace(plugin.Namespace),
+		client.MatchingLabels{
+			utils.ClusterLabelName: clusterName,
+			utils.PodRoleLabelName: string(utils.PodRoleInstance),
+		},
+	); err != nil {
+		return fmt.Errorf("while listing the instances of cluster %s: %w", clusterName, err)
+	}
+
+	currentParametersFrom, err := postgresManagement.ReadParametersFrom(ctx, plugin.Client, &cluster)
+	if err != nil {
+		return err
+	}
+	newParametersFrom, err := postgresManagement.ReadParametersFrom(ctx, plugin.Client, newCluster)
+	if err != nil {
+		return err
+	}
+
+	majorVersion, err := cluster.GetPostgresqlMajorVersion()
+	if err != nil {
+		return fmt.Errorf("while detecting the PostgreSQL version: %w", err)
+	}
+
+	// The instance manager reads the overrides of each instance from the
+	// status, which the operator will compute from the new manifest
+	newCluster.Status.InstanceParameters = nil
+	for i := range pods.Items {
+		overrides := newCluster.GetInstanceParameterOverrides(pods.Items[i].Name, pods.Items[i].Labels)
+		if len(overrides) == 0 {
+			continue
+		}
+		if newCluster.Status.InstanceParameters == nil {
+			newCluster.Status.InstanceParameters = make(map[apiv1.PodName]map[string]string)
+		}
+		newCluster.Status.InstanceParameters[apiv1.PodName(pods.Items[i].Name)] = overrides
+	}
+
+	secretParameters := getSecretParameters(&cluster, newCluster)
+	rejected, err := getRejectedChanges(ctx, newCluster, newParametersFrom, majorVersion)
+	if err != nil {
+		return err
+	}
+	result := ConfigurationDiff{
+		ClusterName: clusterName,
+		Rejected:    maskSecretValues(rejected, secretParameters),
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		instance := InstanceDiff{
+			Name:    pod.Name,
+			Primary: pod.Name == cluster.Status.CurrentPrimary,
+		}
+
+		settings, err := getInstanceSettings(ctx, pod, timeout)
+		if err != nil {
+			instance.Error = err.Error()
+			result.Instances = append(result.Instances, instance)
+			continue
+		}
+
+		currentParameters, err := renderParameters(ctx, &cluster, pod.Name, majorVersion, currentParametersFrom)
+		if err != nil {
+			return err
+		}
+		newParameters, err := renderParameters(ctx, newCluster, pod.Name, majorVersion, newParametersFrom)
+		if err != nil {
+			return err
+		}
+		instance.Changes = maskSecretValues(
+			compareParameters(currentParameters, newParameters, settings),
+			secretParameters,
+		)
+		result.Instances = append(result.Instances, instance)
+	}
+	slices.SortFunc(result.Instances, func(a, b InstanceDiff) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	result.Rollout = describeRollout(newCluster, result.Instances)
+
+	switch format {
+	case plugin.OutputFormatJSON:
+		return plugin.Print(result, format, os.Stdout)
+	default:
+		printDiff(result)
+		return nil
+	}
+}
+
+// readClusterManifest reads the cluster manifest contained in the passed
+// file, completing it with the status of the existing cluster
+func readClusterManifest(fileName string, cluster *apiv1.Cluster) (*apiv1.Cluster, error) {
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("while reading %s: %w", fileName, err)
+	}
+
+	var newCluster apiv1.Cluster
+	if err := yaml.UnmarshalStrict(data, &newCluster); err != nil {
+		return nil, fmt.Errorf("while decoding %s: %w", fileName, err)
+	}
+	if newCluster.Kind != "" && newCluster.Kind != apiv1.ClusterKind {
+		return nil, fmt.Errorf("%s contains a %s, while a %s is expected",
+			fileName, newCluster.Kind, apiv1.ClusterKind)
+	}
+	if newCluster.Name != "" && newCluster.Name != cluster.Name {
+		return nil, fmt.Errorf("%s contains the manifest of cluster %s, while %s is expected",
+			fileName, newCluster.Name, cluster.Name)
+	}
+
+	// The new manifest is compared with the running cluster, whose
+	// status is needed to compute the configuration
+	newCluster.ObjectMeta.Name = cluster.Name
+	newCluster.ObjectMeta.Namespace = cluster.Namespace
+	newCluster.Status = *cluster.Status.DeepCopy()
+	newCluster.Default()
+
+	return &newCluster, nil
+}
+
+// getUserSettings merges the parameters set by the user for the whole
+// cluster, in the same order used by the instance manager
+func getUserSettings(cluster *apiv1.Cluster, parametersFrom map[string]string) map[string]string {
+	parameters := cluster.GetPostgresParameters()
+	userSettings := make(map[string]string, len(parameters)+len(parametersFrom))
+	maps.Copy(userSettings, parameters)
+	maps.Copy(userSettings, parametersFrom)
+	return userSettings
+}
+
+// renderParameters computes the PostgreSQL parameters of an instance
+// with the same function used by the instance manager
+func renderParameters(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instanceName string,
+	majorVersion int,
+	parametersFrom map[string]string,
+) (map[string]string, error) {
+	config, err := postgresManagement.BuildPostgresqlConfiguration(
+		cluster.SetInContext(ctx),
+		cluster,
+		instanceName,
+		parametersFrom,
+		false,
+		majorVersion,
+		postgresClient.OperationType_TYPE_RECONCILE,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("while computing the PostgreSQL configuration: %w", err)
+	}
+	return config.GetConfigurationParameters(), nil
+}
+
+// getRejectedChanges gets the fixed or blocked parameters set by the user
+// whose value differs from the one the operator uses
+func getRejectedChanges(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	parametersFrom map[string]string,
+	majorVersion int,
+) ([]ParameterChange, error) {
+	userSettings := getUserSettings(cluster, parametersFrom)
+	for _, override := range cluster.Spec.PostgresConfiguration.InstanceOverrides {
+		maps.Copy(userSettings, override.Parameters)
+	}
+	parameters, err := renderParameters(ctx, cluster, "", majorVersion, parametersFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ParameterChange
+	for _, name := range slices.Sorted(maps.Keys(userSettings)) {
+		status, isFixed := postgres.FixedConfigurationParameters[name]
+		if !isFixed {
+			continue
+		}
+		if value, ok := parameters[name]; ok && value == userSettings[name] {
+			continue
+		}
+		result = append(result, ParameterChange{
+			Name:         name,
+			CurrentValue: parameters[name],
+			NewValue:     userSettings[name],
+			Kind:         ChangeKindRejected,
+			Reason:       fmt.Sprintf("the parameter is %s", status),
+		})
+	}
+	return result, nil
+}
+
+// compareParameters computes the changes between the current and the new
+// parameters of an instance, using the settings it reports
+func compareParameters(
+	currentParameters map[string]string,
+	newParameters map[string]string,
+	settings map[string]instanceSetting,
+) []ParameterChange {
+	names := slices.Collect(maps.Keys(currentParameters))
+	for name := range newParameters {
+		if _, ok := currentParameters[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var result []ParameterChange
+	for _, name := range names {
+		currentValue, inCurrent := currentParameters[name]
+		newValue, inNew := newParameters[name]
+		if inCurrent == inNew && currentValue == newValue {
+			continue
+		}
+
+		setting := settings[name]
+		if setting.Value != nil {
+			// The instance already uses the new value
+			if inNew && *setting.Value == newValue {
+				continue
+			}
+			currentValue = *setting.Value
+		}
+
+		change := ParameterChange{
+			Name:         name,
+			CurrentValue: currentValue,
+			NewValue:     newValue,
+			Kind:         ChangeKindReload,
+		}
+		if setting.Context != nil {
+			switch *setting.Context {
+			case "postmaster":
+				change.Kind = ChangeKindRestart
+			case "internal":
+				change.Kind = ChangeKindRejected
+				change.Reason = "the parameter is read-only"
+			}
+		}
+		if change.Kind == ChangeKindRestart && postgres.HotStandbySensitiveParameters[name] &&
+			isDecreased(change.CurrentValue, change.NewValue) {
+			change.Reason = "decreasing the value requires the primary to be restarted first"
+		}
+		result = append(result, change)
+	}
+
+	return result
+}
+
+// getSecretParameters gets the names of the parameters that the passed
+// clusters read from Secrets
+func getSecretParameters(clusters ...*apiv1.Cluster) map[string]bool {
+	result := make(map[string]bool)
+	for _, cluster := range clusters {
+		for _, source := range cluster.Spec.PostgresConfiguration.ParametersFrom {
+			if source.SecretKeyRef != nil {
+				result[source.Name] = true
+			}
+		}
+	}
+	return result
+}
+
+// maskSecretValues hides the values of the parameters read from Secrets,
+// only showing whether they are set
+func maskSecretValues(changes []ParameterChange, secretParameters map[string]bool) []ParameterChange {
+	for i := range changes {
+		if !secretParameters[changes[i].Name] {
+			continue
+		}
+		if changes[i].CurrentValue != "" {
+			changes[i].CurrentValue = maskedValue
+		}
+		if changes[i].NewValue != "" {
+			changes[i].NewValue = maskedValue
+		}
+	}
+	return changes
+}
+
+// isDecreased checks whether a numeric value is being decreased
+func isDecreased(currentValue, newValue string) bool {
+	current, err := strconv.Atoi(currentValue)
+	if err != nil {
+		return false
+	}
+	next, err := strconv.Atoi(newValue)
+	if err != nil {
+		return false
+	}
+	return next < current
+}
+
+// describeRollout describes how the operator will apply the changes
+func describeRollout(cluster *apiv1.Cluster, instances []InstanceDiff) string {
+	var replicasRestart, primaryRestart, primaryFirst, changed bool
+	for _, instance := range instances {
+		for _, change := range instance.Changes {
+			if change.Kind == ChangeKindRejected {
+				continue
+			}
+			changed = true
+			if change.Kind != ChangeKindRestart {
+				continue
+			}
+			if !instance.Primary {
+				replicasRestart = true
+				continue
+			}
+			primaryRestart = true
+			if change.Reason != "" {
+				primaryFirst = true
+			}
+		}
+	}
+
+	switch {
+	case !changed:
+		return "no changes"
+	case !replicasRestart && !primaryRestart:
+		return "the configuration will be reloaded"
+	case primaryFirst:
+		return "the primary will be restarted in place first, followed by the replicas"
+	case !primaryRestart:
+		return "the replicas will be restarted one at a time"
+	case cluster.GetPrimaryUpdateStrategy() == apiv1.PrimaryUpdateStrategySupervised:
+		return "the replicas will be restarted one at a time, " +
+			"then the operator will wait for a manual switchover or restart of the primary"
+	case cluster.GetPrimaryUpdateMethod() == apiv1.PrimaryUpdateMethodRestart || len(instances) < 2:
+		return "the replicas will be restarted one at a time, then the primary will be restarted in place"
+	default:
+		return "the replicas will be restarted one at a time, then a switchover will happen"
+	}
+}
+
+func printDiff(result ConfigurationDiff) {
+	if len(result.Rejected) > 0 {
+		fmt.Println(aurora.Red("Rejected parameters"))
+		rejected := tabby.New()
+		rejected.AddHeader("Parameter", "Operator value", "Requested value", "Reason")
+		for _, change := range result.Rejected {
+			rejected.AddLine(change.Name, change.CurrentValue, change.NewValue, change.Reason)
+		}
+		rejected.Print()
+		fmt.Println()
+	}
+
+	for _, instance := range result.Instances {
+		title := instance.Name
+		if instance.Primary {
+			title += " (primary)"
+		}
+		fmt.Println(aurora.Green(title))
+
+		switch {
+		case instance.Error != "":
+			fmt.Println(aurora.Red(instance.Error))
+		case len(instance.Changes) == 0:
+			fmt.Println("No changes")
+		default:
+			changes := tabby.New()
+			changes.AddHeader("Parameter", "Current value", "New value", "Change", "Reason")
+			for _, change := range instance.Changes {
+				changes.AddLine(change.Name, change.CurrentValue, change.NewValue, change.Kind, change.Reason)
+			}
+			changes.Print()
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("%s %s\n", aurora.Green("Rollout:"), result.Rollout)
+}
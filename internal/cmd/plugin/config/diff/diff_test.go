@@ -0,0 +1,254 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("compareParameters", func() {
+	settings := map[string]instanceSetting{
+		"work_mem":        {Value: ptr.To("4MB"), Context: ptr.To("user")},
+		"shared_buffers":  {Value: ptr.To("128MB"), Context: ptr.To("postmaster")},
+		"max_connections": {Value: ptr.To("200"), Context: ptr.To("postmaster")},
+		"block_size":      {Context: ptr.To("internal")},
+		"pgaudit.log":     {Value: ptr.To("all")},
+		"log_temp_files":  {Value: ptr.To("1024"), Context: ptr.To("superuser")},
+	}
+
+	It("classifies reloads and restarts", func() {
+		changes := compareParameters(
+			map[string]string{"work_mem": "4MB", "shared_buffers": "128MB"},
+			map[string]string{"work_mem": "8MB", "shared_buffers": "256MB"},
+			settings,
+		)
+		Expect(changes).To(Equal([]ParameterChange{
+			{Name: "shared_buffers", CurrentValue: "128MB", NewValue: "256MB", Kind: ChangeKindRestart},
+			{Name: "work_mem", CurrentValue: "4MB", NewValue: "8MB", Kind: ChangeKindReload},
+		}))
+	})
+
+	It("reports removed parameters and read-only ones", func() {
+		changes := compareParameters(
+			map[string]string{"log_temp_files": "1024"},
+			map[string]string{"block_size": "16384"},
+			settings,
+		)
+		Expect(changes).To(Equal([]ParameterChange{
+			{
+				Name:     "block_size",
+				NewValue: "16384",
+				Kind:     ChangeKindRejected,
+				Reason:   "the parameter is read-only",
+			},
+			{Name: "log_temp_files", CurrentValue: "1024", Kind: ChangeKindReload},
+		}))
+	})
+
+	It("ignores the changes already applied to the instance", func() {
+		changes := compareParameters(
+			map[string]string{"pgaudit.log": "ddl"},
+			map[string]string{"pgaudit.log": "all"},
+			settings,
+		)
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("detects the decrease of hot standby sensitive parameters", func() {
+		changes := compareParameters(
+			map[string]string{"max_connections": "200"},
+			map[string]string{"max_connections": "100"},
+			settings,
+		)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Kind).To(Equal(ChangeKindRestart))
+		Expect(changes[0].Reason).ToNot(BeEmpty())
+	})
+})
+
+var _ = Describe("maskSecretValues", func() {
+	It("hides the values of the parameters read from Secrets", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					ParametersFrom: []apiv1.ParameterSource{
+						{
+							Name: "krb_server_keyfile",
+							SecretKeyRef: &apiv1.SecretKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{Name: "kerberos"},
+								Key:                  "keyfile",
+							},
+						},
+						{
+							Name: "work_mem",
+							ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+								LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+								Key:                  "work_mem",
+							},
+						},
+					},
+				},
+			},
+		}
+		newCluster := cluster.DeepCopy()
+		newCluster.Spec.PostgresConfiguration.ParametersFrom[0].Name = "dynamic_library_path"
+
+		changes := maskSecretValues([]ParameterChange{
+			{
+				Name:         "dynamic_library_path",
+				CurrentValue: "$libdir",
+				NewValue:     "/secret/path",
+				Kind:         ChangeKindReload,
+			},
+			{Name: "krb_server_keyfile", CurrentValue: "/secret/keyfile", Kind: ChangeKindRestart},
+			{Name: "work_mem", CurrentValue: "4MB", NewValue: "8MB", Kind: ChangeKindReload},
+		}, getSecretParameters(cluster, newCluster))
+		Expect(changes).To(Equal([]ParameterChange{
+			{
+				Name:         "dynamic_library_path",
+				CurrentValue: maskedValue,
+				NewValue:     maskedValue,
+				Kind:         ChangeKindReload,
+			},
+			{Name: "krb_server_keyfile", CurrentValue: maskedValue, Kind: ChangeKindRestart},
+			{Name: "work_mem", CurrentValue: "4MB", NewValue: "8MB", Kind: ChangeKindReload},
+		}))
+	})
+})
+
+var _ = Describe("getRejectedChanges", func() {
+	It("reports the fixed parameters set by the user", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"work_mem":          "8MB",
+						"port":              "5433",
+						"logging_collector": "off",
+					},
+				},
+			},
+		}
+		changes, err := getRejectedChanges(ctx, cluster, nil, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Name).To(Equal("logging_collector"))
+		Expect(changes[0].Reason).To(Equal("the parameter is blocked"))
+		Expect(changes[1].Name).To(Equal("port"))
+		Expect(changes[1].Reason).To(Equal("the parameter is fixed"))
+	})
+})
+
+var _ = Describe("renderParameters", func() {
+	It("applies the instance overrides on top of the other parameters", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{"work_mem": "8MB"},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				InstanceParameters: map[apiv1.PodName]map[string]string{
+					"cluster-example-2": {"work_mem": "64MB"},
+				},
+			},
+		}
+		parameters, err := renderParameters(ctx, cluster, "cluster-example-2", 17,
+			map[string]string{"maintenance_work_mem": "256MB"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(HaveKeyWithValue("work_mem", "64MB"))
+		Expect(parameters).To(HaveKeyWithValue("maintenance_work_mem", "256MB"))
+
+		parameters, err = renderParameters(ctx, cluster, "cluster-example-1", 17, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(HaveKeyWithValue("work_mem", "8MB"))
+	})
+
+	It("includes the parameters computed by the instance manager", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Synchronous: &apiv1.SynchronousReplicaConfiguration{
+						Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+						Number: 1,
+					},
+				},
+				Tablespaces: []apiv1.TablespaceConfiguration{
+					{Name: "scratch", Temporary: true},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				InstanceNames:  []string{"cluster-example-1", "cluster-example-2", "cluster-example-3"},
+			},
+		}
+		parameters, err := renderParameters(ctx, cluster, "cluster-example-1", 17, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(HaveKeyWithValue("temp_tablespaces", "scratch"))
+		Expect(parameters).To(HaveKey("synchronous_standby_names"))
+		Expect(parameters["synchronous_standby_names"]).To(ContainSubstring("cluster-example-2"))
+	})
+})
+
+var _ = Describe("describeRollout", func() {
+	cluster := &apiv1.Cluster{}
+	primaryRestart := InstanceDiff{
+		Name:    "cluster-example-1",
+		Primary: true,
+		Changes: []ParameterChange{{Name: "shared_buffers", Kind: ChangeKindRestart}},
+	}
+	replicaRestart := InstanceDiff{
+		Name:    "cluster-example-2",
+		Changes: []ParameterChange{{Name: "shared_buffers", Kind: ChangeKindRestart}},
+	}
+
+	It("reports when nothing changes", func() {
+		Expect(describeRollout(cluster, []InstanceDiff{{Name: "cluster-example-1"}})).
+			To(Equal("no changes"))
+	})
+
+	It("reports a reload", func() {
+		Expect(describeRollout(cluster, []InstanceDiff{{
+			Name:    "cluster-example-1",
+			Changes: []ParameterChange{{Name: "work_mem", Kind: ChangeKindReload}},
+		}})).To(Equal("the configuration will be reloaded"))
+	})
+
+	It("reports an in-place restart of the primary", func() {
+		Expect(describeRollout(cluster, []InstanceDiff{primaryRestart, replicaRestart})).
+			To(ContainSubstring("primary will be restarted in place"))
+	})
+
+	It("reports a switchover", func() {
+		switchoverCluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover},
+		}
+		Expect(describeRollout(switchoverCluster, []InstanceDiff{primaryRestart, replicaRestart})).
+			To(ContainSubstring("switchover will happen"))
+	})
+})
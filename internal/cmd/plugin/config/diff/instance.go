@@ -0,0 +1,71 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// instanceSettingsQuery reads the value of each parameter from the
+// configuration files, together with its context
+const instanceSettingsQuery = `
+SELECT COALESCE(pg_catalog.json_object_agg(
+    COALESCE(s.name, f.name),
+    pg_catalog.json_build_object('value', f.setting, 'context', s.context)), '{}')
+FROM pg_catalog.pg_settings s
+FULL JOIN (
+    SELECT DISTINCT ON (name) name, setting
+    FROM pg_catalog.pg_file_settings
+    ORDER BY name, seqno DESC
+) f ON f.name = s.name`
+
+// getInstanceSettings gets the parameters reported by an instance
+func getInstanceSettings(
+	ctx context.Context,
+	pod *corev1.Pod,
+	timeout time.Duration,
+) (map[string]instanceSetting, error) {
+	stdout, _, err := utils.ExecCommand(
+		ctx,
+		plugin.ClientInterface,
+		plugin.Config,
+		*pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"psql", "-XAtq", "-c", instanceSettingsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("while reading the configuration of %s: %w", pod.Name, err)
+	}
+
+	var settings map[string]instanceSetting
+	if err := json.Unmarshal([]byte(stdout), &settings); err != nil {
+		return nil, fmt.Errorf("while decoding the configuration of %s: %w", pod.Name, err)
+	}
+	return settings, nil
+}
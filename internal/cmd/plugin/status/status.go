@@ -71,6 +71,9 @@ type PostgresqlStatus struct {
 	// with the label selector
 	PodDisruptionBudgetList policyv1.PodDisruptionBudgetList
 
+	// BreakGlassAccessList contains the break-glass accesses targeting the cluster
+	BreakGlassAccessList []apiv1.BreakGlassAccess
+
 	// ErrorList store the possible errors while getting the PostgreSQL status
 	ErrorList []error
 
@@ -139,6 +142,7 @@ func Status(
 	status.printHibernationInfo()
 	status.printDemotionTokenInfo()
 	status.printPromotionTokenInfo()
+	status.printBreakGlassAccessStatus(verbosity)
 	if verbosity > 1 {
 		errs = append(errs, status.printPostgresConfiguration(ctx, clientInterface, timeout)...)
 		status.printCertificatesStatus()
@@ -200,12 +204,25 @@ func extractPostgresqlStatus(ctx context.Context, cluster apiv1.Cluster) *Postgr
 	); err != nil {
 		errs = append(errs, err)
 	}
+
+	var accessList apiv1.BreakGlassAccessList
+	if err := plugin.Client.List(ctx, &accessList, client.InNamespace(plugin.Namespace)); err != nil {
+		errs = append(errs, err)
+	}
+	var accesses []apiv1.BreakGlassAccess
+	for _, access := range accessList.Items {
+		if access.Spec.ClusterRef.Name == cluster.Name {
+			accesses = append(accesses, access)
+		}
+	}
+
 	// Extract the status from the instances
 	status := PostgresqlStatus{
 		Cluster:                 &cluster,
 		InstanceStatus:          &instancesStatus,
 		PrimaryPod:              primaryPod,
 		PodDisruptionBudgetList: pdbl,
+		BreakGlassAccessList:    accesses,
 		ErrorList:               errs,
 	}
 	return &status
@@ -1122,6 +1139,56 @@ func (fullStatus *PostgresqlStatus) printPodDisruptionBudgetStatus() {
 	fmt.Println()
 }
 
+// printBreakGlassAccessStatus prints the break-glass accesses of the cluster
+// that have not expired yet, or all of them with a higher verbosity
+func (fullStatus *PostgresqlStatus) printBreakGlassAccessStatus(verbosity int) {
+	const header = "Break-glass accesses"
+
+	accesses := make([]apiv1.BreakGlassAccess, 0, len(fullStatus.BreakGlassAccessList))
+	for _, access := range fullStatus.BreakGlassAccessList {
+		if verbosity > 0 || access.Status.Phase != apiv1.BreakGlassAccessPhaseExpired {
+			accesses = append(accesses, access)
+		}
+	}
+	if len(accesses) == 0 {
+		return
+	}
+
+	fmt.Println(aurora.Yellow(header))
+	status := tabby.New()
+	status.AddHeader("Name", "Role", "Phase", "Expiration", "Memberships", "Reason")
+	for _, access := range accesses {
+		phase := string(access.Status.Phase)
+		if phase == "" {
+			phase = "pending"
+		}
+		if access.Status.Message != "" {
+			phase = fmt.Sprintf("%s (%s)", phase, access.Status.Message)
+		}
+
+		expiration := "-"
+		if access.Status.ExpirationTime != nil {
+			expiration = access.Status.ExpirationTime.Format(time.RFC3339)
+		}
+
+		memberships := access.Status.GrantedRoles
+		if access.Status.Phase != apiv1.BreakGlassAccessPhaseActive {
+			memberships = access.Spec.InRoles
+		}
+
+		status.AddLine(
+			access.Name,
+			access.Spec.Role,
+			phase,
+			expiration,
+			strings.Join(memberships, ", "),
+			access.Spec.Reason,
+		)
+	}
+	status.Print()
+	fmt.Println()
+}
+
 func (fullStatus *PostgresqlStatus) printBasebackupStatus(verbosity int) {
 	const header = "Physical backups"
 
@@ -0,0 +1,61 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd initializes the upgrade command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "upgrade",
+		Short:   `Major version upgrade related commands`,
+		GroupID: plugin.GroupIDCluster,
+	}
+
+	cmd.AddCommand(newRollbackCmd())
+
+	return cmd
+}
+
+func newRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback CLUSTER",
+		Short: "Restores the previous major version of the cluster named CLUSTER",
+		Long: "Restores the PVCs of the cluster named CLUSTER from the volume snapshot backup " +
+			"taken before the latest in-place major upgrade, and pins the image of the previous " +
+			"major version. Every change made after the backup was taken will be lost.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+			return rollback(cmd.Context(), plugin.Client, client.ObjectKey{
+				Name:      clusterName,
+				Namespace: plugin.Namespace,
+			})
+		},
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// rollback requests the operator to restore the cluster from the backup
+// taken before the latest major upgrade, pinning the previous image
+func rollback(
+	ctx context.Context,
+	cli client.Client,
+	clusterKey client.ObjectKey,
+) error {
+	var cluster apiv1.Cluster
+	if err := cli.Get(ctx, clusterKey, &cluster); err != nil {
+		return fmt.Errorf("failed to get cluster %s: %w", clusterKey.Name, err)
+	}
+
+	rollbackPoint := cluster.Status.MajorUpgradeRollbackPoint
+	if rollbackPoint == nil {
+		return fmt.Errorf("cluster %s has no major upgrade rollback point", clusterKey.Name)
+	}
+
+	if cluster.IsMajorUpgradeRollbackRequested() {
+		return fmt.Errorf("cluster %s is already being rolled back", clusterKey.Name)
+	}
+
+	var backup apiv1.Backup
+	if err := cli.Get(
+		ctx,
+		client.ObjectKey{Namespace: clusterKey.Namespace, Name: rollbackPoint.BackupName},
+		&backup,
+	); err != nil {
+		return fmt.Errorf("failed to get the pre-upgrade backup %s: %w", rollbackPoint.BackupName, err)
+	}
+
+	if !backup.IsCompletedVolumeSnapshot() {
+		return fmt.Errorf("the pre-upgrade backup %s is not a completed volume snapshot backup", backup.Name)
+	}
+
+	origCluster := cluster.DeepCopy()
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string)
+	}
+	cluster.Annotations[utils.MajorUpgradeRollbackAnnotationName] = backup.Name
+
+	// Pin the image of the previous major version, so that the
+	// operator won't start the upgrade again after the restore
+	if cluster.Spec.ImageCatalogRef != nil {
+		cluster.Spec.ImageCatalogRef.Major = rollbackPoint.PGDataImageInfo.MajorVersion
+	} else {
+		cluster.Spec.ImageName = rollbackPoint.PGDataImageInfo.Image
+	}
+
+	if err := cli.Patch(ctx, &cluster, client.MergeFrom(origCluster)); err != nil {
+		return fmt.Errorf("failed to patch cluster %s: %w", clusterKey.Name, err)
+	}
+
+	fmt.Printf("%s will be restored to major version %d from backup %s\n",
+		cluster.Name, rollbackPoint.PGDataImageInfo.MajorVersion, backup.Name)
+	return nil
+}
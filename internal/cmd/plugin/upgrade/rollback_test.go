@@ -0,0 +1,135 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rollback", func() {
+	var (
+		cluster    *apiv1.Cluster
+		backup     *apiv1.Backup
+		clusterKey k8client.ObjectKey
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-namespace",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+			},
+			Status: apiv1.ClusterStatus{
+				MajorUpgradeRollbackPoint: &apiv1.MajorUpgradeRollbackPoint{
+					BackupName: "test-cluster-pre-upgrade",
+					PGDataImageInfo: apiv1.ImageInfo{
+						Image:        "postgres:16",
+						MajorVersion: 16,
+					},
+					TargetMajorVersion: 17,
+				},
+			},
+		}
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster-pre-upgrade",
+				Namespace: "test-namespace",
+			},
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodVolumeSnapshot,
+			},
+			Status: apiv1.BackupStatus{
+				Phase: apiv1.BackupPhaseCompleted,
+			},
+		}
+		clusterKey = k8client.ObjectKeyFromObject(cluster)
+	})
+
+	newClient := func(objects ...k8client.Object) k8client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	It("requests the rollback and pins the previous image", func(ctx SpecContext) {
+		cli := newClient(cluster, backup)
+		Expect(rollback(ctx, cli, clusterKey)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, clusterKey, &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).To(
+			HaveKeyWithValue(utils.MajorUpgradeRollbackAnnotationName, "test-cluster-pre-upgrade"))
+		Expect(updatedCluster.Spec.ImageName).To(Equal("postgres:16"))
+	})
+
+	It("pins the previous major version of the image catalog", func(ctx SpecContext) {
+		cluster.Spec.ImageName = ""
+		cluster.Spec.ImageCatalogRef = &apiv1.ImageCatalogRef{
+			TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+				APIGroup: &apiv1.SchemeGroupVersion.Group,
+				Kind:     "ImageCatalog",
+				Name:     "catalog",
+			},
+			Major: 17,
+		}
+		cli := newClient(cluster, backup)
+		Expect(rollback(ctx, cli, clusterKey)).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, clusterKey, &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Spec.ImageCatalogRef.Major).To(Equal(16))
+		Expect(updatedCluster.Spec.ImageName).To(BeEmpty())
+	})
+
+	It("fails when the cluster has no rollback point", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeRollbackPoint = nil
+		cli := newClient(cluster, backup)
+		Expect(rollback(ctx, cli, clusterKey)).To(MatchError(ContainSubstring("no major upgrade rollback point")))
+	})
+
+	It("fails when the backup is not completed", func(ctx SpecContext) {
+		backup.Status.Phase = apiv1.BackupPhaseRunning
+		cli := newClient(cluster, backup)
+		Expect(rollback(ctx, cli, clusterKey)).To(MatchError(ContainSubstring("not a completed volume snapshot")))
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, clusterKey, &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.MajorUpgradeRollbackAnnotationName))
+	})
+
+	It("fails when the backup does not exist", func(ctx SpecContext) {
+		cli := newClient(cluster)
+		Expect(rollback(ctx, cli, clusterKey)).To(HaveOccurred())
+	})
+})
@@ -780,6 +780,18 @@ func (r *BackupReconciler) getSnapshotTargetPod(
 		return targetPod, nil
 	}
 
+	// The instance to be snapshotted has been explicitly chosen
+	if backup.Spec.Instance != "" {
+		var pod corev1.Pod
+		if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: backup.Spec.Instance}, &pod); err != nil {
+			return nil, err
+		}
+		if pod.Labels[utils.ClusterLabelName] != cluster.Name {
+			return nil, fmt.Errorf("instance %s does not belong to cluster %s", backup.Spec.Instance, cluster.Name)
+		}
+		return &pod, nil
+	}
+
 	// If no good running backups are found we elect a pod for the backup
 	// Note: we only need the Pod from the status for volume snapshot backups
 	// (they are managed by the operator, not instance manager)
@@ -842,9 +854,19 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context, //nolint: goc
 ) (*postgresStatus.PostgresqlStatus, error) {
 	contextLogger := log.FromContext(ctx)
 
+	// The backup taken before a major version upgrade is the only one
+	// that is allowed to run while the upgrade is in progress, and it
+	// must run on the image of the current data directory
+	expectedImage := cluster.Status.Image
+	isRollbackPoint := cluster.IsMajorUpgradeRollbackPoint(backup.Name) && cluster.Status.PGDataImageInfo != nil
+	if isRollbackPoint {
+		expectedImage = cluster.Status.PGDataImageInfo.Image
+	}
+
 	podHasLatestMajorImage := func(pod *corev1.Pod) bool {
 		// No backup should run during a major version upgrade
-		if cluster.Status.PGDataImageInfo != nil &&
+		if !isRollbackPoint &&
+			cluster.Status.PGDataImageInfo != nil &&
 			cluster.Status.Image != cluster.Status.PGDataImageInfo.Image {
 			return false
 		}
@@ -860,7 +882,7 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context, //nolint: goc
 			return false
 		}
 
-		if pgContainer.Image != cluster.Status.Image {
+		if pgContainer.Image != expectedImage {
 			contextLogger.Debug("Instance not having expected image, discarded as target for backup")
 			return false
 		}
@@ -1156,6 +1178,12 @@ func (r *BackupReconciler) reconcileMajorVersion(
 		return fmt.Errorf("cannot get major version from cluster: %w", err)
 	}
 
+	// The backup taken before a major version upgrade contains the data
+	// directory of the previous major version
+	if cluster.IsMajorUpgradeRollbackPoint(backup.Name) {
+		majorVersion = cluster.Status.MajorUpgradeRollbackPoint.PGDataImageInfo.MajorVersion
+	}
+
 	if backup.Status.MajorVersion == majorVersion {
 		return nil
 	}
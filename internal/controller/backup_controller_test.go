@@ -831,3 +831,46 @@ var _ = Describe("backup pending state", func() {
 		})
 	})
 })
+
+var _ = Describe("getSnapshotTargetPod with an explicit instance", func() {
+	var env *testingEnvironment
+	BeforeEach(func() { env = buildTestEnvironment() })
+
+	It("uses the chosen instance", func(ctx context.Context) {
+		ns := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, ns)
+		pods := generateFakeClusterPods(env.client, cluster, true)
+
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "branch-backup", Namespace: ns},
+			Spec: apiv1.BackupSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: cluster.Name},
+				Method:   apiv1.BackupMethodVolumeSnapshot,
+				Instance: pods[2].Name,
+			},
+		}
+
+		pod, err := env.backupReconciler.getSnapshotTargetPod(ctx, cluster, backup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Name).To(Equal(pods[2].Name))
+	})
+
+	It("refuses an instance of another cluster", func(ctx context.Context) {
+		ns := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, ns)
+		otherCluster := newFakeCNPGCluster(env.client, ns)
+		otherPods := generateFakeClusterPods(env.client, otherCluster, true)
+
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "branch-backup", Namespace: ns},
+			Spec: apiv1.BackupSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: cluster.Name},
+				Method:   apiv1.BackupMethodVolumeSnapshot,
+				Instance: otherPods[0].Name,
+			},
+		}
+
+		_, err := env.backupReconciler.getSnapshotTargetPod(ctx, cluster, backup)
+		Expect(err).To(MatchError(ContainSubstring("does not belong to cluster")))
+	})
+})
@@ -0,0 +1,343 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// cloneSourceRetryPeriod is the time to wait before checking again the
+// Cluster to be cloned, when it is not ready to be cloned yet
+const cloneSourceRetryPeriod = 30 * time.Second
+
+// checkReadyForClone checks if the Cluster referenced in the clone bootstrap
+// section can be cloned, provisioning the credentials needed to connect to
+// it, and returns for requeue if not
+func (r *ClusterReconciler) checkReadyForClone(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	source, _ := cluster.GetCloneSource()
+
+	// The volume snapshots are already in the namespace of the cluster:
+	// no grant is involved, as nothing is read from the source namespace
+	if cluster.IsCloningViaVolumeSnapshot() {
+		return r.checkCloneVolumeSnapshots(ctx, cluster, source)
+	}
+
+	granted, err := r.isCloneGranted(ctx, cluster, source)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !granted {
+		if err := r.deleteCloneSourceSecret(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+		contextLogger.Info("Cloning not granted by the source namespace, waiting",
+			"sourceNamespace", source.Namespace,
+			"sourceCluster", source.Name)
+		return r.waitForCloneSource(ctx, cluster, fmt.Sprintf(
+			"No ClusterCloneGrant in namespace %s allows cloning Cluster %s in namespace %s",
+			source.Namespace, source.Name, cluster.Namespace))
+	}
+
+	var sourceCluster apiv1.Cluster
+	err = r.Get(ctx, client.ObjectKey{Namespace: source.Namespace, Name: source.Name}, &sourceCluster)
+	if apierrors.IsNotFound(err) {
+		return r.waitForCloneSource(ctx, cluster, fmt.Sprintf(
+			"Cluster %s to be cloned not found in namespace %s", source.Name, source.Namespace))
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if sourceCluster.Status.CurrentPrimary == "" {
+		return r.waitForCloneSource(ctx, cluster, fmt.Sprintf(
+			"Cluster %s to be cloned has no primary instance yet", source.Name))
+	}
+
+	if err := r.ensureCloneSourceSecret(ctx, cluster, &sourceCluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while provisioning the clone source credentials: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// waitForCloneSource registers the reason why the clone can't proceed,
+// and returns for requeue
+func (r *ClusterReconciler) waitForCloneSource(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	reason string,
+) (ctrl.Result, error) {
+	if cluster.Status.Phase != apiv1.PhaseWaitingForCloneSource || cluster.Status.PhaseReason != reason {
+		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseWaitingForCloneSource, reason); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: cloneSourceRetryPeriod}, nil
+}
+
+// isCloneGranted checks if the Cluster can be bootstrapped cloning the
+// passed source. Clusters in the same namespace can always be cloned,
+// while a ClusterCloneGrant in the source namespace is needed otherwise
+func (r *ClusterReconciler) isCloneGranted(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	source apiv1.ClusterReference,
+) (bool, error) {
+	if source.Namespace == cluster.Namespace {
+		return true, nil
+	}
+
+	var grants apiv1.ClusterCloneGrantList
+	if err := r.List(ctx, &grants, client.InNamespace(source.Namespace)); err != nil {
+		return false, fmt.Errorf("while listing the ClusterCloneGrants in namespace %s: %w",
+			source.Namespace, err)
+	}
+
+	return grants.Allows(source.Name, cluster.Namespace), nil
+}
+
+// checkCloneVolumeSnapshots checks that the volume snapshots the cluster
+// is being cloned from are ready to be used and have been taken from the
+// source Cluster
+func (r *ClusterReconciler) checkCloneVolumeSnapshots(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	source apiv1.ClusterReference,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	volumeSnapshots := cluster.Spec.Bootstrap.Clone.VolumeSnapshots
+	status, err := persistentvolumeclaim.VerifyDataSourceCoherence(
+		ctx, r.Client, cluster.Namespace, volumeSnapshots)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if status.ContainsErrors() {
+		contextLogger.Warning(
+			"Volume snapshots verification failed, retrying",
+			"status", status)
+		return ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 5 * time.Second,
+		}, nil
+	}
+	if status.ContainsWarnings() {
+		contextLogger.Warning("Volume snapshots verification warnings",
+			"status", status)
+	}
+
+	references := []corev1.TypedLocalObjectReference{volumeSnapshots.Storage}
+	if volumeSnapshots.WalStorage != nil {
+		references = append(references, *volumeSnapshots.WalStorage)
+	}
+	for _, reference := range volumeSnapshots.TablespaceStorage {
+		references = append(references, reference)
+	}
+	for _, reference := range references {
+		metadata, err := persistentvolumeclaim.GetSourceMetadataOrNil(ctx, r.Client, cluster.Namespace, reference)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if metadata == nil {
+			continue
+		}
+		if snapshotCluster := metadata.GetLabels()[utils.ClusterLabelName]; snapshotCluster != source.Name {
+			return r.waitForCloneSource(ctx, cluster, fmt.Sprintf(
+				"%s %s was not taken from Cluster %s (%s label: %q)",
+				reference.Kind, reference.Name, source.Name, utils.ClusterLabelName, snapshotCluster))
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ensureCloneSourceSecret makes sure the secret containing the credentials
+// of the streaming replication user of the source Cluster exists in the
+// namespace of the cluster being created
+func (r *ClusterReconciler) ensureCloneSourceSecret(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	sourceCluster *apiv1.Cluster,
+) error {
+	secretKey := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetCloneSourceSecretName()}
+
+	var secret corev1.Secret
+	err := r.Get(ctx, secretKey, &secret)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	newSecret, err := r.generateCloneSourceSecret(ctx, sourceCluster, secretKey)
+	if err != nil {
+		return err
+	}
+
+	utils.SetAsOwnedBy(&newSecret.ObjectMeta, cluster.ObjectMeta, cluster.TypeMeta)
+	if newSecret.Labels == nil {
+		newSecret.Labels = make(map[string]string)
+	}
+	newSecret.Labels[utils.ClusterLabelName] = cluster.Name
+
+	if err := r.Create(ctx, newSecret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	r.Recorder.Eventf(cluster, "Normal", "CloneSourceCredentials",
+		"Provisioned credentials to clone Cluster %s/%s", sourceCluster.Namespace, sourceCluster.Name)
+	return nil
+}
+
+// reconcileCloneSourceSecret removes the credentials used to clone the
+// source Cluster once they are not needed anymore, that is when the clone
+// bootstrap has been completed or the ClusterCloneGrant has been revoked
+func (r *ClusterReconciler) reconcileCloneSourceSecret(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) error {
+	source, ok := cluster.GetCloneSource()
+	if !ok || cluster.IsCloningViaVolumeSnapshot() {
+		return nil
+	}
+
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetCloneSourceSecretName()}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cluster.Status.ReadyInstances == 0 {
+		granted, err := r.isCloneGranted(ctx, cluster, source)
+		if err != nil || granted {
+			return err
+		}
+	}
+
+	return r.deleteCloneSourceSecret(ctx, cluster)
+}
+
+// deleteCloneSourceSecret deletes the secret containing the credentials
+// of the streaming replication user of the source Cluster
+func (r *ClusterReconciler) deleteCloneSourceSecret(ctx context.Context, cluster *apiv1.Cluster) error {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      cluster.GetCloneSourceSecretName(),
+		},
+	}
+	if err := r.Delete(ctx, &secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	source, _ := cluster.GetCloneSource()
+	log.FromContext(ctx).Info("Deleted the clone source credentials", "secretName", secret.Name)
+	r.Recorder.Eventf(cluster, "Normal", "CloneSourceCredentials",
+		"Deleted the credentials used to clone Cluster %s/%s", source.Namespace, source.Name)
+	return nil
+}
+
+// generateCloneSourceSecret builds the secret containing a client certificate
+// of the streaming replication user of the source Cluster, together with the
+// CA needed to verify its server certificate. When the private key of the
+// client CA of the source Cluster is available a new certificate is issued,
+// otherwise its streaming replication certificate is used
+func (r *ClusterReconciler) generateCloneSourceSecret(
+	ctx context.Context,
+	sourceCluster *apiv1.Cluster,
+	secretKey client.ObjectKey,
+) (*corev1.Secret, error) {
+	getSourceSecret := func(name string) (*corev1.Secret, error) {
+		var secret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: sourceCluster.Namespace, Name: name}, &secret); err != nil {
+			return nil, fmt.Errorf("while getting secret %s of the source Cluster: %w", name, err)
+		}
+		return &secret, nil
+	}
+
+	serverCASecret, err := getSourceSecret(sourceCluster.GetServerCASecretName())
+	if err != nil {
+		return nil, err
+	}
+	serverCA, ok := serverCASecret.Data[certs.CACertKey]
+	if !ok {
+		return nil, fmt.Errorf("missing %s in the server CA secret %s of the source Cluster",
+			certs.CACertKey, serverCASecret.Name)
+	}
+
+	clientCASecret, err := getSourceSecret(sourceCluster.GetClientCASecretName())
+	if err != nil {
+		return nil, err
+	}
+
+	var secret *corev1.Secret
+	if _, hasPrivateKey := clientCASecret.Data[certs.CAPrivateKeyKey]; hasPrivateKey {
+		secret, err = generateCertificateFromCA(
+			clientCASecret,
+			apiv1.StreamingReplicationUser,
+			certs.CertTypeClient,
+			nil,
+			secretKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		replicationSecret, err := getSourceSecret(sourceCluster.GetReplicationSecretName())
+		if err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: secretKey.Namespace,
+				Name:      secretKey.Name,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				certs.TLSCertKey:       replicationSecret.Data[certs.TLSCertKey],
+				certs.TLSPrivateKeyKey: replicationSecret.Data[certs.TLSPrivateKeyKey],
+			},
+		}
+	}
+
+	secret.Data[certs.CACertKey] = serverCA
+	return secret, nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("checkReadyForClone", func() {
+	var (
+		sourceCluster *apiv1.Cluster
+		cluster       *apiv1.Cluster
+		caSecret      *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		sourceCluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "production"},
+			Status:     apiv1.ClusterStatus{CurrentPrimary: "source-1"},
+		}
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "staging"},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Clone: &apiv1.BootstrapClone{
+						Cluster: apiv1.ClusterReference{Name: "source", Namespace: "production"},
+						Method:  apiv1.CloneMethodPgBaseBackup,
+					},
+				},
+			},
+		}
+
+		rootCA, err := certs.CreateRootCA("source", "production")
+		Expect(err).ToNot(HaveOccurred())
+		caSecret = rootCA.GenerateCASecret(sourceCluster.Namespace, sourceCluster.GetServerCASecretName())
+	})
+
+	newReconciler := func(objects ...client.Object) *ClusterReconciler {
+		return &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&apiv1.Cluster{}).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	It("waits for a grant when cloning a Cluster in another namespace", func(ctx SpecContext) {
+		r := newReconciler(cluster, sourceCluster, caSecret)
+
+		res, err := r.checkReadyForClone(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cloneSourceRetryPeriod))
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForCloneSource))
+
+		var secret corev1.Secret
+		err = r.Get(ctx, client.ObjectKey{Namespace: "staging", Name: cluster.GetCloneSourceSecretName()}, &secret)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("ignores grants for other namespaces", func(ctx SpecContext) {
+		grant := &apiv1.ClusterCloneGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "production"},
+			Spec: apiv1.ClusterCloneGrantSpec{
+				Cluster:    apiv1.LocalObjectReference{Name: "source"},
+				Namespaces: []string{"development"},
+			},
+		}
+		r := newReconciler(cluster, sourceCluster, caSecret, grant)
+
+		res, err := r.checkReadyForClone(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cloneSourceRetryPeriod))
+	})
+
+	It("provisions the clone source credentials once granted", func(ctx SpecContext) {
+		grant := &apiv1.ClusterCloneGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "production"},
+			Spec: apiv1.ClusterCloneGrantSpec{
+				Cluster:    apiv1.LocalObjectReference{Name: "source"},
+				Namespaces: []string{"staging"},
+			},
+		}
+		r := newReconciler(cluster, sourceCluster, caSecret, grant)
+
+		res, err := r.checkReadyForClone(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+
+		var secret corev1.Secret
+		Expect(r.Get(ctx,
+			client.ObjectKey{Namespace: "staging", Name: cluster.GetCloneSourceSecretName()},
+			&secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue(certs.CACertKey, caSecret.Data[certs.CACertKey]))
+		Expect(secret.Data).To(HaveKey(certs.TLSCertKey))
+		Expect(secret.Data).To(HaveKey(certs.TLSPrivateKeyKey))
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+
+		pair, err := certs.ParseServerSecret(&secret)
+		Expect(err).ToNot(HaveOccurred())
+		certificate, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificate.Subject.CommonName).To(Equal(apiv1.StreamingReplicationUser))
+	})
+
+	It("copies the replication credentials when the client CA key is not available", func(ctx SpecContext) {
+		delete(caSecret.Data, certs.CAPrivateKeyKey)
+		replicationSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sourceCluster.GetReplicationSecretName(),
+				Namespace: "production",
+			},
+			Data: map[string][]byte{
+				certs.TLSCertKey:       []byte("certificate"),
+				certs.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+		sourceCluster.Namespace = "staging"
+		cluster.Spec.Bootstrap.Clone.Cluster.Namespace = ""
+		caSecret.Namespace = "staging"
+		replicationSecret.Namespace = "staging"
+		r := newReconciler(cluster, sourceCluster, caSecret, replicationSecret)
+
+		res, err := r.checkReadyForClone(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+
+		var secret corev1.Secret
+		Expect(r.Get(ctx,
+			client.ObjectKey{Namespace: "staging", Name: cluster.GetCloneSourceSecretName()},
+			&secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue(certs.TLSCertKey, []byte("certificate")))
+		Expect(secret.Data).To(HaveKeyWithValue(certs.TLSPrivateKeyKey, []byte("key")))
+	})
+
+	It("waits for the source Cluster to have a primary", func(ctx SpecContext) {
+		sourceCluster.Namespace = "staging"
+		sourceCluster.Status.CurrentPrimary = ""
+		cluster.Spec.Bootstrap.Clone.Cluster.Namespace = ""
+		r := newReconciler(cluster, sourceCluster)
+
+		res, err := r.checkReadyForClone(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cloneSourceRetryPeriod))
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("no primary"))
+	})
+
+	Context("with the volumeSnapshot method", func() {
+		var snapshot *volumesnapshotv1.VolumeSnapshot
+
+		BeforeEach(func() {
+			snapshot = &volumesnapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-20260102150405",
+					Namespace: "staging",
+					Labels: map[string]string{
+						utils.ClusterLabelName:    "source",
+						utils.PvcRoleLabelName:    string(utils.PVCRolePgData),
+						utils.BackupNameLabelName: "source-20260102150405",
+					},
+				},
+			}
+			cluster.Spec.Bootstrap.Clone.Method = apiv1.CloneMethodVolumeSnapshot
+			cluster.Spec.Bootstrap.Clone.VolumeSnapshots = &apiv1.DataSource{
+				Storage: corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To(volumesnapshotv1.GroupName),
+					Kind:     apiv1.VolumeSnapshotKind,
+					Name:     snapshot.Name,
+				},
+			}
+		})
+
+		It("doesn't require a grant to use the snapshots of the source Cluster", func(ctx SpecContext) {
+			r := newReconciler(cluster, snapshot)
+
+			res, err := r.checkReadyForClone(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+			Expect(cluster.Status.Phase).To(BeEmpty())
+		})
+
+		It("refuses the snapshots taken from another Cluster", func(ctx SpecContext) {
+			snapshot.Labels[utils.ClusterLabelName] = "other"
+			r := newReconciler(cluster, snapshot)
+
+			res, err := r.checkReadyForClone(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(cloneSourceRetryPeriod))
+			Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForCloneSource))
+			Expect(cluster.Status.PhaseReason).To(ContainSubstring("was not taken from Cluster source"))
+		})
+	})
+
+	Describe("reconcileCloneSourceSecret", func() {
+		var grant *apiv1.ClusterCloneGrant
+
+		BeforeEach(func() {
+			grant = &apiv1.ClusterCloneGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "production"},
+				Spec: apiv1.ClusterCloneGrantSpec{
+					Cluster:    apiv1.LocalObjectReference{Name: "source"},
+					Namespaces: []string{"staging"},
+				},
+			}
+		})
+
+		getCloneSourceSecret := func(ctx SpecContext, r *ClusterReconciler) error {
+			var secret corev1.Secret
+			return r.Get(ctx,
+				client.ObjectKey{Namespace: "staging", Name: cluster.GetCloneSourceSecretName()},
+				&secret)
+		}
+
+		It("keeps the credentials while the clone bootstrap is running", func(ctx SpecContext) {
+			r := newReconciler(cluster, sourceCluster, caSecret, grant)
+			Expect(r.checkReadyForClone(ctx, cluster)).To(BeZero())
+
+			Expect(r.reconcileCloneSourceSecret(ctx, cluster)).To(Succeed())
+			Expect(getCloneSourceSecret(ctx, r)).To(Succeed())
+		})
+
+		It("deletes the credentials once the clone bootstrap is completed", func(ctx SpecContext) {
+			r := newReconciler(cluster, sourceCluster, caSecret, grant)
+			Expect(r.checkReadyForClone(ctx, cluster)).To(BeZero())
+
+			cluster.Status.ReadyInstances = 1
+			Expect(r.reconcileCloneSourceSecret(ctx, cluster)).To(Succeed())
+			Expect(apierrors.IsNotFound(getCloneSourceSecret(ctx, r))).To(BeTrue())
+		})
+
+		It("deletes the credentials when the grant is revoked", func(ctx SpecContext) {
+			r := newReconciler(cluster, sourceCluster, caSecret, grant)
+			Expect(r.checkReadyForClone(ctx, cluster)).To(BeZero())
+
+			Expect(r.Delete(ctx, grant)).To(Succeed())
+			Expect(r.reconcileCloneSourceSecret(ctx, cluster)).To(Succeed())
+			Expect(apierrors.IsNotFound(getCloneSourceSecret(ctx, r))).To(BeTrue())
+		})
+	})
+})
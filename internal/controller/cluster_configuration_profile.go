@@ -0,0 +1,251 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// reconcileConfigurationProfileRef applies the configuration profile
+// referenced by the cluster when the reference is set or changed, and
+// removes it when the reference is removed. The new versions of an
+// already applied profile are handled by reconcileConfigurationProfileVersion
+func (r *ClusterReconciler) reconcileConfigurationProfileRef(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (*ctrl.Result, error) {
+	ref := cluster.Spec.ConfigurationProfileRef
+	applied := cluster.Status.ConfigurationProfile
+
+	if ref == nil {
+		if applied == nil {
+			return nil, nil
+		}
+		return nil, status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			cluster,
+			status.SetConfigurationProfile(nil),
+		)
+	}
+
+	if applied != nil && applied.Kind == ref.Kind && applied.Name == ref.Name {
+		return nil, nil
+	}
+
+	profile, err := r.getConfigurationProfile(ctx, cluster)
+	if err != nil {
+		r.Recorder.Eventf(cluster, "Warning", "ConfigurationProfile", "Error getting %v/%v: %v",
+			ref.Kind, ref.Name, err)
+		log.FromContext(ctx).Error(err, "while getting the configuration profile",
+			"configurationProfileRef", ref)
+		return &ctrl.Result{}, r.RegisterPhase(ctx, cluster, apiv1.PhaseConfigurationProfileError, err.Error())
+	}
+
+	return nil, status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		status.SetConfigurationProfile(newAppliedConfigurationProfile(profile)),
+	)
+}
+
+// reconcileConfigurationProfileVersion applies the latest version of the
+// configuration profile referenced by the cluster. The rollout manager
+// paces the changes, so that a new version of a profile shared by many
+// clusters is rolled out one cluster at a time
+func (r *ClusterReconciler) reconcileConfigurationProfileVersion(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	applied := cluster.Status.ConfigurationProfile
+	if cluster.Spec.ConfigurationProfileRef == nil || applied == nil {
+		return ctrl.Result{}, nil
+	}
+
+	contextLogger := log.FromContext(ctx).WithValues(
+		"configurationProfileRef", cluster.Spec.ConfigurationProfileRef)
+
+	profile, err := r.getConfigurationProfile(ctx, cluster)
+	if err != nil {
+		// The cluster keeps running the version it has already applied
+		contextLogger.Warning("Cannot get the latest version of the configuration profile",
+			"appliedGeneration", applied.Generation,
+			"error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	if profile.GetGeneration() == applied.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	managerResult := r.rolloutManager.CoordinateRollout(
+		client.ObjectKeyFromObject(cluster),
+		apiv1.ConfigurationProfileIdentifier(profile))
+	if !managerResult.RolloutAllowed {
+		contextLogger.Info("Waiting to roll out the new version of the configuration profile",
+			"appliedGeneration", applied.Generation,
+			"generation", profile.GetGeneration(),
+			"timeToWait", managerResult.TimeToWait)
+		return ctrl.Result{RequeueAfter: managerResult.TimeToWait}, nil
+	}
+
+	contextLogger.Info("Rolling out the new version of the configuration profile",
+		"appliedGeneration", applied.Generation,
+		"generation", profile.GetGeneration())
+	r.Recorder.Eventf(cluster, "Normal", "ConfigurationProfile",
+		"Applying generation %d of %s", profile.GetGeneration(),
+		apiv1.ConfigurationProfileIdentifier(profile))
+
+	if err := status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		status.SetConfigurationProfile(newAppliedConfigurationProfile(profile)),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the instances to apply the new configuration
+	return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
+}
+
+// getConfigurationProfile gets and validates the configuration profile
+// referenced by the cluster.
+// The caller must ensure that cluster.Spec.ConfigurationProfileRef is not nil.
+func (r *ClusterReconciler) getConfigurationProfile(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (apiv1.GenericPostgresConfigurationProfile, error) {
+	ref := cluster.Spec.ConfigurationProfileRef
+
+	var profile apiv1.GenericPostgresConfigurationProfile
+	namespace := ""
+	switch ref.Kind {
+	case apiv1.PostgresConfigurationProfileKind:
+		profile = &apiv1.PostgresConfigurationProfile{}
+		namespace = cluster.Namespace
+	case apiv1.ClusterPostgresConfigurationProfileKind:
+		profile = &apiv1.ClusterPostgresConfigurationProfile{}
+	default:
+		return nil, fmt.Errorf("invalid configuration profile type: %s", ref.Kind)
+	}
+
+	if ref.APIGroup == nil || *ref.APIGroup != apiv1.SchemeGroupVersion.Group {
+		return nil, fmt.Errorf("invalid configuration profile group")
+	}
+
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, profile)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("configuration profile %s/%s not found", ref.Kind, ref.Name)
+		}
+		return nil, fmt.Errorf("error getting configuration profile: %w", err)
+	}
+
+	if err := profile.GetSpec().ValidateParameters(); err != nil {
+		return nil, err
+	}
+
+	// The configuration resulting from the profile is validated with the
+	// same rules the admission webhook applies to the cluster
+	if r.admission != nil && r.admission.Validator != nil {
+		layeredCluster := cluster.DeepCopy()
+		layeredCluster.Status.ConfigurationProfile = newAppliedConfigurationProfile(profile)
+		if _, err := r.admission.Validator.ValidateCreate(ctx, layeredCluster); err != nil {
+			return nil, fmt.Errorf("the configuration resulting from the profile is not valid: %w", err)
+		}
+	}
+
+	return profile, nil
+}
+
+// newAppliedConfigurationProfile creates the status entry recording the
+// version of the passed configuration profile
+func newAppliedConfigurationProfile(
+	profile apiv1.GenericPostgresConfigurationProfile,
+) *apiv1.AppliedConfigurationProfile {
+	kind := apiv1.PostgresConfigurationProfileKind
+	if _, ok := profile.(*apiv1.ClusterPostgresConfigurationProfile); ok {
+		kind = apiv1.ClusterPostgresConfigurationProfileKind
+	}
+
+	return &apiv1.AppliedConfigurationProfile{
+		Kind:       kind,
+		Name:       profile.GetName(),
+		Generation: profile.GetGeneration(),
+		Spec:       *profile.GetSpec().DeepCopy(),
+	}
+}
+
+// mapConfigurationProfilesToClusters returns a function mapping the
+// configuration profiles to the clusters referencing them
+func (r *ClusterReconciler) mapConfigurationProfilesToClusters() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		listOptions := []client.ListOption{
+			client.MatchingFields{configurationProfileKey: obj.GetName()},
+		}
+
+		var kind string
+		switch obj.(type) {
+		case *apiv1.PostgresConfigurationProfile:
+			kind = apiv1.PostgresConfigurationProfileKind
+			listOptions = append(listOptions, client.InNamespace(obj.GetNamespace()))
+		case *apiv1.ClusterPostgresConfigurationProfile:
+			kind = apiv1.ClusterPostgresConfigurationProfileKind
+		default:
+			return nil
+		}
+
+		var clusters apiv1.ClusterList
+		if err := r.List(ctx, &clusters, listOptions...); err != nil {
+			log.FromContext(ctx).Error(err, "while getting cluster list",
+				"configurationProfile", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			if cluster.Spec.ConfigurationProfileRef.Kind != kind {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      cluster.Name,
+					Namespace: cluster.Namespace,
+				},
+			})
+		}
+		return requests
+	}
+}
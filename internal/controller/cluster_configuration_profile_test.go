@@ -0,0 +1,269 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	rolloutManager "github.com/cloudnative-pg/cloudnative-pg/internal/controller/rollout"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/webhook/guard"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// profileRejectingValidator rejects the clusters having a configuration
+// profile applied
+type profileRejectingValidator struct{}
+
+func (profileRejectingValidator) ValidateCreate(
+	_ context.Context,
+	cluster *apiv1.Cluster,
+) (admission.Warnings, error) {
+	if cluster.Status.ConfigurationProfile != nil {
+		return nil, errors.New("invalid wal_log_hints")
+	}
+	return nil, nil
+}
+
+func (profileRejectingValidator) ValidateUpdate(
+	context.Context, *apiv1.Cluster, *apiv1.Cluster,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (profileRejectingValidator) ValidateDelete(context.Context, *apiv1.Cluster) (admission.Warnings, error) {
+	return nil, nil
+}
+
+var _ = Describe("Configuration profiles", func() {
+	const namespace = "configuration-profile-test"
+
+	var (
+		reconciler *ClusterReconciler
+		k8sClient  k8client.Client
+	)
+
+	newCluster := func(name string, ref *apiv1.ConfigurationProfileRef) *apiv1.Cluster {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: apiv1.ClusterSpec{
+				Instances:               1,
+				ConfigurationProfileRef: ref,
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+		return cluster
+	}
+
+	profileRef := func(kind, name string) *apiv1.ConfigurationProfileRef {
+		return &apiv1.ConfigurationProfileRef{
+			TypedLocalObjectReference: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(apiv1.SchemeGroupVersion.Group),
+				Kind:     kind,
+				Name:     name,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := schemeBuilder.BuildWithAllKnownScheme()
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&apiv1.Cluster{}).
+			WithIndex(&apiv1.Cluster{}, configurationProfileKey, func(rawObj k8client.Object) []string {
+				cluster := rawObj.(*apiv1.Cluster)
+				if cluster.Spec.ConfigurationProfileRef == nil {
+					return nil
+				}
+				return []string{cluster.Spec.ConfigurationProfileRef.Name}
+			}).
+			WithObjects(
+				&apiv1.PostgresConfigurationProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: namespace, Generation: 1},
+					Spec: apiv1.PostgresConfigurationProfileSpec{
+						Parameters: map[string]string{"work_mem": "8MB"},
+					},
+				},
+				&apiv1.ClusterPostgresConfigurationProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "global", Generation: 1},
+					Spec: apiv1.PostgresConfigurationProfileSpec{
+						PgHBA: []string{"host all all 10.0.0.0/8 scram-sha-256"},
+					},
+				},
+				&apiv1.ClusterPostgresConfigurationProfile{
+					ObjectMeta: metav1.ObjectMeta{Name: "invalid", Generation: 1},
+					Spec: apiv1.PostgresConfigurationProfileSpec{
+						Parameters: map[string]string{"port": "5433"},
+					},
+				},
+			).
+			Build()
+
+		reconciler = &ClusterReconciler{
+			Client:         k8sClient,
+			Scheme:         scheme,
+			Recorder:       record.NewFakeRecorder(120),
+			rolloutManager: rolloutManager.New(time.Hour, 0),
+		}
+	})
+
+	bumpProfileGeneration := func(ctx context.Context) {
+		var profile apiv1.PostgresConfigurationProfile
+		Expect(k8sClient.Get(ctx, k8client.ObjectKey{Namespace: namespace, Name: "baseline"}, &profile)).
+			To(Succeed())
+		profile.Generation++
+		profile.Spec.Parameters["work_mem"] = "16MB"
+		Expect(k8sClient.Update(ctx, &profile)).To(Succeed())
+	}
+
+	It("applies the referenced namespaced profile", func(ctx SpecContext) {
+		cluster := newCluster("cluster-example", profileRef(apiv1.PostgresConfigurationProfileKind, "baseline"))
+
+		res, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(cluster.Status.ConfigurationProfile).ToNot(BeNil())
+		Expect(cluster.Status.ConfigurationProfile.Kind).To(Equal(apiv1.PostgresConfigurationProfileKind))
+		Expect(cluster.Status.ConfigurationProfile.Name).To(Equal("baseline"))
+		Expect(cluster.Status.ConfigurationProfile.Generation).To(BeEquivalentTo(1))
+		Expect(cluster.GetPostgresParameters()).To(HaveKeyWithValue("work_mem", "8MB"))
+	})
+
+	It("applies the referenced cluster-wide profile", func(ctx SpecContext) {
+		cluster := newCluster("cluster-example", profileRef(apiv1.ClusterPostgresConfigurationProfileKind, "global"))
+
+		res, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+		Expect(cluster.Status.ConfigurationProfile).ToNot(BeNil())
+		Expect(cluster.Status.ConfigurationProfile.Kind).To(Equal(apiv1.ClusterPostgresConfigurationProfileKind))
+		Expect(cluster.GetPgHBA()).To(ContainElement("host all all 10.0.0.0/8 scram-sha-256"))
+	})
+
+	It("sets the cluster phase when the profile is missing", func(ctx SpecContext) {
+		cluster := newCluster("cluster-example", profileRef(apiv1.PostgresConfigurationProfileKind, "missing"))
+
+		res, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseConfigurationProfileError))
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("not found"))
+		Expect(cluster.Status.ConfigurationProfile).To(BeNil())
+	})
+
+	It("sets the cluster phase when the profile contains forbidden parameters", func(ctx SpecContext) {
+		cluster := newCluster("cluster-example", profileRef(apiv1.ClusterPostgresConfigurationProfileKind, "invalid"))
+
+		res, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseConfigurationProfileError))
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("port"))
+	})
+
+	It("sets the cluster phase when the resulting configuration is not valid", func(ctx SpecContext) {
+		reconciler.admission = &guard.Admission[*apiv1.Cluster]{Validator: profileRejectingValidator{}}
+		cluster := newCluster("cluster-example", profileRef(apiv1.PostgresConfigurationProfileKind, "baseline"))
+
+		res, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).ToNot(BeNil())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseConfigurationProfileError))
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("wal_log_hints"))
+		Expect(cluster.Status.ConfigurationProfile).To(BeNil())
+	})
+
+	It("removes the applied profile when the reference is removed", func(ctx SpecContext) {
+		cluster := newCluster("cluster-example", profileRef(apiv1.PostgresConfigurationProfileKind, "baseline"))
+		_, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.ConfigurationProfile).ToNot(BeNil())
+
+		cluster.Spec.ConfigurationProfileRef = nil
+		_, err = reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.ConfigurationProfile).To(BeNil())
+	})
+
+	It("paces the rollout of new profile versions across clusters", func(ctx SpecContext) {
+		ref := profileRef(apiv1.PostgresConfigurationProfileKind, "baseline")
+		first := newCluster("cluster-first", ref)
+		second := newCluster("cluster-second", ref)
+		for _, cluster := range []*apiv1.Cluster{first, second} {
+			_, err := reconciler.reconcileConfigurationProfileRef(ctx, cluster)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		By("leaving the clusters alone while the profile doesn't change", func() {
+			res, err := reconciler.reconcileConfigurationProfileVersion(ctx, first)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+		})
+
+		bumpProfileGeneration(ctx)
+
+		By("applying the new version to the first cluster", func() {
+			res, err := reconciler.reconcileConfigurationProfileVersion(ctx, first)
+			Expect(err).To(MatchError(ErrNextLoop))
+			Expect(res.RequeueAfter).To(Equal(time.Second))
+			Expect(first.Status.ConfigurationProfile.Generation).To(BeEquivalentTo(2))
+			Expect(first.GetPostgresParameters()).To(HaveKeyWithValue("work_mem", "16MB"))
+		})
+
+		By("delaying the new version on the second cluster", func() {
+			res, err := reconciler.reconcileConfigurationProfileVersion(ctx, second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(second.Status.ConfigurationProfile.Generation).To(BeEquivalentTo(1))
+			Expect(second.GetPostgresParameters()).To(HaveKeyWithValue("work_mem", "8MB"))
+		})
+	})
+
+	It("maps the profiles to the clusters referencing them", func(ctx SpecContext) {
+		newCluster("cluster-namespaced", profileRef(apiv1.PostgresConfigurationProfileKind, "baseline"))
+		newCluster("cluster-global", profileRef(apiv1.ClusterPostgresConfigurationProfileKind, "global"))
+		newCluster("cluster-none", nil)
+
+		requests := reconciler.mapConfigurationProfilesToClusters()(ctx, &apiv1.PostgresConfigurationProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline", Namespace: namespace},
+		})
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Name).To(Equal("cluster-namespaced"))
+
+		requests = reconciler.mapConfigurationProfilesToClusters()(ctx, &apiv1.ClusterPostgresConfigurationProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+		})
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Name).To(Equal("cluster-global"))
+	})
+})
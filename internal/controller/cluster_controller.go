@@ -78,6 +78,7 @@ const (
 	poolerClusterKey              = ".spec.cluster.name"
 	disableDefaultQueriesSpecPath = ".spec.monitoring.disableDefaultQueries"
 	imageCatalogKey               = ".spec.imageCatalog.name"
+	configurationProfileKey       = ".spec.configurationProfileRef.name"
 	databaseRoleClusterKey        = ".spec.cluster.name"
 	// usedPluginsClusterKey is a synthetic index key, not a real Cluster spec field;
 	// it is populated by getPluginsNeededForReconcile.
@@ -162,6 +163,11 @@ var ErrNextLoop = utils.ErrNextLoop
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create;watch;list;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=imagecatalogs,verbs=get;watch;list
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterimagecatalogs,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=postgresconfigurationprofiles,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterpostgresconfigurationprofiles,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterclonegrants,verbs=get;watch;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=breakglassaccesses,verbs=get;watch;list;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=breakglassaccesses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=failoverquorums,verbs=create;get;watch;delete;list
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=failoverquorums/status,verbs=get;patch;update;watch
 
@@ -289,6 +295,18 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+
+	// Make sure we wake up when the next password rotation is due
+	if next := getPasswordRotationRequeue(cluster, time.Now()); next > 0 && !result.Requeue &&
+		(result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
+	// Make sure we remove the break-glass rules when they expire
+	if next := getBreakGlassRequeue(cluster, time.Now()); next > 0 && !result.Requeue &&
+		(result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
 	return result, nil
 }
 
@@ -350,6 +368,15 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, fmt.Errorf("cannot set image name: %w", err)
 	}
 
+	// Apply the configuration profile referenced by the cluster
+	if result, err := r.reconcileConfigurationProfileRef(ctx, cluster); result != nil || err != nil {
+		if result != nil {
+			return *result, err
+		}
+
+		return ctrl.Result{}, fmt.Errorf("cannot apply the configuration profile: %w", err)
+	}
+
 	// Ensure we load all the plugins that are required to reconcile this cluster
 	if err := r.updatePluginsStatus(ctx, cluster); err != nil {
 		return ctrl.Result{}, fmt.Errorf("cannot reconcile required plugins: %w", err)
@@ -368,6 +395,21 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return ctrl.Result{}, err
 	}
 
+	// Merge the pg_hba rules declared by the database roles and report the effective ones
+	if err := r.reconcileHBAStatus(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Rotate the passwords of the managed roles when due
+	if err := r.reconcileManagedRolesPasswordRotation(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Generate the service binding secrets of the managed roles
+	if err := r.reconcileManagedRolesServiceBindings(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Ensure we have the required global objects
 	if err := r.createPostgresClusterObjects(ctx, cluster); err != nil {
 		if errors.Is(err, ErrNextLoop) {
@@ -406,6 +448,14 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return hookResult.Result, hookResult.Err
 	}
 
+	// The refresh of the data removes every instance, so it runs before
+	// any other operation on them, including failovers and switchovers
+	if result, err := r.reconcileRefresh(ctx, cluster, resources); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot refresh the data of the cluster: %w", err)
+	} else if result != nil {
+		return *result, nil
+	}
+
 	if cluster.Status.CurrentPrimary != "" &&
 		cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
 		// Mark the old primary as unhealthy on every pass while failover is
@@ -590,15 +640,40 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return *result, nil
 	}
 
+	if err := r.reconcileCloneSourceSecret(ctx, cluster); err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot reconcile the clone source credentials: %w", err)
+	}
+
 	// Updates all the objects managed by the controller
 	res, err := r.reconcileResources(ctx, cluster, resources, instancesStatus)
 	if err != nil || !res.IsZero() {
 		return res, err
 	}
 
+	// The maintenance operations following a major upgrade are tracked by
+	// the UpgradeFinalizing condition, and don't prevent the cluster from
+	// being healthy
+	finalizationResult, err := majorupgrade.ReconcileFinalization(
+		ctx,
+		r.Client,
+		r.Recorder,
+		r.InstanceClient,
+		cluster,
+		instancesStatus,
+	)
+	if err != nil {
+		contextLogger.Error(err, "Cannot reconcile the post-upgrade maintenance, retrying")
+		finalizationResult = &ctrl.Result{RequeueAfter: 30 * time.Second}
+	}
+
 	// Run plugin post-reconcile hooks, sync per-plugin statuses, and
 	// register PhaseHealthy as the LAST status mutation. See #8582.
-	return r.finalizeReconciliation(ctx, cnpgiClient.GetPluginClientFromContext(ctx), cluster)
+	res, err = r.finalizeReconciliation(ctx, cnpgiClient.GetPluginClientFromContext(ctx), cluster)
+	if err != nil || !res.IsZero() || finalizationResult == nil {
+		return res, err
+	}
+
+	return *finalizationResult, nil
 }
 
 // evaluatePodReadinessGuards short-circuits the reconciliation loop with a
@@ -1168,7 +1243,7 @@ func (r *ClusterReconciler) reconcilePods(
 		return ctrl.Result{RequeueAfter: 1 * time.Second}, ErrNextLoop
 	}
 
-	report := instancesStatus.GetConfigurationReport()
+	report := getConfigurationReport(cluster, instancesStatus)
 
 	// If any pod is not reporting its configuration (i.e., uniform == nil),
 	// proceed with a rolling update to upgrade the instance manager
@@ -1190,6 +1265,12 @@ func (r *ClusterReconciler) reconcilePods(
 			"configurationReport", report)
 	}
 
+	// Roll out the new versions of the configuration profile one
+	// cluster at a time, once the current one has been applied
+	if res, err := r.reconcileConfigurationProfileVersion(ctx, cluster); !res.IsZero() || err != nil {
+		return res, err
+	}
+
 	return r.handleRollingUpdate(ctx, cluster, instancesStatus)
 }
 
@@ -1319,11 +1400,22 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			handler.EnqueueRequestsFromMapFunc(r.mapClusterImageCatalogsToClusters()),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&apiv1.PostgresConfigurationProfile{},
+			handler.EnqueueRequestsFromMapFunc(r.mapConfigurationProfilesToClusters()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&apiv1.ClusterPostgresConfigurationProfile{},
+			handler.EnqueueRequestsFromMapFunc(r.mapConfigurationProfilesToClusters()),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Watches(
 			&apiv1.DatabaseRole{},
 			handler.EnqueueRequestsFromMapFunc(mapClusterOwnedResourceToCluster),
 			// The cluster only consumes spec.passwordSecret (to maintain the
-			// instance RBAC), so status-only changes are irrelevant here. OR-ed with
+			// instance RBAC) and spec.hbaRules, so status-only changes are
+			// irrelevant here. OR-ed with
 			// isBeingDeletedPredicate so a reconciliation loop can be enqueued
 			// to remove the finalizer from the resource.
 			builder.WithPredicates(predicate.Or(
@@ -1331,7 +1423,14 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 				isBeingDeletedPredicate,
 			)),
 		).
-		// Watch the owned Database, Publication and Subscription resources only
+		Watches(
+			&apiv1.BreakGlassAccess{},
+			handler.EnqueueRequestsFromMapFunc(mapClusterOwnedResourceToCluster),
+			// The pg_hba rule of an access is applied while the access
+			// is active, so the phase changes recorded by the instance
+			// manager in the status are relevant here too.
+		).
+		// Watch the owned Database, Publication, Subscription and ReplicationSlot resources only
 		// while they are being deleted. Their reconcilers run in the instance
 		// manager, so when the cluster is torn down together with its pods the
 		// finalizer can only be removed by the operator controller.
@@ -1339,10 +1438,15 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		// the Cluster object is gone and nothing would ever re-trigger
 		// that cleanup after a restart; these watches deliver the lingering
 		// resources on the initial cache sync so the cleanup runs.
+		// The spec of the Database resources is relevant too, as the secrets
+		// of their user mappings are part of the instance RBAC.
 		Watches(
 			&apiv1.Database{},
 			handler.EnqueueRequestsFromMapFunc(mapClusterOwnedResourceToCluster),
-			builder.WithPredicates(isBeingDeletedPredicate),
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				isBeingDeletedPredicate,
+			)),
 		).
 		Watches(
 			&apiv1.Publication{},
@@ -1353,6 +1457,11 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			&apiv1.Subscription{},
 			handler.EnqueueRequestsFromMapFunc(mapClusterOwnedResourceToCluster),
 			builder.WithPredicates(isBeingDeletedPredicate),
+		).
+		Watches(
+			&apiv1.ReplicationSlot{},
+			handler.EnqueueRequestsFromMapFunc(mapClusterOwnedResourceToCluster),
+			builder.WithPredicates(isBeingDeletedPredicate),
 		)
 
 	if configuration.Current.OperatorNamespace != "" {
@@ -1497,6 +1606,21 @@ func (r *ClusterReconciler) createFieldIndexes(ctx context.Context, mgr ctrl.Man
 		return err
 	}
 
+	// Create a new indexed field on Clusters. This field will be used to easily
+	// find all the Clusters referencing a configuration profile.
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&apiv1.Cluster{},
+		configurationProfileKey, func(rawObj client.Object) []string {
+			cluster := rawObj.(*apiv1.Cluster)
+			if cluster.Spec.ConfigurationProfileRef == nil || cluster.Spec.ConfigurationProfileRef.Name == "" {
+				return nil
+			}
+			return []string{cluster.Spec.ConfigurationProfileRef.Name}
+		}); err != nil {
+		return err
+	}
+
 	// Create a new indexed field on Roles. This field will be used to easily
 	// find all the Roles pointing to a cluster.
 	if err := mgr.GetFieldIndexer().IndexField(
@@ -28,6 +28,7 @@ import (
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -36,6 +37,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	rolloutManager "github.com/cloudnative-pg/cloudnative-pg/internal/controller/rollout"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -127,6 +129,88 @@ var _ = Describe("reconcilePods instance recreation while a PVC is terminating (
 	})
 })
 
+var _ = Describe("reconcilePods rolling update with instance parameter overrides", func() {
+	var env *testingEnvironment
+	var namespace string
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		namespace = newFakeNamespace(env.client)
+	})
+
+	// Builds a 2-instance cluster where the replica overrides some parameters,
+	// loading a different configuration from the primary, and needs a restart
+	newOverridingCluster := func(ctx SpecContext) (*apiv1.Cluster, *managedResources, postgres.PostgresqlStatusList) {
+		cluster := newFakeCNPGCluster(env.client, namespace, func(c *apiv1.Cluster) {
+			c.Spec.Instances = 2
+		})
+		primaryName := specs.GetInstanceName(cluster.Name, 1)
+		replicaName := specs.GetInstanceName(cluster.Name, 2)
+		cluster.Status.Instances = 2
+		cluster.Status.ReadyInstances = 2
+		cluster.Status.InstanceNames = []string{primaryName, replicaName}
+		cluster.Status.CurrentPrimary = primaryName
+		cluster.Status.TargetPrimary = primaryName
+
+		readyPod := func(serial int) *corev1.Pod {
+			pod, err := specs.NewInstance(ctx, *cluster, serial, true)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Status = corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			}
+			Expect(env.client.Create(ctx, pod)).To(Succeed())
+			return pod
+		}
+		primary := readyPod(1)
+		replica := readyPod(2)
+
+		resources := &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{*primary, *replica}},
+		}
+		statusList := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: primary, IsPodReady: true, IsPrimary: true, LoadedConfigurationHash: "abc"},
+				{Pod: replica, IsPodReady: true, PendingRestart: true, LoadedConfigurationHash: "def"},
+			},
+		}
+		return cluster, resources, statusList
+	}
+
+	It("waits for a uniform configuration without overrides", func(ctx SpecContext) {
+		cluster, resources, statusList := newOverridingCluster(ctx)
+
+		res, err := env.clusterReconciler.reconcilePods(ctx, cluster, resources, statusList)
+		Expect(err).To(MatchError(ErrNextLoop))
+		Expect(res.RequeueAfter).To(Equal(time.Second))
+
+		var pod corev1.Pod
+		Expect(env.client.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      statusList.Items[1].Pod.Name,
+		}, &pod)).To(Succeed())
+	})
+
+	It("proceeds with the rollout when the replica overrides some parameters", func(ctx SpecContext) {
+		cluster, resources, statusList := newOverridingCluster(ctx)
+		cluster.Status.InstanceParameters = map[apiv1.PodName]map[string]string{
+			apiv1.PodName(statusList.Items[1].Pod.Name): {"work_mem": "64MB"},
+		}
+
+		env.clusterReconciler.rolloutManager = rolloutManager.New(0, 0)
+
+		_, err := env.clusterReconciler.reconcilePods(ctx, cluster, resources, statusList)
+		Expect(err).To(MatchError(ErrNextLoop))
+
+		var pod corev1.Pod
+		err = env.client.Get(ctx, types.NamespacedName{
+			Namespace: namespace,
+			Name:      statusList.Items[1].Pod.Name,
+		}, &pod)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue(), "the replica should have been restarted")
+	})
+})
+
 var _ = Describe("ensureInstancesAreCreated reattachment while a PVC is terminating (#10985)", func() {
 	var env *testingEnvironment
 	var namespace string
@@ -751,7 +751,7 @@ func (r *ClusterReconciler) copyPullSecretFromOperator(ctx context.Context, clus
 // createOrPatchRole ensures that the required role for the instance manager exists and
 // contains the right rules
 func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv1.Cluster) error {
-	originBackup, err := r.getOriginBackup(ctx, cluster)
+	originBackup, err := r.getOriginBackup(ctx, cluster.GetBootstrapCluster())
 	if err != nil {
 		return err
 	}
@@ -773,6 +773,13 @@ func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv
 		return fmt.Errorf("while listing database roles: %w", err)
 	}
 
+	// The same applies to the secrets referred by the user mappings
+	// of the databases of the cluster
+	databases, err := r.getClusterDatabases(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	var role rbacv1.Role
 	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}, &role); err != nil {
 		if !apierrs.IsNotFound(err) {
@@ -780,7 +787,7 @@ func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv
 		}
 
 		r.Recorder.Event(cluster, "Normal", "CreatingRole", "Creating Cluster Role")
-		return r.createRole(ctx, cluster, originBackup, roleList.Items)
+		return r.createRole(ctx, cluster, originBackup, roleList.Items, databases)
 	}
 
 	generatedRole := specs.CreateRole(
@@ -788,6 +795,7 @@ func (r *ClusterReconciler) createOrPatchRole(ctx context.Context, cluster *apiv
 			Cluster:      cluster,
 			BackupOrigin: originBackup,
 			Roles:        roleList.Items,
+			Databases:    databases,
 		},
 	)
 	if equality.Semantic.DeepEqual(generatedRole.Rules, role.Rules) {
@@ -1082,18 +1090,39 @@ func createOrPatchPodMonitor(
 	}
 }
 
+// getClusterDatabases gets the Database objects of the cluster
+func (r *ClusterReconciler) getClusterDatabases(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) ([]apiv1.Database, error) {
+	var databaseList apiv1.DatabaseList
+	if err := r.List(ctx, &databaseList, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("while listing databases: %w", err)
+	}
+
+	databases := make([]apiv1.Database, 0, len(databaseList.Items))
+	for _, database := range databaseList.Items {
+		if database.Spec.ClusterRef.Name == cluster.Name {
+			databases = append(databases, database)
+		}
+	}
+	return databases, nil
+}
+
 // createRole creates the role
 func (r *ClusterReconciler) createRole(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 	backupOrigin *apiv1.Backup,
 	roles []apiv1.DatabaseRole,
+	databases []apiv1.Database,
 ) error {
 	role := specs.CreateRole(
 		specs.RoleOptions{
 			Cluster:      cluster,
 			BackupOrigin: backupOrigin,
 			Roles:        roles,
+			Databases:    databases,
 		},
 	)
 	cluster.SetInheritedDataAndOwnership(&role.ObjectMeta)
@@ -1142,7 +1171,7 @@ func (r *ClusterReconciler) createPrimaryInstance(
 ) (ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
-	if cluster.IsInitialized() {
+	if cluster.IsInitialized() && !cluster.IsRefreshInProgress() {
 		// We are we creating a new blank primary when we had previously generated
 		// other nodes, and we don't have any PVC to reuse?
 		// This can happen when:
@@ -1222,6 +1251,10 @@ func (r *ClusterReconciler) ensurePrimaryInstancePVCs(
 ) (ctrl.Result, error) {
 	var recoverySnapshot *persistentvolumeclaim.StorageSource
 
+	// While refreshing the data, the first instance is bootstrapped
+	// from the backup chosen for the refresh
+	cluster = cluster.GetBootstrapCluster()
+
 	// If the cluster is bootstrapping from recovery, it may do so from:
 	//  1 - a backup object, which may be done with volume snapshots or object storage
 	//  2 - volume snapshots
@@ -1239,6 +1272,16 @@ func (r *ClusterReconciler) ensurePrimaryInstancePVCs(
 		recoverySnapshot = persistentvolumeclaim.GetCandidateStorageSourceForPrimary(cluster, backup)
 	}
 
+	// If the cluster is cloning another Cluster, it needs to be allowed to
+	// do so, and the source has to be ready
+	if cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Clone != nil {
+		if res, err := r.checkReadyForClone(ctx, cluster); !res.IsZero() || err != nil {
+			return res, err
+		}
+
+		recoverySnapshot = persistentvolumeclaim.GetCandidateStorageSourceForPrimary(cluster, nil)
+	}
+
 	if err := validateStorageSourceAgreesWithExisting(
 		recoverySnapshot, existingDataSource, cluster.Name, nodeSerial,
 	); err != nil {
@@ -1295,8 +1338,8 @@ func validateStorageSourceAgreesWithExisting(
 }
 
 // buildPrimaryInstanceJob builds the bootstrap Job for the first primary
-// instance, selecting the variant (initdb, recovery, pgBaseBackup or volume
-// snapshot restore) according to the cluster bootstrap configuration. It is
+// instance, selecting the variant (initdb, recovery, pgBaseBackup, clone or
+// volume snapshot restore) according to the cluster bootstrap configuration. It is
 // invoked from the unified PVC-state-driven path (ensureInstanceBootstrapJob)
 // when an initializing primary PVC has no Job advancing it.
 //
@@ -1312,8 +1355,13 @@ func (r *ClusterReconciler) buildPrimaryInstanceJob(
 	nodeSerial int,
 	dataSource *corev1.TypedLocalObjectReference,
 ) (*batchv1.Job, error) {
+	// While refreshing the data, the first instance is bootstrapped
+	// from the backup chosen for the refresh
+	cluster = cluster.GetBootstrapCluster()
+
 	isBootstrappingFromRecovery := cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Recovery != nil
 	isBootstrappingFromBaseBackup := cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.PgBaseBackup != nil
+	isBootstrappingFromClone := cluster.Spec.Bootstrap != nil && cluster.Spec.Bootstrap.Clone != nil
 
 	var backup *apiv1.Backup
 	if isBootstrappingFromRecovery {
@@ -1335,7 +1383,7 @@ func (r *ClusterReconciler) buildPrimaryInstanceJob(
 	}
 
 	switch {
-	case isBootstrappingFromRecovery && dataSource != nil:
+	case (isBootstrappingFromRecovery || isBootstrappingFromClone) && dataSource != nil:
 		metadata, err := persistentvolumeclaim.GetSourceMetadataOrNil(
 			ctx,
 			r.Client,
@@ -1363,6 +1411,10 @@ func (r *ClusterReconciler) buildPrimaryInstanceJob(
 		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (from physical backup)")
 		return specs.CreatePrimaryJobViaPgBaseBackup(*cluster, nodeSerial), nil
 
+	case isBootstrappingFromClone:
+		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (cloning another Cluster)")
+		return specs.CreatePrimaryJobViaPgBaseBackup(*cluster, nodeSerial), nil
+
 	default:
 		r.Recorder.Event(cluster, "Normal", "CreatingInstance", "Primary instance (initdb)")
 		return specs.CreatePrimaryJobViaInitdb(*cluster, nodeSerial), nil
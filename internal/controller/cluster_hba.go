@@ -0,0 +1,189 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/hba"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// redactedLDAPBindPassword replaces the LDAP bind password in the
+// pg_hba.conf content reported in the cluster status
+const redactedLDAPBindPassword = "********"
+
+// reconcileHBAStatus collects the pg_hba rules declared by the DatabaseRole
+// and the active BreakGlassAccess objects of the cluster, which the instance manager reads from the
+// cluster status, and reports the effective pg_hba.conf together with
+// the rules that have been rejected
+func (r *ClusterReconciler) reconcileHBAStatus(ctx context.Context, cluster *apiv1.Cluster) error {
+	var roles apiv1.DatabaseRoleList
+	if err := r.List(
+		ctx,
+		&roles,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingFields{databaseRoleClusterKey: cluster.Name},
+	); err != nil {
+		return fmt.Errorf("while getting the database roles: %w", err)
+	}
+
+	var accesses apiv1.BreakGlassAccessList
+	if err := r.List(ctx, &accesses, client.InNamespace(cluster.Namespace)); err != nil {
+		return fmt.Errorf("while getting the break-glass accesses: %w", err)
+	}
+
+	hbaStatus := buildHBAStatus(cluster, roles.Items, accesses.Items, time.Now())
+
+	updatedCluster := cluster.DeepCopy()
+	updatedCluster.Status.HBA = hbaStatus
+	content, err := postgresManagement.GeneratePostgresqlHBA(updatedCluster, redactedLDAPBindPassword)
+	if err != nil {
+		log.FromContext(ctx).Warning("Cannot generate the pg_hba.conf content", "error", err.Error())
+	}
+	hbaStatus.Content = content
+
+	if equality.Semantic.DeepEqual(cluster.Status.HBA, hbaStatus) {
+		return nil
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, status.SetHBA(hbaStatus))
+}
+
+// getBreakGlassRequeue returns the time to wait before the first active
+// break-glass rule of the cluster expires, or zero if there is none
+func getBreakGlassRequeue(cluster *apiv1.Cluster, now time.Time) time.Duration {
+	if cluster.Status.HBA == nil || cluster.Status.HBA.BreakGlassExpirationTime == nil {
+		return 0
+	}
+
+	// Wake up even if the expiration time has already passed, so
+	// that the expired rules are removed
+	return max(cluster.Status.HBA.BreakGlassExpirationTime.Sub(now), time.Second)
+}
+
+// buildHBAStatus merges the pg_hba rules declared by the passed database
+// roles and by the break-glass accesses active at the passed time, sorted
+// by name, and checks every rule of the cluster, recording the ones that
+// cannot be applied
+func buildHBAStatus(
+	cluster *apiv1.Cluster,
+	roles []apiv1.DatabaseRole,
+	accesses []apiv1.BreakGlassAccess,
+	now time.Time,
+) *apiv1.HBAStatus {
+	knownSelectors := stringset.New()
+	for _, ref := range cluster.Spec.PodSelectorRefs {
+		knownSelectors.Put(ref.Name)
+	}
+
+	result := &apiv1.HBAStatus{}
+	reject := func(source, rule string, err error) {
+		result.RejectedRules = append(result.RejectedRules, apiv1.RejectedHBARule{
+			Source: source,
+			Rule:   rule,
+			Reason: err.Error(),
+		})
+	}
+
+	slices.SortFunc(accesses, func(a, b apiv1.BreakGlassAccess) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range accesses {
+		access := &accesses[i]
+		rule := access.GetHBARule()
+		if access.Spec.ClusterRef.Name != cluster.Name || rule == nil || !access.IsActive(now) {
+			continue
+		}
+		if err := validateHBARule(rule, knownSelectors); err != nil {
+			reject(fmt.Sprintf("BreakGlassAccess/%s: spec.hbaRule", access.Name), rule.String(), err)
+			continue
+		}
+		result.BreakGlassRules = append(result.BreakGlassRules, rule.String())
+		expirationTime := access.Status.ExpirationTime
+		if result.BreakGlassExpirationTime == nil || expirationTime.Before(result.BreakGlassExpirationTime) {
+			result.BreakGlassExpirationTime = expirationTime.DeepCopy()
+		}
+	}
+
+	for i, line := range cluster.Spec.PostgresConfiguration.PgHBA {
+		if err := hba.ValidateLine(line, knownSelectors); err != nil {
+			reject(fmt.Sprintf("spec.postgresql.pg_hba[%d]", i), line, err)
+		}
+	}
+
+	for i := range cluster.Spec.PostgresConfiguration.HBARules {
+		rule := &cluster.Spec.PostgresConfiguration.HBARules[i]
+		if err := validateHBARule(rule, knownSelectors); err != nil {
+			reject(fmt.Sprintf("spec.postgresql.hbaRules[%d]", i), rule.String(), err)
+		}
+	}
+
+	slices.SortFunc(roles, func(a, b apiv1.DatabaseRole) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, role := range roles {
+		if !role.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for i := range role.Spec.HBARules {
+			rule := role.Spec.HBARules[i].DeepCopy()
+			rule.Users = []string{role.Spec.Name}
+			if err := validateHBARule(rule, knownSelectors); err != nil {
+				reject(fmt.Sprintf("DatabaseRole/%s: spec.hbaRules[%d]", role.Name, i), rule.String(), err)
+				continue
+			}
+			result.RoleRules = append(result.RoleRules, rule.String())
+		}
+	}
+
+	if profile := cluster.Status.ConfigurationProfile; profile != nil {
+		for i, line := range profile.Spec.PgHBA {
+			if err := hba.ValidateLine(line, knownSelectors); err != nil {
+				reject(fmt.Sprintf("%s/%s: spec.pg_hba[%d]", profile.Kind, profile.Name, i), line, err)
+			}
+		}
+	}
+
+	return result
+}
+
+// validateHBARule checks that a structured pg_hba rule can be rendered
+// and that the pod selector it references is defined
+func validateHBARule(rule *apiv1.HBARule, knownSelectors *stringset.Data) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.PodSelectorRef != "" && !knownSelectors.Has(rule.PodSelectorRef) {
+		return &hba.ErrPodSelectorNotFound{SelectorName: rule.PodSelectorRef}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pg_hba status", func() {
+	const namespace = "hba-test"
+
+	newRole := func(name, roleName string, rules ...apiv1.HBARule) apiv1.DatabaseRole {
+		return apiv1.DatabaseRole{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: apiv1.DatabaseRoleSpec{
+				RoleConfiguration: apiv1.RoleConfiguration{Name: roleName},
+				HBARules:          rules,
+			},
+		}
+	}
+
+	newCluster := func() *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:18",
+				PodSelectorRefs: []apiv1.PodSelectorRef{
+					{Name: "app-pods"},
+				},
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgHBA: []string{
+						"host all all 10.0.0.0/8 scram-sha-256",
+					},
+					HBARules: []apiv1.HBARule{
+						{Type: apiv1.HBARuleTypeHostSSL, PodSelectorRef: "app-pods", Method: "cert"},
+					},
+				},
+			},
+		}
+	}
+
+	It("merges the rules of the database roles sorted by name", func() {
+		status := buildHBAStatus(newCluster(), []apiv1.DatabaseRole{
+			newRole("zeta", "reporting", apiv1.HBARule{Address: "192.168.0.0/16", Method: "scram-sha-256"}),
+			newRole("alpha", "app",
+				apiv1.HBARule{Type: apiv1.HBARuleTypeHostSSL, Databases: []string{"app"}, Address: "all", Method: "cert"},
+				apiv1.HBARule{PodSelectorRef: "app-pods", Method: "scram-sha-256"},
+			),
+		}, nil, time.Now())
+
+		Expect(status.RoleRules).To(Equal([]string{
+			"hostssl app app all cert",
+			"host all app ${podselector:app-pods} scram-sha-256",
+			"host all reporting 192.168.0.0/16 scram-sha-256",
+		}))
+		Expect(status.RejectedRules).To(BeEmpty())
+	})
+
+	It("skips the database roles being deleted", func() {
+		role := newRole("app", "app", apiv1.HBARule{Address: "all", Method: "scram-sha-256"})
+		role.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		status := buildHBAStatus(newCluster(), []apiv1.DatabaseRole{role}, nil, time.Now())
+		Expect(status.RoleRules).To(BeEmpty())
+	})
+
+	It("rejects the rules that cannot be applied", func() {
+		cluster := newCluster()
+		cluster.Spec.PostgresConfiguration.PgHBA = append(cluster.Spec.PostgresConfiguration.PgHBA,
+			"host all all ${podselector:missing} md5")
+		cluster.Status.ConfigurationProfile = &apiv1.AppliedConfigurationProfile{
+			Kind: apiv1.ClusterPostgresConfigurationProfileKind,
+			Name: "baseline",
+			Spec: apiv1.PostgresConfigurationProfileSpec{
+				PgHBA: []string{"host all all ${unknown:ref} md5"},
+			},
+		}
+
+		status := buildHBAStatus(cluster, []apiv1.DatabaseRole{
+			newRole("app", "app",
+				apiv1.HBARule{PodSelectorRef: "missing", Method: "scram-sha-256"},
+				apiv1.HBARule{Address: "10.0.0.1", Method: "scram-sha-256"},
+				apiv1.HBARule{Address: "10.0.0.1/32", Method: "scram-sha-256"},
+			),
+		}, nil, time.Now())
+
+		Expect(status.RoleRules).To(Equal([]string{"host all app 10.0.0.1/32 scram-sha-256"}))
+		Expect(status.RejectedRules).To(HaveLen(4))
+		Expect(status.RejectedRules[0].Source).To(Equal("spec.postgresql.pg_hba[1]"))
+		Expect(status.RejectedRules[0].Reason).To(ContainSubstring("missing"))
+		Expect(status.RejectedRules[1].Source).To(Equal("DatabaseRole/app: spec.hbaRules[0]"))
+		Expect(status.RejectedRules[1].Rule).To(Equal("host all app ${podselector:missing} scram-sha-256"))
+		Expect(status.RejectedRules[2].Source).To(Equal("DatabaseRole/app: spec.hbaRules[1]"))
+		Expect(status.RejectedRules[2].Reason).To(ContainSubstring("CIDR notation"))
+		Expect(status.RejectedRules[3].Source).To(Equal("ClusterPostgresConfigurationProfile/baseline: spec.pg_hba[0]"))
+	})
+
+	It("puts the rules of the active break-glass accesses first", func() {
+		now := time.Now()
+		newAccess := func(
+			name, roleName string,
+			phase apiv1.BreakGlassAccessPhase,
+			expiration time.Time,
+		) apiv1.BreakGlassAccess {
+			return apiv1.BreakGlassAccess{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: apiv1.BreakGlassAccessSpec{
+					ClusterRef: corev1.LocalObjectReference{Name: "cluster-example"},
+					Role:       roleName,
+					HBARule:    &apiv1.HBARule{Address: "10.1.0.0/16", Method: "scram-sha-256"},
+					Duration:   metav1.Duration{Duration: time.Hour},
+				},
+				Status: apiv1.BreakGlassAccessStatus{
+					Phase:          phase,
+					ExpirationTime: &metav1.Time{Time: expiration},
+				},
+			}
+		}
+		otherCluster := newAccess("other", "dba", apiv1.BreakGlassAccessPhaseActive, now.Add(time.Hour))
+		otherCluster.Spec.ClusterRef.Name = "cluster-other"
+		invalid := newAccess("invalid", "dba", apiv1.BreakGlassAccessPhaseActive, now.Add(time.Hour))
+		invalid.Spec.HBARule.Address = "10.1.0.1"
+
+		status := buildHBAStatus(newCluster(), nil, []apiv1.BreakGlassAccess{
+			newAccess("zeta", "oncall", apiv1.BreakGlassAccessPhaseActive, now.Add(time.Hour)),
+			newAccess("alpha", "dba", apiv1.BreakGlassAccessPhaseActive, now.Add(time.Hour)),
+			newAccess("expired", "dba", apiv1.BreakGlassAccessPhaseExpired, now.Add(-time.Hour)),
+			newAccess("elapsed", "dba", apiv1.BreakGlassAccessPhaseActive, now.Add(-time.Minute)),
+			newAccess("pending", "dba", "", now.Add(time.Hour)),
+			otherCluster,
+			invalid,
+		}, now)
+
+		Expect(status.BreakGlassRules).To(Equal([]string{
+			"host all dba 10.1.0.0/16 scram-sha-256",
+			"host all oncall 10.1.0.0/16 scram-sha-256",
+		}))
+		Expect(status.RejectedRules).To(HaveLen(1))
+		Expect(status.RejectedRules[0].Source).To(Equal("BreakGlassAccess/invalid: spec.hbaRule"))
+
+		cluster := newCluster()
+		cluster.Status.HBA = status
+		Expect(cluster.GetPgHBA()[0]).To(Equal("host all dba 10.1.0.0/16 scram-sha-256"))
+	})
+
+	It("requeues the cluster when the first break-glass access expires", func() {
+		now := time.Now()
+		newAccess := func(name string, expiration time.Time) apiv1.BreakGlassAccess {
+			return apiv1.BreakGlassAccess{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: apiv1.BreakGlassAccessSpec{
+					ClusterRef: corev1.LocalObjectReference{Name: "cluster-example"},
+					Role:       "dba",
+					HBARule:    &apiv1.HBARule{Address: "10.1.0.0/16", Method: "scram-sha-256"},
+					Duration:   metav1.Duration{Duration: time.Hour},
+				},
+				Status: apiv1.BreakGlassAccessStatus{
+					Phase:          apiv1.BreakGlassAccessPhaseActive,
+					ExpirationTime: &metav1.Time{Time: expiration},
+				},
+			}
+		}
+
+		cluster := newCluster()
+		Expect(getBreakGlassRequeue(cluster, now)).To(BeZero())
+
+		cluster.Status.HBA = buildHBAStatus(cluster, nil, nil, now)
+		Expect(cluster.Status.HBA.BreakGlassExpirationTime).To(BeNil())
+		Expect(getBreakGlassRequeue(cluster, now)).To(BeZero())
+
+		cluster.Status.HBA = buildHBAStatus(cluster, nil, []apiv1.BreakGlassAccess{
+			newAccess("long", now.Add(time.Hour)),
+			newAccess("short", now.Add(10*time.Minute)),
+			newAccess("elapsed", now.Add(-time.Minute)),
+		}, now)
+		Expect(cluster.Status.HBA.BreakGlassExpirationTime.Time).To(BeTemporally("==", now.Add(10*time.Minute)))
+		Expect(getBreakGlassRequeue(cluster, now)).To(Equal(10 * time.Minute))
+		Expect(getBreakGlassRequeue(cluster, now.Add(time.Hour))).To(Equal(time.Second))
+	})
+
+	It("reports the effective pg_hba.conf in the cluster status", func(ctx SpecContext) {
+		scheme := schemeBuilder.BuildWithAllKnownScheme()
+		cluster := newCluster()
+		role := newRole("app", "app", apiv1.HBARule{Address: "all", Method: "scram-sha-256"})
+		role.Spec.ClusterRef.Name = cluster.Name
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&apiv1.Cluster{}).
+			WithIndex(&apiv1.DatabaseRole{}, databaseRoleClusterKey, func(rawObj k8client.Object) []string {
+				return []string{rawObj.(*apiv1.DatabaseRole).Spec.ClusterRef.Name}
+			}).
+			WithObjects(cluster, &role).
+			Build()
+		reconciler := &ClusterReconciler{
+			Client:   k8sClient,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(120),
+		}
+
+		Expect(reconciler.reconcileHBAStatus(ctx, cluster)).To(Succeed())
+		Expect(cluster.Status.HBA).ToNot(BeNil())
+		Expect(cluster.Status.HBA.RoleRules).To(Equal([]string{"host all app all scram-sha-256"}))
+		Expect(cluster.Status.HBA.Content).To(ContainSubstring("host all all 10.0.0.0/8 scram-sha-256\n"))
+		Expect(cluster.Status.HBA.Content).To(ContainSubstring("host all app all scram-sha-256\n"))
+
+		var stored apiv1.Cluster
+		Expect(k8sClient.Get(ctx, k8client.ObjectKeyFromObject(cluster), &stored)).To(Succeed())
+		Expect(stored.Status.HBA).To(Equal(cluster.Status.HBA))
+	})
+})
@@ -84,6 +84,17 @@ func (r *ClusterReconciler) reconcileImage(ctx context.Context, cluster *apiv1.C
 	// an image of the same major version or if a change in the major
 	// version has been requested.
 	if imageChanged {
+		if currentMajorVersion > requestedMajorVersion && cluster.IsMajorUpgradeRollbackRequested() {
+			// The previous major version is being restored from the
+			// pre-upgrade backup: the major upgrade reconciler will
+			// update the image once the data directory is restored.
+			contextLogger.Info(
+				"Major version upgrade rollback requested, waiting for the data directory to be restored",
+				"currentImage", cluster.Status.PGDataImageInfo.Image,
+				"requestedImage", requestedImageInfo)
+			return nil, nil
+		}
+
 		if currentMajorVersion > requestedMajorVersion {
 			// Major version downgrade requested. This is not allowed.
 			contextLogger.Info(
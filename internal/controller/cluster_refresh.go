@@ -0,0 +1,250 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// refreshDeletionRetryPeriod is the time to wait before checking again
+// if the resources holding the previous data have been removed
+const refreshDeletionRetryPeriod = 10 * time.Second
+
+// reconcileRefresh replaces the data of the cluster with the content of
+// the backup chosen by a ClusterRefresh.
+//
+// The process consists of the following steps:
+//
+//  1. Delete all Pods and Jobs in the cluster.
+//  2. Delete all the PVCs in the cluster.
+//  3. Delete the application secret if its credentials need to be rotated.
+//  4. Reset the primary instance in the status, allowing the usual
+//     bootstrap process to create a new primary from the backup.
+//
+// The refresh is considered complete as soon as the new primary is ready,
+// and failed when the job bootstrapping it fails: the cluster is then
+// marked as unrecoverable until the next refresh.
+// The Cluster object and its dependent resources, such as services,
+// secrets and poolers, are never touched.
+func (r *ClusterReconciler) reconcileRefresh(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+) (*ctrl.Result, error) {
+	refresh := cluster.Status.Refresh
+	if refresh == nil {
+		return nil, nil
+	}
+
+	if !refresh.DataRemoved {
+		return r.removeDataForRefresh(ctx, cluster, resources)
+	}
+
+	if failedJobs := resources.failedJobNames(); len(failedJobs) > 0 {
+		return nil, r.failRefresh(ctx, cluster, failedJobs)
+	}
+
+	if !isCurrentPrimaryReady(cluster, resources.instances.Items) {
+		// The new primary is being created by the usual bootstrap process
+		return nil, nil
+	}
+
+	if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, status.SetRefresh(nil)); err != nil {
+		return nil, err
+	}
+
+	r.Recorder.Eventf(cluster, "Normal", "RefreshCompleted",
+		"Data refreshed from backup %s", refresh.BackupName)
+	return nil, nil
+}
+
+// failRefresh records that the bootstrap of the new primary from the
+// backup chosen for the refresh has failed, so that the cluster can be
+// refreshed again. The phase is registered before removing the request,
+// letting the ClusterRefresh controller detect the failure.
+func (r *ClusterReconciler) failRefresh(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	failedJobs []string,
+) error {
+	refresh := cluster.Status.Refresh
+
+	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseUnrecoverable,
+		fmt.Sprintf("Refresh from backup %s failed for the following jobs: %s. "+
+			"Check the job logs to investigate the cause of the failure.",
+			refresh.BackupName, strings.Join(failedJobs, ", "))); err != nil {
+		return err
+	}
+
+	if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, status.SetRefresh(nil)); err != nil {
+		return err
+	}
+
+	r.Recorder.Eventf(cluster, "Warning", "RefreshFailed",
+		"Refresh from backup %s failed", refresh.BackupName)
+	return nil
+}
+
+// removeDataForRefresh removes the instances and the volumes holding the
+// previous data of the cluster, and prepares the status for the bootstrap
+// of the new primary
+func (r *ClusterReconciler) removeDataForRefresh(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	resources *managedResources,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+	refresh := cluster.Status.Refresh
+
+	if cluster.Status.Phase != apiv1.PhaseRefreshing {
+		r.Recorder.Eventf(cluster, "Normal", "RefreshStarted",
+			"Refreshing data from backup %s, as requested by %s", refresh.BackupName, refresh.Name)
+		if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseRefreshing,
+			fmt.Sprintf("Refreshing the data from backup %s", refresh.BackupName)); err != nil {
+			return nil, err
+		}
+	}
+
+	waitingForDeletion := false
+	for idx := range resources.instances.Items {
+		waitingForDeletion = true
+		if err := deleteIfNotDeleting(ctx, r.Client, &resources.instances.Items[idx]); err != nil {
+			return nil, err
+		}
+	}
+	for idx := range resources.jobs.Items {
+		waitingForDeletion = true
+		if err := deleteIfNotDeleting(ctx, r.Client, &resources.jobs.Items[idx],
+			client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+			return nil, err
+		}
+	}
+	if waitingForDeletion {
+		contextLogger.Info("Waiting for the instances to be removed before refreshing the data")
+		return &ctrl.Result{RequeueAfter: refreshDeletionRetryPeriod}, nil
+	}
+
+	for idx := range resources.pvcs.Items {
+		waitingForDeletion = true
+		if err := deleteIfNotDeleting(ctx, r.Client, &resources.pvcs.Items[idx]); err != nil {
+			return nil, err
+		}
+	}
+	if waitingForDeletion {
+		contextLogger.Info("Waiting for the PVCs to be removed before refreshing the data")
+		return &ctrl.Result{RequeueAfter: refreshDeletionRetryPeriod}, nil
+	}
+
+	if refresh.ApplicationCredentials == apiv1.ClusterRefreshCredentialsRotate {
+		if err := r.deleteApplicationSecretForRotation(ctx, cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	dataRemoved := refresh.DeepCopy()
+	dataRemoved.DataRemoved = true
+	if err := status.PatchWithOptimisticLock(
+		ctx,
+		r.Client,
+		cluster,
+		status.SetRefresh(dataRemoved),
+		func(cluster *apiv1.Cluster) {
+			cluster.Status.TargetPrimary = ""
+			cluster.Status.CurrentPrimary = ""
+			cluster.Status.CurrentPrimaryTimestamp = ""
+			cluster.Status.TargetPrimaryTimestamp = ""
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &ctrl.Result{RequeueAfter: time.Second}, nil
+}
+
+// deleteApplicationSecretForRotation deletes the application secret, if
+// it has been generated by the operator, so that a new one with a new
+// password is generated
+func (r *ClusterReconciler) deleteApplicationSecretForRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) error {
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetApplicationSecretName()}, &secret)
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, owned := IsOwnedByCluster(&secret); !owned {
+		r.Recorder.Eventf(cluster, "Warning", "RefreshCredentialsNotRotated",
+			"The application secret %s is not managed by the operator, not rotating its credentials",
+			secret.Name)
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.Delete(ctx, &secret))
+}
+
+// deleteIfNotDeleting deletes the passed object, unless its deletion
+// has already been requested
+func deleteIfNotDeleting(
+	ctx context.Context,
+	c client.Client,
+	object client.Object,
+	opts ...client.DeleteOption,
+) error {
+	if object.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	return client.IgnoreNotFound(c.Delete(ctx, object, opts...))
+}
+
+// isCurrentPrimaryReady checks if the current primary instance
+// of the cluster is running and ready
+func isCurrentPrimaryReady(cluster *apiv1.Cluster, instances []corev1.Pod) bool {
+	if cluster.Status.CurrentPrimary == "" {
+		return false
+	}
+
+	for idx := range instances {
+		if instances[idx].Name == cluster.Status.CurrentPrimary {
+			return utils.IsPodReady(instances[idx])
+		}
+	}
+
+	return false
+}
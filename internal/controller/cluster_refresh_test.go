@@ -0,0 +1,221 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reconcileRefresh", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       apiv1.ClusterKind,
+				APIVersion: apiv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{Database: "app", Owner: "app"},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "staging-1",
+				TargetPrimary:  "staging-1",
+				Refresh: &apiv1.ClusterRefreshRequest{
+					Name:       "nightly",
+					BackupName: "production-backup",
+				},
+			},
+		}
+	})
+
+	newReconciler := func(objects ...client.Object) *ClusterReconciler {
+		return &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&apiv1.Cluster{}).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	newPod := func(name string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	newPVC := func(name string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	It("does nothing when no refresh has been requested", func(ctx SpecContext) {
+		cluster.Status.Refresh = nil
+		r := newReconciler(cluster)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("deletes the instances before the PVCs", func(ctx SpecContext) {
+		pod := newPod("staging-1")
+		pvc := newPVC("staging-1")
+		r := newReconciler(cluster, &pod, &pvc)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{pod}},
+			pvcs:      corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{pvc}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(refreshDeletionRetryPeriod))
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseRefreshing))
+
+		err = r.Get(ctx, client.ObjectKeyFromObject(&pod), &corev1.Pod{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(&pvc), &corev1.PersistentVolumeClaim{})).To(Succeed())
+	})
+
+	It("deletes the PVCs once the instances are gone", func(ctx SpecContext) {
+		pvc := newPVC("staging-1")
+		r := newReconciler(cluster, &pvc)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{
+			pvcs: corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{pvc}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(refreshDeletionRetryPeriod))
+
+		err = r.Get(ctx, client.ObjectKeyFromObject(&pvc), &corev1.PersistentVolumeClaim{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		Expect(cluster.Status.Refresh.DataRemoved).To(BeFalse())
+	})
+
+	It("resets the primary once the data has been removed", func(ctx SpecContext) {
+		r := newReconciler(cluster)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		var updated apiv1.Cluster
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cluster), &updated)).To(Succeed())
+		Expect(updated.Status.Refresh.DataRemoved).To(BeTrue())
+		Expect(updated.Status.CurrentPrimary).To(BeEmpty())
+		Expect(updated.Status.TargetPrimary).To(BeEmpty())
+	})
+
+	It("rotates the application credentials when they are managed by the operator", func(ctx SpecContext) {
+		cluster.Status.Refresh.ApplicationCredentials = apiv1.ClusterRefreshCredentialsRotate
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.GetApplicationSecretName(),
+				Namespace: "default",
+			},
+		}
+		cluster.SetInheritedDataAndOwnership(&secret.ObjectMeta)
+		r := newReconciler(cluster, secret)
+
+		_, err := r.reconcileRefresh(ctx, cluster, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("keeps the application credentials provided by the user", func(ctx SpecContext) {
+		cluster.Status.Refresh.ApplicationCredentials = apiv1.ClusterRefreshCredentialsRotate
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.GetApplicationSecretName(),
+				Namespace: "default",
+			},
+		}
+		r := newReconciler(cluster, secret)
+
+		_, err := r.reconcileRefresh(ctx, cluster, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})).To(Succeed())
+	})
+
+	It("lets the bootstrap proceed until the new primary is ready", func(ctx SpecContext) {
+		cluster.Status.Refresh.DataRemoved = true
+		cluster.Status.CurrentPrimary = ""
+		r := newReconciler(cluster)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Refresh).ToNot(BeNil())
+	})
+
+	It("removes the refresh when the bootstrap of the new primary fails", func(ctx SpecContext) {
+		cluster.Status.Refresh.DataRemoved = true
+		cluster.Status.CurrentPrimary = ""
+		job := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging-1-full-recovery", Namespace: "default"},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			},
+		}
+		r := newReconciler(cluster)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{
+			jobs: batchv1.JobList{Items: []batchv1.Job{job}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+
+		var updated apiv1.Cluster
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cluster), &updated)).To(Succeed())
+		Expect(updated.Status.Refresh).To(BeNil())
+		Expect(updated.Status.Phase).To(Equal(apiv1.PhaseUnrecoverable))
+		Expect(updated.Status.PhaseReason).To(ContainSubstring("staging-1-full-recovery"))
+	})
+
+	It("completes the refresh when the new primary is ready", func(ctx SpecContext) {
+		cluster.Status.Refresh.DataRemoved = true
+		cluster.Status.CurrentPrimary = "staging-2"
+		pod := newPod("staging-2")
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		r := newReconciler(cluster)
+
+		result, err := r.reconcileRefresh(ctx, cluster, &managedResources{
+			instances: corev1.PodList{Items: []corev1.Pod{pod}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.Refresh).To(BeNil())
+	})
+})
@@ -21,11 +21,13 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
 	"slices"
 	"sort"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
@@ -39,6 +41,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	postgresManagement "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres/replication"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/hibernation"
@@ -268,6 +271,12 @@ func (r *ClusterReconciler) updateResourceStatus(
 	)
 	updateSyncReplicationTopologyCondition(cluster)
 
+	// Parameters computed by the tuning profile
+	cluster.Status.TunedParameters = cluster.GetTunedParameters()
+
+	// Parameters overridden on each instance
+	cluster.Status.InstanceParameters = getInstanceParameters(cluster, resources.instances.Items)
+
 	// Services
 	cluster.Status.WriteService = cluster.GetServiceReadWriteName()
 	cluster.Status.ReadService = cluster.GetServiceReadName()
@@ -355,6 +364,10 @@ func (r *ClusterReconciler) updateResourceStatus(
 		return err
 	}
 
+	if err := r.updateParametersFromCondition(ctx, cluster); err != nil {
+		return err
+	}
+
 	if cluster.Spec.ReplicaCluster != nil && len(cluster.Spec.ReplicaCluster.PromotionToken) == 0 {
 		cluster.Status.LastPromotionToken = ""
 	}
@@ -583,9 +596,9 @@ func (r *ClusterReconciler) setCertExpiration(ctx context.Context, cluster *apiv
 // refreshConfigMapResourceVersions set the resource version of the secrets
 func (r *ClusterReconciler) refreshConfigMapResourceVersions(ctx context.Context, cluster *apiv1.Cluster) error {
 	versions := apiv1.ConfigMapResourceVersion{}
-	if cluster.Spec.Monitoring != nil {
+	if configMaps := cluster.GetCustomQueriesConfigMaps(); len(configMaps) > 0 {
 		versions.Metrics = make(map[string]string)
-		for _, config := range cluster.Spec.Monitoring.CustomQueriesConfigMap {
+		for _, config := range configMaps {
 			version, err := r.getConfigMapResourceVersion(ctx, cluster, config.Name)
 			if err != nil {
 				return err
@@ -594,11 +607,54 @@ func (r *ClusterReconciler) refreshConfigMapResourceVersions(ctx context.Context
 		}
 	}
 
+	for _, configMapName := range cluster.GetParametersFromConfigMaps().ToSortedList() {
+		version, err := r.getConfigMapResourceVersion(ctx, cluster, configMapName)
+		if err != nil {
+			return err
+		}
+		if versions.Parameters == nil {
+			versions.Parameters = make(map[string]string)
+		}
+		versions.Parameters[configMapName] = version
+	}
+
 	cluster.Status.ConfigMapResourceVersion = versions
 
 	return nil
 }
 
+// updateParametersFromCondition sets the ParametersFromAvailable condition,
+// reporting the ConfigMaps and Secrets referenced by parametersFrom that
+// the instances cannot read
+func (r *ClusterReconciler) updateParametersFromCondition(ctx context.Context, cluster *apiv1.Cluster) error {
+	if len(cluster.Spec.PostgresConfiguration.ParametersFrom) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionParametersFromAvailable))
+		return nil
+	}
+
+	_, err := postgresManagement.ReadParametersFrom(ctx, r.Client, cluster)
+	switch {
+	case errors.Is(err, postgresManagement.ErrParametersFromNotAvailable):
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionParametersFromAvailable),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(apiv1.ConditionReasonParametersFromNotAvailable),
+			Message: err.Error(),
+		})
+	case err != nil:
+		return err
+	default:
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionParametersFromAvailable),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(apiv1.ConditionReasonParametersFromAvailable),
+			Message: "Every parameter source is available",
+		})
+	}
+
+	return nil
+}
+
 // refreshSecretResourceVersions set the resource version of the secrets
 func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, cluster *apiv1.Cluster) error {
 	versions := apiv1.SecretsResourceVersion{}
@@ -621,12 +677,12 @@ func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, c
 
 	if cluster.ContainsManagedRolesConfiguration() {
 		for _, role := range cluster.Spec.Managed.Roles {
-			if role.PasswordSecret != nil {
-				version, err = r.getSecretResourceVersion(ctx, cluster, role.PasswordSecret.Name)
+			for _, secretName := range role.GetPasswordSecretNames() {
+				version, err = r.getSecretResourceVersion(ctx, cluster, secretName)
 				if err != nil {
 					return err
 				}
-				versions.SetManagedRoleSecretVersion(role.PasswordSecret.Name, &version)
+				versions.SetManagedRoleSecretVersion(secretName, &version)
 			}
 		}
 	}
@@ -676,9 +732,9 @@ func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, c
 		versions.BarmanEndpointCA = version
 	}
 
-	if cluster.Spec.Monitoring != nil {
+	if secrets := cluster.GetCustomQueriesSecrets(); len(secrets) > 0 {
 		versions.Metrics = make(map[string]string)
-		for _, secret := range cluster.Spec.Monitoring.CustomQueriesSecret {
+		for _, secret := range secrets {
 			version, err = r.getSecretResourceVersion(ctx, cluster, secret.Name)
 			if err != nil {
 				return err
@@ -687,6 +743,17 @@ func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, c
 		}
 	}
 
+	for _, secretName := range cluster.GetParametersFromSecrets().ToSortedList() {
+		version, err = r.getSecretResourceVersion(ctx, cluster, secretName)
+		if err != nil {
+			return err
+		}
+		if versions.Parameters == nil {
+			versions.Parameters = make(map[string]string)
+		}
+		versions.Parameters[secretName] = version
+	}
+
 	cluster.Status.SecretsResourceVersion = versions
 
 	return nil
@@ -825,6 +892,9 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		})
 	}
 
+	meta.SetStatusCondition(&cluster.Status.Conditions,
+		getConfigurationAppliedCondition(getConfigurationReport(cluster, statuses)))
+
 	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
 		return r.Status().Update(ctx, cluster)
 	}
@@ -990,3 +1060,102 @@ func hasPostgresContainerTerminationReason(pod *corev1.Pod, reason func(state *c
 
 	return false
 }
+
+// getInstanceParameters resolves the `instanceOverrides` of the cluster
+// into the parameters to be overridden on each instance, as the instance
+// manager cannot read the labels of its own Pod
+func getInstanceParameters(cluster *apiv1.Cluster, instances []corev1.Pod) map[apiv1.PodName]map[string]string {
+	if len(cluster.Spec.PostgresConfiguration.InstanceOverrides) == 0 {
+		return nil
+	}
+
+	var result map[apiv1.PodName]map[string]string
+	for _, instance := range instances {
+		parameters := cluster.GetInstanceParameterOverrides(instance.Name, instance.Labels)
+		if len(parameters) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[apiv1.PodName]map[string]string)
+		}
+		result[apiv1.PodName(instance.Name)] = parameters
+	}
+
+	return result
+}
+
+// getConfigurationReport generates the report on the PostgreSQL configuration
+// loaded by the instances, grouping together the instances sharing the same
+// parameter overrides as they are expected to load the same configuration
+func getConfigurationReport(cluster *apiv1.Cluster, statuses postgres.PostgresqlStatusList) postgres.ConfigurationReport {
+	report := statuses.GetConfigurationReport()
+	for i := range report {
+		// maps are printed sorted by key, giving a stable group identifier
+		report[i].Group = fmt.Sprint(cluster.Status.InstanceParameters[apiv1.PodName(report[i].PodName)])
+	}
+	return report
+}
+
+// getConfigurationAppliedCondition computes the ConfigurationApplied
+// condition from the configuration report of the instances
+func getConfigurationAppliedCondition(report postgres.ConfigurationReport) metav1.Condition {
+	condition := metav1.Condition{
+		Type: string(apiv1.ConditionConfigurationApplied),
+	}
+
+	var notReporting, hashes, pendingRestart, alterSystem []string
+	for _, item := range report {
+		if item.ConfigHash == "" {
+			notReporting = append(notReporting, item.PodName)
+			continue
+		}
+		hashes = append(hashes, fmt.Sprintf("%s=%s", item.PodName, item.ConfigHash))
+		if len(item.PendingRestartParameters) > 0 {
+			pendingRestart = append(pendingRestart,
+				fmt.Sprintf("%s: %v", item.PodName, item.PendingRestartParameters))
+		}
+		if len(item.AlterSystemParameters) > 0 {
+			names := make([]string, 0, len(item.AlterSystemParameters))
+			for name := range item.AlterSystemParameters {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			alterSystem = append(alterSystem, fmt.Sprintf("%s: %v", item.PodName, names))
+		}
+	}
+
+	switch {
+	case len(report) == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = string(apiv1.ConditionReasonConfigurationNotReported)
+		condition.Message = "No instances are reporting their configuration"
+
+	case len(notReporting) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = string(apiv1.ConditionReasonConfigurationNotReported)
+		condition.Message = fmt.Sprintf("Instances not reporting their configuration: %v", notReporting)
+
+	case !*report.IsUniform():
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonConfigurationNotUniform)
+		condition.Message = fmt.Sprintf("Instances are running different configurations: %v", hashes)
+
+	case len(pendingRestart) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonPendingRestart)
+		condition.Message = fmt.Sprintf("Parameters pending restart: %s", strings.Join(pendingRestart, "; "))
+
+	case len(alterSystem) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonAlterSystemOverride)
+		condition.Message = fmt.Sprintf(
+			"Parameters overridden via ALTER SYSTEM: %s", strings.Join(alterSystem, "; "))
+
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = string(apiv1.ConditionReasonConfigurationApplied)
+		condition.Message = fmt.Sprintf("All instances are running the declared configuration: %v", hashes)
+	}
+
+	return condition
+}
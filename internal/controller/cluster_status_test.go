@@ -849,3 +849,200 @@ var _ = Describe("updateClusterStatusThatRequiresInstancesState tests", func() {
 		})
 	})
 })
+
+var _ = Describe("getInstanceParameters", func() {
+	cluster := &apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			PostgresConfiguration: apiv1.PostgresConfiguration{
+				InstanceOverrides: []apiv1.InstanceParametersOverride{
+					{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"workload": "reporting"},
+						},
+						Parameters: map[string]string{"work_mem": "64MB"},
+					},
+				},
+			},
+		},
+	}
+
+	It("returns nil without overrides", func() {
+		Expect(getInstanceParameters(&apiv1.Cluster{}, []corev1.Pod{{}})).To(BeNil())
+	})
+
+	It("resolves the overrides of the matching instances", func() {
+		instances := []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"}},
+			{ObjectMeta: metav1.ObjectMeta{
+				Name:   "cluster-example-2",
+				Labels: map[string]string{"workload": "reporting"},
+			}},
+		}
+		Expect(getInstanceParameters(cluster, instances)).To(Equal(map[apiv1.PodName]map[string]string{
+			"cluster-example-2": {"work_mem": "64MB"},
+		}))
+	})
+})
+
+var _ = Describe("getConfigurationAppliedCondition", func() {
+	newStatus := func(name, hash string) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:                     &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			LoadedConfigurationHash: hash,
+		}
+	}
+
+	It("is unknown when an instance is not reporting its configuration", func() {
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+			newStatus("pod-1", "abc"),
+			newStatus("pod-2", ""),
+		}}
+
+		condition := getConfigurationAppliedCondition(getConfigurationReport(&apiv1.Cluster{}, statuses))
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonConfigurationNotReported)))
+		Expect(condition.Message).To(ContainSubstring("pod-2"))
+	})
+
+	It("is false when the instances run different configurations", func() {
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+			newStatus("pod-1", "abc"),
+			newStatus("pod-2", "def"),
+		}}
+
+		condition := getConfigurationAppliedCondition(getConfigurationReport(&apiv1.Cluster{}, statuses))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonConfigurationNotUniform)))
+		Expect(condition.Message).To(ContainSubstring("pod-1=abc pod-2=def"))
+	})
+
+	It("accepts different configurations on instances with parameter overrides", func() {
+		cluster := &apiv1.Cluster{
+			Status: apiv1.ClusterStatus{
+				InstanceParameters: map[apiv1.PodName]map[string]string{
+					"pod-2": {"work_mem": "64MB"},
+				},
+			},
+		}
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+			newStatus("pod-1", "abc"),
+			newStatus("pod-2", "def"),
+			newStatus("pod-3", "abc"),
+		}}
+
+		condition := getConfigurationAppliedCondition(getConfigurationReport(cluster, statuses))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonConfigurationApplied)))
+	})
+
+	It("is false when parameters are pending restart", func() {
+		pending := newStatus("pod-2", "abc")
+		pending.PendingRestart = true
+		pending.PendingRestartParameters = []string{"max_connections", "shared_buffers"}
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+			newStatus("pod-1", "abc"),
+			pending,
+		}}
+
+		condition := getConfigurationAppliedCondition(getConfigurationReport(&apiv1.Cluster{}, statuses))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonPendingRestart)))
+		Expect(condition.Message).To(Equal("Parameters pending restart: pod-2: [max_connections shared_buffers]"))
+	})
+
+	It("is false when parameters are overridden via ALTER SYSTEM", func() {
+		altered := newStatus("pod-1", "abc")
+		altered.AlterSystemParameters = map[string]string{"work_mem": "1GB", "jit": "off"}
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{altered}}
+
+		condition := getConfigurationAppliedCondition(getConfigurationReport(&apiv1.Cluster{}, statuses))
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonAlterSystemOverride)))
+		Expect(condition.Message).To(Equal("Parameters overridden via ALTER SYSTEM: pod-1: [jit work_mem]"))
+	})
+
+	It("is set by updateClusterStatusThatRequiresInstancesState", func(ctx SpecContext) {
+		env := buildTestEnvironment()
+		cluster := newFakeCNPGCluster(env.client, newFakeNamespace(env.client))
+		statuses := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+			newStatus("pod-1", "abc"),
+		}}
+
+		Expect(env.clusterReconciler.updateClusterStatusThatRequiresInstancesState(ctx, cluster, statuses)).
+			To(Succeed())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionConfigurationApplied))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	})
+})
+
+var _ = Describe("updateParametersFromCondition", func() {
+	var (
+		env     *testingEnvironment
+		cluster *apiv1.Cluster
+	)
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+		cluster = newFakeCNPGCluster(env.client, newFakeNamespace(env.client))
+		cluster.Spec.PostgresConfiguration.ParametersFrom = []apiv1.ParameterSource{
+			{
+				Name: "work_mem",
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "pg-parameters"},
+					Key:                  "work_mem",
+				},
+			},
+		}
+	})
+
+	It("is false when the referenced ConfigMap is missing", func(ctx SpecContext) {
+		Expect(env.clusterReconciler.updateParametersFromCondition(ctx, cluster)).To(Succeed())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionParametersFromAvailable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonParametersFromNotAvailable)))
+	})
+
+	It("is false when the referenced key is missing", func(ctx SpecContext) {
+		Expect(env.client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "pg-parameters", Namespace: cluster.Namespace},
+			Data:       map[string]string{"maintenance_work_mem": "64MB"},
+		})).To(Succeed())
+
+		Expect(env.clusterReconciler.updateParametersFromCondition(ctx, cluster)).To(Succeed())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionParametersFromAvailable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring(`missing key "work_mem"`))
+	})
+
+	It("is true when every referenced key exists", func(ctx SpecContext) {
+		Expect(env.client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "pg-parameters", Namespace: cluster.Namespace},
+			Data:       map[string]string{"work_mem": "8MB"},
+		})).To(Succeed())
+
+		Expect(env.clusterReconciler.updateParametersFromCondition(ctx, cluster)).To(Succeed())
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionParametersFromAvailable))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("is removed when parametersFrom is not set", func(ctx SpecContext) {
+		Expect(env.clusterReconciler.updateParametersFromCondition(ctx, cluster)).To(Succeed())
+		cluster.Spec.PostgresConfiguration.ParametersFrom = nil
+
+		Expect(env.clusterReconciler.updateParametersFromCondition(ctx, cluster)).To(Succeed())
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions,
+			string(apiv1.ConditionParametersFromAvailable))).To(BeNil())
+	})
+})
@@ -0,0 +1,402 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// clusterBranchRetryPeriod is the time to wait before checking again
+// a source cluster that cannot be branched yet
+const clusterBranchRetryPeriod = 30 * time.Second
+
+// ClusterBranchReconciler reconciles a ClusterBranch object
+type ClusterBranchReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterbranches,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterbranches/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is the main reconciler logic
+func (r *ClusterBranchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	contextLogger.Debug("Reconciliation loop start")
+	defer func() {
+		contextLogger.Debug("Reconciliation loop end")
+	}()
+
+	var branch apiv1.ClusterBranch
+	if err := r.Get(ctx, req.NamespacedName, &branch); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !branch.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	switch branch.Status.Phase {
+	case "":
+		return r.startBranch(ctx, &branch)
+	case apiv1.ClusterBranchPhaseSnapshotting:
+		return r.reconcileSnapshot(ctx, &branch)
+	case apiv1.ClusterBranchPhaseProvisioning:
+		return r.reconcileProvisioning(ctx, &branch)
+	case apiv1.ClusterBranchPhaseReady:
+		return r.reconcileExpiration(ctx, &branch)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// startBranch requests the volume snapshot backup of the source cluster
+func (r *ClusterBranchReconciler) startBranch(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var source apiv1.Cluster
+	if err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: branch.Namespace, Name: branch.Spec.Cluster.Name},
+		&source,
+	); err != nil {
+		if apierrs.IsNotFound(err) {
+			return r.waitForSource(ctx, branch, fmt.Sprintf("Cluster %s not found", branch.Spec.Cluster.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if source.Spec.Backup == nil || source.Spec.Backup.VolumeSnapshot == nil {
+		return ctrl.Result{}, r.setFailed(ctx, branch,
+			fmt.Sprintf("Cluster %s has no volume snapshot backup configuration", source.Name))
+	}
+
+	if source.IsRefreshInProgress() {
+		return r.waitForSource(ctx, branch, fmt.Sprintf("Cluster %s is being refreshed", source.Name))
+	}
+
+	backup := branch.CreateBackup()
+	contextLogger.Info("Creating volume snapshot backup", "backupName", backup.Name)
+	if err := r.Create(ctx, backup); err != nil {
+		if !apierrs.IsAlreadyExists(err) {
+			return ctrl.Result{}, err
+		}
+
+		var existingBackup apiv1.Backup
+		if err := r.Get(ctx, client.ObjectKeyFromObject(backup), &existingBackup); err != nil {
+			return ctrl.Result{}, err
+		}
+		if !branch.IsOwnerOf(existingBackup.ObjectMeta) {
+			return ctrl.Result{}, r.setFailed(ctx, branch,
+				fmt.Sprintf("Backup %s already exists", backup.Name))
+		}
+	}
+	r.Recorder.Eventf(branch, "Normal", "BranchStarted",
+		"Taking a volume snapshot backup of cluster %s", source.Name)
+
+	origBranch := branch.DeepCopy()
+	branch.Status.Phase = apiv1.ClusterBranchPhaseSnapshotting
+	branch.Status.BackupName = backup.Name
+	branch.Status.Message = ""
+	return ctrl.Result{}, r.Status().Patch(ctx, branch, client.MergeFrom(origBranch))
+}
+
+// reconcileSnapshot waits for the volume snapshot backup to be completed
+// and creates the branch cluster from it
+func (r *ClusterBranchReconciler) reconcileSnapshot(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var backup apiv1.Backup
+	if err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: branch.Namespace, Name: branch.Status.BackupName},
+		&backup,
+	); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, r.setFailed(ctx, branch,
+				fmt.Sprintf("Backup %s has been deleted", branch.Status.BackupName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	switch backup.Status.Phase {
+	case apiv1.BackupPhaseFailed:
+		return ctrl.Result{}, r.setFailed(ctx, branch,
+			fmt.Sprintf("Backup %s failed: %s", backup.Name, backup.Status.Error))
+	case apiv1.BackupPhaseCompleted:
+	default:
+		// We'll be notified when the backup changes
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.adoptSnapshots(ctx, branch, &backup); err != nil {
+		if errors.Is(err, errForeignSnapshot) {
+			return ctrl.Result{}, r.setFailed(ctx, branch, err.Error())
+		}
+		return ctrl.Result{}, err
+	}
+
+	var source apiv1.Cluster
+	if err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: branch.Namespace, Name: branch.Spec.Cluster.Name},
+		&source,
+	); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, r.setFailed(ctx, branch,
+				fmt.Sprintf("Cluster %s has been deleted", branch.Spec.Cluster.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	sourceLineage, err := r.getSourceLineage(ctx, &source)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cluster := branch.CreateCluster(&source, &backup)
+	contextLogger.Info("Creating branch cluster", "clusterName", cluster.Name)
+	if err := r.Create(ctx, cluster); err != nil {
+		if !apierrs.IsAlreadyExists(err) {
+			return ctrl.Result{}, err
+		}
+
+		var existingCluster apiv1.Cluster
+		if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), &existingCluster); err != nil {
+			return ctrl.Result{}, err
+		}
+		if !branch.IsOwnerOf(existingCluster.ObjectMeta) {
+			return ctrl.Result{}, r.setFailed(ctx, branch,
+				fmt.Sprintf("Cluster %s already exists", cluster.Name))
+		}
+	}
+
+	origBranch := branch.DeepCopy()
+	branch.Status.Phase = apiv1.ClusterBranchPhaseProvisioning
+	branch.Status.Lineage = branch.NewLineage(sourceLineage, &backup)
+	return ctrl.Result{}, r.Status().Patch(ctx, branch, client.MergeFrom(origBranch))
+}
+
+// errForeignSnapshot is raised when a volume snapshot labeled with the
+// name of the backup of a branch is controlled by another object
+var errForeignSnapshot = errors.New("volume snapshot controlled by another object")
+
+// adoptSnapshots makes the ClusterBranch the owner of the volume snapshots
+// of its backup, so that they are deleted together with the branch. The
+// snapshots controlled by an object other than the backup are never adopted.
+func (r *ClusterBranchReconciler) adoptSnapshots(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+	backup *apiv1.Backup,
+) error {
+	var snapshots volumesnapshotv1.VolumeSnapshotList
+	if err := r.List(
+		ctx,
+		&snapshots,
+		client.InNamespace(branch.Namespace),
+		client.MatchingLabels{utils.BackupNameLabelName: backup.Name},
+	); err != nil {
+		return err
+	}
+
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if owner := metav1.GetControllerOfNoCopy(snapshot); owner != nil &&
+			!branch.IsOwnerOf(snapshot.ObjectMeta) && owner.UID != backup.UID {
+			return fmt.Errorf("%w: VolumeSnapshot %s is controlled by %s %s",
+				errForeignSnapshot, snapshot.Name, owner.Kind, owner.Name)
+		}
+	}
+
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if branch.IsOwnerOf(snapshot.ObjectMeta) {
+			continue
+		}
+
+		origSnapshot := snapshot.DeepCopy()
+		branch.SetAsOwnerOf(&snapshot.ObjectMeta)
+		if err := r.Patch(ctx, snapshot, client.MergeFrom(origSnapshot)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSourceLineage gets the lineage of the source cluster, which is
+// not empty only when the source cluster is itself a branch
+func (r *ClusterBranchReconciler) getSourceLineage(
+	ctx context.Context,
+	source *apiv1.Cluster,
+) ([]apiv1.ClusterBranchLineage, error) {
+	owner := metav1.GetControllerOfNoCopy(source)
+	if owner == nil || owner.Kind != apiv1.ClusterBranchKind {
+		return nil, nil
+	}
+
+	var parentBranch apiv1.ClusterBranch
+	if err := r.Get(ctx, client.ObjectKey{Namespace: source.Namespace, Name: owner.Name}, &parentBranch); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return parentBranch.Status.Lineage, nil
+}
+
+// reconcileProvisioning waits for the branch cluster to be ready
+func (r *ClusterBranchReconciler) reconcileProvisioning(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+) (ctrl.Result, error) {
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{Namespace: branch.Namespace, Name: branch.Name}, &cluster); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, r.setFailed(ctx, branch,
+				fmt.Sprintf("Cluster %s has been deleted", branch.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Status.Phase != apiv1.PhaseHealthy {
+		// We'll be notified when the cluster changes
+		return ctrl.Result{}, nil
+	}
+
+	r.Recorder.Eventf(branch, "Normal", "BranchReady", "Cluster %s is ready", cluster.Name)
+
+	origBranch := branch.DeepCopy()
+	branch.Status.Phase = apiv1.ClusterBranchPhaseReady
+	branch.Status.ReadyTime = &metav1.Time{Time: time.Now()}
+	branch.Status.ExpirationTime = branch.GetExpirationTime()
+	if err := r.Status().Patch(ctx, branch, client.MergeFrom(origBranch)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.reconcileExpiration(ctx, branch)
+}
+
+// reconcileExpiration deletes the branch when its time to live expires
+func (r *ClusterBranchReconciler) reconcileExpiration(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	// The time to live can be changed after the branch is ready
+	expirationTime := branch.GetExpirationTime()
+	if !expirationTime.Equal(branch.Status.ExpirationTime) {
+		origBranch := branch.DeepCopy()
+		branch.Status.ExpirationTime = expirationTime
+		if err := r.Status().Patch(ctx, branch, client.MergeFrom(origBranch)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if expirationTime == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if remaining := time.Until(expirationTime.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	contextLogger.Info("Deleting expired branch", "expirationTime", expirationTime)
+	r.Recorder.Eventf(branch, "Normal", "BranchExpired", "Branch expired at %v", expirationTime)
+	return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, branch))
+}
+
+// waitForSource records why the source cluster cannot be branched yet
+func (r *ClusterBranchReconciler) waitForSource(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+	message string,
+) (ctrl.Result, error) {
+	log.FromContext(ctx).Info("Cannot branch the cluster yet, retrying", "reason", message)
+
+	origBranch := branch.DeepCopy()
+	branch.Status.Message = message
+	if err := r.Status().Patch(ctx, branch, client.MergeFrom(origBranch)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: clusterBranchRetryPeriod}, nil
+}
+
+// setFailed marks the branch as failed
+func (r *ClusterBranchReconciler) setFailed(
+	ctx context.Context,
+	branch *apiv1.ClusterBranch,
+	message string,
+) error {
+	r.Recorder.Event(branch, "Warning", "BranchFailed", message)
+
+	origBranch := branch.DeepCopy()
+	branch.Status.Phase = apiv1.ClusterBranchPhaseFailed
+	branch.Status.Message = message
+	return r.Status().Patch(ctx, branch, client.MergeFrom(origBranch))
+}
+
+// SetupWithManager install this controller in the controller manager
+func (r *ClusterBranchReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrentReconciles int,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.ClusterBranch{}).
+		Owns(&apiv1.Backup{}).
+		Owns(&apiv1.Cluster{}).
+		Named("cluster-branch").
+		Complete(r)
+}
@@ -0,0 +1,280 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterBranchReconciler", func() {
+	var (
+		source *apiv1.Cluster
+		branch *apiv1.ClusterBranch
+	)
+
+	BeforeEach(func() {
+		source = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				Backup: &apiv1.BackupConfiguration{
+					VolumeSnapshot: &apiv1.VolumeSnapshotConfiguration{ClassName: "csi-snapclass"},
+				},
+				StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+			},
+		}
+		branch = &apiv1.ClusterBranch{
+			ObjectMeta: metav1.ObjectMeta{Name: "migration-test", Namespace: "default", UID: "branch-uid"},
+			Spec: apiv1.ClusterBranchSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: "production"},
+				Instance: "production-2",
+				TTL:      &metav1.Duration{Duration: time.Hour},
+			},
+		}
+	})
+
+	newReconciler := func(objects ...client.Object) *ClusterBranchReconciler {
+		return &ClusterBranchReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Backup{}, &apiv1.ClusterBranch{}).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	reconcileBranch := func(ctx SpecContext, r *ClusterBranchReconciler) {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(branch)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(branch), branch)).To(Succeed())
+	}
+
+	completeBackup := func(ctx SpecContext, r *ClusterBranchReconciler) {
+		var backup apiv1.Backup
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: branch.Status.BackupName}, &backup)).To(Succeed())
+		backup.Status.Phase = apiv1.BackupPhaseCompleted
+		backup.Status.InstanceID = &apiv1.InstanceID{PodName: "production-2"}
+		backup.Status.StoppedAt = &metav1.Time{Time: time.Now()}
+		Expect(r.Status().Update(ctx, &backup)).To(Succeed())
+	}
+
+	It("takes an online snapshot of the chosen instance", func(ctx SpecContext) {
+		r := newReconciler(source, branch)
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseSnapshotting))
+		Expect(branch.Status.BackupName).To(Equal("migration-test"))
+
+		var backup apiv1.Backup
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "migration-test"}, &backup)).To(Succeed())
+		Expect(backup.Spec.Method).To(Equal(apiv1.BackupMethodVolumeSnapshot))
+		Expect(backup.Spec.Online).To(Equal(ptr.To(true)))
+		Expect(backup.Spec.Instance).To(Equal("production-2"))
+		Expect(branch.IsOwnerOf(backup.ObjectMeta)).To(BeTrue())
+	})
+
+	It("fails when the source cluster can't take volume snapshots", func(ctx SpecContext) {
+		source.Spec.Backup = nil
+		r := newReconciler(source, branch)
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseFailed))
+		Expect(branch.Status.Message).To(ContainSubstring("no volume snapshot backup configuration"))
+	})
+
+	It("waits for the source cluster to exist", func(ctx SpecContext) {
+		r := newReconciler(branch)
+
+		res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(branch)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(clusterBranchRetryPeriod))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(branch), branch)).To(Succeed())
+		Expect(branch.Status.Phase).To(BeEmpty())
+		Expect(branch.Status.Message).To(ContainSubstring("not found"))
+	})
+
+	It("creates the branch cluster once the snapshots are ready", func(ctx SpecContext) {
+		snapshot := &volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "production-2-migration-test",
+				Namespace: "default",
+				Labels:    map[string]string{utils.BackupNameLabelName: "migration-test"},
+			},
+		}
+		r := newReconciler(source, branch, snapshot)
+
+		reconcileBranch(ctx, r)
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseSnapshotting))
+
+		completeBackup(ctx, r)
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseProvisioning))
+		Expect(branch.Status.Lineage).To(HaveLen(1))
+		Expect(branch.Status.Lineage[0].Cluster).To(Equal("production"))
+		Expect(branch.Status.Lineage[0].Backup).To(Equal("migration-test"))
+		Expect(branch.Status.Lineage[0].Instance).To(Equal("production-2"))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot)).To(Succeed())
+		Expect(branch.IsOwnerOf(snapshot.ObjectMeta)).To(BeTrue())
+
+		var cluster apiv1.Cluster
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "migration-test"}, &cluster)).To(Succeed())
+		Expect(cluster.Spec.Instances).To(Equal(1))
+		Expect(cluster.Spec.Bootstrap.Recovery.Backup.Name).To(Equal("migration-test"))
+		Expect(branch.IsOwnerOf(cluster.ObjectMeta)).To(BeTrue())
+	})
+
+	It("extends the lineage of the source cluster", func(ctx SpecContext) {
+		parentBranch := &apiv1.ClusterBranch{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "default", UID: "parent-uid"},
+			Spec:       apiv1.ClusterBranchSpec{Cluster: apiv1.LocalObjectReference{Name: "origin"}},
+			Status: apiv1.ClusterBranchStatus{
+				Phase:   apiv1.ClusterBranchPhaseReady,
+				Lineage: []apiv1.ClusterBranchLineage{{Cluster: "origin", Backup: "production"}},
+			},
+		}
+		parentBranch.SetAsOwnerOf(&source.ObjectMeta)
+		r := newReconciler(source, parentBranch, branch)
+
+		reconcileBranch(ctx, r)
+		completeBackup(ctx, r)
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Lineage).To(HaveLen(2))
+		Expect(branch.Status.Lineage[0].Cluster).To(Equal("origin"))
+		Expect(branch.Status.Lineage[1].Cluster).To(Equal("production"))
+	})
+
+	It("fails when the backup fails", func(ctx SpecContext) {
+		r := newReconciler(source, branch)
+
+		reconcileBranch(ctx, r)
+		var backup apiv1.Backup
+		Expect(r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "migration-test"}, &backup)).To(Succeed())
+		backup.Status.Phase = apiv1.BackupPhaseFailed
+		backup.Status.Error = "snapshot class not found"
+		Expect(r.Status().Update(ctx, &backup)).To(Succeed())
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseFailed))
+		Expect(branch.Status.Message).To(ContainSubstring("snapshot class not found"))
+	})
+
+	It("doesn't take over an existing cluster", func(ctx SpecContext) {
+		existing := &apiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "migration-test", Namespace: "default"}}
+		r := newReconciler(source, branch, existing)
+
+		reconcileBranch(ctx, r)
+		completeBackup(ctx, r)
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseFailed))
+		Expect(branch.Status.Message).To(ContainSubstring("already exists"))
+	})
+
+	It("doesn't use an existing backup with the same name", func(ctx SpecContext) {
+		existing := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "migration-test", Namespace: "default"},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "other"},
+				Method:  apiv1.BackupMethodVolumeSnapshot,
+			},
+		}
+		r := newReconciler(source, branch, existing)
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseFailed))
+		Expect(branch.Status.Message).To(ContainSubstring("Backup migration-test already exists"))
+		Expect(branch.Status.BackupName).To(BeEmpty())
+	})
+
+	It("doesn't adopt the snapshots controlled by other objects", func(ctx SpecContext) {
+		snapshot := &volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "production-2-migration-test",
+				Namespace: "default",
+				Labels:    map[string]string{utils.BackupNameLabelName: "migration-test"},
+			},
+		}
+		utils.SetAsOwnedBy(&snapshot.ObjectMeta, metav1.ObjectMeta{Name: "manual", UID: "manual-uid"},
+			metav1.TypeMeta{APIVersion: apiv1.SchemeGroupVersion.String(), Kind: apiv1.BackupKind})
+		r := newReconciler(source, branch, snapshot)
+
+		reconcileBranch(ctx, r)
+		completeBackup(ctx, r)
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseFailed))
+		Expect(branch.Status.Message).To(ContainSubstring("is controlled by Backup manual"))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(snapshot), snapshot)).To(Succeed())
+		Expect(branch.IsOwnerOf(snapshot.ObjectMeta)).To(BeFalse())
+	})
+
+	It("becomes ready with the cluster and sets the expiration", func(ctx SpecContext) {
+		branch.Status.Phase = apiv1.ClusterBranchPhaseProvisioning
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "migration-test", Namespace: "default"},
+			Status:     apiv1.ClusterStatus{Phase: apiv1.PhaseHealthy},
+		}
+		r := newReconciler(branch, cluster)
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.Phase).To(Equal(apiv1.ClusterBranchPhaseReady))
+		Expect(branch.Status.ReadyTime).ToNot(BeNil())
+		Expect(branch.Status.ExpirationTime.Time).To(Equal(branch.Status.ReadyTime.Add(time.Hour)))
+	})
+
+	It("deletes the branch once expired", func(ctx SpecContext) {
+		branch.Status.Phase = apiv1.ClusterBranchPhaseReady
+		branch.Status.ReadyTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		r := newReconciler(branch)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(branch)})
+		Expect(err).ToNot(HaveOccurred())
+		err = r.Get(ctx, client.ObjectKeyFromObject(branch), branch)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("keeps a branch without time to live", func(ctx SpecContext) {
+		branch.Spec.TTL = nil
+		branch.Status.Phase = apiv1.ClusterBranchPhaseReady
+		branch.Status.ReadyTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		r := newReconciler(branch)
+
+		reconcileBranch(ctx, r)
+		Expect(branch.Status.ExpirationTime).To(BeNil())
+	})
+})
@@ -0,0 +1,358 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// clusterRefreshRetryPeriod is the time to wait before checking again
+// the progress of a refresh, or a cluster that cannot be refreshed yet
+const clusterRefreshRetryPeriod = 30 * time.Second
+
+// ClusterRefreshReconciler reconciles a ClusterRefresh object
+type ClusterRefreshReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterrefreshes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusterrefreshes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=backups,verbs=get;list
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile is the main reconciler logic
+func (r *ClusterRefreshReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger, ctx := log.SetupLogger(ctx)
+
+	contextLogger.Debug("Reconciliation loop start")
+	defer func() {
+		contextLogger.Debug("Reconciliation loop end")
+	}()
+
+	var refresh apiv1.ClusterRefresh
+	if err := r.Get(ctx, req.NamespacedName, &refresh); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if refresh.IsRunning() {
+		return r.reconcileRunningRefresh(ctx, &refresh)
+	}
+
+	if refresh.IsSuspended() {
+		contextLogger.Info("Skipping as refresh is suspended")
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileSchedule(ctx, &refresh)
+}
+
+// reconcileRunningRefresh checks if the refresh requested to the target
+// cluster has been completed
+func (r *ClusterRefreshReconciler) reconcileRunningRefresh(
+	ctx context.Context,
+	refresh *apiv1.ClusterRefresh,
+) (ctrl.Result, error) {
+	var cluster apiv1.Cluster
+	err := r.Get(ctx, client.ObjectKey{Namespace: refresh.Namespace, Name: refresh.Spec.Cluster.Name}, &cluster)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	if err == nil && cluster.Status.Refresh != nil && cluster.Status.Refresh.Name == refresh.Name {
+		return ctrl.Result{RequeueAfter: clusterRefreshRetryPeriod}, nil
+	}
+
+	origRefresh := refresh.DeepCopy()
+	refresh.Status.Phase = apiv1.ClusterRefreshPhaseCompleted
+	switch {
+	case err != nil:
+		refresh.Status.Message = fmt.Sprintf("Cluster %s has been deleted during the refresh", refresh.Spec.Cluster.Name)
+	case cluster.Status.Phase == apiv1.PhaseUnrecoverable:
+		refresh.Status.Phase = apiv1.ClusterRefreshPhaseFailed
+		refresh.Status.Message = cluster.Status.PhaseReason
+		r.Recorder.Eventf(refresh, "Warning", "RefreshFailed",
+			"Refresh of cluster %s from backup %s failed", cluster.Name, refresh.Status.BackupName)
+	default:
+		refresh.Status.LastRefreshTime = &metav1.Time{Time: time.Now()}
+		refresh.Status.Message = ""
+		r.Recorder.Eventf(refresh, "Normal", "RefreshCompleted",
+			"Cluster %s refreshed from backup %s", cluster.Name, refresh.Status.BackupName)
+	}
+	if err := r.Status().Patch(ctx, refresh, client.MergeFrom(origRefresh)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// reconcileSchedule starts a new refresh when the schedule is due
+func (r *ClusterRefreshReconciler) reconcileSchedule(
+	ctx context.Context,
+	refresh *apiv1.ClusterRefresh,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	schedule, err := cron.Parse(refresh.Spec.Schedule)
+	if err != nil {
+		contextLogger.Info("Detected an invalid cron schedule",
+			"schedule", refresh.Spec.Schedule)
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	if schedule.Next(now).IsZero() {
+		r.Recorder.Eventf(
+			refresh,
+			"Warning",
+			"NoSchedule",
+			"No time satisfying the schedule %q have been found", refresh.Spec.Schedule)
+		return ctrl.Result{}, nil
+	}
+
+	if refresh.Status.LastCheckTime == nil && !refresh.IsImmediate() {
+		// This is the first time we check this schedule,
+		// let's wait until the first refresh will be actually
+		// scheduled
+		origRefresh := refresh.DeepCopy()
+		nextTime := schedule.Next(now)
+		refresh.Status.LastCheckTime = &metav1.Time{Time: now}
+		refresh.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+		if err := r.Status().Patch(ctx, refresh, client.MergeFrom(origRefresh)); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		contextLogger.Info("Next refresh schedule", "next", nextTime)
+		r.Recorder.Eventf(refresh, "Normal", "RefreshSchedule", "Scheduled first refresh by %v", nextTime)
+		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+	}
+
+	if refresh.Status.LastCheckTime != nil {
+		nextTime := schedule.Next(refresh.Status.LastCheckTime.Time)
+		if now.Before(nextTime) {
+			// No need to start a new refresh, let's wait a bit
+			return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+		}
+	}
+
+	return r.startRefresh(ctx, refresh, now, schedule.Next(now))
+}
+
+// startRefresh requests the target cluster to refresh its data from
+// the backup selected by the source of the ClusterRefresh
+func (r *ClusterRefreshReconciler) startRefresh(
+	ctx context.Context,
+	refresh *apiv1.ClusterRefresh,
+	now time.Time,
+	nextTime time.Time,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var cluster apiv1.Cluster
+	if err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: refresh.Namespace, Name: refresh.Spec.Cluster.Name},
+		&cluster,
+	); err != nil {
+		if apierrs.IsNotFound(err) {
+			return r.skipRefresh(ctx, refresh, now, nextTime,
+				fmt.Sprintf("Cluster %s not found", refresh.Spec.Cluster.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if cluster.IsReplica() {
+		return r.skipRefresh(ctx, refresh, now, nextTime,
+			fmt.Sprintf("Cluster %s is a replica cluster and cannot be refreshed", cluster.Name))
+	}
+
+	if hibernation := cluster.Annotations[utils.HibernationAnnotationName]; hibernation ==
+		string(utils.HibernationAnnotationValueOn) {
+		return r.skipRefresh(ctx, refresh, now, nextTime,
+			fmt.Sprintf("Cluster %s is hibernated and cannot be refreshed", cluster.Name))
+	}
+
+	// The WAL archive of the cluster contains the WAL files of its current
+	// data, which would clash with the ones of the refreshed data
+	if cluster.Status.Refresh == nil && cluster.IsArchivingWAL() {
+		return r.skipRefresh(ctx, refresh, now, nextTime,
+			fmt.Sprintf("Cluster %s archives WAL files and cannot be refreshed, "+
+				"disable the WAL archiving with the %s annotation", cluster.Name, utils.SkipWalArchiving))
+	}
+
+	if cluster.Status.Refresh != nil && cluster.Status.Refresh.Name != refresh.Name {
+		contextLogger.Info("Another refresh is in progress, waiting",
+			"clusterRefresh", cluster.Status.Refresh.Name)
+		return ctrl.Result{RequeueAfter: clusterRefreshRetryPeriod}, nil
+	}
+
+	// A previous reconciliation may have already requested the refresh
+	// without being able to record it in the status
+	var backupName string
+	if cluster.Status.Refresh != nil {
+		backupName = cluster.Status.Refresh.BackupName
+	} else {
+		backup, err := r.getSourceBackup(ctx, refresh)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if backup == nil {
+			return r.skipRefresh(ctx, refresh, now, nextTime, "No completed backup found in the source")
+		}
+
+		backupName = backup.Name
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			r.Client,
+			&cluster,
+			status.SetRefresh(refresh.NewRequest(backupName)),
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	contextLogger.Info("Refresh started", "cluster", cluster.Name, "backupName", backupName)
+	r.Recorder.Eventf(refresh, "Normal", "RefreshStarted",
+		"Refreshing cluster %s from backup %s", cluster.Name, backupName)
+
+	origRefresh := refresh.DeepCopy()
+	refresh.Status.LastCheckTime = &metav1.Time{Time: now}
+	refresh.Status.LastScheduleTime = &metav1.Time{Time: now}
+	refresh.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+	refresh.Status.Phase = apiv1.ClusterRefreshPhaseRunning
+	refresh.Status.BackupName = backupName
+	refresh.Status.Message = ""
+	if err := r.Status().Patch(ctx, refresh, client.MergeFrom(origRefresh)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: clusterRefreshRetryPeriod}, nil
+}
+
+// skipRefresh records that the current iteration of the schedule
+// has been skipped, and waits for the next one
+func (r *ClusterRefreshReconciler) skipRefresh(
+	ctx context.Context,
+	refresh *apiv1.ClusterRefresh,
+	now time.Time,
+	nextTime time.Time,
+	message string,
+) (ctrl.Result, error) {
+	r.Recorder.Eventf(refresh, "Warning", "RefreshSkipped", "%s, next refresh scheduled by %v", message, nextTime)
+
+	origRefresh := refresh.DeepCopy()
+	refresh.Status.LastCheckTime = &metav1.Time{Time: now}
+	refresh.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+	refresh.Status.Message = message
+	if err := r.Status().Patch(ctx, refresh, client.MergeFrom(origRefresh)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
+}
+
+// getSourceBackup gets the completed backup the data will be refreshed
+// from, or nil if there is none
+func (r *ClusterRefreshReconciler) getSourceBackup(
+	ctx context.Context,
+	refresh *apiv1.ClusterRefresh,
+) (*apiv1.Backup, error) {
+	source := refresh.Spec.Source
+
+	if source.Backup != nil {
+		var backup apiv1.Backup
+		err := r.Get(ctx, client.ObjectKey{Namespace: refresh.Namespace, Name: source.Backup.Name}, &backup)
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if backup.Status.Phase != apiv1.BackupPhaseCompleted {
+			return nil, nil
+		}
+		return &backup, nil
+	}
+
+	if source.Cluster == nil {
+		return nil, nil
+	}
+
+	var backupList apiv1.BackupList
+	if err := r.List(ctx, &backupList, client.InNamespace(refresh.Namespace)); err != nil {
+		return nil, err
+	}
+
+	return getLatestCompletedBackup(backupList.Items, source.Cluster.Name), nil
+}
+
+// getLatestCompletedBackup gets the completed backup of the passed
+// cluster that stopped last, or nil if there is none
+func getLatestCompletedBackup(backups []apiv1.Backup, clusterName string) *apiv1.Backup {
+	var result *apiv1.Backup
+	for idx := range backups {
+		backup := &backups[idx]
+		if backup.Spec.Cluster.Name != clusterName ||
+			backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			backup.Status.StoppedAt == nil {
+			continue
+		}
+
+		if result == nil || backup.Status.StoppedAt.After(result.Status.StoppedAt.Time) {
+			result = backup
+		}
+	}
+
+	return result
+}
+
+// SetupWithManager install this controller in the controller manager
+func (r *ClusterRefreshReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrentReconciles int,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		For(&apiv1.ClusterRefresh{}).
+		Named("cluster-refresh").
+		Complete(r)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getLatestCompletedBackup", func() {
+	newBackup := func(name, clusterName, phase string, stoppedAt time.Time) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       apiv1.BackupSpec{Cluster: apiv1.LocalObjectReference{Name: clusterName}},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhase(phase),
+				StoppedAt: &metav1.Time{Time: stoppedAt},
+			},
+		}
+	}
+
+	It("selects the completed backup of the cluster that stopped last", func() {
+		now := time.Now()
+		backups := []apiv1.Backup{
+			newBackup("old", "production", apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+			newBackup("latest", "production", apiv1.BackupPhaseCompleted, now.Add(-time.Hour)),
+			newBackup("failed", "production", apiv1.BackupPhaseFailed, now),
+			newBackup("other", "other", apiv1.BackupPhaseCompleted, now),
+		}
+
+		Expect(getLatestCompletedBackup(backups, "production").Name).To(Equal("latest"))
+	})
+
+	It("returns nil when there are no completed backups", func() {
+		backups := []apiv1.Backup{
+			newBackup("failed", "production", apiv1.BackupPhaseFailed, time.Now()),
+		}
+
+		Expect(getLatestCompletedBackup(backups, "production")).To(BeNil())
+	})
+})
+
+var _ = Describe("ClusterRefreshReconciler", func() {
+	var (
+		cluster *apiv1.Cluster
+		backup  *apiv1.Backup
+		refresh *apiv1.ClusterRefresh
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "default"},
+		}
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "production-backup", Namespace: "default"},
+			Spec:       apiv1.BackupSpec{Cluster: apiv1.LocalObjectReference{Name: "production"}},
+			Status: apiv1.BackupStatus{
+				Phase:     apiv1.BackupPhaseCompleted,
+				StoppedAt: &metav1.Time{Time: time.Now()},
+			},
+		}
+		refresh = &apiv1.ClusterRefresh{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec: apiv1.ClusterRefreshSpec{
+				Schedule:               "0 0 0 * * *",
+				Immediate:              ptr.To(true),
+				Cluster:                apiv1.LocalObjectReference{Name: "staging"},
+				Source:                 apiv1.ClusterRefreshSource{Cluster: &apiv1.LocalObjectReference{Name: "production"}},
+				ApplicationCredentials: apiv1.ClusterRefreshCredentialsRotate,
+				PostRecoverySQL:        []string{"SELECT 1"},
+			},
+		}
+	})
+
+	newReconciler := func(objects ...client.Object) *ClusterRefreshReconciler {
+		return &ClusterRefreshReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(objects...).
+				WithStatusSubresource(&apiv1.Cluster{}, &apiv1.ClusterRefresh{}).
+				Build(),
+			Recorder: record.NewFakeRecorder(120),
+		}
+	}
+
+	reconcileRefresh := func(ctx SpecContext, r *ClusterRefreshReconciler) {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(refresh)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(refresh), refresh)).To(Succeed())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+	}
+
+	It("waits for the schedule when the refresh is not immediate", func(ctx SpecContext) {
+		refresh.Spec.Immediate = nil
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.LastCheckTime).ToNot(BeNil())
+		Expect(refresh.Status.NextScheduleTime).ToNot(BeNil())
+		Expect(refresh.Status.Phase).To(BeEmpty())
+		Expect(cluster.Status.Refresh).To(BeNil())
+	})
+
+	It("requests the refresh from the latest backup of the source", func(ctx SpecContext) {
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(Equal(apiv1.ClusterRefreshPhaseRunning))
+		Expect(refresh.Status.BackupName).To(Equal("production-backup"))
+		Expect(cluster.Status.Refresh).To(Equal(&apiv1.ClusterRefreshRequest{
+			Name:                   "nightly",
+			BackupName:             "production-backup",
+			ApplicationCredentials: apiv1.ClusterRefreshCredentialsRotate,
+			PostRecoverySQL:        []string{"SELECT 1"},
+		}))
+	})
+
+	It("skips the refresh when there is no completed backup", func(ctx SpecContext) {
+		backup.Status.Phase = apiv1.BackupPhaseRunning
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(BeEmpty())
+		Expect(refresh.Status.Message).ToNot(BeEmpty())
+		Expect(cluster.Status.Refresh).To(BeNil())
+	})
+
+	It("skips the refresh of a cluster archiving WAL files", func(ctx SpecContext) {
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://backups/staging",
+			},
+		}
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(BeEmpty())
+		Expect(refresh.Status.Message).To(ContainSubstring("archives WAL files"))
+		Expect(cluster.Status.Refresh).To(BeNil())
+	})
+
+	It("refreshes a cluster whose WAL archiving is disabled", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{utils.SkipWalArchiving: "enabled"}
+		cluster.Spec.Backup = &apiv1.BackupConfiguration{
+			BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+				DestinationPath: "s3://backups/staging",
+			},
+		}
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(Equal(apiv1.ClusterRefreshPhaseRunning))
+		Expect(cluster.Status.Refresh).ToNot(BeNil())
+	})
+
+	It("waits for the cluster to complete the refresh", func(ctx SpecContext) {
+		refresh.Status.Phase = apiv1.ClusterRefreshPhaseRunning
+		cluster.Status.Refresh = refresh.NewRequest("production-backup")
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(Equal(apiv1.ClusterRefreshPhaseRunning))
+		Expect(refresh.Status.LastRefreshTime).To(BeNil())
+	})
+
+	It("records the completion of the refresh", func(ctx SpecContext) {
+		refresh.Status.Phase = apiv1.ClusterRefreshPhaseRunning
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(Equal(apiv1.ClusterRefreshPhaseCompleted))
+		Expect(refresh.Status.LastRefreshTime).ToNot(BeNil())
+	})
+
+	It("records the failure of the refresh", func(ctx SpecContext) {
+		refresh.Status.Phase = apiv1.ClusterRefreshPhaseRunning
+		cluster.Status.Phase = apiv1.PhaseUnrecoverable
+		cluster.Status.PhaseReason = "Refresh from backup production-backup failed"
+		r := newReconciler(cluster, backup, refresh)
+
+		reconcileRefresh(ctx, r)
+		Expect(refresh.Status.Phase).To(Equal(apiv1.ClusterRefreshPhaseFailed))
+		Expect(refresh.Status.Message).To(Equal(cluster.Status.PhaseReason))
+		Expect(refresh.Status.LastRefreshTime).To(BeNil())
+	})
+})
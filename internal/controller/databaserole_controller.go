@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -43,6 +44,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // DatabaseRoleReconciler reconciles a DatabaseRole object
@@ -60,6 +62,7 @@ const clientCertReconcileInterval = time.Hour
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=databaseroles/status,verbs=get;update;patch;watch
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=databaseroles/finalizers,verbs=update
 // +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=poolers,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile implements the main reconciliation loop for Role objects
@@ -85,20 +88,143 @@ func (r *DatabaseRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcilePasswordRotation(ctx, &role); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileServiceBindings(ctx, &role); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// A DatabaseRole has two status writers: the instance manager owns every
 	// field except the PasswordSecretChange condition (handled above) and the
-	// ClientCertificate state set here. Merge-patch so we only touch our own
-	// fields and never clobber the instance manager's update.
+	// ClientCertificate and PasswordRotation states set here. Merge-patch so
+	// we only touch our own fields and never clobber the instance manager's update.
 	if !reflect.DeepEqual(origRole.Status, role.Status) {
 		if err := r.Status().Patch(ctx, &role, client.MergeFrom(origRole)); err != nil {
 			return ctrl.Result{}, fmt.Errorf("while patching role status: %w", err)
 		}
 	}
 
+	var result ctrl.Result
+	if role.IsClientCertificateEnabled() {
+		result.RequeueAfter = clientCertReconcileInterval
+	}
+	if role.Spec.PasswordRotation != nil {
+		if next := nextPasswordRotation(
+			[]*apiv1.PasswordRotationState{role.Status.PasswordRotation}, time.Now(),
+		); next > 0 && (result.RequeueAfter == 0 || next < result.RequeueAfter) {
+			result.RequeueAfter = next
+		}
+	}
+	return result, nil
+}
+
+// reconcilePasswordRotation rotates the password of the role when due. It
+// modifies role.Status.PasswordRotation in memory; the caller is responsible
+// for persisting the status.
+func (r *DatabaseRoleReconciler) reconcilePasswordRotation(
+	ctx context.Context,
+	role *apiv1.DatabaseRole,
+) error {
+	if role.Spec.PasswordRotation == nil {
+		role.Status.PasswordRotation = nil
+		return nil
+	}
+	if !role.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: role.Namespace,
+		Name:      role.Spec.ClusterRef.Name,
+	}, &cluster); apierrs.IsNotFound(err) {
+		log.FromContext(ctx).Info("cluster not found, will retry when it appears",
+			"cluster", role.Spec.ClusterRef.Name)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("while getting cluster %q: %w", role.Spec.ClusterRef.Name, err)
+	}
+
+	state, err := reconcilePasswordRotation(
+		ctx,
+		r.Client,
+		passwordRotationRequest{
+			role:      &role.Spec.RoleConfiguration,
+			namespace: role.Namespace,
+			hostname:  cluster.GetServiceReadWriteName(),
+			setOwnership: func(secret *corev1.Secret) error {
+				return ctrl.SetControllerReference(role, secret, r.Scheme)
+			},
+		},
+		role.Status.PasswordRotation,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("while rotating the password of role %q: %w", role.Spec.Name, err)
+	}
+
+	role.Status.PasswordRotation = state
+	return nil
+}
+
+// reconcileServiceBindings generates the service binding Secrets of the
+// role. The Secrets are owned by the role, so they are garbage collected
+// together with it.
+func (r *DatabaseRoleReconciler) reconcileServiceBindings(
+	ctx context.Context,
+	role *apiv1.DatabaseRole,
+) error {
+	if !role.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: role.Namespace,
+		Name:      role.Spec.ClusterRef.Name,
+	}, &cluster); apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("while getting cluster %q: %w", role.Spec.ClusterRef.Name, err)
+	}
+
+	request := serviceBindingRequest{
+		cluster:  &cluster,
+		role:     &role.Spec.RoleConfiguration,
+		rotation: role.Status.PasswordRotation,
+	}
 	if role.IsClientCertificateEnabled() {
-		return ctrl.Result{RequeueAfter: clientCertReconcileInterval}, nil
+		request.clientCertSecretName = role.GetClientCertSecretName()
+	}
+
+	var pending []string
+	secrets, err := buildServiceBindingSecrets(ctx, r.Client, request)
+	if err != nil {
+		return fmt.Errorf("while building the service bindings of role %q: %w", role.Spec.Name, err)
+	}
+	if secrets == nil {
+		pending = getServiceBindingSecretNames(cluster.Name, &role.Spec.RoleConfiguration)
+	}
+	for _, secret := range secrets {
+		if err := ctrl.SetControllerReference(role, secret, r.Scheme); err != nil {
+			return err
+		}
 	}
-	return ctrl.Result{}, nil
+
+	return reconcileServiceBindingSecrets(
+		ctx,
+		r.Client,
+		role,
+		role.Namespace,
+		secrets,
+		pending,
+		client.MatchingLabels{
+			utils.ClusterLabelName:            cluster.Name,
+			utils.ServiceBindingRoleLabelName: role.Spec.Name,
+		},
+	)
 }
 
 // reconcilePasswordCondition manages the ConditionPasswordSecretChange status condition.
@@ -109,7 +235,11 @@ func (r *DatabaseRoleReconciler) reconcilePasswordCondition(
 	ctx context.Context,
 	role *apiv1.DatabaseRole,
 ) error {
-	if role.Spec.PasswordSecret == nil {
+	// The secret in use is the one of the active login when the password is rotated
+	secretName := role.Spec.GetPasswordRotationSecretName(
+		role.Status.PasswordRotation.GetActiveLogin(role.Spec.Name))
+
+	if secretName == "" {
 		// If passwordSecret was removed, clear any stale PasswordSecretChange
 		// condition left over from a previously configured secret.
 		if meta.FindStatusCondition(role.Status.Conditions, string(apiv1.ConditionPasswordSecretChange)) != nil {
@@ -125,7 +255,7 @@ func (r *DatabaseRoleReconciler) reconcilePasswordCondition(
 	var secret corev1.Secret
 	if err := r.Get(ctx, types.NamespacedName{
 		Namespace: role.Namespace,
-		Name:      role.Spec.PasswordSecret.Name,
+		Name:      secretName,
 	}, &secret); err != nil {
 		// There's no need to fill the operator log with errors
 		// if the secret still doesn't exist.
@@ -135,7 +265,7 @@ func (r *DatabaseRoleReconciler) reconcilePasswordCondition(
 
 		return fmt.Errorf(
 			"while getting secret %q referred by role %q: %w",
-			role.Spec.PasswordSecret.Name,
+			secretName,
 			role.Name,
 			err,
 		)
@@ -226,6 +356,11 @@ func (r *DatabaseRoleReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurren
 			handler.EnqueueRequestsFromMapFunc(r.mapClusterToRoles()),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
+		// Pooler: the service binding Secrets expose the poolers of the cluster
+		Watches(
+			&apiv1.Pooler{},
+			handler.EnqueueRequestsFromMapFunc(r.mapPoolerToRoles()),
+		).
 		Complete(r)
 }
 
@@ -331,12 +466,40 @@ func (r *DatabaseRoleReconciler) mapClusterToRoles() handler.MapFunc {
 	}
 }
 
+// mapPoolerToRoles returns a function that enqueues all DatabaseRoles
+// having service bindings on the cluster of the changed Pooler.
+func (r *DatabaseRoleReconciler) mapPoolerToRoles() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		pooler, ok := obj.(*apiv1.Pooler)
+		if !ok {
+			return nil
+		}
+
+		var roles apiv1.DatabaseRoleList
+		if err := r.List(ctx, &roles, client.InNamespace(pooler.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "while listing roles for pooler", "pooler", pooler.Name)
+			return nil
+		}
+
+		var result []reconcile.Request
+		for i := range roles.Items {
+			role := &roles.Items[i]
+			if role.Spec.ClusterRef.Name == pooler.Spec.Cluster.Name && len(role.Spec.ServiceBindings) > 0 {
+				result = append(result, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: role.Name, Namespace: role.Namespace},
+				})
+			}
+		}
+		return result
+	}
+}
+
 // getRolesUsingSecret returns the namespaced names of roles that reference the
 // given secret as their password secret.
 func getRolesUsingSecret(roles apiv1.DatabaseRoleList, secret *corev1.Secret) (requests []types.NamespacedName) {
 	for i := range roles.Items {
 		role := &roles.Items[i]
-		if role.Spec.PasswordSecret != nil && role.Spec.PasswordSecret.Name == secret.Name {
+		if slices.Contains(role.Spec.GetPasswordSecretNames(), secret.Name) {
 			requests = append(requests,
 				types.NamespacedName{
 					Name:      role.Name,
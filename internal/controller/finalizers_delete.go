@@ -88,12 +88,42 @@ func (r *ClusterReconciler) notifyDeletionToOwnedResources(
 		return err
 	}
 
-	return notifyOwnedResourceDeletion(
+	if err := notifyOwnedResourceDeletion(
 		ctx,
 		r.Client,
 		namespacedName,
 		toSliceWithPointers(roleList.Items),
 		utils.RoleFinalizerName,
+	); err != nil {
+		return err
+	}
+
+	var accessList apiv1.BreakGlassAccessList
+	if err := r.List(ctx, &accessList, client.InNamespace(namespacedName.Namespace)); err != nil {
+		return err
+	}
+
+	if err := notifyOwnedResourceDeletion(
+		ctx,
+		r.Client,
+		namespacedName,
+		toSliceWithPointers(accessList.Items),
+		utils.BreakGlassAccessFinalizerName,
+	); err != nil {
+		return err
+	}
+
+	var slotList apiv1.ReplicationSlotList
+	if err := r.List(ctx, &slotList, client.InNamespace(namespacedName.Namespace)); err != nil {
+		return err
+	}
+
+	return notifyOwnedResourceDeletion(
+		ctx,
+		r.Client,
+		namespacedName,
+		toSliceWithPointers(slotList.Items),
+		utils.ReplicationSlotFinalizerName,
 	)
 }
 
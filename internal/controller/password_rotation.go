@@ -0,0 +1,287 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/sethvargo/go-password/password"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// errPasswordSecretNotRotatable is raised when rotating the password into a
+// Secret that was neither generated by the operator nor opted in
+var errPasswordSecretNotRotatable = errors.New("the password secret is not enabled for rotation")
+
+// passwordRotationRequest is the information needed to rotate the password
+// of a role
+type passwordRotationRequest struct {
+	// role is the role whose password is rotated
+	role *apiv1.RoleConfiguration
+
+	// namespace is the namespace of the password secrets
+	namespace string
+
+	// hostname is the host stored in the password secrets
+	hostname string
+
+	// setOwnership is called on the password secrets created by the operator
+	setOwnership func(secret *corev1.Secret) error
+}
+
+// reconcilePasswordRotation rotates the password of a role when due, and
+// returns the updated rotation state. At the first reconciliation the
+// password secret of the role is only generated when missing, and the
+// rotation starts from there.
+//
+// Every rotation generates a new password for the login not in use,
+// stores it into its secret, and makes it the active one. The previous
+// credentials stay valid until the end of the grace period, which the
+// instance manager applies as the expiration time of the previous login.
+func reconcilePasswordRotation(
+	ctx context.Context,
+	cli client.Client,
+	request passwordRotationRequest,
+	state *apiv1.PasswordRotationState,
+	now time.Time,
+) (*apiv1.PasswordRotationState, error) {
+	contextLogger := log.FromContext(ctx).WithValues("role", request.role.Name)
+	rotation := request.role.PasswordRotation
+
+	if state == nil || state.LastRotationTime == nil {
+		exists, err := passwordSecretExists(ctx, cli, request.namespace, request.role.GetRoleSecretName())
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			contextLogger.Info("Generating the password secret of the role")
+			if err := storeGeneratedPassword(ctx, cli, request, request.role.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		return &apiv1.PasswordRotationState{
+			ActiveLogin:      request.role.Name,
+			LastRotationTime: ptr.To(metav1.NewTime(now)),
+			NextRotationTime: ptr.To(metav1.NewTime(now.Add(rotation.Interval.Duration))),
+		}, nil
+	}
+
+	result := state.DeepCopy()
+	nextRotationTime := state.LastRotationTime.Add(rotation.Interval.Duration)
+	if now.Before(nextRotationTime) {
+		result.NextRotationTime = ptr.To(metav1.NewTime(nextRotationTime))
+		return result, nil
+	}
+
+	nextLogin := request.role.GetPasswordRotationNextLogin()
+	if state.GetActiveLogin(request.role.Name) == nextLogin {
+		nextLogin = request.role.Name
+	}
+
+	contextLogger.Info("Rotating the password of the role", "login", nextLogin)
+	if err := storeGeneratedPassword(ctx, cli, request, nextLogin); errors.Is(err, errPasswordSecretNotRotatable) {
+		// The credentials managed by the user are never overwritten: the
+		// rotation is suspended until the Secret is opted in
+		contextLogger.Info("Suspending the password rotation of the role", "reason", err.Error())
+		result.NextRotationTime = nil
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result.ActiveLogin = nextLogin
+	result.LastRotationTime = ptr.To(metav1.NewTime(now))
+	result.NextRotationTime = ptr.To(metav1.NewTime(now.Add(rotation.Interval.Duration)))
+	result.GracePeriodEnd = ptr.To(metav1.NewTime(now.Add(rotation.GetGracePeriod())))
+	return result, nil
+}
+
+// passwordSecretExists checks whether the passed password secret exists
+func passwordSecretExists(ctx context.Context, cli client.Client, namespace, name string) (bool, error) {
+	var secret corev1.Secret
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret)
+	switch {
+	case apierrs.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("while getting password secret %q: %w", name, err)
+	default:
+		return true, nil
+	}
+}
+
+// storeGeneratedPassword generates a new password for the passed login and
+// stores it, together with the login name, into the secret of the login.
+// The secrets created by the operator are marked for rotation, while the
+// existing ones are only updated when marked, retaining any additional key
+// they contain.
+func storeGeneratedPassword(
+	ctx context.Context,
+	cli client.Client,
+	request passwordRotationRequest,
+	login string,
+) error {
+	generatedPassword, err := password.Generate(64, 10, 0, false, true)
+	if err != nil {
+		return err
+	}
+
+	secretName := request.role.GetPasswordRotationSecretName(login)
+	proposed := specs.CreateSecret(
+		secretName,
+		request.namespace,
+		request.hostname,
+		"*",
+		login,
+		generatedPassword,
+		utils.UserTypeApp,
+	)
+	proposed.Data = make(map[string][]byte, len(proposed.StringData))
+	for key, value := range proposed.StringData {
+		proposed.Data[key] = []byte(value)
+	}
+	proposed.StringData = nil
+	utils.SetPasswordRotationEnabled(&proposed.ObjectMeta)
+
+	var current corev1.Secret
+	err = cli.Get(ctx, client.ObjectKey{Namespace: request.namespace, Name: secretName}, &current)
+	switch {
+	case apierrs.IsNotFound(err):
+		if err := request.setOwnership(proposed); err != nil {
+			return fmt.Errorf("while setting the owner of password secret %q: %w", secretName, err)
+		}
+		if err := cli.Create(ctx, proposed); err != nil {
+			return fmt.Errorf("while creating password secret %q: %w", secretName, err)
+		}
+		return nil
+
+	case err != nil:
+		return fmt.Errorf("while getting password secret %q: %w", secretName, err)
+
+	case !utils.IsPasswordRotationEnabled(&current.ObjectMeta):
+		return fmt.Errorf("%w: %q is missing the %s annotation",
+			errPasswordSecretNotRotatable, secretName, utils.PasswordRotationAnnotationName)
+	}
+
+	updated := current.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = make(map[string][]byte, len(proposed.Data))
+	}
+	maps.Copy(updated.Data, proposed.Data)
+	if updated.Labels == nil {
+		updated.Labels = make(map[string]string)
+	}
+	updated.Labels[utils.WatchedLabelName] = "true"
+	// The generated password is never a pre-hashed one
+	delete(updated.Annotations, utils.PasswordPassthroughAnnotationName)
+	if err := cli.Patch(ctx, updated, client.MergeFrom(&current)); err != nil {
+		return fmt.Errorf("while updating password secret %q: %w", secretName, err)
+	}
+	return nil
+}
+
+// nextPasswordRotation returns the time until the first of the passed
+// password rotations is due, or zero if there is none
+func nextPasswordRotation(states []*apiv1.PasswordRotationState, now time.Time) time.Duration {
+	var result time.Duration
+	for _, state := range states {
+		if state == nil || state.NextRotationTime == nil {
+			continue
+		}
+		after := max(state.NextRotationTime.Sub(now), time.Second)
+		if result == 0 || after < result {
+			result = after
+		}
+	}
+	return result
+}
+
+// reconcileManagedRolesPasswordRotation rotates the password of the managed
+// roles when due, and records the rotation state in the cluster status
+func (r *ClusterReconciler) reconcileManagedRolesPasswordRotation(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) error {
+	rotation := make(map[string]*apiv1.PasswordRotationState)
+	if cluster.ContainsManagedRolesConfiguration() {
+		now := time.Now()
+		for i := range cluster.Spec.Managed.Roles {
+			role := &cluster.Spec.Managed.Roles[i]
+			if role.PasswordRotation == nil || role.Ensure == apiv1.EnsureAbsent {
+				continue
+			}
+
+			state, err := reconcilePasswordRotation(
+				ctx,
+				r.Client,
+				passwordRotationRequest{
+					role:      role,
+					namespace: cluster.Namespace,
+					hostname:  cluster.GetServiceReadWriteName(),
+					setOwnership: func(secret *corev1.Secret) error {
+						cluster.SetInheritedDataAndOwnership(&secret.ObjectMeta)
+						return nil
+					},
+				},
+				cluster.Status.ManagedRolesStatus.PasswordStatus[role.Name].Rotation,
+				now,
+			)
+			if err != nil {
+				return fmt.Errorf("while rotating the password of role %q: %w", role.Name, err)
+			}
+			rotation[role.Name] = state
+		}
+	}
+
+	tx := status.SetPasswordRotation(rotation)
+	updatedCluster := cluster.DeepCopy()
+	tx(updatedCluster)
+	if equality.Semantic.DeepEqual(cluster.Status, updatedCluster.Status) {
+		return nil
+	}
+
+	return status.PatchWithOptimisticLock(ctx, r.Client, cluster, tx)
+}
+
+// getPasswordRotationRequeue returns the time until the first password
+// rotation of the managed roles is due, or zero if there is none
+func getPasswordRotationRequeue(cluster *apiv1.Cluster, now time.Time) time.Duration {
+	states := make([]*apiv1.PasswordRotationState, 0, len(cluster.Status.ManagedRolesStatus.PasswordStatus))
+	for _, state := range cluster.Status.ManagedRolesStatus.PasswordStatus {
+		states = append(states, state.Rotation)
+	}
+	return nextPasswordRotation(states, now)
+}
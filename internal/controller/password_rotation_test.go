@@ -0,0 +1,211 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("password rotation", func() {
+	const namespace = "default"
+
+	var (
+		cli  client.Client
+		role *apiv1.RoleConfiguration
+		now  time.Time
+	)
+
+	newRequest := func() passwordRotationRequest {
+		return passwordRotationRequest{
+			role:         role,
+			namespace:    namespace,
+			hostname:     "cluster-example-rw",
+			setOwnership: func(*corev1.Secret) error { return nil },
+		}
+	}
+
+	getCredentials := func(ctx SpecContext, name string) (string, string) {
+		var secret corev1.Secret
+		Expect(cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret)).To(Succeed())
+		return string(secret.Data["username"]), string(secret.Data["password"])
+	}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).Build()
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		role = &apiv1.RoleConfiguration{
+			Name:           "app",
+			Login:          true,
+			PasswordSecret: &apiv1.LocalObjectReference{Name: "app-password"},
+			PasswordRotation: &apiv1.PasswordRotationConfiguration{
+				Interval:    metav1.Duration{Duration: 30 * 24 * time.Hour},
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		}
+	})
+
+	It("generates the missing password secret of the role at the first reconciliation", func(ctx SpecContext) {
+		state, err := reconcilePasswordRotation(ctx, cli, newRequest(), nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app"))
+		Expect(state.LastRotationTime.Time).To(Equal(now))
+		Expect(state.NextRotationTime.Time).To(Equal(now.Add(30 * 24 * time.Hour)))
+		Expect(state.GracePeriodEnd).To(BeNil())
+
+		username, password := getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app"))
+		Expect(password).To(HaveLen(64))
+	})
+
+	It("keeps the existing password secret at the first reconciliation", func(ctx SpecContext) {
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-password", Namespace: namespace},
+			Data: map[string][]byte{
+				"username": []byte("app"),
+				"password": []byte("secret"),
+			},
+		})).To(Succeed())
+
+		state, err := reconcilePasswordRotation(ctx, cli, newRequest(), nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app"))
+
+		_, password := getCredentials(ctx, "app-password")
+		Expect(password).To(Equal("secret"))
+	})
+
+	It("only rotates the password into the existing secrets opted in", func(ctx SpecContext) {
+		userSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-password", Namespace: namespace},
+			Data: map[string][]byte{
+				"username": []byte("app"),
+				"password": []byte("secret"),
+			},
+		}
+		Expect(cli.Create(ctx, userSecret)).To(Succeed())
+
+		state, err := reconcilePasswordRotation(ctx, cli, newRequest(), nil, now)
+		Expect(err).ToNot(HaveOccurred())
+
+		now = now.Add(31 * 24 * time.Hour)
+		suspended, err := reconcilePasswordRotation(ctx, cli, newRequest(), state, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(suspended.ActiveLogin).To(Equal("app"))
+		Expect(suspended.LastRotationTime).To(Equal(state.LastRotationTime))
+		Expect(suspended.NextRotationTime).To(BeNil())
+
+		username, password := getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app"))
+		Expect(password).To(Equal("secret"))
+
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(userSecret), userSecret)).To(Succeed())
+		utils.SetPasswordRotationEnabled(&userSecret.ObjectMeta)
+		Expect(cli.Update(ctx, userSecret)).To(Succeed())
+
+		state, err = reconcilePasswordRotation(ctx, cli, newRequest(), suspended, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app_next"))
+
+		username, password = getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app_next"))
+		Expect(password).ToNot(Equal("secret"))
+	})
+
+	It("does not rotate the password before the interval elapsed", func(ctx SpecContext) {
+		state := &apiv1.PasswordRotationState{
+			ActiveLogin:      "app",
+			LastRotationTime: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+		}
+
+		result, err := reconcilePasswordRotation(ctx, cli, newRequest(), state, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.ActiveLogin).To(Equal("app"))
+		Expect(result.LastRotationTime).To(Equal(state.LastRotationTime))
+		Expect(result.NextRotationTime.Time).To(Equal(now.Add(30*24*time.Hour - time.Hour)))
+	})
+
+	It("alternates the logins in the password secret with the nextRole strategy", func(ctx SpecContext) {
+		state, err := reconcilePasswordRotation(ctx, cli, newRequest(), nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		_, firstPassword := getCredentials(ctx, "app-password")
+
+		now = now.Add(31 * 24 * time.Hour)
+		state, err = reconcilePasswordRotation(ctx, cli, newRequest(), state, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app_next"))
+		Expect(state.LastRotationTime.Time).To(Equal(now))
+		Expect(state.GracePeriodEnd.Time).To(Equal(now.Add(time.Hour)))
+
+		username, password := getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app_next"))
+		Expect(password).ToNot(Equal(firstPassword))
+
+		now = now.Add(31 * 24 * time.Hour)
+		state, err = reconcilePasswordRotation(ctx, cli, newRequest(), state, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app"))
+
+		username, _ = getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app"))
+	})
+
+	It("rotates the alternate password secret with the alternateSecrets strategy", func(ctx SpecContext) {
+		role.PasswordRotation.Strategy = apiv1.PasswordRotationStrategyAlternateSecrets
+		role.PasswordRotation.AlternatePasswordSecret = &apiv1.LocalObjectReference{Name: "app-next-password"}
+
+		state, err := reconcilePasswordRotation(ctx, cli, newRequest(), nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		_, firstPassword := getCredentials(ctx, "app-password")
+
+		now = now.Add(31 * 24 * time.Hour)
+		state, err = reconcilePasswordRotation(ctx, cli, newRequest(), state, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.ActiveLogin).To(Equal("app_next"))
+
+		username, _ := getCredentials(ctx, "app-next-password")
+		Expect(username).To(Equal("app_next"))
+		username, password := getCredentials(ctx, "app-password")
+		Expect(username).To(Equal("app"))
+		Expect(password).To(Equal(firstPassword))
+	})
+
+	It("computes the time until the first password rotation", func() {
+		states := []*apiv1.PasswordRotationState{
+			nil,
+			{NextRotationTime: ptr.To(metav1.NewTime(now.Add(2 * time.Hour)))},
+			{NextRotationTime: ptr.To(metav1.NewTime(now.Add(time.Hour)))},
+		}
+		Expect(nextPasswordRotation(states, now)).To(Equal(time.Hour))
+		Expect(nextPasswordRotation(nil, now)).To(BeZero())
+	})
+})
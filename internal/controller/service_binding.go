@@ -0,0 +1,340 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// serviceBindingRequest is the information needed to generate the service
+// binding Secrets of a role
+type serviceBindingRequest struct {
+	// cluster is the cluster the role belongs to
+	cluster *apiv1.Cluster
+
+	// role is the role whose service bindings are generated
+	role *apiv1.RoleConfiguration
+
+	// rotation is the password rotation state of the role, if any
+	rotation *apiv1.PasswordRotationState
+
+	// clientCertSecretName is the name of the Secret with the client
+	// certificate of the role, if any
+	clientCertSecretName string
+}
+
+// buildServiceBindingSecrets builds the service binding Secrets of a role.
+// No Secret is built while the password Secret of the role is missing: in
+// that case, nil is returned together with no error.
+func buildServiceBindingSecrets(
+	ctx context.Context,
+	cli client.Client,
+	request serviceBindingRequest,
+) ([]*corev1.Secret, error) {
+	role := request.role
+	if len(role.ServiceBindings) == 0 {
+		return nil, nil
+	}
+
+	// When the password is rotated, the credentials are the ones of the active login
+	username := request.rotation.GetActiveLogin(role.Name)
+	password := ""
+	if secretName := role.GetPasswordRotationSecretName(username); secretName != "" && !role.DisablePassword {
+		secret, err := getOptionalSecret(ctx, cli, request.cluster.Namespace, secretName)
+		if err != nil || secret == nil {
+			return nil, err
+		}
+		if value := string(secret.Data["username"]); value != "" {
+			username = value
+		}
+		password = string(secret.Data["password"])
+	}
+
+	hosts, err := getServiceBindingHosts(ctx, cli, request.cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates, err := getServiceBindingCertificates(ctx, cli, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*corev1.Secret, 0, len(role.ServiceBindings))
+	for _, binding := range role.ServiceBindings {
+		secret := specs.CreateServiceBindingSecret(
+			binding.GetSecretName(request.cluster.Name, role.Name),
+			request.cluster.Namespace,
+			hosts,
+			binding.Database,
+			username,
+			password,
+			certificates,
+		)
+		secret.Labels[utils.ClusterLabelName] = request.cluster.Name
+		secret.Labels[utils.ServiceBindingRoleLabelName] = role.Name
+		result = append(result, secret)
+	}
+	return result, nil
+}
+
+// reconcileManagedRolesServiceBindings generates the service binding
+// Secrets of the managed roles
+func (r *ClusterReconciler) reconcileManagedRolesServiceBindings(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) error {
+	var proposed []*corev1.Secret
+	var pending []string
+	if cluster.ContainsManagedRolesConfiguration() {
+		for i := range cluster.Spec.Managed.Roles {
+			role := &cluster.Spec.Managed.Roles[i]
+			if role.Ensure == apiv1.EnsureAbsent {
+				continue
+			}
+
+			secrets, err := buildServiceBindingSecrets(ctx, r.Client, serviceBindingRequest{
+				cluster:  cluster,
+				role:     role,
+				rotation: cluster.Status.ManagedRolesStatus.PasswordStatus[role.Name].Rotation,
+			})
+			if err != nil {
+				return fmt.Errorf("while building the service bindings of role %q: %w", role.Name, err)
+			}
+			if secrets == nil {
+				pending = append(pending, getServiceBindingSecretNames(cluster.Name, role)...)
+				continue
+			}
+			for _, secret := range secrets {
+				cluster.SetInheritedDataAndOwnership(&secret.ObjectMeta)
+			}
+			proposed = append(proposed, secrets...)
+		}
+	}
+
+	return reconcileServiceBindingSecrets(
+		ctx,
+		r.Client,
+		cluster,
+		cluster.Namespace,
+		proposed,
+		pending,
+		client.MatchingLabels{utils.ClusterLabelName: cluster.Name},
+	)
+}
+
+// getServiceBindingHosts gets the hosts exposed by the service binding
+// Secrets of a cluster: the default services, or the first additional
+// managed service of the same type when a default one is disabled, and the
+// first pooler of each type
+func getServiceBindingHosts(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) (specs.ServiceBindingHosts, error) {
+	hosts := specs.ServiceBindingHosts{
+		ReadWrite: cluster.GetServiceReadWriteName(),
+	}
+	if cluster.IsReadOnlyServiceEnabled() {
+		hosts.ReadOnly = cluster.GetServiceReadOnlyName()
+	} else if cluster.Spec.Managed != nil && cluster.Spec.Managed.Services != nil {
+		for _, service := range cluster.Spec.Managed.Services.Additional {
+			if service.SelectorType == apiv1.ServiceSelectorTypeRO {
+				hosts.ReadOnly = service.ServiceTemplate.ObjectMeta.Name
+				break
+			}
+		}
+	}
+
+	var poolers apiv1.PoolerList
+	if err := cli.List(ctx, &poolers, client.InNamespace(cluster.Namespace)); err != nil {
+		return hosts, fmt.Errorf("while getting the poolers: %w", err)
+	}
+	slices.SortFunc(poolers.Items, func(a, b apiv1.Pooler) int {
+		switch {
+		case a.Name < b.Name:
+			return -1
+		case a.Name > b.Name:
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, pooler := range poolers.Items {
+		if pooler.Spec.Cluster.Name != cluster.Name {
+			continue
+		}
+		switch {
+		case pooler.Spec.Type == apiv1.PoolerTypeRW && hosts.PoolerReadWrite == "":
+			hosts.PoolerReadWrite = pooler.Name
+		case pooler.Spec.Type == apiv1.PoolerTypeRO && hosts.PoolerReadOnly == "":
+			hosts.PoolerReadOnly = pooler.Name
+		}
+	}
+
+	return hosts, nil
+}
+
+// getServiceBindingCertificates gets the certificates exposed by the
+// service binding Secrets of a role: the CA of the server and, when
+// generated, the client certificate of the role
+func getServiceBindingCertificates(
+	ctx context.Context,
+	cli client.Client,
+	request serviceBindingRequest,
+) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+
+	caSecret, err := getOptionalSecret(ctx, cli, request.cluster.Namespace, request.cluster.GetServerCASecretName())
+	if err != nil {
+		return nil, err
+	}
+	if caSecret != nil && len(caSecret.Data[certs.CACertKey]) > 0 {
+		result[certs.CACertKey] = caSecret.Data[certs.CACertKey]
+	}
+
+	if request.clientCertSecretName == "" {
+		return result, nil
+	}
+	certSecret, err := getOptionalSecret(ctx, cli, request.cluster.Namespace, request.clientCertSecretName)
+	if err != nil {
+		return nil, err
+	}
+	if certSecret != nil {
+		for _, key := range []string{certs.TLSCertKey, certs.TLSPrivateKeyKey} {
+			if value := certSecret.Data[key]; len(value) > 0 {
+				result[key] = value
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// reconcileServiceBindingSecrets creates or updates the passed service
+// binding Secrets, and deletes the ones matching the passed selector that
+// are owned by the passed object but neither proposed nor pending. Pending
+// Secrets are the ones that cannot be built yet, and are kept as they are.
+// Secrets with the same name not owned by the object are left untouched.
+func reconcileServiceBindingSecrets(
+	ctx context.Context,
+	cli client.Client,
+	owner metav1.Object,
+	namespace string,
+	proposed []*corev1.Secret,
+	pending []string,
+	selector client.MatchingLabels,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	var secrets corev1.SecretList
+	if err := cli.List(ctx, &secrets, client.InNamespace(namespace), selector,
+		client.HasLabels{utils.ServiceBindingRoleLabelName}); err != nil {
+		return fmt.Errorf("while getting the service binding secrets: %w", err)
+	}
+
+	requested := make(map[string]bool, len(proposed)+len(pending))
+	for _, secret := range proposed {
+		requested[secret.Name] = true
+	}
+	for _, name := range pending {
+		requested[name] = true
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if requested[secret.Name] || !metav1.IsControlledBy(secret, owner) {
+			continue
+		}
+		contextLogger.Info("Deleting service binding secret", "secret", secret.Name)
+		if err := cli.Delete(ctx, secret); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("while deleting service binding secret %q: %w", secret.Name, err)
+		}
+	}
+
+	for _, secret := range proposed {
+		current, err := getOptionalSecret(ctx, cli, namespace, secret.Name)
+		if err != nil {
+			return err
+		}
+
+		if current == nil {
+			contextLogger.Info("Creating service binding secret", "secret", secret.Name)
+			if err := cli.Create(ctx, secret); err != nil {
+				return fmt.Errorf("while creating service binding secret %q: %w", secret.Name, err)
+			}
+			continue
+		}
+
+		if !metav1.IsControlledBy(current, owner) {
+			contextLogger.Warning("Service binding secret exists and is not owned by the operator, skipping",
+				"secret", secret.Name)
+			continue
+		}
+
+		if equality.Semantic.DeepEqual(current.Data, secret.Data) {
+			continue
+		}
+		updated := current.DeepCopy()
+		updated.Data = secret.Data
+		if err := cli.Patch(ctx, updated, client.MergeFrom(current)); err != nil {
+			return fmt.Errorf("while updating service binding secret %q: %w", secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getServiceBindingSecretNames gets the names of the service binding
+// Secrets of a role
+func getServiceBindingSecretNames(clusterName string, role *apiv1.RoleConfiguration) []string {
+	result := make([]string, 0, len(role.ServiceBindings))
+	for i := range role.ServiceBindings {
+		result = append(result, role.ServiceBindings[i].GetSecretName(clusterName, role.Name))
+	}
+	return result
+}
+
+// getOptionalSecret gets the passed Secret, returning nil if it does not exist
+func getOptionalSecret(ctx context.Context, cli client.Client, namespace, name string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("while getting secret %q: %w", name, err)
+	default:
+		return &secret, nil
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("service binding secrets", func() {
+	const namespace = "default"
+
+	var (
+		cli        client.Client
+		cluster    *apiv1.Cluster
+		reconciler *ClusterReconciler
+	)
+
+	newSecret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}
+	}
+
+	getSecret := func(ctx SpecContext, name string) (*corev1.Secret, error) {
+		var secret corev1.Secret
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret)
+		return &secret, err
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiv1.SchemeGroupVersion.String(),
+				Kind:       apiv1.ClusterKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace, UID: "cluster-uid"},
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:           "app_user",
+							Login:          true,
+							PasswordSecret: &apiv1.LocalObjectReference{Name: "app-password"},
+							ServiceBindings: []apiv1.ServiceBinding{
+								{Database: "app"},
+								{Database: "reports", SecretName: "reports-binding"},
+							},
+						},
+					},
+				},
+			},
+		}
+		pooler := &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{Name: "pooler-example-rw", Namespace: namespace},
+			Spec: apiv1.PoolerSpec{
+				Cluster: apiv1.LocalObjectReference{Name: cluster.Name},
+				Type:    apiv1.PoolerTypeRW,
+			},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(
+				cluster,
+				pooler,
+				newSecret("app-password", map[string][]byte{
+					"username": []byte("app_user"),
+					"password": []byte("secret"),
+				}),
+				newSecret(cluster.GetServerCASecretName(), map[string][]byte{"ca.crt": []byte("ca")}),
+			).
+			Build()
+		reconciler = &ClusterReconciler{Client: cli}
+	})
+
+	It("generates a secret for every binding of the managed roles", func(ctx SpecContext) {
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+
+		secret, err := getSecret(ctx, "cluster-example-app-user-app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1.IsControlledBy(secret, cluster)).To(BeTrue())
+		Expect(secret.Labels).To(HaveKeyWithValue(utils.ClusterLabelName, cluster.Name))
+		Expect(secret.Labels).To(HaveKeyWithValue(utils.ServiceBindingRoleLabelName, "app_user"))
+		Expect(secret.Data).To(HaveKeyWithValue("host", []byte("cluster-example-rw")))
+		Expect(secret.Data).To(HaveKeyWithValue("ro-host", []byte("cluster-example-ro")))
+		Expect(secret.Data).To(HaveKeyWithValue("pooler-host", []byte("pooler-example-rw")))
+		Expect(secret.Data).To(HaveKeyWithValue("database", []byte("app")))
+		Expect(secret.Data).To(HaveKeyWithValue("password", []byte("secret")))
+		Expect(secret.Data).To(HaveKeyWithValue("ca.crt", []byte("ca")))
+
+		secret, err = getSecret(ctx, "reports-binding")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue("database", []byte("reports")))
+	})
+
+	It("keeps the secrets in sync with the password of the role", func(ctx SpecContext) {
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+
+		password, err := getSecret(ctx, "app-password")
+		Expect(err).ToNot(HaveOccurred())
+		password.Data["password"] = []byte("changed")
+		Expect(cli.Update(ctx, password)).To(Succeed())
+
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+		secret, err := getSecret(ctx, "reports-binding")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue("password", []byte("changed")))
+	})
+
+	It("uses the credentials of the active login of a rotated role", func(ctx SpecContext) {
+		role := &cluster.Spec.Managed.Roles[0]
+		role.PasswordRotation = &apiv1.PasswordRotationConfiguration{
+			Strategy:                apiv1.PasswordRotationStrategyAlternateSecrets,
+			AlternatePasswordSecret: &apiv1.LocalObjectReference{Name: "app-password-next"},
+		}
+		cluster.Status.ManagedRolesStatus.PasswordStatus = map[string]apiv1.PasswordState{
+			role.Name: {Rotation: &apiv1.PasswordRotationState{ActiveLogin: role.GetPasswordRotationNextLogin()}},
+		}
+		Expect(cli.Create(ctx, newSecret("app-password-next", map[string][]byte{
+			"username": []byte("app_user_next"),
+			"password": []byte("next"),
+		}))).To(Succeed())
+
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+		secret, err := getSecret(ctx, "reports-binding")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue("username", []byte("app_user_next")))
+		Expect(secret.Data).To(HaveKeyWithValue("password", []byte("next")))
+	})
+
+	It("waits for the password secret of the role and removes the stale bindings", func(ctx SpecContext) {
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+
+		// A missing password secret leaves the existing bindings untouched
+		password, err := getSecret(ctx, "app-password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cli.Delete(ctx, password)).To(Succeed())
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+		_, err = getSecret(ctx, "reports-binding")
+		Expect(err).ToNot(HaveOccurred())
+
+		// Removing the bindings deletes the owned secrets only
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign",
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.ClusterLabelName:            cluster.Name,
+					utils.ServiceBindingRoleLabelName: "app_user",
+				},
+			},
+		})).To(Succeed())
+		cluster.Spec.Managed.Roles[0].ServiceBindings = nil
+		Expect(reconciler.reconcileManagedRolesServiceBindings(ctx, cluster)).To(Succeed())
+		_, err = getSecret(ctx, "reports-binding")
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+		_, err = getSecret(ctx, "foreign")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
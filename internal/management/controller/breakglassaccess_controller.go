@@ -0,0 +1,352 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/controller/roles"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// BreakGlassAccessReconciler reconciles a BreakGlassAccess object,
+// granting the role memberships when the access starts and revoking
+// them when it expires. The pg_hba rule of an active access is collected
+// by the operator in the cluster status, like the other pg_hba rules
+type BreakGlassAccessReconciler struct {
+	client.Client
+
+	recorder record.EventRecorder
+	instance instanceInterface
+	now      func() time.Time
+}
+
+// breakGlassAccessReconciliationInterval is the time between the
+// break-glass access reconciliation loop failures
+const breakGlassAccessReconciliationInterval = 30 * time.Second
+
+// errBreakGlassAccessOnReplicaCluster is raised when a break-glass access
+// granting role memberships targets a replica cluster
+var errBreakGlassAccessOnReplicaCluster = errors.New(
+	"role memberships cannot be granted on a replica cluster")
+
+// Reconcile is the break-glass access reconciliation loop
+func (r *BreakGlassAccessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	var access apiv1.BreakGlassAccess
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+	}, &access); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Accesses for other clusters are handled by their own instance managers
+	if access.Spec.ClusterRef.Name != r.instance.GetClusterName() {
+		return ctrl.Result{}, nil
+	}
+
+	// Expired accesses have nothing left to do, unless they still hold the finalizer
+	if access.Status.Phase == apiv1.BreakGlassAccessPhaseExpired &&
+		!controllerutil.ContainsFinalizer(&access, utils.BreakGlassAccessFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := getClusterFromInstance(ctx, r.Client, r.instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			contextLogger.Debug("Could not find Cluster")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("could not fetch Cluster: %w", err)
+	}
+
+	// Only the primary can change the role memberships
+	if cluster.Status.CurrentPrimary != r.instance.GetPodName() ||
+		cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return ctrl.Result{RequeueAfter: breakGlassAccessReconciliationInterval}, nil
+	}
+
+	if !access.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &access)
+	}
+
+	now := r.now()
+	switch {
+	case access.Status.Phase == apiv1.BreakGlassAccessPhaseExpired:
+		return r.releaseFinalizer(ctx, &access)
+
+	case access.Status.Phase == apiv1.BreakGlassAccessPhaseActive && !access.IsActive(now):
+		return r.expire(ctx, &access)
+
+	case access.Status.Phase == apiv1.BreakGlassAccessPhaseActive:
+		return ctrl.Result{RequeueAfter: access.Status.ExpirationTime.Sub(now)}, nil
+	}
+
+	if len(access.Spec.InRoles) > 0 && cluster.IsReplica() {
+		return r.failedReconciliation(ctx, &access, errBreakGlassAccessOnReplicaCluster)
+	}
+
+	return r.grant(ctx, &access, now)
+}
+
+// grant starts the access, granting the role memberships
+func (r *BreakGlassAccessReconciler) grant(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+	now time.Time,
+) (ctrl.Result, error) {
+	if controllerutil.AddFinalizer(access, utils.BreakGlassAccessFinalizerName) {
+		if err := r.Update(ctx, access); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The memberships recorded by a previous attempt may have already been
+	// granted, and must be revoked even if the role now appears to have them
+	grantedRoles := slices.Clone(access.Status.GrantedRoles)
+	if len(access.Spec.InRoles) > 0 {
+		db, err := r.instance.GetSuperUserDB()
+		if err != nil {
+			return r.failedReconciliation(ctx, access, fmt.Errorf(
+				"while connecting to the database: %w", err))
+		}
+
+		dbRole := roles.DatabaseRole{Name: access.Spec.Role}
+		parentRoles, err := roles.GetParentRoles(ctx, db, dbRole)
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.failedReconciliation(ctx, access, fmt.Errorf(
+				"role %q does not exist", access.Spec.Role))
+		}
+		if err != nil {
+			return r.failedReconciliation(ctx, access, err)
+		}
+
+		var toGrant []string
+		for _, inRole := range access.Spec.InRoles {
+			if slices.Contains(parentRoles, inRole) || slices.Contains(toGrant, inRole) {
+				continue
+			}
+			toGrant = append(toGrant, inRole)
+			if !slices.Contains(grantedRoles, inRole) {
+				grantedRoles = append(grantedRoles, inRole)
+			}
+		}
+
+		// Record the memberships before granting them, so that they are
+		// revoked even if the access can't be marked as active afterwards
+		if !slices.Equal(grantedRoles, access.Status.GrantedRoles) {
+			oldAccess := access.DeepCopy()
+			access.Status.GrantedRoles = grantedRoles
+			if err := r.Client.Status().Patch(ctx, access, client.MergeFrom(oldAccess)); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		if err := roles.UpdateMembership(ctx, db, dbRole, toGrant, nil); err != nil {
+			return r.failedReconciliation(ctx, access, err)
+		}
+	}
+
+	oldAccess := access.DeepCopy()
+	access.SetAsReady()
+	access.Status.Phase = apiv1.BreakGlassAccessPhaseActive
+	access.Status.GrantTime = &metav1.Time{Time: now}
+	access.Status.ExpirationTime = &metav1.Time{Time: now.Add(access.Spec.Duration.Duration)}
+	access.Status.GrantedRoles = grantedRoles
+	if err := r.Client.Status().Patch(ctx, access, client.MergeFrom(oldAccess)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.recorder.Eventf(access, "Normal", "Granted",
+		"Granted break-glass access to role %q until %s%s",
+		access.Spec.Role,
+		access.Status.ExpirationTime.Format(time.RFC3339),
+		describeGrantedRoles(grantedRoles))
+
+	return ctrl.Result{RequeueAfter: access.Spec.Duration.Duration}, nil
+}
+
+// expire ends an expired access, revoking the role memberships
+func (r *BreakGlassAccessReconciler) expire(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+) (ctrl.Result, error) {
+	if err := r.revokeGrantedRoles(ctx, access); err != nil {
+		return r.failedReconciliation(ctx, access, err)
+	}
+
+	oldAccess := access.DeepCopy()
+	access.SetAsReady()
+	access.Status.Phase = apiv1.BreakGlassAccessPhaseExpired
+	access.Status.GrantedRoles = nil
+	if err := r.Client.Status().Patch(ctx, access, client.MergeFrom(oldAccess)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.recorder.Eventf(access, "Normal", "Revoked",
+		"Revoked the expired break-glass access of role %q%s",
+		access.Spec.Role,
+		describeGrantedRoles(oldAccess.Status.GrantedRoles))
+
+	return r.releaseFinalizer(ctx, access)
+}
+
+// handleDeletion revokes an access that is deleted before expiring and
+// then releases the finalizer
+func (r *BreakGlassAccessReconciler) handleDeletion(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(access, utils.BreakGlassAccessFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if access.Status.Phase == apiv1.BreakGlassAccessPhaseActive || len(access.Status.GrantedRoles) > 0 {
+		if err := r.revokeGrantedRoles(ctx, access); err != nil {
+			return r.failedReconciliation(ctx, access, err)
+		}
+
+		r.recorder.Eventf(access, "Normal", "Revoked",
+			"Revoked the deleted break-glass access of role %q%s",
+			access.Spec.Role,
+			describeGrantedRoles(access.Status.GrantedRoles))
+	}
+
+	return r.releaseFinalizer(ctx, access)
+}
+
+// releaseFinalizer removes the finalizer of the access, if present
+func (r *BreakGlassAccessReconciler) releaseFinalizer(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+) (ctrl.Result, error) {
+	if controllerutil.RemoveFinalizer(access, utils.BreakGlassAccessFinalizerName) {
+		if err := r.Update(ctx, access); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// revokeGrantedRoles revokes the role memberships granted by the access
+// that the role still has
+func (r *BreakGlassAccessReconciler) revokeGrantedRoles(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+) error {
+	if len(access.Status.GrantedRoles) == 0 {
+		return nil
+	}
+
+	db, err := r.instance.GetSuperUserDB()
+	if err != nil {
+		return fmt.Errorf("while connecting to the database: %w", err)
+	}
+
+	dbRole := roles.DatabaseRole{Name: access.Spec.Role}
+	parentRoles, err := roles.GetParentRoles(ctx, db, dbRole)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The role has been dropped, and its memberships with it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var toRevoke []string
+	for _, grantedRole := range access.Status.GrantedRoles {
+		if slices.Contains(parentRoles, grantedRole) {
+			toRevoke = append(toRevoke, grantedRole)
+		}
+	}
+
+	return roles.UpdateMembership(ctx, db, dbRole, nil, toRevoke)
+}
+
+// failedReconciliation marks the reconciliation as failed and logs the corresponding error
+func (r *BreakGlassAccessReconciler) failedReconciliation(
+	ctx context.Context,
+	access *apiv1.BreakGlassAccess,
+	err error,
+) (ctrl.Result, error) {
+	oldAccess := access.DeepCopy()
+	access.SetAsFailed(err)
+
+	if patchErr := r.Client.Status().Patch(ctx, access, client.MergeFrom(oldAccess)); patchErr != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"while setting the failed status: %w, original error: %w", patchErr, err)
+	}
+
+	return ctrl.Result{
+		RequeueAfter: breakGlassAccessReconciliationInterval,
+	}, nil
+}
+
+// describeGrantedRoles describes the role memberships in the events
+func describeGrantedRoles(grantedRoles []string) string {
+	if len(grantedRoles) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (memberships: %s)", strings.Join(grantedRoles, ", "))
+}
+
+// NewBreakGlassAccessReconciler creates a new break-glass access reconciler
+func NewBreakGlassAccessReconciler(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *BreakGlassAccessReconciler {
+	return &BreakGlassAccessReconciler{
+		Client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor("instance-break-glass-access"), //nolint:staticcheck
+		instance: instance,
+		now:      time.Now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BreakGlassAccessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.BreakGlassAccess{}).
+		Named("instance-break-glass-access").
+		Complete(r)
+}
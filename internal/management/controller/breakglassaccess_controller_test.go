@@ -0,0 +1,296 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const parentRolesQuery = `SELECT mem.inroles`
+
+func newTestBreakGlassAccess() *apiv1.BreakGlassAccess {
+	return &apiv1.BreakGlassAccess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "incident-42",
+			Namespace:  testNamespace,
+			Generation: 1,
+		},
+		Spec: apiv1.BreakGlassAccessSpec{
+			ClusterRef: corev1.LocalObjectReference{Name: testClusterName},
+			Role:       "oncall",
+			HBARule: &apiv1.HBARule{
+				Address: "10.0.0.0/8",
+				Method:  "scram-sha-256",
+			},
+			InRoles:  []string{"pg_monitor", "admin"},
+			Duration: metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+var _ = Describe("BreakGlassAccess reconciler", func() {
+	var (
+		db       *sql.DB
+		dbMock   sqlmock.Sqlmock
+		cluster  *apiv1.Cluster
+		recorder *record.FakeRecorder
+		now      time.Time
+		funcs    interceptor.Funcs
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		cluster = newTestCluster()
+		recorder = record.NewFakeRecorder(10)
+		now = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+		funcs = interceptor.Funcs{}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	reconcilerFor := func(access *apiv1.BreakGlassAccess) *BreakGlassAccessReconciler {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, access).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.BreakGlassAccess{}).
+			WithInterceptorFuncs(funcs).
+			Build()
+		return &BreakGlassAccessReconciler{
+			Client:   fakeClient,
+			recorder: recorder,
+			instance: &fakeRoleInstance{db: db},
+			now:      func() time.Time { return now },
+		}
+	}
+
+	reconcile := func(ctx context.Context, r *BreakGlassAccessReconciler, access *apiv1.BreakGlassAccess) ctrl.Result {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(access)})
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	It("grants the missing memberships and requeues at expiry", func(ctx SpecContext) {
+		access := newTestBreakGlassAccess()
+		r := reconcilerFor(access)
+
+		dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+			WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"pg_monitor"}`)))
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`GRANT "admin" TO "oncall"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectCommit()
+
+		Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+		var got apiv1.BreakGlassAccess
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Finalizers).To(ContainElement(utils.BreakGlassAccessFinalizerName))
+		Expect(got.Status.Phase).To(Equal(apiv1.BreakGlassAccessPhaseActive))
+		Expect(got.Status.Applied).To(HaveValue(BeTrue()))
+		Expect(got.Status.GrantTime.Time).To(BeTemporally("==", now))
+		Expect(got.Status.ExpirationTime.Time).To(BeTemporally("==", now.Add(time.Hour)))
+		Expect(got.Status.GrantedRoles).To(Equal([]string{"admin"}))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Granted")))
+	})
+
+	It("keeps the granted memberships when the access can't be marked as active", func(ctx SpecContext) {
+		failActivation := true
+		funcs.SubResourcePatch = func(
+			ctx context.Context,
+			c client.Client,
+			subResourceName string,
+			obj client.Object,
+			patch client.Patch,
+			opts ...client.SubResourcePatchOption,
+		) error {
+			if obj.(*apiv1.BreakGlassAccess).Status.Phase == apiv1.BreakGlassAccessPhaseActive && failActivation {
+				failActivation = false
+				return apierrors.NewConflict(apiv1.SchemeGroupVersion.WithResource("breakglassaccesses").GroupResource(),
+					obj.GetName(), nil)
+			}
+			return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+		}
+		access := newTestBreakGlassAccess()
+		r := reconcilerFor(access)
+
+		dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+			WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"pg_monitor"}`)))
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`GRANT "admin" TO "oncall"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectCommit()
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(access)})
+		Expect(err).To(HaveOccurred())
+
+		var got apiv1.BreakGlassAccess
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Status.Phase).To(BeEmpty())
+		Expect(got.Status.GrantedRoles).To(Equal([]string{"admin"}))
+
+		// The retry finds the membership already granted by the first attempt
+		dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+			WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"pg_monitor","admin"}`)))
+
+		Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Status.Phase).To(Equal(apiv1.BreakGlassAccessPhaseActive))
+		Expect(got.Status.GrantedRoles).To(Equal([]string{"admin"}))
+	})
+
+	It("fails when the role doesn't exist", func(ctx SpecContext) {
+		access := newTestBreakGlassAccess()
+		r := reconcilerFor(access)
+
+		dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+			WillReturnRows(sqlmock.NewRows([]string{"inroles"}))
+
+		Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{RequeueAfter: breakGlassAccessReconciliationInterval}))
+
+		var got apiv1.BreakGlassAccess
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Status.Phase).To(BeEmpty())
+		Expect(got.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(got.Status.Message).To(ContainSubstring(`role "oncall" does not exist`))
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("refuses to grant memberships on a replica cluster", func(ctx SpecContext) {
+		makeReplica(cluster)
+		access := newTestBreakGlassAccess()
+		r := reconcilerFor(access)
+
+		reconcile(ctx, r, access)
+
+		var got apiv1.BreakGlassAccess
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Status.Message).To(ContainSubstring("replica cluster"))
+	})
+
+	It("waits on the instances that are not the primary", func(ctx SpecContext) {
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+		cluster.Status.TargetPrimary = "cluster-example-2"
+		access := newTestBreakGlassAccess()
+		r := reconcilerFor(access)
+
+		Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{RequeueAfter: breakGlassAccessReconciliationInterval}))
+
+		var got apiv1.BreakGlassAccess
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+		Expect(got.Status.Phase).To(BeEmpty())
+	})
+
+	When("the access has been granted", func() {
+		var access *apiv1.BreakGlassAccess
+
+		BeforeEach(func() {
+			access = newTestBreakGlassAccess()
+			access.Finalizers = []string{utils.BreakGlassAccessFinalizerName}
+			access.Status = apiv1.BreakGlassAccessStatus{
+				Phase:          apiv1.BreakGlassAccessPhaseActive,
+				GrantTime:      &metav1.Time{Time: now.Add(-time.Hour)},
+				ExpirationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+				GrantedRoles:   []string{"admin"},
+			}
+		})
+
+		It("requeues until the expiration time", func(ctx SpecContext) {
+			access.Status.ExpirationTime = &metav1.Time{Time: now.Add(10 * time.Minute)}
+			r := reconcilerFor(access)
+
+			Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Minute}))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("revokes the granted memberships at expiry", func(ctx SpecContext) {
+			r := reconcilerFor(access)
+
+			dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+				WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"pg_monitor","admin"}`)))
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec(`REVOKE "admin" FROM "oncall"`).WillReturnResult(sqlmock.NewResult(0, 0))
+			dbMock.ExpectCommit()
+
+			Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{}))
+
+			var got apiv1.BreakGlassAccess
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+			Expect(got.Finalizers).To(BeEmpty())
+			Expect(got.Status.Phase).To(Equal(apiv1.BreakGlassAccessPhaseExpired))
+			Expect(got.Status.GrantedRoles).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Revoked")))
+		})
+
+		It("doesn't revoke the memberships the role no longer has", func(ctx SpecContext) {
+			r := reconcilerFor(access)
+
+			dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+				WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"pg_monitor"}`)))
+
+			reconcile(ctx, r, access)
+
+			var got apiv1.BreakGlassAccess
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(access), &got)).To(Succeed())
+			Expect(got.Status.Phase).To(Equal(apiv1.BreakGlassAccessPhaseExpired))
+		})
+
+		It("revokes the memberships when deleted before expiring", func(ctx SpecContext) {
+			access.Status.ExpirationTime = &metav1.Time{Time: now.Add(10 * time.Minute)}
+			deletionTime := metav1.NewTime(now)
+			access.DeletionTimestamp = &deletionTime
+			r := reconcilerFor(access)
+
+			dbMock.ExpectQuery(parentRolesQuery).WithArgs("oncall").
+				WillReturnRows(sqlmock.NewRows([]string{"inroles"}).AddRow([]byte(`{"admin"}`)))
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec(`REVOKE "admin" FROM "oncall"`).WillReturnResult(sqlmock.NewResult(0, 0))
+			dbMock.ExpectCommit()
+
+			Expect(reconcile(ctx, r, access)).To(Equal(ctrl.Result{}))
+
+			var got apiv1.BreakGlassAccess
+			err := r.Get(ctx, client.ObjectKeyFromObject(access), &got)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Revoked")))
+		})
+	})
+})
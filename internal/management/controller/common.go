@@ -144,13 +144,22 @@ type markableAsReady interface {
 	SetAsReady()
 }
 
-// markAsReady marks the reconciliation as succeeded inside the resource
+// markAsReady marks the reconciliation as succeeded inside the resource.
+// The state of the resource in PostgreSQL now matches its specification,
+// so the drift previously detected, if any, is cleared.
 func markAsReady(
 	ctx context.Context,
 	cli client.Client,
 	resource markableAsReady,
 ) error {
 	resource.SetAsReady()
+	if detectable, ok := resource.(driftDetectable); ok {
+		if drift := detectable.GetDriftStatus(); drift != nil && len(drift.Differences) > 0 {
+			updatedDrift := drift.DeepCopy()
+			updatedDrift.Differences = nil
+			detectable.SetDriftStatus(updatedDrift)
+		}
+	}
 	return cli.Status().Update(ctx, resource)
 }
 
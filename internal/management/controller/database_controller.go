@@ -23,10 +23,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +42,7 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/webhook/guard"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	pgpostgres "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -88,6 +94,14 @@ var serverObjectManager = databaseObjectManager[apiv1.ServerSpec, serverInfo]{
 	drop:   dropDatabaseForeignServer,
 }
 
+// userMappingObjectManager is the manager of the user mappings
+var userMappingObjectManager = databaseObjectManager[userMapping, userMappingInfo]{
+	get:    getDatabaseUserMappingInfo,
+	create: createDatabaseUserMapping,
+	update: updateDatabaseUserMapping,
+	drop:   dropDatabaseUserMapping,
+}
+
 // databaseReconciliationInterval is the time between the
 // database reconciliation loop failures
 const databaseReconciliationInterval = 30 * time.Second
@@ -149,9 +163,43 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		// evaluate the database again after the promotion.
 		result, proceed, err := handleReplicaRoleTransition(
 			ctx, r.Client, r.instance, cluster, &database, databaseReconciliationInterval)
-		if err != nil || !proceed {
+		if err != nil {
 			return result, err
 		}
+
+		// ...or the Secrets used by the user mappings changed. The instance
+		// manager cannot watch them, so the primary checks them periodically.
+		if !proceed && database.GetDeletionTimestamp().IsZero() && !cluster.IsReplica() &&
+			cluster.Status.CurrentPrimary == r.instance.GetPodName() &&
+			len(database.GetUserMappingSecretNames()) > 0 {
+			if proceed, err = r.hasUserMappingSecretChanges(ctx, &database); err != nil {
+				return ctrl.Result{}, err
+			}
+			result.RequeueAfter = databaseReconciliationInterval
+		}
+
+		// ...or the catalog drifted from the spec and the drift policy
+		// asks to correct it.
+		if !proceed {
+			var driftResult ctrl.Result
+			driftResult, proceed, err = handleDriftDetection(
+				ctx, r.Client, r.instance, cluster, &database,
+				func(ctx context.Context) ([]string, error) {
+					db, err := r.getTargetDB(database.Spec.Name)
+					if err != nil {
+						return nil, fmt.Errorf("while connecting to the database %q: %w", database.Spec.Name, err)
+					}
+					return detectDatabaseDrift(ctx, db, &database)
+				},
+				time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, driftResult)
+		}
+		if !proceed {
+			return result, nil
+		}
 	}
 
 	contextLogger.Info("Reconciling database")
@@ -304,6 +352,10 @@ func (r *DatabaseReconciler) reconcileDatabaseResource(ctx context.Context, obj
 		return err
 	}
 
+	if err := reconcileDatabaseParameters(ctx, db, obj); err != nil {
+		return err
+	}
+
 	if err := r.reconcileDatabaseObjects(ctx, obj); err != nil {
 		return err
 	}
@@ -328,6 +380,21 @@ func (r *DatabaseReconciler) reconcileDatabaseResource(ctx context.Context, obj
 			return ErrFailedDatabaseObjectReconciliation
 		}
 	}
+	for _, status := range obj.Status.Parameters {
+		if !status.Applied {
+			return ErrFailedDatabaseObjectReconciliation
+		}
+	}
+	for _, status := range obj.Status.Privileges {
+		if !status.Applied {
+			return ErrFailedDatabaseObjectReconciliation
+		}
+	}
+	for _, status := range obj.Status.DefaultPrivileges {
+		if !status.Applied {
+			return ErrFailedDatabaseObjectReconciliation
+		}
+	}
 
 	return nil
 }
@@ -340,6 +407,8 @@ func (r *DatabaseReconciler) reconcileDatabaseObjects(
 	objectCount += len(obj.Spec.Extensions)
 	objectCount += len(obj.Spec.FDWs)
 	objectCount += len(obj.Spec.Servers)
+	objectCount += len(obj.Spec.Privileges)
+	objectCount += len(obj.Spec.DefaultPrivileges)
 
 	if objectCount == 0 {
 		return nil
@@ -353,11 +422,99 @@ func (r *DatabaseReconciler) reconcileDatabaseObjects(
 	obj.Status.Schemas = schemaObjectManager.reconcileList(ctx, db, obj.Spec.Schemas)
 	obj.Status.Extensions = extensionObjectManager.reconcileList(ctx, db, obj.Spec.Extensions)
 	obj.Status.FDWs = fdwObjectManager.reconcileList(ctx, db, obj.Spec.FDWs)
-	obj.Status.Servers = serverObjectManager.reconcileList(ctx, db, obj.Spec.Servers)
+	obj.Status.Servers = r.reconcileForeignServers(ctx, db, obj)
+	privilegeObjectManager := newPrivilegeObjectManager(obj.Spec.Name)
+	obj.Status.Privileges = privilegeObjectManager.reconcileList(ctx, db, obj.Spec.Privileges)
+	obj.Status.DefaultPrivileges = defaultPrivilegeObjectManager.reconcileList(ctx, db, obj.Spec.DefaultPrivileges)
 
 	return nil
 }
 
+// reconcileForeignServers reconciles the foreign servers and their user
+// mappings, recording the resource versions of the Secrets used by the
+// user mappings. The user mappings of the servers to be removed are dropped
+// before them, the other ones are reconciled after the servers.
+func (r *DatabaseReconciler) reconcileForeignServers(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Database,
+) []apiv1.DatabaseObjectStatus {
+	secrets := make(map[string]*corev1.Secret)
+	getSecret := func(name string) (*corev1.Secret, error) {
+		if secret, found := secrets[name]; found {
+			return secret, nil
+		}
+		var secret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: obj.Namespace, Name: name}, &secret); err != nil {
+			return nil, err
+		}
+		secrets[name] = &secret
+		return &secret, nil
+	}
+
+	var droppedMappings, mappings []userMapping
+	for _, server := range obj.Spec.Servers {
+		for _, spec := range server.UserMappings {
+			mapping := userMapping{UserMappingSpec: spec, server: server.Name}
+			if server.Ensure == apiv1.EnsureAbsent {
+				mapping.Ensure = apiv1.EnsureAbsent
+				droppedMappings = append(droppedMappings, mapping)
+				continue
+			}
+
+			if spec.PasswordSecret != nil && spec.Ensure != apiv1.EnsureAbsent {
+				secret, err := getSecret(spec.PasswordSecret.Name)
+				if err != nil {
+					mapping.passwordErr = fmt.Errorf("while getting the password secret %q: %w",
+						spec.PasswordSecret.Name, err)
+				} else if !apiv1.IsUserMappingSecret(secret) {
+					mapping.passwordErr = fmt.Errorf("the password secret %q must be labeled with %s=true",
+						spec.PasswordSecret.Name, utils.UserMappingSecretLabelName)
+				} else if value, ok := secret.Data[spec.PasswordSecret.Key]; !ok {
+					mapping.passwordErr = fmt.Errorf("missing key %q in the password secret %q",
+						spec.PasswordSecret.Key, spec.PasswordSecret.Name)
+				} else {
+					mapping.password = ptr.To(string(value))
+				}
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	result := userMappingObjectManager.reconcileList(ctx, db, droppedMappings)
+	result = append(result, serverObjectManager.reconcileList(ctx, db, obj.Spec.Servers)...)
+	result = append(result, userMappingObjectManager.reconcileList(ctx, db, mappings)...)
+
+	obj.Status.SecretResourceVersions = nil
+	if len(secrets) > 0 {
+		obj.Status.SecretResourceVersions = make(map[string]string, len(secrets))
+		for name, secret := range secrets {
+			obj.Status.SecretResourceVersions[name] = secret.ResourceVersion
+		}
+	}
+
+	return result
+}
+
+// hasUserMappingSecretChanges checks whether the Secrets used by the user
+// mappings changed since the last reconciliation
+func (r *DatabaseReconciler) hasUserMappingSecretChanges(ctx context.Context, obj *apiv1.Database) (bool, error) {
+	for _, name := range obj.GetUserMappingSecretNames() {
+		var secret corev1.Secret
+		err := r.Get(ctx, client.ObjectKey{Namespace: obj.Namespace, Name: name}, &secret)
+		if apierrs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("while getting the password secret %q: %w", name, err)
+		}
+		if obj.Status.SecretResourceVersions[name] != secret.ResourceVersion {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (r *DatabaseReconciler) reconcilePostgresDatabase(ctx context.Context, db *sql.DB, obj *apiv1.Database) error {
 	dbExists, err := detectDatabase(ctx, db, obj)
 	if err != nil {
@@ -370,3 +527,53 @@ func (r *DatabaseReconciler) reconcilePostgresDatabase(ctx context.Context, db *
 
 	return createDatabase(ctx, db, obj)
 }
+
+// reconcileDatabaseParameters applies the database-level configuration
+// parameters, resetting the ones previously managed that have been removed
+// from the spec, and records the outcome in the status
+func reconcileDatabaseParameters(ctx context.Context, db *sql.DB, obj *apiv1.Database) error {
+	if len(obj.Spec.Parameters) == 0 && len(obj.Status.Parameters) == 0 {
+		return nil
+	}
+
+	current, err := getDatabaseParameters(ctx, db, obj)
+	if err != nil {
+		return err
+	}
+
+	previouslyManaged := make([]string, 0, len(obj.Status.Parameters))
+	for _, status := range obj.Status.Parameters {
+		previouslyManaged = append(previouslyManaged, status.Name)
+	}
+	toSet, toReset := pgpostgres.ParametersDiff(obj.Spec.Parameters, current, previouslyManaged)
+
+	statuses := make([]apiv1.DatabaseObjectStatus, 0, len(obj.Spec.Parameters))
+	for _, name := range slices.Sorted(maps.Keys(obj.Spec.Parameters)) {
+		status := apiv1.DatabaseObjectStatus{Name: name, Applied: true}
+		if value, found := toSet[name]; found {
+			if err := setDatabaseParameter(ctx, db, obj, name, value); err != nil {
+				status.Applied = false
+				status.Message = err.Error()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Parameters that failed to be reset are kept in the status, so
+	// that the reset is retried at the next reconciliation
+	for _, name := range toReset {
+		if err := resetDatabaseParameter(ctx, db, obj, name); err != nil {
+			statuses = append(statuses, apiv1.DatabaseObjectStatus{
+				Name:    name,
+				Applied: false,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	obj.Status.Parameters = statuses
+	return nil
+}
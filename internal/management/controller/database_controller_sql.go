@@ -24,6 +24,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -31,6 +32,7 @@ import (
 	"github.com/lib/pq"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
 type extInfo struct {
@@ -242,6 +244,54 @@ func dropDatabase(
 	return nil
 }
 
+const detectDatabaseParametersSQL = `
+SELECT s.setconfig
+FROM pg_catalog.pg_db_role_setting s
+JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+WHERE d.datname = $1 AND s.setrole = 0
+`
+
+func getDatabaseParameters(ctx context.Context, db *sql.DB, obj *apiv1.Database) (map[string]string, error) {
+	var setconfig pq.StringArray
+	row := db.QueryRowContext(ctx, detectDatabaseParametersSQL, obj.Spec.Name)
+	if err := row.Scan(&setconfig); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("while reading the parameters of database %q: %w", obj.Spec.Name, err)
+	}
+
+	return postgres.ParseParameterSettings(setconfig), nil
+}
+
+func setDatabaseParameter(ctx context.Context, db *sql.DB, obj *apiv1.Database, name, value string) error {
+	contextLogger := log.FromContext(ctx)
+	query := fmt.Sprintf(
+		"ALTER DATABASE %s %s",
+		pgx.Identifier{obj.Spec.Name}.Sanitize(),
+		postgres.ParameterSetClause(name, value))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while setting database parameter", "query", query)
+		return fmt.Errorf("while setting parameter %q of database %q: %w", name, obj.Spec.Name, err)
+	}
+
+	return nil
+}
+
+func resetDatabaseParameter(ctx context.Context, db *sql.DB, obj *apiv1.Database, name string) error {
+	contextLogger := log.FromContext(ctx)
+	query := fmt.Sprintf(
+		"ALTER DATABASE %s %s",
+		pgx.Identifier{obj.Spec.Name}.Sanitize(),
+		postgres.ParameterResetClause(name))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while resetting database parameter", "query", query)
+		return fmt.Errorf("while resetting parameter %q of database %q: %w", name, obj.Spec.Name, err)
+	}
+
+	return nil
+}
+
 const detectDatabaseExtensionSQL = `
 SELECT e.extname, e.extversion, n.nspname
 FROM pg_catalog.pg_extension e
@@ -860,3 +910,491 @@ func dropDatabaseForeignServer(ctx context.Context, db *sql.DB, server apiv1.Ser
 	contextLogger.Info("dropped foreign server", "name", server.Name)
 	return nil
 }
+
+// userMapping is a user mapping of a foreign server, together with the
+// value of its password option, read from the Secret referred by the spec
+type userMapping struct {
+	apiv1.UserMappingSpec
+
+	// server is the name of the foreign server
+	server string
+
+	// password is the value of the password option, if any
+	password *string
+
+	// passwordErr is the error raised while reading the password, if any
+	passwordErr error
+}
+
+// GetName gets a description of the user mapping, identifying it in the
+// status of the database
+func (mapping userMapping) GetName() string {
+	return fmt.Sprintf("user mapping for %s on server %s", mapping.Role, mapping.server)
+}
+
+// GetEnsure gets the ensure status of the user mapping
+func (mapping userMapping) GetEnsure() apiv1.EnsureOption {
+	return mapping.Ensure
+}
+
+// getOptions gets the options of the user mapping, including the password
+func (mapping userMapping) getOptions() []apiv1.OptionSpec {
+	if mapping.password == nil {
+		return mapping.Options
+	}
+	return append(slices.Clone(mapping.Options), apiv1.OptionSpec{
+		Name:   "password",
+		Value:  *mapping.password,
+		Ensure: apiv1.EnsurePresent,
+	})
+}
+
+type userMappingInfo struct {
+	Options map[string]string `json:"options"`
+}
+
+// The user of the user mappings defined for PUBLIC is reported as `public`
+const detectDatabaseUserMappingSQL = `
+SELECT umoptions
+FROM pg_catalog.pg_user_mappings
+WHERE srvname = $1 AND usename = $2
+`
+
+func getDatabaseUserMappingInfo(ctx context.Context, db *sql.DB, mapping userMapping) (*userMappingInfo, error) {
+	username := mapping.Role
+	if strings.EqualFold(username, publicRole) {
+		username = "public"
+	}
+
+	var optionsRaw pq.StringArray
+	if err := db.QueryRowContext(
+		ctx, detectDatabaseUserMappingSQL,
+		mapping.server, username).Scan(&optionsRaw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while scanning if %s exists: %w", mapping.GetName(), err)
+	}
+
+	opts, err := parseOptions(optionsRaw)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing options of %s: %w", mapping.GetName(), err)
+	}
+
+	return &userMappingInfo{Options: opts}, nil
+}
+
+// createDatabaseUserMapping creates a user mapping of a foreign server
+func createDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping) error {
+	if mapping.passwordErr != nil {
+		return mapping.passwordErr
+	}
+
+	var sqlCreateUserMapping strings.Builder
+	fmt.Fprintf(&sqlCreateUserMapping, "CREATE USER MAPPING FOR %s SERVER %s",
+		sanitizeGrantee(mapping.Role),
+		pgx.Identifier{mapping.server}.Sanitize())
+	if opts := extractOptionsClauses(mapping.getOptions()); len(opts) > 0 {
+		sqlCreateUserMapping.WriteString(" OPTIONS (" + strings.Join(opts, ", ") + ")")
+	}
+
+	// The query is not logged, as it may contain the password
+	if _, err := db.ExecContext(ctx, sqlCreateUserMapping.String()); err != nil {
+		return fmt.Errorf("while creating %s: %w", mapping.GetName(), err)
+	}
+	log.FromContext(ctx).Info("created user mapping", "role", mapping.Role, "server", mapping.server)
+
+	return nil
+}
+
+// updateDatabaseUserMapping updates the options of a user mapping of a
+// foreign server
+func updateDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping, info *userMappingInfo) error {
+	if mapping.passwordErr != nil {
+		return mapping.passwordErr
+	}
+
+	toUpdateOpts := calculateAlterOptionsClauses(mapping.getOptions(), info.Options)
+	if len(toUpdateOpts) == 0 {
+		return nil
+	}
+
+	changeOptionSQL := fmt.Sprintf(
+		"ALTER USER MAPPING FOR %s SERVER %s OPTIONS (%s)",
+		sanitizeGrantee(mapping.Role),
+		pgx.Identifier{mapping.server}.Sanitize(),
+		strings.Join(toUpdateOpts, ", "),
+	)
+	if _, err := db.ExecContext(ctx, changeOptionSQL); err != nil {
+		return fmt.Errorf("altering options of %s: %w", mapping.GetName(), err)
+	}
+	log.FromContext(ctx).Info("altered user mapping options", "role", mapping.Role, "server", mapping.server)
+
+	return nil
+}
+
+// dropDatabaseUserMapping drops a user mapping of a foreign server
+func dropDatabaseUserMapping(ctx context.Context, db *sql.DB, mapping userMapping) error {
+	contextLogger := log.FromContext(ctx)
+	query := fmt.Sprintf("DROP USER MAPPING IF EXISTS FOR %s SERVER %s",
+		sanitizeGrantee(mapping.Role),
+		pgx.Identifier{mapping.server}.Sanitize())
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping user mapping", "query", query)
+		return err
+	}
+	contextLogger.Info("dropped user mapping", "role", mapping.Role, "server", mapping.server)
+	return nil
+}
+
+// privilegeInfo describes the privileges held by a role on the objects
+// of a PrivilegeSpec or DefaultPrivilegeSpec. It is only returned when the
+// role holds at least one of the privileges.
+type privilegeInfo struct {
+	// Complete is true when the role holds all the privileges on every object
+	Complete bool `json:"complete"`
+}
+
+// privilegeCatalog describes where the access privileges of an object type
+// are stored
+type privilegeCatalog struct {
+	table       string
+	aclColumn   string
+	ownerColumn string
+	// aclDefaultType is the object type passed to acldefault() to get the
+	// privileges of the objects with no explicit ACL
+	aclDefaultType string
+	filter         string
+	// sqlObject is how the objects are referenced in GRANT and REVOKE, with
+	// a placeholder for the quoted name of the database or schema
+	sqlObject string
+}
+
+const privilegeSchemaOIDSQL = "(SELECT oid FROM pg_catalog.pg_namespace WHERE nspname = $2)"
+
+var privilegeCatalogs = map[apiv1.PrivilegeObjectType]privilegeCatalog{
+	apiv1.PrivilegeObjectTypeDatabase: {
+		table:          "pg_catalog.pg_database",
+		aclColumn:      "datacl",
+		ownerColumn:    "datdba",
+		aclDefaultType: "d",
+		filter:         "o.datname = pg_catalog.current_database()",
+		sqlObject:      "DATABASE %s",
+	},
+	apiv1.PrivilegeObjectTypeSchema: {
+		table:          "pg_catalog.pg_namespace",
+		aclColumn:      "nspacl",
+		ownerColumn:    "nspowner",
+		aclDefaultType: "n",
+		filter:         "o.nspname = $2",
+		sqlObject:      "SCHEMA %s",
+	},
+	apiv1.PrivilegeObjectTypeTables: {
+		table:          "pg_catalog.pg_class",
+		aclColumn:      "relacl",
+		ownerColumn:    "relowner",
+		aclDefaultType: "r",
+		filter:         "o.relnamespace = " + privilegeSchemaOIDSQL + " AND o.relkind IN ('r', 'p', 'v', 'm', 'f')",
+		sqlObject:      "ALL TABLES IN SCHEMA %s",
+	},
+	apiv1.PrivilegeObjectTypeSequences: {
+		table:          "pg_catalog.pg_class",
+		aclColumn:      "relacl",
+		ownerColumn:    "relowner",
+		aclDefaultType: "s",
+		filter:         "o.relnamespace = " + privilegeSchemaOIDSQL + " AND o.relkind = 'S'",
+		sqlObject:      "ALL SEQUENCES IN SCHEMA %s",
+	},
+	apiv1.PrivilegeObjectTypeFunctions: {
+		table:          "pg_catalog.pg_proc",
+		aclColumn:      "proacl",
+		ownerColumn:    "proowner",
+		aclDefaultType: "f",
+		filter:         "o.pronamespace = " + privilegeSchemaOIDSQL + " AND o.prokind IN ('f', 'a', 'w')",
+		sqlObject:      "ALL FUNCTIONS IN SCHEMA %s",
+	},
+}
+
+// defaultPrivilegeObjectTypes maps the object types of the default privileges
+// to the values of pg_default_acl.defaclobjtype
+var defaultPrivilegeObjectTypes = map[apiv1.PrivilegeObjectType]string{
+	apiv1.PrivilegeObjectTypeTables:    "r",
+	apiv1.PrivilegeObjectTypeSequences: "S",
+	apiv1.PrivilegeObjectTypeFunctions: "f",
+	apiv1.PrivilegeObjectTypeTypes:     "T",
+}
+
+// privilegeGranteeSQL matches the grantee of an aclexplode() entry with the
+// role passed as the first parameter, `PUBLIC` being represented by OID 0
+const privilegeGranteeSQL = "CASE WHEN pg_catalog.upper($1) = 'PUBLIC' THEN 0::pg_catalog.oid " +
+	"ELSE (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1) END"
+
+// expandPrivileges validates the privileges requested for an object type,
+// replacing `ALL` with the privileges available on it. Since the privileges
+// are interpolated into the SQL statements, only the known keywords are
+// accepted.
+func expandPrivileges(objectType apiv1.PrivilegeObjectType, privileges []string) ([]string, error) {
+	available := objectType.GetPrivileges()
+	var result []string
+	for _, privilege := range privileges {
+		privilege = strings.ToUpper(privilege)
+		switch {
+		case privilege == "ALL":
+			// MAINTAIN is left out, as it is not available before PostgreSQL 17
+			for _, item := range available {
+				if item != "MAINTAIN" {
+					result = append(result, item)
+				}
+			}
+		case slices.Contains(available, privilege):
+			result = append(result, privilege)
+		default:
+			return nil, fmt.Errorf("privilege %q is not available on %s", privilege, objectType)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
+
+// evaluatePrivilegeRows builds the privilegeInfo out of the privileges held by
+// the role on each object, one row per object
+func evaluatePrivilegeRows(rows *sql.Rows, privileges []string) (*privilegeInfo, error) {
+	anyHeld := false
+	complete := true
+	for rows.Next() {
+		var held pq.StringArray
+		if err := rows.Scan(&held); err != nil {
+			return nil, err
+		}
+		for _, privilege := range privileges {
+			if slices.Contains(held, privilege) {
+				anyHeld = true
+			} else {
+				complete = false
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !anyHeld {
+		return nil, nil
+	}
+	return &privilegeInfo{Complete: complete}, nil
+}
+
+// newPrivilegeObjectManager creates the manager of the privileges of the
+// given database
+func newPrivilegeObjectManager(dbname string) databaseObjectManager[apiv1.PrivilegeSpec, privilegeInfo] {
+	return databaseObjectManager[apiv1.PrivilegeSpec, privilegeInfo]{
+		get: getDatabasePrivilegeInfo,
+		create: func(ctx context.Context, db *sql.DB, spec apiv1.PrivilegeSpec) error {
+			return applyDatabasePrivilege(ctx, db, dbname, spec, "GRANT %s ON %s TO %s")
+		},
+		update: func(ctx context.Context, db *sql.DB, spec apiv1.PrivilegeSpec, info *privilegeInfo) error {
+			if info.Complete {
+				return nil
+			}
+			return applyDatabasePrivilege(ctx, db, dbname, spec, "GRANT %s ON %s TO %s")
+		},
+		drop: func(ctx context.Context, db *sql.DB, spec apiv1.PrivilegeSpec) error {
+			return applyDatabasePrivilege(ctx, db, dbname, spec, "REVOKE %s ON %s FROM %s")
+		},
+	}
+}
+
+func getDatabasePrivilegeInfo(ctx context.Context, db *sql.DB, spec apiv1.PrivilegeSpec) (*privilegeInfo, error) {
+	catalog, ok := privilegeCatalogs[spec.ObjectType]
+	if !ok {
+		return nil, fmt.Errorf("unknown object type %q", spec.ObjectType)
+	}
+	privileges, err := expandPrivileges(spec.ObjectType, spec.Privileges)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf( //nolint:gosec
+		`SELECT COALESCE(pg_catalog.array_agg(a.privilege_type) FILTER (WHERE a.grantee = %s), '{}')
+FROM %s o
+LEFT JOIN LATERAL pg_catalog.aclexplode(
+	COALESCE(o.%s, pg_catalog.acldefault('%s', o.%s))) a ON true
+WHERE %s
+GROUP BY o.oid`,
+		privilegeGranteeSQL, catalog.table, catalog.aclColumn, catalog.aclDefaultType, catalog.ownerColumn,
+		catalog.filter)
+	args := []any{spec.Role}
+	if spec.ObjectType != apiv1.PrivilegeObjectTypeDatabase {
+		args = append(args, spec.Schema)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return evaluatePrivilegeRows(rows, privileges)
+}
+
+func applyDatabasePrivilege(
+	ctx context.Context,
+	db *sql.DB,
+	dbname string,
+	spec apiv1.PrivilegeSpec,
+	statementTemplate string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	privileges, err := expandPrivileges(spec.ObjectType, spec.Privileges)
+	if err != nil {
+		return err
+	}
+	objectName := spec.Schema
+	if spec.ObjectType == apiv1.PrivilegeObjectTypeDatabase {
+		objectName = dbname
+	}
+	catalog := privilegeCatalogs[spec.ObjectType]
+
+	query := fmt.Sprintf(statementTemplate, //nolint:gosec
+		strings.Join(privileges, ", "),
+		fmt.Sprintf(catalog.sqlObject, pgx.Identifier{objectName}.Sanitize()),
+		sanitizeGrantee(spec.Role))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while applying privileges", "query", query)
+		return fmt.Errorf("while applying privileges on %s: %w", spec.GetName(), err)
+	}
+	contextLogger.Info("applied privileges", "query", query)
+
+	return nil
+}
+
+// defaultPrivilegeObjectManager is the manager of the default privileges
+var defaultPrivilegeObjectManager = databaseObjectManager[apiv1.DefaultPrivilegeSpec, privilegeInfo]{
+	get: getDatabaseDefaultPrivilegeInfo,
+	create: func(ctx context.Context, db *sql.DB, spec apiv1.DefaultPrivilegeSpec) error {
+		return applyDatabaseDefaultPrivilege(ctx, db, spec, "GRANT %s ON %s TO %s")
+	},
+	update: func(ctx context.Context, db *sql.DB, spec apiv1.DefaultPrivilegeSpec, info *privilegeInfo) error {
+		if info.Complete {
+			return nil
+		}
+		return applyDatabaseDefaultPrivilege(ctx, db, spec, "GRANT %s ON %s TO %s")
+	},
+	drop: func(ctx context.Context, db *sql.DB, spec apiv1.DefaultPrivilegeSpec) error {
+		return applyDatabaseDefaultPrivilege(ctx, db, spec, "REVOKE %s ON %s FROM %s")
+	},
+}
+
+const detectDatabaseDefaultPrivilegesSQL = `
+SELECT COALESCE(pg_catalog.array_agg(a.privilege_type) FILTER (WHERE a.grantee = ` + privilegeGranteeSQL + `), '{}')
+FROM pg_catalog.pg_default_acl d
+CROSS JOIN LATERAL pg_catalog.aclexplode(d.defaclacl) a
+WHERE d.defaclrole = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $2)
+AND d.defaclnamespace = (SELECT oid FROM pg_catalog.pg_namespace WHERE nspname = $3)
+AND d.defaclobjtype = $4
+`
+
+func getDatabaseDefaultPrivilegeInfo(
+	ctx context.Context,
+	db *sql.DB,
+	spec apiv1.DefaultPrivilegeSpec,
+) (*privilegeInfo, error) {
+	objectType, ok := defaultPrivilegeObjectTypes[spec.ObjectType]
+	if !ok {
+		return nil, fmt.Errorf("unknown object type %q", spec.ObjectType)
+	}
+	privileges, err := expandPrivileges(spec.ObjectType, spec.Privileges)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(
+		ctx, detectDatabaseDefaultPrivilegesSQL,
+		spec.Role, spec.ForRole, spec.Schema, objectType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	return evaluatePrivilegeRows(rows, privileges)
+}
+
+func applyDatabaseDefaultPrivilege(
+	ctx context.Context,
+	db *sql.DB,
+	spec apiv1.DefaultPrivilegeSpec,
+	statementTemplate string,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	privileges, err := expandPrivileges(spec.ObjectType, spec.Privileges)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s ", //nolint:gosec
+		pgx.Identifier{spec.ForRole}.Sanitize(),
+		pgx.Identifier{spec.Schema}.Sanitize()) +
+		fmt.Sprintf(statementTemplate, //nolint:gosec
+			strings.Join(privileges, ", "),
+			strings.ToUpper(string(spec.ObjectType)),
+			sanitizeGrantee(spec.Role))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while applying default privileges", "query", query)
+		return fmt.Errorf("while applying default privileges on %s: %w", spec.GetName(), err)
+	}
+	contextLogger.Info("applied default privileges", "query", query)
+
+	return nil
+}
+
+// detectDatabaseDrift compares the extensions and the schemas declared in
+// the spec of a database with the ones in its catalog, returning the
+// differences found
+func detectDatabaseDrift(ctx context.Context, db *sql.DB, obj *apiv1.Database) ([]string, error) {
+	var differences []string
+
+	for _, ext := range obj.Spec.Extensions {
+		info, err := getDatabaseExtensionInfo(ctx, db, ext)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case ext.Ensure == apiv1.EnsureAbsent && info != nil:
+			differences = append(differences, fmt.Sprintf("extension %q should be absent", ext.Name))
+		case ext.Ensure == apiv1.EnsureAbsent:
+		case info == nil:
+			differences = append(differences, fmt.Sprintf("extension %q is missing", ext.Name))
+		case ext.Version != "" && ext.Version != info.Version:
+			differences = append(differences, fmt.Sprintf("extension %q has version %q instead of %q",
+				ext.Name, info.Version, ext.Version))
+		case ext.Schema != "" && ext.Schema != info.Schema:
+			differences = append(differences, fmt.Sprintf("extension %q is in schema %q instead of %q",
+				ext.Name, info.Schema, ext.Schema))
+		}
+	}
+
+	for _, schema := range obj.Spec.Schemas {
+		info, err := getDatabaseSchemaInfo(ctx, db, schema)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case schema.Ensure == apiv1.EnsureAbsent && info != nil:
+			differences = append(differences, fmt.Sprintf("schema %q should be absent", schema.Name))
+		case schema.Ensure == apiv1.EnsureAbsent:
+		case info == nil:
+			differences = append(differences, fmt.Sprintf("schema %q is missing", schema.Name))
+		case schema.Owner != "" && schema.Owner != info.Owner:
+			differences = append(differences, fmt.Sprintf("schema %q is owned by %q instead of %q",
+				schema.Name, info.Owner, schema.Owner))
+		}
+	}
+
+	return differences, nil
+}
@@ -22,6 +22,7 @@ package controller
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
@@ -1033,6 +1034,107 @@ var _ = Describe("Managed Foreign Server SQL", func() {
 	})
 })
 
+var _ = Describe("Managed User Mapping SQL", func() {
+	var (
+		dbMock  sqlmock.Sqlmock
+		db      *sql.DB
+		mapping userMapping
+		err     error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		mapping = userMapping{
+			UserMappingSpec: apiv1.UserMappingSpec{
+				Role:   "app",
+				Ensure: apiv1.EnsurePresent,
+				Options: []apiv1.OptionSpec{
+					{Name: "user", Value: "remote", Ensure: apiv1.EnsurePresent},
+				},
+			},
+			server:   "testserver",
+			password: ptr.To("secret"),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("is identified by the role and the server in the status", func() {
+		Expect(mapping.GetName()).To(Equal("user mapping for app on server testserver"))
+	})
+
+	Context("getDatabaseUserMappingInfo", func() {
+		It("returns the options of the user mapping", func(ctx SpecContext) {
+			dbMock.
+				ExpectQuery(detectDatabaseUserMappingSQL).
+				WithArgs("testserver", "app").
+				WillReturnRows(sqlmock.NewRows([]string{"umoptions"}).AddRow("{user=remote}"))
+			info, err := getDatabaseUserMappingInfo(ctx, db, mapping)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Options).To(Equal(map[string]string{"user": "remote"}))
+		})
+
+		It("looks up the user mappings defined for PUBLIC", func(ctx SpecContext) {
+			mapping.Role = "PUBLIC"
+			dbMock.
+				ExpectQuery(detectDatabaseUserMappingSQL).
+				WithArgs("testserver", "public").
+				WillReturnRows(sqlmock.NewRows([]string{"umoptions"}))
+			info, err := getDatabaseUserMappingInfo(ctx, db, mapping)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+	})
+
+	Context("createDatabaseUserMapping", func() {
+		It("creates the user mapping with the password", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("CREATE USER MAPPING FOR \"app\" SERVER \"testserver\"" +
+					" OPTIONS (\"user\" 'remote', \"password\" 'secret')").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(createDatabaseUserMapping(ctx, db, mapping)).To(Succeed())
+		})
+
+		It("fails when the password could not be read", func(ctx SpecContext) {
+			mapping.password = nil
+			mapping.passwordErr = fmt.Errorf("test error")
+			Expect(createDatabaseUserMapping(ctx, db, mapping)).To(MatchError("test error"))
+		})
+	})
+
+	Context("updateDatabaseUserMapping", func() {
+		It("does nothing when the options are up to date", func(ctx SpecContext) {
+			Expect(updateDatabaseUserMapping(ctx, db, mapping, &userMappingInfo{
+				Options: map[string]string{"user": "remote", "password": "secret"},
+			})).To(Succeed())
+		})
+
+		It("sets the changed password", func(ctx SpecContext) {
+			dbMock.
+				ExpectExec("ALTER USER MAPPING FOR \"app\" SERVER \"testserver\"" +
+					" OPTIONS (SET \"password\" 'secret')").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(updateDatabaseUserMapping(ctx, db, mapping, &userMappingInfo{
+				Options: map[string]string{"user": "remote", "password": "old"},
+			})).To(Succeed())
+		})
+	})
+
+	Context("dropDatabaseUserMapping", func() {
+		It("drops the user mapping defined for PUBLIC", func(ctx SpecContext) {
+			mapping.Role = "public"
+			dbMock.
+				ExpectExec("DROP USER MAPPING IF EXISTS FOR PUBLIC SERVER \"testserver\"").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			Expect(dropDatabaseUserMapping(ctx, db, mapping)).To(Succeed())
+		})
+	})
+})
+
 var _ = Describe("sanitizeGrantee", func() {
 	It("renders the PUBLIC pseudo-role verbatim, case-insensitively", func() {
 		Expect(sanitizeGrantee("public")).To(Equal("PUBLIC"))
@@ -1129,3 +1231,180 @@ var _ = Describe("applyObjectPrivilege", func() {
 			Error().NotTo(HaveOccurred())
 	})
 })
+
+var _ = Describe("Managed privileges SQL", func() {
+	var (
+		dbMock    sqlmock.Sqlmock
+		db        *sql.DB
+		privilege apiv1.PrivilegeSpec
+		err       error
+	)
+
+	BeforeEach(func() {
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+		Expect(err).ToNot(HaveOccurred())
+
+		privilege = apiv1.PrivilegeSpec{
+			ObjectType: apiv1.PrivilegeObjectTypeTables,
+			Schema:     "app",
+			Role:       "reporting",
+			Privileges: []string{"SELECT", "REFERENCES"},
+			Ensure:     apiv1.EnsurePresent,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("expands ALL to the privileges of the object type", func() {
+		Expect(expandPrivileges(apiv1.PrivilegeObjectTypeTables, []string{"ALL", "select"})).To(Equal([]string{
+			"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE",
+		}))
+		Expect(expandPrivileges(apiv1.PrivilegeObjectTypeDatabase, []string{"ALL"})).To(Equal([]string{
+			"CONNECT", "CREATE", "TEMPORARY",
+		}))
+		_, err := expandPrivileges(apiv1.PrivilegeObjectTypeSchema, []string{"SELECT; DROP TABLE x"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("detects that the privileges are held on every table", func(ctx SpecContext) {
+		dbMock.ExpectQuery("FROM pg_catalog.pg_class o").WithArgs("reporting", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).
+				AddRow([]byte("{SELECT,REFERENCES}")).
+				AddRow([]byte("{SELECT,REFERENCES,INSERT}")))
+
+		info, err := getDatabasePrivilegeInfo(ctx, db, privilege)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&privilegeInfo{Complete: true}))
+	})
+
+	It("detects that the privileges are missing on some tables", func(ctx SpecContext) {
+		dbMock.ExpectQuery("FROM pg_catalog.pg_class o").WithArgs("reporting", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).
+				AddRow([]byte("{SELECT,REFERENCES}")).
+				AddRow([]byte("{}")))
+
+		info, err := getDatabasePrivilegeInfo(ctx, db, privilege)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(Equal(&privilegeInfo{Complete: false}))
+	})
+
+	It("returns nil info when none of the privileges is held", func(ctx SpecContext) {
+		dbMock.ExpectQuery("FROM pg_catalog.pg_database o").WithArgs("reporting").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).AddRow([]byte("{TEMPORARY}")))
+
+		privilege.ObjectType = apiv1.PrivilegeObjectTypeDatabase
+		privilege.Schema = ""
+		privilege.Privileges = []string{"CONNECT"}
+		info, err := getDatabasePrivilegeInfo(ctx, db, privilege)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info).To(BeNil())
+	})
+
+	It("grants the missing privileges and revokes the absent ones", func(ctx SpecContext) {
+		manager := newPrivilegeObjectManager("appdb")
+
+		dbMock.ExpectQuery("FROM pg_catalog.pg_class o").WithArgs("reporting", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).AddRow([]byte("{SELECT}")))
+		dbMock.ExpectExec(regexp.QuoteMeta(
+			`GRANT REFERENCES, SELECT ON ALL TABLES IN SCHEMA "app" TO "reporting"`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		Expect(manager.reconcile(ctx, db, privilege)).To(Equal(apiv1.DatabaseObjectStatus{
+			Name:    "tables in schema app to reporting",
+			Applied: true,
+		}))
+
+		revoke := apiv1.PrivilegeSpec{
+			ObjectType: apiv1.PrivilegeObjectTypeDatabase,
+			Role:       "public",
+			Privileges: []string{"CONNECT"},
+			Ensure:     apiv1.EnsureAbsent,
+		}
+		dbMock.ExpectQuery("FROM pg_catalog.pg_database o").WithArgs("public").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).AddRow([]byte("{CONNECT,TEMPORARY}")))
+		dbMock.ExpectExec(regexp.QuoteMeta(`REVOKE CONNECT ON DATABASE "appdb" FROM PUBLIC`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		Expect(manager.reconcile(ctx, db, revoke)).To(Equal(apiv1.DatabaseObjectStatus{
+			Name:    "database to public",
+			Applied: true,
+		}))
+	})
+
+	It("reconciles the default privileges", func(ctx SpecContext) {
+		defaultPrivilege := apiv1.DefaultPrivilegeSpec{
+			ForRole:    "app",
+			Schema:     "app",
+			ObjectType: apiv1.PrivilegeObjectTypeTables,
+			Role:       "reporting",
+			Privileges: []string{"SELECT"},
+			Ensure:     apiv1.EnsurePresent,
+		}
+
+		dbMock.ExpectQuery("FROM pg_catalog.pg_default_acl d").WithArgs("reporting", "app", "app", "r").
+			WillReturnRows(sqlmock.NewRows([]string{"privileges"}).AddRow([]byte("{}")))
+		dbMock.ExpectExec(regexp.QuoteMeta(
+			`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "app" GRANT SELECT ON TABLES TO "reporting"`)).
+			WillReturnError(fmt.Errorf("role \"reporting\" does not exist"))
+
+		status := defaultPrivilegeObjectManager.reconcile(ctx, db, defaultPrivilege)
+		Expect(status.Name).To(Equal("tables in schema app for app to reporting"))
+		Expect(status.Applied).To(BeFalse())
+		Expect(status.Message).To(ContainSubstring("does not exist"))
+	})
+})
+
+var _ = Describe("Managed Database drift detection SQL", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("detects the drift of extensions and schemas", func(ctx SpecContext) {
+		database := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Extensions: []apiv1.ExtensionSpec{
+					{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "missing", Ensure: apiv1.EnsurePresent}},
+					{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "old", Ensure: apiv1.EnsurePresent}, Version: "2.0"},
+					{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "dropped", Ensure: apiv1.EnsureAbsent}},
+				},
+				Schemas: []apiv1.SchemaSpec{
+					{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "aligned", Ensure: apiv1.EnsurePresent}},
+					{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "owned", Ensure: apiv1.EnsurePresent}, Owner: "app"},
+				},
+			},
+		}
+
+		extensionColumns := []string{"extname", "extversion", "nspname"}
+		dbMock.ExpectQuery(detectDatabaseExtensionSQL).WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(extensionColumns))
+		dbMock.ExpectQuery(detectDatabaseExtensionSQL).WithArgs("old").
+			WillReturnRows(sqlmock.NewRows(extensionColumns).AddRow("old", "1.0", "public"))
+		dbMock.ExpectQuery(detectDatabaseExtensionSQL).WithArgs("dropped").
+			WillReturnRows(sqlmock.NewRows(extensionColumns).AddRow("dropped", "1.0", "public"))
+		schemaColumns := []string{"nspname", "rolname"}
+		dbMock.ExpectQuery(detectDatabaseSchemaSQL).WithArgs("aligned").
+			WillReturnRows(sqlmock.NewRows(schemaColumns).AddRow("aligned", "postgres"))
+		dbMock.ExpectQuery(detectDatabaseSchemaSQL).WithArgs("owned").
+			WillReturnRows(sqlmock.NewRows(schemaColumns).AddRow("owned", "postgres"))
+
+		differences, err := detectDatabaseDrift(ctx, db, database)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{
+			`extension "missing" is missing`,
+			`extension "old" has version "1.0" instead of "2.0"`,
+			`extension "dropped" should be absent`,
+			`schema "owned" is owned by "postgres" instead of "app"`,
+		}))
+	})
+})
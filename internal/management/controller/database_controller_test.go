@@ -159,6 +159,68 @@ var _ = Describe("Managed Database status", func() {
 		Expect(database.GetStatusMessage()).Should(ContainSubstring(expectedError.Error()))
 	})
 
+	It("applies the database parameters and resets the removed ones", func(ctx SpecContext) {
+		database.Spec.Parameters = map[string]string{
+			"search_path": "app, public",
+			"work_mem":    "64MB",
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+		database.Status.Parameters = []apiv1.DatabaseObjectStatus{
+			{Name: "work_mem", Applied: true},
+			{Name: "statement_timeout", Applied: true},
+		}
+		Expect(fakeClient.Status().Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDatabaseParametersSQL).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"setconfig"}).
+				AddRow([]byte(`{work_mem=64MB,statement_timeout=30s,lock_timeout=5s}`)))
+		dbMock.ExpectExec(`ALTER DATABASE "db-one" SET "search_path" TO "app", "public"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(`ALTER DATABASE "db-one" RESET "statement_timeout"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+		Expect(database.Status.Parameters).To(Equal([]apiv1.DatabaseObjectStatus{
+			{Name: "search_path", Applied: true},
+			{Name: "work_mem", Applied: true},
+		}))
+	})
+
+	It("reports the database parameters that failed to be applied", func(ctx SpecContext) {
+		database.Spec.Parameters = map[string]string{"work_mem": "lots"}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(detectDatabaseParametersSQL).WithArgs(database.Spec.Name).
+			WillReturnError(sql.ErrNoRows)
+		dbMock.ExpectExec(`ALTER DATABASE "db-one" SET "work_mem" TO 'lots'`).
+			WillReturnError(fmt.Errorf("invalid value for parameter \"work_mem\""))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).Should(HaveValue(BeFalse()))
+		Expect(database.Status.Parameters).To(HaveLen(1))
+		Expect(database.Status.Parameters[0].Applied).To(BeFalse())
+		Expect(database.Status.Parameters[0].Message).To(ContainSubstring("invalid value"))
+	})
+
 	When("reclaim policy is delete", func() {
 		It("on deletion it removes finalizers and drops DB", func(ctx SpecContext) {
 			// Mocking DetectDB
@@ -550,6 +612,98 @@ var _ = Describe("Managed Database status", func() {
 		Expect(database.Status.Message).To(BeEmpty())
 	})
 
+	It("uses only the labeled secrets as the password of the user mappings", func(ctx SpecContext) {
+		unlabeled := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("app-secret")},
+		}
+		labeled := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fdw-password",
+				Namespace: "default",
+				Labels:    map[string]string{utils.UserMappingSecretLabelName: "true"},
+			},
+			Data: map[string][]byte{"password": []byte("secret")},
+		}
+		Expect(fakeClient.Create(ctx, unlabeled)).To(Succeed())
+		Expect(fakeClient.Create(ctx, labeled)).To(Succeed())
+
+		newMapping := func(role, secretName string) apiv1.UserMappingSpec {
+			return apiv1.UserMappingSpec{
+				Role: role,
+				PasswordSecret: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: secretName},
+					Key:                  "password",
+				},
+				Ensure: apiv1.EnsurePresent,
+			}
+		}
+		database.Spec.Servers = []apiv1.ServerSpec{{
+			DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "remote", Ensure: apiv1.EnsurePresent},
+			FdwName:            "postgres_fdw",
+			UserMappings: []apiv1.UserMappingSpec{
+				newMapping("app", unlabeled.Name),
+				newMapping("reporting", labeled.Name),
+			},
+		}}
+
+		dbMock.ExpectQuery(detectDatabaseForeignServerSQL).WithArgs("remote").
+			WillReturnRows(sqlmock.NewRows([]string{"srvname", "fdwname", "srvoptions"}).
+				AddRow("remote", "postgres_fdw", "{}"))
+		dbMock.ExpectQuery(detectDatabaseUserMappingSQL).WithArgs("remote", "app").
+			WillReturnRows(sqlmock.NewRows([]string{"umoptions"}))
+		dbMock.ExpectQuery(detectDatabaseUserMappingSQL).WithArgs("remote", "reporting").
+			WillReturnRows(sqlmock.NewRows([]string{"umoptions"}))
+		dbMock.ExpectExec(`CREATE USER MAPPING FOR "reporting" SERVER "remote" OPTIONS ("password" 'secret')`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		result := r.reconcileForeignServers(ctx, db, database)
+		Expect(result).To(HaveLen(3))
+		Expect(result[1].Applied).To(BeFalse())
+		Expect(result[1].Message).To(ContainSubstring(utils.UserMappingSecretLabelName))
+		Expect(result[2].Applied).To(BeTrue())
+	})
+
+	It("polls the secrets of the user mappings of an applied database", func(ctx SpecContext) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fdw-password",
+				Namespace: "default",
+				Labels:    map[string]string{utils.UserMappingSecretLabelName: "true"},
+			},
+			Data: map[string][]byte{"password": []byte("secret")},
+		}
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+		database.Spec.Servers = []apiv1.ServerSpec{{
+			DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "remote", Ensure: apiv1.EnsurePresent},
+			FdwName:            "postgres_fdw",
+			UserMappings: []apiv1.UserMappingSpec{{
+				Role: "app",
+				PasswordSecret: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: secret.Name},
+					Key:                  "password",
+				},
+				Ensure: apiv1.EnsurePresent,
+			}},
+		}}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+		database.Status.Applied = ptr.To(true)
+		database.Status.ObservedGeneration = database.Generation
+		database.Status.SecretResourceVersions = map[string]string{secret.Name: secret.ResourceVersion}
+		Expect(fakeClient.Status().Update(ctx, database)).To(Succeed())
+
+		// Nothing changed: the database is not applied again, but the
+		// secret is checked again later
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(database)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(databaseReconciliationInterval))
+
+		secret.Data["password"] = []byte("changed")
+		Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+		Expect(r.hasUserMappingSecretChanges(ctx, database)).To(BeTrue())
+	})
+
 	It("retains the ownership of the managed database across a demotion", func(ctx SpecContext) {
 		database.Status.Applied = ptr.To(true)
 		database.Status.ObservedGeneration = database.Generation
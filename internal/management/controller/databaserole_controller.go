@@ -23,6 +23,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -166,9 +168,13 @@ func (r *DatabaseRoleReconciler) handleDeletion(
 				"while connecting to the database to delete role %q: %w",
 				role.Spec.Name, err))
 		}
-		dbRole := roles.DatabaseRoleFromConfiguration(role.Spec.RoleConfiguration, false)
-		if err := roles.Delete(ctx, db, dbRole); err != nil {
-			return r.failedReconciliation(ctx, role, err)
+		// The login backing the password rotation is dropped before the role
+		logins := roles.ExpandPasswordRotation(role.Spec.RoleConfiguration, role.Status.PasswordRotation)
+		for _, login := range slices.Backward(logins) {
+			dbRole := roles.DatabaseRoleFromConfiguration(login, false)
+			if err := roles.Delete(ctx, db, dbRole); err != nil {
+				return r.failedReconciliation(ctx, role, err)
+			}
 		}
 	} else if role.Spec.ReclaimPolicy == apiv1.DatabaseRoleReclaimDelete {
 		log.FromContext(ctx).Info(
@@ -201,14 +207,18 @@ func (r *DatabaseRoleReconciler) detectMissingPasswordSecret(
 	ctx context.Context,
 	role *apiv1.DatabaseRole,
 ) (ctrl.Result, error) {
+	// The secret in use is the one of the active login when the password is rotated
+	secretName := role.Spec.GetPasswordRotationSecretName(
+		role.Status.PasswordRotation.GetActiveLogin(role.Spec.Name))
+
 	// No password secret is configured, we can continue the reconciliation loop
-	if role.Spec.GetRoleSecretName() == "" {
+	if secretName == "" {
 		return ctrl.Result{}, nil
 	}
 
 	secretObjectKey := types.NamespacedName{
 		Namespace: role.Namespace,
-		Name:      role.Spec.GetRoleSecretName(),
+		Name:      secretName,
 	}
 	var secret corev1.Secret
 	if err := r.Get(ctx, secretObjectKey, &secret); err != nil {
@@ -402,6 +412,7 @@ func (r *DatabaseRoleReconciler) succeededReconciliation(
 	oldRole := role.DeepCopy()
 	role.SetAsReady()
 	role.Status.SecretResourceVersion = passVersion
+	role.Status.Parameters = role.Spec.Parameters
 
 	if err := r.Client.Status().Patch(ctx, role, client.MergeFrom(oldRole)); err != nil {
 		return ctrl.Result{}, err
@@ -530,11 +541,36 @@ func (r *DatabaseRoleReconciler) reconcileRole(ctx context.Context, role *apiv1.
 		return "", fmt.Errorf("while listing roles in postgres: %w", err)
 	}
 
+	// A role whose password is rotated is backed by two logins, and the
+	// password version reported is the one of the login in use
+	passwordVersion := ""
+	for _, login := range roles.ExpandPasswordRotation(role.Spec.RoleConfiguration, role.Status.PasswordRotation) {
+		loginPasswordVersion, err := r.reconcileLogin(ctx, db, rolesInDB, login, role.Status.Parameters)
+		if err != nil {
+			return "", err
+		}
+		if login.PasswordSecret != nil {
+			passwordVersion = loginPasswordVersion
+		}
+	}
+
+	return passwordVersion, nil
+}
+
+// reconcileLogin creates or updates a role in the database, returning the
+// resource version of the password secret applied to it
+func (r *DatabaseRoleReconciler) reconcileLogin(
+	ctx context.Context,
+	db *sql.DB,
+	rolesInDB []roles.DatabaseRole,
+	login apiv1.RoleConfiguration,
+	appliedParameters map[string]string,
+) (string, error) {
 	// Check if the role already exists in the database to determine the
 	// correct validUntilNullIsInfinity setting
 	var existingDBRole *roles.DatabaseRole
 	for i := range rolesInDB {
-		if rolesInDB[i].Name == role.Spec.Name {
+		if rolesInDB[i].Name == login.Name {
 			existingDBRole = &rolesInDB[i]
 			break
 		}
@@ -544,10 +580,10 @@ func (r *DatabaseRoleReconciler) reconcileRole(ctx context.Context, role *apiv1.
 	// database, a nil ValidUntil in the spec should translate to
 	// VALID UNTIL 'infinity' (PostgreSQL cannot restore a NULL ValidUntil).
 	validUntilNullIsInfinity := existingDBRole != nil && existingDBRole.ValidUntil.Valid
-	dbRole := roles.DatabaseRoleFromConfiguration(role.Spec.RoleConfiguration, validUntilNullIsInfinity)
+	dbRole := roles.DatabaseRoleFromConfiguration(login, validUntilNullIsInfinity)
 
 	passwordVersion, err := dbRole.ApplyPassword(
-		ctx, r.Client, &role.Spec.RoleConfiguration, r.instance.GetNamespaceName(),
+		ctx, r.Client, &login, r.instance.GetNamespaceName(),
 	)
 	if err != nil {
 		return "", fmt.Errorf("while getting the role password: %w", err)
@@ -561,5 +597,14 @@ func (r *DatabaseRoleReconciler) reconcileRole(ctx context.Context, role *apiv1.
 		return "", err
 	}
 
+	// The parameters recorded in the status are the ones applied last time,
+	// and need to be reset if they have been removed from the spec
+	if len(dbRole.Parameters)+len(appliedParameters) > 0 {
+		previouslyManaged := slices.Collect(maps.Keys(appliedParameters))
+		if err := roles.ReconcileParameters(ctx, db, dbRole, previouslyManaged); err != nil {
+			return "", fmt.Errorf("while updating parameters: %w", err)
+		}
+	}
+
 	return passwordVersion, nil
 }
@@ -0,0 +1,122 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// driftDetectable is a resource whose state in PostgreSQL is periodically
+// compared with its specification
+type driftDetectable interface {
+	client.Object
+	GetStatusApplied() *bool
+	GetDriftDetection() apiv1.DriftDetectionConfiguration
+	GetDriftStatus() *apiv1.DriftStatus
+	SetDriftStatus(status *apiv1.DriftStatus)
+}
+
+// driftDetector compares the state of a resource in PostgreSQL with its
+// specification, returning the differences found
+type driftDetector func(ctx context.Context) ([]string, error)
+
+// handleDriftDetection compares periodically the state in PostgreSQL of an
+// applied resource with its specification, on the primary of a cluster that
+// is not a replica. The outcome is recorded in the status of the resource.
+//
+// When a drift is detected and the policy of the resource is to correct it,
+// it asks the caller to apply the resource again by returning proceed=true,
+// leaving to the caller the update of the status. Otherwise, it returns the
+// time until the next comparison.
+func handleDriftDetection(
+	ctx context.Context,
+	cli client.Client,
+	instance instanceInterface,
+	cluster *apiv1.Cluster,
+	resource driftDetectable,
+	detect driftDetector,
+	now time.Time,
+) (result ctrl.Result, proceed bool, err error) {
+	configuration := resource.GetDriftDetection()
+	interval := configuration.GetResyncInterval()
+	applied := resource.GetStatusApplied()
+	if interval <= 0 || !resource.GetDeletionTimestamp().IsZero() || cluster.IsReplica() ||
+		cluster.Status.CurrentPrimary != instance.GetPodName() || applied == nil || !*applied {
+		return ctrl.Result{}, false, nil
+	}
+
+	status := resource.GetDriftStatus()
+	if status != nil && status.LastCheckTime != nil {
+		if next := status.LastCheckTime.Add(interval).Sub(now); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, false, nil
+		}
+	}
+
+	differences, err := detect(ctx)
+	if err != nil {
+		// The comparison is retried at the next interval, without
+		// affecting the applied status of the resource
+		log.FromContext(ctx).Error(err, "while detecting the drift")
+		return ctrl.Result{RequeueAfter: interval}, false, nil
+	}
+
+	updatedStatus := &apiv1.DriftStatus{
+		LastCheckTime: ptr.To(metav1.NewTime(now)),
+		Differences:   differences,
+	}
+	if status != nil {
+		updatedStatus.LastCorrectionTime = status.LastCorrectionTime
+	}
+
+	if len(differences) > 0 {
+		log.FromContext(ctx).Info("Drift detected",
+			"differences", differences,
+			"driftPolicy", configuration.GetDriftPolicy())
+		if configuration.GetDriftPolicy() == apiv1.DriftPolicyCorrect {
+			updatedStatus.LastCorrectionTime = updatedStatus.LastCheckTime
+			resource.SetDriftStatus(updatedStatus)
+			return ctrl.Result{}, true, nil
+		}
+	}
+
+	resource.SetDriftStatus(updatedStatus)
+	if err := cli.Status().Update(ctx, resource); err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("while recording the drift: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: interval}, false, nil
+}
+
+// earliestRequeue returns the result requeueing the reconciliation first
+func earliestRequeue(a, b ctrl.Result) ctrl.Result {
+	if a.RequeueAfter == 0 || (b.RequeueAfter > 0 && b.RequeueAfter < a.RequeueAfter) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("handleDriftDetection", func() {
+	const interval = 10 * time.Minute
+
+	var (
+		cluster     *apiv1.Cluster
+		publication *apiv1.Publication
+		cli         client.Client
+		now         time.Time
+		detected    int
+		differences []string
+		detectErr   error
+	)
+
+	detect := func(context.Context) ([]string, error) {
+		detected++
+		return differences, detectErr
+	}
+
+	BeforeEach(func() {
+		cluster = newTestCluster()
+		publication = &apiv1.Publication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "pub",
+				Namespace:  testNamespace,
+				Generation: 1,
+			},
+			Spec: apiv1.PublicationSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: testClusterName},
+				Name:       "pub",
+				DBName:     "app",
+				DriftDetectionConfiguration: apiv1.DriftDetectionConfiguration{
+					ResyncInterval: &metav1.Duration{Duration: interval},
+				},
+			},
+			Status: apiv1.PublicationStatus{
+				ObservedGeneration: 1,
+				Applied:            ptr.To(true),
+			},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(publication).
+			WithStatusSubresource(publication).
+			Build()
+		now = time.Now().Truncate(time.Second)
+		detected = 0
+		differences = nil
+		detectErr = nil
+	})
+
+	run := func() (ctrl.Result, bool, error) {
+		return handleDriftDetection(context.Background(), cli, &fakeRoleInstance{}, cluster, publication, detect, now)
+	}
+
+	It("does nothing without a resync interval", func() {
+		publication.Spec.ResyncInterval = nil
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(detected).To(BeZero())
+	})
+
+	It("does nothing on a replica cluster", func() {
+		makeReplica(cluster)
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(detected).To(BeZero())
+	})
+
+	It("waits for the next check", func() {
+		publication.Status.Drift = &apiv1.DriftStatus{
+			LastCheckTime: ptr.To(metav1.NewTime(now.Add(-4 * time.Minute))),
+		}
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.RequeueAfter).To(Equal(6 * time.Minute))
+		Expect(detected).To(BeZero())
+	})
+
+	It("records a check without differences", func() {
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.RequeueAfter).To(Equal(interval))
+		Expect(detected).To(Equal(1))
+
+		var updated apiv1.Publication
+		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(publication), &updated)).To(Succeed())
+		Expect(updated.Status.Drift).ToNot(BeNil())
+		Expect(updated.Status.Drift.LastCheckTime.Time).To(BeTemporally("==", now))
+		Expect(updated.Status.Drift.Differences).To(BeEmpty())
+		Expect(updated.Status.Drift.LastCorrectionTime).To(BeNil())
+	})
+
+	It("asks to correct a drift by default", func() {
+		differences = []string{`table "public.t" is not published`}
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeTrue())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(publication.Status.Drift.Differences).To(Equal(differences))
+		Expect(publication.Status.Drift.LastCorrectionTime.Time).To(BeTemporally("==", now))
+
+		// The differences are cleared once the correction succeeds
+		Expect(markAsReady(context.Background(), cli, publication)).To(Succeed())
+		var updated apiv1.Publication
+		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(publication), &updated)).To(Succeed())
+		Expect(updated.Status.Drift.Differences).To(BeEmpty())
+		Expect(updated.Status.Drift.LastCheckTime.Time).To(BeTemporally("==", now))
+		Expect(updated.Status.Drift.LastCorrectionTime.Time).To(BeTemporally("==", now))
+	})
+
+	It("only reports a drift with the report policy", func() {
+		publication.Spec.DriftPolicy = apiv1.DriftPolicyReport
+		differences = []string{`table "public.t" is not published`}
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.RequeueAfter).To(Equal(interval))
+
+		var updated apiv1.Publication
+		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(publication), &updated)).To(Succeed())
+		Expect(updated.Status.Drift.Differences).To(Equal(differences))
+		Expect(updated.Status.Drift.LastCorrectionTime).To(BeNil())
+		Expect(updated.Status.Applied).To(Equal(ptr.To(true)))
+	})
+
+	It("retries the check at the next interval on errors", func() {
+		detectErr = errors.New("connection refused")
+		result, proceed, err := run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proceed).To(BeFalse())
+		Expect(result.RequeueAfter).To(Equal(interval))
+		Expect(publication.Status.Drift).To(BeNil())
+	})
+})
@@ -432,11 +432,24 @@ func (r *InstanceReconciler) refreshConfigurationFiles(
 	reloadImages := r.requiresImagesRollout(ctx, cluster)
 	reloadNeeded = reloadNeeded || reloadImages
 
+	parametersFrom, err := postgresManagement.ReadParametersFrom(ctx, r.GetClient(), cluster)
+	if errors.Is(err, postgresManagement.ErrParametersFromNotAvailable) {
+		// The operator reports the missing parameters in the cluster
+		// status: keep running with the current configuration meanwhile
+		log.FromContext(ctx).Warning("Cannot read the PostgreSQL parameters from ConfigMaps and Secrets, "+
+			"skipping the configuration refresh", "error", err.Error())
+		return reloadNeeded, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
 	// Reconcile PostgreSQL configuration
 	// This doesn't need the PG connection, but it needs to reload it in case of changes
 	reloadConfig, err := r.instance.RefreshConfigurationFilesFromCluster(
 		ctx,
 		cluster,
+		parametersFrom,
 		false,
 		postgresClient.OperationType_TYPE_RECONCILE,
 	)
@@ -675,7 +688,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 
 	extensionStatusChanged := false
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		if lastStatus, ok := r.extensionStatus[extension.Name]; !ok || lastStatus != extensionIsUsed {
 			extensionStatusChanged = true
 			break
@@ -691,7 +704,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 			continue
 		}
 		if extensionStatusChanged {
-			if err = r.reconcileExtensions(ctx, db, cluster.Spec.PostgresConfiguration.Parameters); err != nil {
+			if err = r.reconcileExtensions(ctx, db, cluster.GetPostgresParameters()); err != nil {
 				errors = append(errors,
 					fmt.Errorf("could not reconcile extensions for database %s: %w", databaseName, err))
 			}
@@ -702,7 +715,7 @@ func (r *InstanceReconciler) reconcileDatabases(ctx context.Context, cluster *ap
 	}
 
 	for _, extension := range postgres.ManagedExtensions {
-		extensionIsUsed := extension.IsUsed(cluster.Spec.PostgresConfiguration.Parameters)
+		extensionIsUsed := extension.IsUsed(cluster.GetPostgresParameters())
 		r.extensionStatus[extension.Name] = extensionIsUsed
 	}
 
@@ -1001,12 +1014,7 @@ func (r *InstanceReconciler) reconcileMonitoringQueries(
 	queriesCollector := metrics.NewQueriesCollector("cnpg", r.instance, dbname)
 	queriesCollector.InjectUserQueries(metricserver.DefaultQueries)
 
-	if cluster.Spec.Monitoring == nil {
-		r.metricsServerExporter.SetCustomQueries(queriesCollector)
-		return
-	}
-
-	for _, reference := range cluster.Spec.Monitoring.CustomQueriesConfigMap {
+	for _, reference := range cluster.GetCustomQueriesConfigMaps() {
 		var configMap corev1.ConfigMap
 		err := r.GetClient().Get(
 			ctx,
@@ -1035,7 +1043,7 @@ func (r *InstanceReconciler) reconcileMonitoringQueries(
 		}
 	}
 
-	for _, reference := range cluster.Spec.Monitoring.CustomQueriesSecret {
+	for _, reference := range cluster.GetCustomQueriesSecrets() {
 		var secret corev1.Secret
 		err := r.GetClient().Get(ctx,
 			client.ObjectKey{
@@ -47,6 +47,8 @@ type PublicationReconciler struct {
 	instance            *postgres.Instance
 	finalizerReconciler *finalizerReconciler[*apiv1.Publication]
 	getDB               func(name string) (*sql.DB, error)
+
+	getPostgresMajorVersion func() (int, error)
 }
 
 // publicationReconciliationInterval is the time between the
@@ -107,9 +109,36 @@ func (r *PublicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// and evaluate the publication again after the promotion.
 		result, proceed, err := handleReplicaRoleTransition(
 			ctx, r.Client, r.instance, cluster, &publication, publicationReconciliationInterval)
-		if err != nil || !proceed {
+		if err != nil {
 			return result, err
 		}
+
+		// ...or the catalog drifted from the spec and the drift policy
+		// asks to correct it.
+		if !proceed {
+			var driftResult ctrl.Result
+			driftResult, proceed, err = handleDriftDetection(
+				ctx, r.Client, r.instance, cluster, &publication,
+				func(ctx context.Context) ([]string, error) {
+					version, err := r.getPostgresMajorVersion()
+					if err != nil {
+						return nil, fmt.Errorf("while getting the PostgreSQL major version: %w", err)
+					}
+					db, err := r.getDB(publication.Spec.DBName)
+					if err != nil {
+						return nil, fmt.Errorf("while getting DB connection: %w", err)
+					}
+					return detectPublicationDrift(ctx, db, &publication, version)
+				},
+				time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, driftResult)
+		}
+		if !proceed {
+			return result, nil
+		}
 	}
 
 	// Still not for me, we're waiting for a switchover
@@ -220,6 +249,10 @@ func NewPublicationReconciler(
 		getDB: func(name string) (*sql.DB, error) {
 			return instance.ConnectionPool().Connection(name)
 		},
+		getPostgresMajorVersion: func() (int, error) {
+			version, err := instance.GetPgVersion()
+			return int(version.Major()), err //nolint:gosec
+		},
 	}
 
 	pr.finalizerReconciler = newFinalizerReconciler(
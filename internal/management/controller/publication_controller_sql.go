@@ -22,12 +22,18 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/lib/pq"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
 func (r *PublicationReconciler) alignPublication(ctx context.Context, obj *apiv1.Publication) error {
@@ -49,6 +55,16 @@ func (r *PublicationReconciler) alignPublication(ctx context.Context, obj *apiv1
 		return fmt.Errorf("while getting publication status (scan): %w", err)
 	}
 
+	var tables []*apiv1.PublicationTargetTable
+	for _, object := range obj.Spec.Target.Objects {
+		if object.Table != nil {
+			tables = append(tables, object.Table)
+		}
+	}
+	if err := checkRowFilters(ctx, db, tables); err != nil {
+		return err
+	}
+
 	if count > 0 {
 		if err := r.patchPublication(ctx, db, obj); err != nil {
 			return fmt.Errorf("while patching publication: %w", err)
@@ -94,8 +110,8 @@ func toPublicationCreateSQL(obj *apiv1.Publication) string {
 		pgx.Identifier{obj.Spec.Name}.Sanitize(),
 		toPublicationTargetSQL(&obj.Spec.Target),
 	)
-	if len(obj.Spec.Parameters) > 0 {
-		createQuery = fmt.Sprintf("%s WITH (%s)", createQuery, toPostgresParameters(obj.Spec.Parameters))
+	if parameters := getPublicationParameters(obj); len(parameters) > 0 {
+		createQuery = fmt.Sprintf("%s WITH (%s)", createQuery, toPostgresParameters(parameters))
 	}
 
 	return createQuery
@@ -114,12 +130,12 @@ func toPublicationAlterSQL(obj *apiv1.Publication) []string {
 		)
 	}
 
-	if len(obj.Spec.Parameters) > 0 {
+	if parameters := getPublicationParameters(obj); len(parameters) > 0 {
 		result = append(result,
 			fmt.Sprintf(
 				"ALTER PUBLICATION %s SET (%s)",
 				pgx.Identifier{obj.Spec.Name}.Sanitize(),
-				toPostgresParameters(obj.Spec.Parameters),
+				toPostgresParameters(parameters),
 			),
 		)
 	}
@@ -127,6 +143,34 @@ func toPublicationAlterSQL(obj *apiv1.Publication) []string {
 	return result
 }
 
+// getPublicationParameters returns the parameters of the publication,
+// including the ones set through the typed fields of the specification
+func getPublicationParameters(obj *apiv1.Publication) map[string]string {
+	parameters := maps.Clone(obj.Spec.Parameters)
+	setParameter := func(name, value string) {
+		if parameters == nil {
+			parameters = make(map[string]string)
+		}
+		parameters[name] = value
+	}
+
+	if len(obj.Spec.Publish) > 0 {
+		operations := make([]string, len(obj.Spec.Publish))
+		for i, operation := range obj.Spec.Publish {
+			operations[i] = string(operation)
+		}
+		setParameter("publish", strings.Join(operations, ", "))
+	}
+	if obj.Spec.PublishViaPartitionRoot != nil {
+		setParameter("publish_via_partition_root", strconv.FormatBool(*obj.Spec.PublishViaPartitionRoot))
+	}
+	if obj.Spec.PublishGeneratedColumns != "" {
+		setParameter("publish_generated_columns", string(obj.Spec.PublishGeneratedColumns))
+	}
+
+	return parameters
+}
+
 func executeDropPublication(ctx context.Context, db *sql.DB, name string) error {
 	if _, err := db.ExecContext(
 		ctx,
@@ -181,7 +225,7 @@ func toPublicationTargetObjectsSQL(obj *apiv1.PublicationTarget) string {
 	return strings.Join(parts, ", ")
 }
 
-func toTableDefinitionSQL(table *apiv1.PublicationTargetTable) string {
+func toTableNameSQL(table *apiv1.PublicationTargetTable) string {
 	result := strings.Builder{}
 
 	if table.Only {
@@ -194,6 +238,14 @@ func toTableDefinitionSQL(table *apiv1.PublicationTargetTable) string {
 
 	result.WriteString(pgx.Identifier{table.Name}.Sanitize())
 
+	return result.String()
+}
+
+func toTableDefinitionSQL(table *apiv1.PublicationTargetTable) string {
+	result := strings.Builder{}
+
+	result.WriteString(toTableNameSQL(table))
+
 	if len(table.Columns) > 0 {
 		sanitizedColumns := make([]string, 0, len(table.Columns))
 		for _, column := range table.Columns {
@@ -202,5 +254,438 @@ func toTableDefinitionSQL(table *apiv1.PublicationTargetTable) string {
 		fmt.Fprintf(&result, " (%s)", strings.Join(sanitizedColumns, ", "))
 	}
 
+	if len(table.Where) > 0 {
+		fmt.Fprintf(&result, " WHERE (%s)", table.Where)
+	}
+
 	return result.String()
 }
+
+// checkRowFilters checks that the row filters of the passed tables are
+// expressions that cannot end the WHERE clause they are written in, by
+// using them in a query before they reach the statements changing the
+// publication. The query runs in a read-only transaction and never
+// evaluates the filters, while its parameter requires the extended query
+// protocol, which refuses multiple statements.
+func checkRowFilters(ctx context.Context, db *sql.DB, tables []*apiv1.PublicationTargetTable) error {
+	if !slices.ContainsFunc(tables, func(table *apiv1.PublicationTargetTable) bool {
+		return table.Where != ""
+	}) {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, table := range tables {
+		if table.Where == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SELECT FROM %s WHERE (%s) LIMIT $1",
+			toTableNameSQL(table), table.Where), 0); err != nil {
+			return fmt.Errorf("invalid row filter %q for table %q: %w", table.Where, table.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// pg_publication_rel stores the row filter and the column list of the
+// published tables only since PostgreSQL 15
+const detectPublicationTablesSQL = `
+SELECT n.nspname, c.relname,
+	pg_catalog.pg_get_expr(pr.prqual, pr.prrelid),
+	(SELECT pg_catalog.array_agg(a.attname ORDER BY a.attname)
+		FROM pg_catalog.pg_attribute a
+		WHERE a.attrelid = pr.prrelid AND a.attnum = ANY(pr.prattrs))
+FROM pg_catalog.pg_publication_rel pr
+JOIN pg_catalog.pg_publication p ON pr.prpubid = p.oid
+JOIN pg_catalog.pg_class c ON pr.prrelid = c.oid
+JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+WHERE p.pubname = $1
+ORDER BY n.nspname, c.relname
+`
+
+const detectPublicationTablesLegacySQL = `
+SELECT n.nspname, c.relname, NULL::text, NULL::text[]
+FROM pg_catalog.pg_publication_rel pr
+JOIN pg_catalog.pg_publication p ON pr.prpubid = p.oid
+JOIN pg_catalog.pg_class c ON pr.prrelid = c.oid
+JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+WHERE p.pubname = $1
+ORDER BY n.nspname, c.relname
+`
+
+// pg_publication_namespace is only available since PostgreSQL 15, like
+// the publication of the tables in a schema
+const detectPublicationSchemasSQL = `
+SELECT n.nspname
+FROM pg_catalog.pg_publication_namespace pn
+JOIN pg_catalog.pg_publication p ON pn.pnpubid = p.oid
+JOIN pg_catalog.pg_namespace n ON pn.pnnspid = n.oid
+WHERE p.pubname = $1
+ORDER BY n.nspname
+`
+
+// resolveTableSchemaSQL finds the schema of a table using the search_path
+// of the connection, like CREATE PUBLICATION does with an unqualified name
+const resolveTableSchemaSQL = `
+SELECT n.nspname
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+WHERE c.oid = pg_catalog.to_regclass($1)
+`
+
+// detectPublicationSQL returns the query reading a publication from the
+// catalog. pg_publication stores whether the generated columns are
+// published only since PostgreSQL 18.
+func detectPublicationSQL(pgMajorVersion int) string {
+	generatedColumns := `'n'::"char"`
+	if pgMajorVersion >= 18 {
+		generatedColumns = "pubgencols"
+	}
+	return fmt.Sprintf(
+		"SELECT puballtables, pubinsert, pubupdate, pubdelete, pubtruncate, pubviaroot, %s "+
+			"FROM pg_catalog.pg_publication WHERE pubname = $1",
+		generatedColumns)
+}
+
+// publishedTable is a table of a publication, as found in the catalog
+type publishedTable struct {
+	filter  sql.NullString
+	columns []string
+}
+
+// detectPublicationDrift compares the target and the options declared in
+// the spec of a publication with the ones in the catalog, returning the
+// differences found
+func detectPublicationDrift(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Publication,
+	pgMajorVersion int,
+) ([]string, error) {
+	row := db.QueryRowContext(ctx, detectPublicationSQL(pgMajorVersion), obj.Spec.Name)
+	var allTables, insert, update, deleteOperation, truncate, viaRoot bool
+	var generatedColumns string
+	if err := row.Scan(&allTables, &insert, &update, &deleteOperation, &truncate, &viaRoot,
+		&generatedColumns); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{fmt.Sprintf("publication %q is missing", obj.Spec.Name)}, nil
+		}
+		return nil, fmt.Errorf("while detecting publication %q: %w", obj.Spec.Name, err)
+	}
+
+	differences := comparePublicationOptions(obj, pgMajorVersion, publicationOptions{
+		publish: map[apiv1.PublicationOperation]bool{
+			apiv1.PublicationOperationInsert:   insert,
+			apiv1.PublicationOperationUpdate:   update,
+			apiv1.PublicationOperationDelete:   deleteOperation,
+			apiv1.PublicationOperationTruncate: truncate,
+		},
+		publishViaPartitionRoot: viaRoot,
+		generatedColumnsStored:  generatedColumns == "s",
+	})
+
+	switch {
+	case obj.Spec.Target.AllTables && !allTables:
+		return append(differences, fmt.Sprintf("publication %q does not publish all tables", obj.Spec.Name)), nil
+	case obj.Spec.Target.AllTables:
+		return differences, nil
+	case allTables:
+		return append(differences, fmt.Sprintf("publication %q publishes all tables", obj.Spec.Name)), nil
+	}
+
+	var declaredSchemas []string
+	declaredTables := make(map[string]*apiv1.PublicationTargetTable)
+	for _, object := range obj.Spec.Target.Objects {
+		if object.TablesInSchema != "" {
+			declaredSchemas = append(declaredSchemas, object.TablesInSchema)
+		}
+		if object.Table != nil {
+			name, err := resolvePublicationTableName(ctx, db, object.Table)
+			if err != nil {
+				return nil, err
+			}
+			declaredTables[name] = object.Table
+		}
+	}
+
+	tablesQuery := detectPublicationTablesSQL
+	if pgMajorVersion < 15 {
+		tablesQuery = detectPublicationTablesLegacySQL
+	}
+	publishedTables, err := queryPublicationTables(ctx, db, tablesQuery, obj.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while detecting the tables of publication %q: %w", obj.Spec.Name, err)
+	}
+	differences = append(differences, comparePublicationObjects(
+		"table", slices.Sorted(maps.Keys(declaredTables)), slices.Sorted(maps.Keys(publishedTables)))...)
+	if pgMajorVersion >= 15 {
+		filteredTables := make(map[string]*apiv1.PublicationTargetTable)
+		for name, table := range declaredTables {
+			if _, ok := publishedTables[name]; ok && table.Where != "" {
+				filteredTables[name] = table
+			}
+		}
+		declaredFilters, err := deparseRowFilters(ctx, db, filteredTables)
+		if err != nil {
+			return nil, fmt.Errorf("while reading the row filters of publication %q: %w", obj.Spec.Name, err)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(declaredTables)) {
+			if published, ok := publishedTables[name]; ok {
+				differences = append(differences, comparePublishedTable(
+					name, declaredTables[name], declaredFilters[name].filter.String, published)...)
+			}
+		}
+	}
+
+	if len(declaredSchemas) > 0 {
+		publishedSchemas, err := queryPublicationObjects(ctx, db, detectPublicationSchemasSQL, obj.Spec.Name, 1)
+		if err != nil {
+			return nil, fmt.Errorf("while detecting the schemas of publication %q: %w", obj.Spec.Name, err)
+		}
+		differences = append(differences, comparePublicationObjects("schema", declaredSchemas, publishedSchemas)...)
+	}
+
+	return differences, nil
+}
+
+// resolvePublicationTableName returns the schema-qualified name of a table
+// declared in a publication. An unqualified table is looked up in the
+// search_path; when it doesn't exist, its name is returned as declared.
+func resolvePublicationTableName(
+	ctx context.Context,
+	db *sql.DB,
+	table *apiv1.PublicationTargetTable,
+) (string, error) {
+	if table.Schema != "" {
+		return fmt.Sprintf("%s.%s", table.Schema, table.Name), nil
+	}
+
+	var schema string
+	err := db.QueryRowContext(ctx, resolveTableSchemaSQL, pgx.Identifier{table.Name}.Sanitize()).Scan(&schema)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return table.Name, nil
+	case err != nil:
+		return "", fmt.Errorf("while resolving the schema of table %q: %w", table.Name, err)
+	}
+
+	return fmt.Sprintf("%s.%s", schema, table.Name), nil
+}
+
+// queryPublicationTables returns the tables of a publication, indexed by
+// their schema-qualified name
+func queryPublicationTables(
+	ctx context.Context,
+	db interface {
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	},
+	query string,
+	name string,
+) (map[string]publishedTable, error) {
+	rows, err := db.QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]publishedTable)
+	for rows.Next() {
+		var schema, relation string
+		var table publishedTable
+		if err := rows.Scan(&schema, &relation, &table.filter, pq.Array(&table.columns)); err != nil {
+			return nil, err
+		}
+		result[fmt.Sprintf("%s.%s", schema, relation)] = table
+	}
+
+	return result, rows.Err()
+}
+
+// driftDetectionPublicationName is the name of the publication created,
+// and immediately rolled back, to have PostgreSQL print the declared row
+// filters in the form it stores them
+const driftDetectionPublicationName = "cnpg_drift_detection"
+
+// deparseRowFilters returns the row filters declared for the passed tables
+// as PostgreSQL prints them, so that they can be compared with the
+// published ones. They are found creating a publication for those tables
+// in a transaction that is rolled back.
+func deparseRowFilters(
+	ctx context.Context,
+	db *sql.DB,
+	tables map[string]*apiv1.PublicationTargetTable,
+) (map[string]publishedTable, error) {
+	if len(tables) == 0 {
+		return nil, nil
+	}
+
+	definitions := make([]string, 0, len(tables))
+	checkedTables := make([]*apiv1.PublicationTargetTable, 0, len(tables))
+	for _, name := range slices.Sorted(maps.Keys(tables)) {
+		table := &apiv1.PublicationTargetTable{
+			Schema: tables[name].Schema,
+			Name:   tables[name].Name,
+			Where:  tables[name].Where,
+		}
+		checkedTables = append(checkedTables, table)
+		definitions = append(definitions, toTableDefinitionSQL(table))
+	}
+	if err := checkRowFilters(ctx, db, checkedTables); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE PUBLICATION %s FOR TABLE %s",
+		pgx.Identifier{driftDetectionPublicationName}.Sanitize(),
+		strings.Join(definitions, ", "))); err != nil {
+		return nil, err
+	}
+
+	return queryPublicationTables(ctx, tx, detectPublicationTablesSQL, driftDetectionPublicationName)
+}
+
+// comparePublishedTable returns the differences between the row filter and
+// the column list declared for a table and the published ones. The declared
+// row filter is the one printed by PostgreSQL.
+func comparePublishedTable(
+	name string,
+	declared *apiv1.PublicationTargetTable,
+	declaredFilter string,
+	published publishedTable,
+) []string {
+	var differences []string
+
+	if declaredFilter != published.filter.String {
+		differences = append(differences, fmt.Sprintf("table %q is published with row filter %q instead of %q",
+			name, published.filter.String, declaredFilter))
+	}
+
+	declaredColumns := slices.Sorted(slices.Values(declared.Columns))
+	if !slices.Equal(declaredColumns, published.columns) {
+		differences = append(differences, fmt.Sprintf("table %q is published with columns %q instead of %q",
+			name, published.columns, declaredColumns))
+	}
+
+	return differences
+}
+
+// publicationOptions are the options of a publication, as found in the
+// catalog
+type publicationOptions struct {
+	publish                 map[apiv1.PublicationOperation]bool
+	publishViaPartitionRoot bool
+	generatedColumnsStored  bool
+}
+
+// comparePublicationOptions returns the differences between the options
+// declared for a publication, or their defaults, and the ones in the catalog
+func comparePublicationOptions(obj *apiv1.Publication, pgMajorVersion int, published publicationOptions) []string {
+	parameters := getPublicationParameters(obj)
+	var differences []string
+
+	declaredPublish := make(map[apiv1.PublicationOperation]bool, len(published.publish))
+	if value, ok := parameters["publish"]; ok {
+		for _, operation := range strings.Split(value, ",") {
+			declaredPublish[apiv1.PublicationOperation(strings.ToLower(strings.TrimSpace(operation)))] = true
+		}
+	} else {
+		for operation := range published.publish {
+			declaredPublish[operation] = true
+		}
+	}
+	for _, operation := range slices.Sorted(maps.Keys(published.publish)) {
+		if declaredPublish[operation] != published.publish[operation] {
+			differences = append(differences, fmt.Sprintf("publication %q publishes %s: %t instead of %t",
+				obj.Spec.Name, operation, published.publish[operation], declaredPublish[operation]))
+		}
+	}
+
+	declaredViaRoot := false
+	if value, ok := parameters["publish_via_partition_root"]; ok {
+		declaredViaRoot, _ = postgres.ParsePostgresConfigBoolean(value)
+	}
+	if declaredViaRoot != published.publishViaPartitionRoot {
+		differences = append(differences, fmt.Sprintf("publication %q has publish_via_partition_root %t instead of %t",
+			obj.Spec.Name, published.publishViaPartitionRoot, declaredViaRoot))
+	}
+
+	if pgMajorVersion >= 18 {
+		declaredStored := strings.EqualFold(parameters["publish_generated_columns"],
+			string(apiv1.PublicationGeneratedColumnsStored))
+		if declaredStored != published.generatedColumnsStored {
+			differences = append(differences, fmt.Sprintf(
+				"publication %q publishes the stored generated columns: %t instead of %t",
+				obj.Spec.Name, published.generatedColumnsStored, declaredStored))
+		}
+	}
+
+	return differences
+}
+
+// queryPublicationObjects returns the objects of a publication, joining
+// with a dot the columns of each row
+func queryPublicationObjects(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	name string,
+	columns int,
+) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []string
+	for rows.Next() {
+		values := make([]string, columns)
+		destinations := make([]any, columns)
+		for i := range values {
+			destinations[i] = &values[i]
+		}
+		if err := rows.Scan(destinations...); err != nil {
+			return nil, err
+		}
+		result = append(result, strings.Join(values, "."))
+	}
+
+	return result, rows.Err()
+}
+
+// comparePublicationObjects returns the differences between the declared
+// and the published objects of a publication
+func comparePublicationObjects(kind string, declared, published []string) []string {
+	var differences []string
+	for _, name := range declared {
+		if !slices.Contains(published, name) {
+			differences = append(differences, fmt.Sprintf("%s %q is not published", kind, name))
+		}
+	}
+	for _, name := range published {
+		if !slices.Contains(declared, name) {
+			differences = append(differences, fmt.Sprintf("%s %q is published but not declared", kind, name))
+		}
+	}
+	return differences
+}
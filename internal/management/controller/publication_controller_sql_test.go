@@ -25,6 +25,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 
@@ -170,6 +171,239 @@ var _ = Describe("publication sql", func() {
 			`CREATE PUBLICATION "test_pub" FOR TABLES IN SCHEMA "public" WITH ("param1" = 'value1', "param2" = 'value2')`,
 		))
 	})
+
+	publicationColumns := []string{
+		"puballtables", "pubinsert", "pubupdate", "pubdelete", "pubtruncate", "pubviaroot", "pubgencols",
+	}
+	publishedTableColumns := []string{"nspname", "relname", "pg_get_expr", "array_agg"}
+
+	It("detects the drift of the published tables", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{Name: "a"}},
+						{Table: &apiv1.PublicationTargetTable{Name: "b", Schema: "sales"}},
+					},
+				},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(17)).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publicationColumns).AddRow(false, true, true, true, true, false, "n"))
+		dbMock.ExpectQuery(resolveTableSchemaSQL).
+			WithArgs(`"a"`).
+			WillReturnRows(sqlmock.NewRows([]string{"nspname"}).AddRow("app"))
+		dbMock.ExpectQuery(detectPublicationTablesSQL).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publishedTableColumns).
+				AddRow("app", "a", nil, nil).
+				AddRow("public", "c", nil, nil))
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{
+			`table "sales.b" is not published`,
+			`table "public.c" is published but not declared`,
+		}))
+	})
+
+	It("detects the drift of the row filters and the column lists", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{
+							Name: "a", Schema: "app", Where: "id > 10 AND name <> 'b'", Columns: []string{"name", "id"},
+						}},
+						{Table: &apiv1.PublicationTargetTable{Name: "b", Schema: "app", Where: "id > 10"}},
+						{Table: &apiv1.PublicationTargetTable{Name: "c", Schema: "app", Columns: []string{"id"}}},
+					},
+				},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(17)).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publicationColumns).AddRow(false, true, true, true, true, false, "n"))
+		dbMock.ExpectQuery(detectPublicationTablesSQL).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publishedTableColumns).
+				AddRow("app", "a", "((id > 10) AND (name <> 'b'::text))", "{id,name}").
+				AddRow("app", "b", nil, nil).
+				AddRow("app", "c", "(id > 20)", "{id,name}"))
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`SELECT FROM "app"."a" WHERE (id > 10 AND name <> 'b') LIMIT $1`).
+			WithArgs(0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(`SELECT FROM "app"."b" WHERE (id > 10) LIMIT $1`).
+			WithArgs(0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectRollback()
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`CREATE PUBLICATION "cnpg_drift_detection" FOR TABLE ` +
+			`"app"."a" WHERE (id > 10 AND name <> 'b'), "app"."b" WHERE (id > 10)`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectQuery(detectPublicationTablesSQL).
+			WithArgs(driftDetectionPublicationName).
+			WillReturnRows(sqlmock.NewRows(publishedTableColumns).
+				AddRow("app", "a", "((id > 10) AND (name <> 'b'::text))", nil).
+				AddRow("app", "b", "(id > 10)", nil))
+		dbMock.ExpectRollback()
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{
+			`table "app.b" is published with row filter "" instead of "(id > 10)"`,
+			`table "app.c" is published with row filter "(id > 20)" instead of ""`,
+			`table "app.c" is published with columns ["id" "name"] instead of ["id"]`,
+		}))
+	})
+
+	It("refuses the row filters that end their WHERE clause", func(ctx SpecContext) {
+		maliciousFilter := "true); ALTER ROLE app SUPERUSER; --"
+		tables := []*apiv1.PublicationTargetTable{
+			{Name: "a", Where: "id > 10"},
+			{Name: "b", Where: maliciousFilter},
+		}
+
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(`SELECT FROM "a" WHERE (id > 10) LIMIT $1`).
+			WithArgs(0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(fmt.Sprintf(`SELECT FROM "b" WHERE (%s) LIMIT $1`, maliciousFilter)).
+			WithArgs(0).
+			WillReturnError(fmt.Errorf("cannot insert multiple commands into a prepared statement"))
+		dbMock.ExpectRollback()
+
+		err := checkRowFilters(ctx, db, tables)
+		Expect(err).To(MatchError(ContainSubstring(`invalid row filter %q for table "b"`, maliciousFilter)))
+	})
+
+	It("doesn't create a publication with a malicious row filter", func(ctx SpecContext) {
+		maliciousFilter := "true); ALTER ROLE app SUPERUSER; --"
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name:   "pub",
+				DBName: "app",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{Name: "a", Where: maliciousFilter}},
+					},
+				},
+			},
+		}
+		r := &PublicationReconciler{getDB: func(string) (*sql.DB, error) { return db, nil }}
+
+		dbMock.ExpectQuery(`
+		SELECT count(*)
+		FROM pg_catalog.pg_publication
+	        WHERE pubname = $1
+		`).WithArgs("pub").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		dbMock.ExpectBegin()
+		dbMock.ExpectExec(fmt.Sprintf(`SELECT FROM "a" WHERE (%s) LIMIT $1`, maliciousFilter)).
+			WithArgs(0).
+			WillReturnError(fmt.Errorf("cannot insert multiple commands into a prepared statement"))
+		dbMock.ExpectRollback()
+
+		Expect(r.alignPublication(ctx, obj)).To(MatchError(ContainSubstring("invalid row filter")))
+	})
+
+	It("doesn't check the tables without row filters", func(ctx SpecContext) {
+		Expect(checkRowFilters(ctx, db, []*apiv1.PublicationTargetTable{{Name: "a"}})).To(Succeed())
+	})
+
+	It("ignores the row filters and the column lists before PostgreSQL 15", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{Name: "a", Schema: "app"}},
+					},
+				},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(14)).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publicationColumns).AddRow(false, true, true, true, true, false, "n"))
+		dbMock.ExpectQuery(detectPublicationTablesLegacySQL).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publishedTableColumns).AddRow("app", "a", nil, nil))
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 14)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(BeEmpty())
+	})
+
+	It("detects the drift of the publication options", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name:                    "pub",
+				Publish:                 []apiv1.PublicationOperation{apiv1.PublicationOperationInsert},
+				PublishGeneratedColumns: apiv1.PublicationGeneratedColumnsStored,
+				Target:                  apiv1.PublicationTarget{AllTables: true},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(18)).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publicationColumns).AddRow(true, true, false, true, false, true, "n"))
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 18)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{
+			`publication "pub" publishes delete: true instead of false`,
+			`publication "pub" has publish_via_partition_root true instead of false`,
+			`publication "pub" publishes the stored generated columns: false instead of true`,
+		}))
+	})
+
+	It("detects the drift of the published schemas", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{{TablesInSchema: "sales"}},
+				},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(17)).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publicationColumns).AddRow(false, true, true, true, true, false, "n"))
+		dbMock.ExpectQuery(detectPublicationTablesSQL).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows(publishedTableColumns))
+		dbMock.ExpectQuery(detectPublicationSchemasSQL).
+			WithArgs("pub").
+			WillReturnRows(sqlmock.NewRows([]string{"nspname"}))
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{`schema "sales" is not published`}))
+	})
+
+	It("detects a missing publication", func(ctx SpecContext) {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name:   "pub",
+				Target: apiv1.PublicationTarget{AllTables: true},
+			},
+		}
+
+		dbMock.ExpectQuery(detectPublicationSQL(17)).
+			WithArgs("pub").
+			WillReturnError(sql.ErrNoRows)
+
+		differences, err := detectPublicationDrift(ctx, db, obj, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{`publication "pub" is missing`}))
+	})
 })
 
 var _ = Describe("toPublicationCreateSQL", func() {
@@ -227,4 +461,69 @@ var _ = Describe("toPublicationCreateSQL", func() {
 		sql := toPublicationCreateSQL(obj)
 		Expect(sql).To(Equal(`CREATE PUBLICATION "test_pub" FOR TABLE "table1" ("a", "b"), "table2" ("c")`))
 	})
+	It("returns correct SQL for tables with row filters", func() {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "test_pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{
+							Name:    "orders",
+							Columns: []string{"id", "region"},
+							Where:   "region = 'EU'",
+						}},
+						{Table: &apiv1.PublicationTargetTable{Name: "customers"}},
+					},
+				},
+			},
+		}
+
+		sql := toPublicationCreateSQL(obj)
+		Expect(sql).To(Equal(
+			`CREATE PUBLICATION "test_pub" FOR TABLE "orders" ("id", "region") WHERE (region = 'EU'), "customers"`))
+	})
+
+	It("returns correct SQL for the typed publish options", func() {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "test_pub",
+				Target: apiv1.PublicationTarget{
+					AllTables: true,
+				},
+				Parameters: map[string]string{"param1": "value1"},
+				Publish: []apiv1.PublicationOperation{
+					apiv1.PublicationOperationInsert,
+					apiv1.PublicationOperationUpdate,
+				},
+				PublishViaPartitionRoot: ptr.To(true),
+				PublishGeneratedColumns: apiv1.PublicationGeneratedColumnsStored,
+			},
+		}
+
+		sql := toPublicationCreateSQL(obj)
+		Expect(sql).To(Equal(
+			`CREATE PUBLICATION "test_pub" FOR ALL TABLES WITH ("param1" = 'value1', "publish" = 'insert, update', ` +
+				`"publish_generated_columns" = 'stored', "publish_via_partition_root" = 'true')`))
+		Expect(obj.Spec.Parameters).To(HaveLen(1))
+	})
+
+	It("alters the row filters and the publish options of an existing publication", func() {
+		obj := &apiv1.Publication{
+			Spec: apiv1.PublicationSpec{
+				Name: "test_pub",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{Name: "orders", Where: "region = 'EU'"}},
+					},
+				},
+				PublishViaPartitionRoot: ptr.To(false),
+			},
+		}
+
+		sqls := toPublicationAlterSQL(obj)
+		Expect(sqls).To(Equal([]string{
+			`ALTER PUBLICATION "test_pub" SET TABLE "orders" WHERE (region = 'EU')`,
+			`ALTER PUBLICATION "test_pub" SET ("publish_via_partition_root" = 'false')`,
+		}))
+	})
 })
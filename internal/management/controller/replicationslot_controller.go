@@ -0,0 +1,443 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ReplicationSlotReconciler reconciles a ReplicationSlot object
+type ReplicationSlotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	recorder                record.EventRecorder
+	instance                *postgres.Instance
+	finalizerReconciler     *finalizerReconciler[*apiv1.ReplicationSlot]
+	getDB                   func(name string) (*sql.DB, error)
+	getPostgresMajorVersion func() (int, error)
+}
+
+// replicationSlotReconciliationInterval is the time between the
+// replication slot reconciliation loop failures, and between two
+// refreshes of the state of an applied replication slot
+const replicationSlotReconciliationInterval = 30 * time.Second
+
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=replicationslots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=replicationslots/status,verbs=get;update;patch
+
+// Reconcile is the replication slot reconciliation loop
+func (r *ReplicationSlotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx).
+		WithName("replication_slot_reconciler").
+		WithValues("replicationSlotName", req.Name)
+
+	// Get the replication slot object
+	var slot apiv1.ReplicationSlot
+	if err := r.Get(ctx, client.ObjectKey{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+	}, &slot); err != nil {
+		contextLogger.Trace("Could not fetch ReplicationSlot", "error", err)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// This is not for me!
+	if slot.Spec.ClusterRef.Name != r.instance.GetClusterName() {
+		contextLogger.Trace("ReplicationSlot is not for this cluster",
+			"cluster", slot.Spec.ClusterRef.Name,
+			"expected", r.instance.GetClusterName(),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	// Fetch the Cluster from the cache
+	cluster, err := r.GetCluster(ctx)
+	if err != nil {
+		// A reconciled replication slot keeps its status: the cluster may be
+		// gone or unreadable while it is being deleted.
+		if slot.Generation == slot.Status.ObservedGeneration {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{}, markAsFailed(ctx, r.Client, &slot, fmt.Errorf("while fetching the cluster: %w", err))
+	}
+
+	// If everything is reconciled, we're done here
+	if slot.Generation == slot.Status.ObservedGeneration {
+		// A slot dropped because of the WAL it retained is not created
+		// again until its specification changes
+		if slot.Status.DroppedTime != nil {
+			return ctrl.Result{}, nil
+		}
+
+		// ...unless the cluster moved in or out of the replica role after
+		// the replication slot was applied: report the demotion on the
+		// status, and evaluate the replication slot again after the
+		// promotion.
+		result, proceed, err := handleReplicaRoleTransition(
+			ctx, r.Client, r.instance, cluster, &slot, replicationSlotReconciliationInterval)
+		if err != nil {
+			return result, err
+		}
+
+		// Keep the state of the replication slot up to date, enforcing
+		// the limit of the retained WAL
+		if !proceed {
+			statusResult, err := r.refreshReplicationSlotStatus(ctx, cluster, &slot, time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, statusResult)
+		}
+		if !proceed {
+			return result, nil
+		}
+	}
+
+	// Still not for me, we're waiting for a switchover
+	if cluster.Status.CurrentPrimary != cluster.Status.TargetPrimary {
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	// This is not for me, at least now
+	if cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	contextLogger.Info("Reconciling replication slot")
+	defer func() {
+		contextLogger.Info("Reconciliation loop of replication slot exited")
+	}()
+
+	// A replica cluster is read-only, so the apply path is gated here. Deletion
+	// is still allowed through: an object that acquired its finalizer while this
+	// cluster was primary must release it after a demotion. The drop itself is
+	// skipped on a replica (see evaluateDropReplicationSlot).
+	if cluster.IsReplica() && slot.GetDeletionTimestamp().IsZero() {
+		if err := markAsUnknown(ctx, r.Client, &slot, errClusterIsReplica); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	// The detection only gates the apply path: a replication slot being
+	// deleted must release its finalizer regardless of conflicting managers.
+	if slot.GetDeletionTimestamp().IsZero() {
+		if res, err := detectConflictingManagers(ctx, r.Client, &slot, &apiv1.ReplicationSlotList{}); err != nil ||
+			!res.IsZero() {
+			return res, err
+		}
+	}
+
+	if err := r.finalizerReconciler.reconcile(ctx, &slot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while reconciling the finalizer: %w", err)
+	}
+	if !slot.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.alignReplicationSlot(ctx, cluster, &slot); err != nil {
+		contextLogger.Error(err, "while reconciling replication slot")
+		if markErr := markAsFailed(ctx, r.Client, &slot, err); markErr != nil {
+			contextLogger.Error(err, "while marking as failed the replication slot resource",
+				"error", err,
+				"markError", markErr,
+			)
+			return ctrl.Result{}, fmt.Errorf(
+				"encountered an error while marking as failed the replication slot resource: %w, original error: %w",
+				markErr,
+				err)
+		}
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	contextLogger.Info("Reconciliation of replication slot completed")
+	slot.Status.DroppedTime = nil
+	if err := markAsReady(ctx, r.Client, &slot); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+}
+
+// alignReplicationSlot creates the replication slot if it does not exist,
+// or makes sure the existing one matches the specification
+func (r *ReplicationSlotReconciler) alignReplicationSlot(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	obj *apiv1.ReplicationSlot,
+) error {
+	var haConfiguration *apiv1.ReplicationSlotsHAConfiguration
+	if cluster.Spec.ReplicationSlots != nil {
+		haConfiguration = cluster.Spec.ReplicationSlots.HighAvailability
+	}
+	if prefix := haConfiguration.GetSlotPrefix(); strings.HasPrefix(obj.Spec.Name, prefix) {
+		return fmt.Errorf("the prefix %q is reserved for the replication slots for high availability", prefix)
+	}
+
+	db, err := r.getReplicationSlotDB(obj)
+	if err != nil {
+		return err
+	}
+
+	state, err := getReplicationSlotState(ctx, db, obj.Spec.Name)
+	if err != nil {
+		return err
+	}
+
+	version, err := r.getPostgresMajorVersion()
+	if err != nil {
+		return fmt.Errorf("while getting the PostgreSQL major version: %w", err)
+	}
+	if state != nil {
+		if err := checkReplicationSlotState(obj, state); err != nil {
+			return err
+		}
+		return checkReplicationSlotFailover(ctx, db, obj, version)
+	}
+	return createReplicationSlot(ctx, db, obj, version)
+}
+
+// refreshReplicationSlotStatus records periodically in the status the state
+// of an applied replication slot, on the primary of a cluster that is not a
+// replica, and takes the retention action when the slot retains more WAL
+// than allowed. The other instances keep polling, so that the one promoted
+// by a failover creates the slot again and records its state.
+func (r *ReplicationSlotReconciler) refreshReplicationSlotStatus(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	slot *apiv1.ReplicationSlot,
+	now time.Time,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	applied := slot.Status.Applied
+	if !slot.GetDeletionTimestamp().IsZero() || cluster.IsReplica() || applied == nil || !*applied {
+		return ctrl.Result{}, nil
+	}
+
+	// A failover doesn't change the generation of the cluster, and doesn't
+	// trigger the reconciliation of the replication slots
+	if cluster.Status.CurrentPrimary != r.instance.GetPodName() {
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	if lastUpdate := slot.Status.LastUpdateTime; lastUpdate != nil {
+		if next := lastUpdate.Add(replicationSlotReconciliationInterval).Sub(now); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+	}
+
+	db, err := r.getReplicationSlotDB(slot)
+	if err != nil {
+		contextLogger.Error(err, "while refreshing the state of the replication slot")
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+	state, err := getReplicationSlotState(ctx, db, slot.Spec.Name)
+	if err != nil {
+		// The state is refreshed again at the next interval, without
+		// affecting the applied status of the replication slot
+		contextLogger.Error(err, "while refreshing the state of the replication slot")
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	slot.Status.LastUpdateTime = ptr.To(metav1.NewTime(now))
+	if state == nil {
+		// The slot is created again by the next reconciliation, without
+		// the changes its consumers had not read yet
+		r.recorder.Event(slot, corev1.EventTypeWarning, "SlotMissing",
+			fmt.Sprintf("replication slot %q not found, it will be created again at the current LSN, "+
+				"losing the changes its consumers had not read yet", slot.Spec.Name))
+		slot.Status.Active = false
+		slot.SetAsFailed(fmt.Errorf("replication slot %q not found", slot.Spec.Name))
+		if err := r.Status().Update(ctx, slot); err != nil {
+			return ctrl.Result{}, fmt.Errorf("while recording the state of the replication slot: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+	}
+
+	slot.Status.Active = state.Active
+	slot.Status.RestartLSN = state.RestartLSN
+	slot.Status.ConfirmedFlushLSN = state.ConfirmedFlushLSN
+	slot.Status.WALStatus = state.WALStatus
+	slot.Status.RetainedWALBytes = state.RetainedWALBytes
+	slot.Status.LagBytes = state.LagBytes
+
+	r.enforceRetainedWALLimit(ctx, db, slot, now)
+
+	if err := r.Status().Update(ctx, slot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while recording the state of the replication slot: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: replicationSlotReconciliationInterval}, nil
+}
+
+// enforceRetainedWALLimit takes the retention action of a replication slot
+// retaining more WAL than `maxRetainedWAL`, recording it in the status
+func (r *ReplicationSlotReconciler) enforceRetainedWALLimit(
+	ctx context.Context,
+	db *sql.DB,
+	slot *apiv1.ReplicationSlot,
+	now time.Time,
+) {
+	limit := slot.Spec.MaxRetainedWAL
+	if limit == nil || slot.Status.RetainedWALBytes <= limit.Value() {
+		slot.Status.RetentionExceededTime = nil
+		return
+	}
+
+	firstExceeded := slot.Status.RetentionExceededTime == nil
+	if firstExceeded {
+		slot.Status.RetentionExceededTime = ptr.To(metav1.NewTime(now))
+	}
+	reason := fmt.Sprintf("replication slot %q retains %d bytes of WAL, more than the maxRetainedWAL of %s",
+		slot.Spec.Name, slot.Status.RetainedWALBytes, limit.String())
+
+	if slot.GetRetentionAction() != apiv1.ReplicationSlotRetentionDrop {
+		if firstExceeded {
+			r.recorder.Event(slot, corev1.EventTypeWarning, "RetentionExceeded", reason)
+		}
+		return
+	}
+
+	if err := executeDropReplicationSlot(ctx, db, slot.Spec.Name); err != nil {
+		// The drop is attempted again at the next interval
+		log.FromContext(ctx).Error(err, "while dropping the replication slot exceeding the retained WAL limit")
+		r.recorder.Event(slot, corev1.EventTypeWarning, "DropFailed", err.Error())
+		return
+	}
+
+	r.recorder.Event(slot, corev1.EventTypeWarning, "Dropped", reason)
+	slot.Status.Active = false
+	slot.Status.DroppedTime = ptr.To(metav1.NewTime(now))
+	slot.SetAsFailed(fmt.Errorf("dropped because %s", reason))
+}
+
+func (r *ReplicationSlotReconciler) evaluateDropReplicationSlot(
+	ctx context.Context,
+	slot *apiv1.ReplicationSlot,
+) error {
+	if slot.Spec.ReclaimPolicy != apiv1.ReplicationSlotReclaimDelete {
+		return nil
+	}
+
+	// On a replica we cannot drop the replication slot: return without
+	// touching PostgreSQL so the finalizer is released.
+	cluster, err := r.GetCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("while fetching the cluster: %w", err)
+	}
+	if cluster.IsReplica() {
+		return nil
+	}
+
+	// An object that never reconciled does not own the replication slot: a
+	// conflicting duplicate is blocked before applying anything, and its
+	// deletion must not drop the slot owned by the surviving object.
+	if !slot.HasReconciliations() {
+		return nil
+	}
+
+	db, err := r.getReplicationSlotDB(slot)
+	if err != nil {
+		return err
+	}
+	return executeDropReplicationSlot(ctx, db, slot.Spec.Name)
+}
+
+// getReplicationSlotDB gets a connection to the database of a logical
+// replication slot, which can only be created and dropped from there, or to
+// the `postgres` database for a physical one
+func (r *ReplicationSlotReconciler) getReplicationSlotDB(slot *apiv1.ReplicationSlot) (*sql.DB, error) {
+	dbName := "postgres"
+	if slot.Spec.Type == apiv1.ReplicationSlotTypeLogical {
+		dbName = slot.Spec.Database
+	}
+	db, err := r.getDB(dbName)
+	if err != nil {
+		return nil, fmt.Errorf("while getting DB connection: %w", err)
+	}
+	return db, nil
+}
+
+// NewReplicationSlotReconciler creates a new replication slot reconciler
+func NewReplicationSlotReconciler(
+	mgr manager.Manager,
+	instance *postgres.Instance,
+) *ReplicationSlotReconciler {
+	rr := &ReplicationSlotReconciler{
+		Client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor("instance-replication-slot"), //nolint:staticcheck
+		instance: instance,
+		getDB: func(name string) (*sql.DB, error) {
+			return instance.ConnectionPool().Connection(name)
+		},
+		getPostgresMajorVersion: func() (int, error) {
+			version, err := instance.GetPgVersion()
+			return int(version.Major()), err //nolint:gosec
+		},
+	}
+	rr.finalizerReconciler = newFinalizerReconciler(
+		mgr.GetClient(),
+		utils.ReplicationSlotFinalizerName,
+		rr.evaluateDropReplicationSlot,
+	)
+
+	return rr
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ReplicationSlotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.ReplicationSlot{}).
+		Watches(
+			&apiv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(mapClusterToManagedResources(
+				r.instance, mgr.GetClient(),
+				func() client.ObjectList { return &apiv1.ReplicationSlotList{} })),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Named("instance-replication-slot").
+		Complete(r)
+}
+
+// GetCluster gets the managed cluster through the client
+func (r *ReplicationSlotReconciler) GetCluster(ctx context.Context) (*apiv1.Cluster, error) {
+	return getClusterFromInstance(ctx, r.Client, r.instance)
+}
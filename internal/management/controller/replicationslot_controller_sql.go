@@ -0,0 +1,182 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// replicationSlotState is the state of a replication slot in
+// `pg_replication_slots`
+type replicationSlotState struct {
+	Type              string
+	Plugin            string
+	Database          string
+	Active            bool
+	RestartLSN        string
+	ConfirmedFlushLSN string
+	WALStatus         string
+	RetainedWALBytes  int64
+	LagBytes          int64
+}
+
+// replicationSlotStateSQL gets the state of a replication slot. The lag of
+// a physical slot, which has no confirmed position, is measured from the
+// position it requires.
+const replicationSlotStateSQL = `SELECT slot_type, coalesce(plugin, ''), coalesce(database, ''), active,
+	coalesce(restart_lsn::text, ''), coalesce(confirmed_flush_lsn::text, ''), coalesce(wal_status, ''),
+	coalesce(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_current_wal_lsn(), restart_lsn), 0)::bigint,
+	coalesce(pg_catalog.pg_wal_lsn_diff(pg_catalog.pg_current_wal_lsn(),
+		coalesce(confirmed_flush_lsn, restart_lsn)), 0)::bigint
+	FROM pg_catalog.pg_replication_slots
+	WHERE slot_name = $1 AND NOT temporary`
+
+// getReplicationSlotState gets the state of a replication slot, nil if
+// the slot does not exist
+func getReplicationSlotState(ctx context.Context, db *sql.DB, name string) (*replicationSlotState, error) {
+	var state replicationSlotState
+	row := db.QueryRowContext(ctx, replicationSlotStateSQL, name)
+	if err := row.Scan(
+		&state.Type,
+		&state.Plugin,
+		&state.Database,
+		&state.Active,
+		&state.RestartLSN,
+		&state.ConfirmedFlushLSN,
+		&state.WALStatus,
+		&state.RetainedWALBytes,
+		&state.LagBytes,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while getting the state of the replication slot %q: %w", name, err)
+	}
+	return &state, nil
+}
+
+// checkReplicationSlotState makes sure an existing replication slot matches
+// the specification, as its type, database and plugin cannot be changed
+func checkReplicationSlotState(obj *apiv1.ReplicationSlot, state *replicationSlotState) error {
+	if state.Type != string(obj.Spec.Type) {
+		return fmt.Errorf("replication slot %q already exists with type %q",
+			obj.Spec.Name, state.Type)
+	}
+	if obj.Spec.Type != apiv1.ReplicationSlotTypeLogical {
+		return nil
+	}
+	if state.Database != obj.Spec.Database || state.Plugin != obj.Spec.Plugin {
+		return fmt.Errorf("replication slot %q already exists in database %q with plugin %q",
+			obj.Spec.Name, state.Database, state.Plugin)
+	}
+	return nil
+}
+
+// replicationSlotFailoverSQL gets whether a logical replication slot is
+// synchronized to the standbys, available since PostgreSQL 17
+const replicationSlotFailoverSQL = `SELECT failover FROM pg_catalog.pg_replication_slots
+	WHERE slot_name = $1 AND NOT temporary`
+
+// checkReplicationSlotFailover makes sure an existing logical replication
+// slot is synchronized to the standbys as requested by the specification,
+// as the operator cannot change it. A slot not synchronized is lost at
+// the next switchover.
+func checkReplicationSlotFailover(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.ReplicationSlot,
+	pgMajorVersion int,
+) error {
+	if obj.Spec.Type != apiv1.ReplicationSlotTypeLogical {
+		return nil
+	}
+	if pgMajorVersion < 17 {
+		if obj.Spec.Failover {
+			return fmt.Errorf("the failover of replication slot %q requires PostgreSQL 17 or later", obj.Spec.Name)
+		}
+		return nil
+	}
+
+	var failover bool
+	if err := db.QueryRowContext(ctx, replicationSlotFailoverSQL, obj.Spec.Name).Scan(&failover); err != nil {
+		return fmt.Errorf("while getting the failover of replication slot %q: %w", obj.Spec.Name, err)
+	}
+	if failover != obj.Spec.Failover {
+		return fmt.Errorf("replication slot %q already exists with failover set to %t",
+			obj.Spec.Name, failover)
+	}
+	return nil
+}
+
+// createReplicationSlot creates a replication slot. A physical slot
+// reserves the WAL immediately, without waiting for its consumer.
+func createReplicationSlot(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.ReplicationSlot,
+	pgMajorVersion int,
+) error {
+	var err error
+	switch {
+	case obj.Spec.Type == apiv1.ReplicationSlotTypePhysical:
+		_, err = db.ExecContext(ctx,
+			"SELECT pg_catalog.pg_create_physical_replication_slot($1, true)",
+			obj.Spec.Name)
+	case obj.Spec.Failover && pgMajorVersion < 17:
+		return fmt.Errorf("the failover of replication slot %q requires PostgreSQL 17 or later", obj.Spec.Name)
+	case obj.Spec.Failover:
+		_, err = db.ExecContext(ctx,
+			"SELECT pg_catalog.pg_create_logical_replication_slot($1, $2, false, false, true)",
+			obj.Spec.Name, obj.Spec.Plugin)
+	default:
+		_, err = db.ExecContext(ctx,
+			"SELECT pg_catalog.pg_create_logical_replication_slot($1, $2)",
+			obj.Spec.Name, obj.Spec.Plugin)
+	}
+	if err != nil {
+		return fmt.Errorf("while creating replication slot %q: %w", obj.Spec.Name, err)
+	}
+	return nil
+}
+
+// executeDropReplicationSlot drops a replication slot if it exists,
+// terminating its consumer first, as an active slot cannot be dropped
+func executeDropReplicationSlot(ctx context.Context, db *sql.DB, name string) error {
+	if _, err := db.ExecContext(ctx,
+		`SELECT pg_catalog.pg_terminate_backend(active_pid)
+		FROM pg_catalog.pg_replication_slots
+		WHERE slot_name = $1 AND active_pid IS NOT NULL`,
+		name); err != nil {
+		return fmt.Errorf("while terminating the consumer of replication slot %q: %w", name, err)
+	}
+	if _, err := db.ExecContext(ctx,
+		`SELECT pg_catalog.pg_drop_replication_slot(slot_name)
+		FROM pg_catalog.pg_replication_slots
+		WHERE slot_name = $1`,
+		name); err != nil {
+		return fmt.Errorf("while dropping replication slot %q: %w", name, err)
+	}
+	return nil
+}
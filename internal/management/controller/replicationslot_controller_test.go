@@ -0,0 +1,446 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	terminateReplicationSlotConsumerSQL = `SELECT pg_catalog.pg_terminate_backend(active_pid)
+		FROM pg_catalog.pg_replication_slots
+		WHERE slot_name = $1 AND active_pid IS NOT NULL`
+	dropReplicationSlotSQL = `SELECT pg_catalog.pg_drop_replication_slot(slot_name)
+		FROM pg_catalog.pg_replication_slots
+		WHERE slot_name = $1`
+)
+
+var replicationSlotStateColumns = []string{
+	"slot_type", "plugin", "database", "active", "restart_lsn", "confirmed_flush_lsn", "wal_status",
+	"retained_wal", "lag",
+}
+
+var _ = Describe("Managed replication slot controller tests", func() {
+	const defaultPostgresMajorVersion = 17
+
+	var (
+		dbMock     sqlmock.Sqlmock
+		db         *sql.DB
+		slot       *apiv1.ReplicationSlot
+		cluster    *apiv1.Cluster
+		r          *ReplicationSlotReconciler
+		recorder   *record.FakeRecorder
+		fakeClient client.Client
+		request    ctrl.Request
+		dbName     string
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+			},
+		}
+		slot = &apiv1.ReplicationSlot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "slot-one",
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: apiv1.ReplicationSlotSpec{
+				ClusterRef: corev1.LocalObjectReference{
+					Name: cluster.Name,
+				},
+				ReclaimPolicy: apiv1.ReplicationSlotReclaimDelete,
+				Name:          "debezium",
+				Type:          apiv1.ReplicationSlotTypeLogical,
+				Database:      "app",
+				Plugin:        "pgoutput",
+			},
+		}
+		request = ctrl.Request{NamespacedName: types.NamespacedName{
+			Namespace: slot.GetNamespace(),
+			Name:      slot.GetName(),
+		}}
+
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		pgInstance := postgres.NewInstance().
+			WithNamespace("default").
+			WithPodName("cluster-example-1").
+			WithClusterName("cluster-example")
+
+		fakeClient = fake.NewClientBuilder().WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, slot).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.ReplicationSlot{}).
+			Build()
+
+		recorder = record.NewFakeRecorder(10)
+		r = &ReplicationSlotReconciler{
+			Client:   fakeClient,
+			Scheme:   schemeBuilder.BuildWithAllKnownScheme(),
+			recorder: recorder,
+			instance: pgInstance,
+			getDB: func(name string) (*sql.DB, error) {
+				dbName = name
+				return db, nil
+			},
+			getPostgresMajorVersion: func() (int, error) {
+				return defaultPostgresMajorVersion, nil
+			},
+		}
+		r.finalizerReconciler = newFinalizerReconciler(
+			fakeClient,
+			utils.ReplicationSlotFinalizerName,
+			r.evaluateDropReplicationSlot,
+		)
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	markApplied := func(ctx SpecContext) {
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		slot.Status.Applied = ptr.To(true)
+		slot.Status.ObservedGeneration = slot.Generation
+		Expect(fakeClient.Status().Update(ctx, slot)).To(Succeed())
+	}
+
+	It("creates the logical replication slot in its database", func(ctx SpecContext) {
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+		dbMock.ExpectExec("SELECT pg_catalog.pg_create_logical_replication_slot($1, $2)").
+			WithArgs(slot.Spec.Name, slot.Spec.Plugin).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(replicationSlotReconciliationInterval))
+		Expect(dbName).To(Equal("app"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeTrue()))
+		Expect(slot.Status.Message).To(BeEmpty())
+		Expect(slot.GetFinalizers()).To(ContainElement(utils.ReplicationSlotFinalizerName))
+	})
+
+	It("creates a physical replication slot reserving the WAL", func(ctx SpecContext) {
+		slot.Spec.Type = apiv1.ReplicationSlotTypePhysical
+		slot.Spec.Database = ""
+		slot.Spec.Plugin = ""
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+		dbMock.ExpectExec("SELECT pg_catalog.pg_create_physical_replication_slot($1, true)").
+			WithArgs(slot.Spec.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dbName).To(Equal("postgres"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeTrue()))
+	})
+
+	It("refuses a name reserved for the high availability slots", func(ctx SpecContext) {
+		slot.Spec.Name = "_cnpg_debezium"
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("reserved"))
+	})
+
+	It("reports an existing slot not matching the specification", func(ctx SpecContext) {
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns).
+				AddRow("logical", "wal2json", "app", false, "0/3000000", "0/3000000", "reserved", 0, 0))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring(`with plugin "wal2json"`))
+	})
+
+	It("reports an existing slot not synchronized to the standbys as declared", func(ctx SpecContext) {
+		existingSlotRows := func() *sqlmock.Rows {
+			return sqlmock.NewRows(replicationSlotStateColumns).
+				AddRow("logical", "pgoutput", "app", false, "0/3000000", "0/3000000", "reserved", 0, 0)
+		}
+
+		slot.Spec.Failover = true
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(existingSlotRows())
+		dbMock.ExpectQuery(replicationSlotFailoverSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"failover"}).AddRow(false))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("failover set to false"))
+
+		slot.Spec.Failover = false
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(existingSlotRows())
+		dbMock.ExpectQuery(replicationSlotFailoverSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"failover"}).AddRow(true))
+
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("failover set to true"))
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(existingSlotRows())
+		dbMock.ExpectQuery(replicationSlotFailoverSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{"failover"}).AddRow(false))
+
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeTrue()))
+	})
+
+	It("requires PostgreSQL 17 for the failover of the slot", func(ctx SpecContext) {
+		slot.Spec.Failover = true
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		r.getPostgresMajorVersion = func() (int, error) {
+			return 16, nil
+		}
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("PostgreSQL 17"))
+	})
+
+	It("records periodically the state of an applied replication slot", func(ctx SpecContext) {
+		markApplied(ctx)
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns).
+				AddRow("logical", "pgoutput", "app", true, "0/3000000", "0/3000100", "reserved", 4096, 1024))
+
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(replicationSlotReconciliationInterval))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.LastUpdateTime).ToNot(BeNil())
+		Expect(slot.Status.Active).To(BeTrue())
+		Expect(slot.Status.RestartLSN).To(Equal("0/3000000"))
+		Expect(slot.Status.ConfirmedFlushLSN).To(Equal("0/3000100"))
+		Expect(slot.Status.WALStatus).To(Equal("reserved"))
+		Expect(slot.Status.RetainedWALBytes).To(BeEquivalentTo(4096))
+		Expect(slot.Status.LagBytes).To(BeEquivalentTo(1024))
+		Expect(slot.Status.RetentionExceededTime).To(BeNil())
+
+		// The state is not refreshed again before the interval elapses
+		result, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", replicationSlotReconciliationInterval))
+	})
+
+	It("marks a missing replication slot to be created again", func(ctx SpecContext) {
+		markApplied(ctx)
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("not found"))
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring("SlotMissing"),
+			ContainSubstring("current LSN"))))
+	})
+
+	It("creates the replication slot again on the instance promoted by a failover", func(ctx SpecContext) {
+		markApplied(ctx)
+		cluster.Status.CurrentPrimary = "cluster-example-2"
+		cluster.Status.TargetPrimary = "cluster-example-2"
+		Expect(fakeClient.Status().Update(ctx, cluster)).To(Succeed())
+
+		// The standby keeps polling, without touching the replication slot
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(replicationSlotReconciliationInterval))
+
+		// The failover promotes this instance, which doesn't find the slot
+		cluster.Status.CurrentPrimary = "cluster-example-1"
+		cluster.Status.TargetPrimary = "cluster-example-1"
+		Expect(fakeClient.Status().Update(ctx, cluster)).To(Succeed())
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+		result, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(replicationSlotReconciliationInterval))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+		dbMock.ExpectExec("SELECT pg_catalog.pg_create_logical_replication_slot($1, $2)").
+			WithArgs(slot.Spec.Name, slot.Spec.Plugin).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.Applied).To(HaveValue(BeTrue()))
+	})
+
+	It("alerts once when the slot retains more WAL than allowed", func(ctx SpecContext) {
+		slot.Spec.MaxRetainedWAL = ptr.To(resource.MustParse("1Ki"))
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		markApplied(ctx)
+
+		for range 2 {
+			dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+				WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns).
+					AddRow("logical", "pgoutput", "app", false, "0/3000000", "0/3000000", "extended", 4096, 4096))
+		}
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring("RetentionExceeded")))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.RetentionExceededTime).ToNot(BeNil())
+		Expect(slot.Status.Applied).To(HaveValue(BeTrue()))
+
+		// The alert is raised only when the limit is first exceeded
+		slot.Status.LastUpdateTime = ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))
+		Expect(fakeClient.Status().Update(ctx, slot)).To(Succeed())
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("drops the slot retaining more WAL than allowed, without creating it again", func(ctx SpecContext) {
+		slot.Spec.MaxRetainedWAL = ptr.To(resource.MustParse("1Ki"))
+		slot.Spec.RetentionAction = apiv1.ReplicationSlotRetentionDrop
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		markApplied(ctx)
+
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns).
+				AddRow("logical", "pgoutput", "app", true, "0/3000000", "0/3000000", "extended", 4096, 4096))
+		dbMock.ExpectExec(terminateReplicationSlotConsumerSQL).WithArgs(slot.Spec.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(dropReplicationSlotSQL).WithArgs(slot.Spec.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Dropped")))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.Status.DroppedTime).ToNot(BeNil())
+		Expect(slot.Status.Active).To(BeFalse())
+		Expect(slot.Status.Applied).To(HaveValue(BeFalse()))
+		Expect(slot.Status.Message).To(ContainSubstring("more than the maxRetainedWAL of 1Ki"))
+
+		// No query is run until the specification changes
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+	})
+
+	It("drops the replication slot on deletion with the delete reclaim policy", func(ctx SpecContext) {
+		dbMock.ExpectQuery(replicationSlotStateSQL).WithArgs(slot.Spec.Name).
+			WillReturnRows(sqlmock.NewRows(replicationSlotStateColumns))
+		dbMock.ExpectExec("SELECT pg_catalog.pg_create_logical_replication_slot($1, $2)").
+			WithArgs(slot.Spec.Name, slot.Spec.Plugin).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectExec(terminateReplicationSlotConsumerSQL).WithArgs(slot.Spec.Name).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec(dropReplicationSlotSQL).WithArgs(slot.Spec.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)).To(Succeed())
+		Expect(slot.GetFinalizers()).NotTo(BeEmpty())
+
+		// See fake.Client known issues with `Generation`
+		slot.SetGeneration(slot.GetGeneration() + 1)
+		Expect(fakeClient.Update(ctx, slot)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, slot)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(slot), slot)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
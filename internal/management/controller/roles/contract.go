@@ -23,33 +23,37 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"sort"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
 // DatabaseRole represents the role information read from / written to the Database
 // The password management in the apiv1.RoleConfiguration assumes the use of Secrets,
 // so cannot cleanly be mapped to Postgres
 type DatabaseRole struct {
-	Name            string           `json:"name"`
-	Comment         string           `json:"comment,omitempty"`
-	Superuser       bool             `json:"superuser,omitempty"`
-	CreateDB        bool             `json:"createdb,omitempty"`
-	CreateRole      bool             `json:"createrole,omitempty"`
-	Inherit         bool             `json:"inherit,omitempty"` // defaults to true
-	Login           bool             `json:"login,omitempty"`
-	Replication     bool             `json:"replication,omitempty"`
-	BypassRLS       bool             `json:"bypassrls,omitempty"` // Row-Level Security
-	ignorePassword  bool             `json:"-"`
-	ConnectionLimit int64            `json:"connectionLimit,omitempty"` // default is -1
-	ValidUntil      pgtype.Timestamp `json:"validUntil,omitempty"`
-	InRoles         []string         `json:"inRoles,omitempty"`
-	password        sql.NullString   `json:"-"`
+	Name            string            `json:"name"`
+	Comment         string            `json:"comment,omitempty"`
+	Superuser       bool              `json:"superuser,omitempty"`
+	CreateDB        bool              `json:"createdb,omitempty"`
+	CreateRole      bool              `json:"createrole,omitempty"`
+	Inherit         bool              `json:"inherit,omitempty"` // defaults to true
+	Login           bool              `json:"login,omitempty"`
+	Replication     bool              `json:"replication,omitempty"`
+	BypassRLS       bool              `json:"bypassrls,omitempty"` // Row-Level Security
+	ignorePassword  bool              `json:"-"`
+	ConnectionLimit int64             `json:"connectionLimit,omitempty"` // default is -1
+	ValidUntil      pgtype.Timestamp  `json:"validUntil,omitempty"`
+	InRoles         []string          `json:"inRoles,omitempty"`
+	Parameters      map[string]string `json:"parameters,omitempty"`
+	password        sql.NullString    `json:"-"`
 	// passwordPassthrough, when true, instructs the instance manager to send the
 	// password literal verbatim rather than SCRAM-SHA-256 encoding it
 	// client-side. It is populated from the cnpg.io/passwordPassthrough
@@ -86,6 +90,20 @@ func (d *DatabaseRole) isInSameRolesAs(inSpec apiv1.RoleConfiguration) bool {
 	return reflect.DeepEqual(d.InRoles, inSpec.InRoles)
 }
 
+// hasSameParametersAs checks whether the role-level configuration
+// parameters of the role are the ones in the spec, and whether the
+// parameters applied last time have been recorded. The parameters that
+// are no longer in the spec are compared only when they have been
+// previously applied, as the other ones are not managed
+func (d *DatabaseRole) hasSameParametersAs(inSpec apiv1.RoleConfiguration, lastApplied map[string]string) bool {
+	if !maps.Equal(inSpec.Parameters, lastApplied) {
+		return false
+	}
+
+	toSet, toReset := postgres.ParametersDiff(inSpec.Parameters, d.Parameters, slices.Collect(maps.Keys(lastApplied)))
+	return len(toSet) == 0 && len(toReset) == 0
+}
+
 func (d *DatabaseRole) hasSameValidUntilAs(inSpec apiv1.RoleConfiguration) bool {
 	if inSpec.ValidUntil == nil {
 		return !d.ValidUntil.Valid || d.ValidUntil.InfinityModifier == pgtype.Infinity
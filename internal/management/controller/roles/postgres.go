@@ -24,6 +24,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -32,6 +34,7 @@ import (
 	"github.com/lib/pq"
 
 	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 )
 
 // List the available roles excluding all the roles that start with `pg_`
@@ -46,7 +49,9 @@ func List(ctx context.Context, db *sql.DB) ([]DatabaseRole, error) {
 		`SELECT rolname, rolsuper, rolinherit, rolcreaterole, rolcreatedb, 
        			rolcanlogin, rolreplication, rolconnlimit, rolpassword, rolvaliduntil, rolbypassrls,
 				pg_catalog.shobj_description(auth.oid, 'pg_authid') as comment, auth.xmin,
-				mem.inroles
+				mem.inroles,
+				(SELECT s.setconfig FROM pg_catalog.pg_db_role_setting s
+				WHERE s.setrole = auth.oid AND s.setdatabase = 0) as setconfig
 		FROM pg_catalog.pg_authid as auth
 		LEFT JOIN (
 			SELECT pg_catalog.array_agg(pg_catalog.pg_get_userbyid(roleid)) as inroles, member
@@ -67,6 +72,7 @@ func List(ctx context.Context, db *sql.DB) ([]DatabaseRole, error) {
 		var comment sql.NullString
 		var role DatabaseRole
 		var inRoles pq.StringArray
+		var setconfig pq.StringArray
 		err := rows.Scan(
 			&role.Name,
 			&role.Superuser,
@@ -82,6 +88,7 @@ func List(ctx context.Context, db *sql.DB) ([]DatabaseRole, error) {
 			&comment,
 			&role.transactionID,
 			&inRoles,
+			&setconfig,
 		)
 		if err != nil {
 			return nil, wrapErr(err)
@@ -91,6 +98,9 @@ func List(ctx context.Context, db *sql.DB) ([]DatabaseRole, error) {
 		}
 
 		role.InRoles = inRoles
+		if len(setconfig) > 0 {
+			role.Parameters = postgres.ParseParameterSettings(setconfig)
+		}
 
 		roles = append(roles, role)
 	}
@@ -323,6 +333,103 @@ func GetParentRoles(ctx context.Context, db *sql.DB, role DatabaseRole) ([]strin
 	return parentRoles, nil
 }
 
+// GetParameters gets the role-level configuration parameters of the role
+func GetParameters(ctx context.Context, db *sql.DB, role DatabaseRole) (map[string]string, error) {
+	contextLog := log.FromContext(ctx).WithName("roles_reconciler")
+	contextLog.Trace("Invoked", "role", role)
+	wrapErr := func(err error) error {
+		return fmt.Errorf("while getting parameters for role %s with role reconciler: %w", role.Name, err)
+	}
+	query := `SELECT s.setconfig
+		FROM pg_catalog.pg_db_role_setting s
+		JOIN pg_catalog.pg_authid auth ON auth.oid = s.setrole
+		WHERE auth.rolname = $1 AND s.setdatabase = 0`
+	contextLog.Debug("get role parameters", "query", query)
+	var setconfig pq.StringArray
+	err := db.QueryRowContext(ctx, query, role.Name).Scan(&setconfig)
+	if errors.Is(err, sql.ErrNoRows) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return postgres.ParseParameterSettings(setconfig), nil
+}
+
+// UpdateParameters sets and resets the role-level configuration parameters
+// of the role in a single transaction
+func UpdateParameters(
+	ctx context.Context,
+	db *sql.DB,
+	role DatabaseRole,
+	parametersToSet map[string]string,
+	parametersToReset []string,
+) error {
+	contextLog := log.FromContext(ctx).WithName("roles_reconciler")
+	contextLog.Trace("Invoked", "role", role)
+	wrapErr := func(err error) error {
+		return fmt.Errorf("while updating parameters for role %s with role reconciler: %w", role.Name, err)
+	}
+	if len(parametersToSet)+len(parametersToReset) == 0 {
+		contextLog.Debug("No parameter change query to execute for role")
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(parametersToSet))
+	queries := make([]string, 0, len(parametersToSet)+len(parametersToReset))
+	for _, name := range names {
+		queries = append(queries, fmt.Sprintf("ALTER ROLE %s %s",
+			pgx.Identifier{role.Name}.Sanitize(),
+			postgres.ParameterSetClause(name, parametersToSet[name])),
+		)
+	}
+	for _, name := range parametersToReset {
+		queries = append(queries, fmt.Sprintf("ALTER ROLE %s %s",
+			pgx.Identifier{role.Name}.Sanitize(),
+			postgres.ParameterResetClause(name)),
+		)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			contextLog.Error(rollbackErr, "rolling back transaction")
+		}
+	}()
+
+	for _, sqlQuery := range queries {
+		contextLog.Debug("Executing query", "sqlQuery", sqlQuery)
+		if _, err := tx.ExecContext(ctx, sqlQuery); err != nil {
+			contextLog.Error(err, "executing query", "sqlQuery", sqlQuery, "err", err)
+			return wrapErr(err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ReconcileParameters brings the role-level configuration parameters of
+// the role in line with the ones in the DatabaseRole, resetting the
+// previously managed parameters that are no longer wanted
+func ReconcileParameters(
+	ctx context.Context,
+	db *sql.DB,
+	role DatabaseRole,
+	previouslyManaged []string,
+) error {
+	currentParameters, err := GetParameters(ctx, db, role)
+	if err != nil {
+		return err
+	}
+
+	toSet, toReset := postgres.ParametersDiff(role.Parameters, currentParameters, previouslyManaged)
+	return UpdateParameters(ctx, db, role, toSet, toReset)
+}
+
 func appendInRoleOptions(role DatabaseRole, query *strings.Builder) {
 	if len(role.InRoles) > 0 {
 		quotedInRoles := make([]string, len(role.InRoles))
@@ -58,6 +58,8 @@ func parseRoleError(err error, roleName string, action roleAction) (*RoleError,
 		"2BP01": errPGX.Detail,  // 2BP01 -> dependent_objects_still_exist
 		"42704": errPGX.Message, // 42704 -> undefined_object
 		"0LP01": errPGX.Message, // 0LP01 -> invalid_grant_operation
+		"22023": errPGX.Message, // 22023 -> invalid_parameter_value
+		"55P02": errPGX.Message, // 55P02 -> cant_change_runtime_param
 	}
 
 	if cause, known := knownCauses[errPGX.Code]; known {
@@ -160,22 +160,22 @@ var _ = Describe("Postgres RoleManager implementation test", func() {
 		rows := sqlmock.NewRows([]string{
 			"rolname", "rolsuper", "rolinherit", "rolcreaterole", "rolcreatedb",
 			"rolcanlogin", "rolreplication", "rolconnlimit", "rolpassword", "rolvaliduntil", "rolbypassrls", "comment",
-			"xmin", "inroles",
+			"xmin", "inroles", "setconfig",
 		}).
 			AddRow("postgres", true, false, true, true, true, false, -1, []byte("12345"),
-				nil, false, []byte("This is postgres user"), 11, []byte("{}")).
+				nil, false, []byte("This is postgres user"), 11, []byte("{}"), nil).
 			AddRow("streaming_replica", false, false, true, true, false, true, 10, []byte("54321"),
 				pgtype.Timestamp{
 					Valid:            true,
 					Time:             testDate,
 					InfinityModifier: pgtype.Finite,
-				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`)).
+				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`), nil).
 			AddRow("future_man", false, false, true, true, false, true, 10, []byte("54321"),
 				pgtype.Timestamp{
 					Valid:            true,
 					Time:             time.Time{},
 					InfinityModifier: pgtype.Infinity,
-				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`))
+				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`), nil)
 		mock.ExpectQuery(expectedSelStmt).WillReturnRows(rows)
 		mock.ExpectExec("CREATE ROLE foo").WillReturnResult(sqlmock.NewResult(11, 1))
 		roles, err := List(ctx, db)
@@ -51,9 +51,13 @@ func Reconcile(
 		return reconcile.Result{}, err
 	}
 
+	// the roles whose password is rotated are backed by two logins
+	managedConfig := expandManagedPasswordRotation(
+		cluster.Spec.Managed, cluster.Status.ManagedRolesStatus.PasswordStatus)
+
 	// get current passwords from spec/secrets
 	latestPasswordResourceVersion := getPasswordSecretResourceVersion(
-		ctx, c, cluster.Spec.Managed.Roles, cluster.Namespace)
+		ctx, c, managedConfig.Roles, cluster.Namespace)
 
 	contextLogger.Debug("getting the managed roles status")
 	rolesInDB, err := List(ctx, db)
@@ -63,10 +67,11 @@ func Reconcile(
 
 	rolesByStatus := evaluateNextRoleActions(
 		ctx,
-		cluster.Spec.Managed,
+		managedConfig,
 		rolesInDB,
 		cluster.Status.ManagedRolesStatus.PasswordStatus,
 		latestPasswordResourceVersion,
+		cluster.Status.ManagedRolesStatus.Parameters,
 	).convertToRolesByStatus()
 
 	roleNamesByStatus := make(map[apiv1.RoleStatus][]string)
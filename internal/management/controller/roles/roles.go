@@ -77,6 +77,7 @@ func DatabaseRoleFromConfiguration(config apiv1.RoleConfiguration, validUntilNul
 		BypassRLS:       config.BypassRLS,
 		ConnectionLimit: config.ConnectionLimit,
 		InRoles:         config.InRoles,
+		Parameters:      config.Parameters,
 	}
 	switch {
 	case config.ValidUntil != nil:
@@ -107,6 +108,7 @@ func (r rolesByAction) convertToRolesByStatus() rolesByStatus {
 		roleUpdate:            apiv1.RoleStatusPendingReconciliation,
 		roleSetComment:        apiv1.RoleStatusPendingReconciliation,
 		roleUpdateMemberships: apiv1.RoleStatusPendingReconciliation,
+		roleUpdateParameters:  apiv1.RoleStatusPendingReconciliation,
 		roleIsReconciled:      apiv1.RoleStatusReconciled,
 		roleIgnore:            apiv1.RoleStatusNotManaged,
 		roleIsReserved:        apiv1.RoleStatusReserved,
@@ -146,6 +148,7 @@ func evaluateNextRoleActions(
 	rolesInDB []DatabaseRole,
 	lastPasswordState map[string]apiv1.PasswordState,
 	latestSecretResourceVersion map[string]string,
+	lastAppliedParameters map[string]map[string]string,
 ) rolesByAction {
 	contextLog := log.FromContext(ctx).WithName("roles_reconciler")
 	contextLog.Debug("evaluating role actions")
@@ -189,6 +192,11 @@ func evaluateNextRoleActions(
 				RoleConfiguration: inSpec,
 			}
 			rolesByAction[roleUpdateMemberships] = append(rolesByAction[roleUpdateMemberships], internalRole)
+		case isInSpec && !role.hasSameParametersAs(inSpec, lastAppliedParameters[role.Name]):
+			internalRole := roleConfigurationAdapter{
+				RoleConfiguration: inSpec,
+			}
+			rolesByAction[roleUpdateParameters] = append(rolesByAction[roleUpdateParameters], internalRole)
 		case !isInSpec:
 			rolesByAction[roleIgnore] = append(rolesByAction[roleIgnore],
 				roleAdapterFromName(role.Name))
@@ -0,0 +1,117 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package roles
+
+import (
+	"fmt"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// ExpandPasswordRotation returns the configurations of the logins backing
+// a role whose password is rotated: the role itself and its `<role>_next`
+// login, which is a member of the role and inherits its privileges.
+//
+// Only the login in use, as recorded in the passed rotation state, gets its
+// password from the corresponding secret. The other login keeps its current
+// password, which stays valid until the end of the grace period.
+// Roles without a password rotation policy are returned unchanged.
+func ExpandPasswordRotation(
+	role apiv1.RoleConfiguration,
+	state *apiv1.PasswordRotationState,
+) []apiv1.RoleConfiguration {
+	if role.PasswordRotation == nil {
+		return []apiv1.RoleConfiguration{role}
+	}
+
+	nextLogin := role
+	nextLogin.Name = role.GetPasswordRotationNextLogin()
+	nextLogin.Comment = fmt.Sprintf("password rotation login of role %s", role.Name)
+	nextLogin.InRoles = []string{role.Name}
+	nextLogin.Inherit = nil
+	nextLogin.PasswordRotation = nil
+
+	if role.Ensure == apiv1.EnsureAbsent {
+		role.PasswordRotation = nil
+		return []apiv1.RoleConfiguration{role, nextLogin}
+	}
+
+	activeLogin := state.GetActiveLogin(role.Name)
+	logins := []apiv1.RoleConfiguration{role, nextLogin}
+	for i := range logins {
+		login := &logins[i]
+		if login.Name == activeLogin {
+			login.PasswordSecret = &apiv1.LocalObjectReference{
+				Name: role.GetPasswordRotationSecretName(login.Name),
+			}
+		} else {
+			// The password of the login not in use is left untouched,
+			// and expires at the end of the grace period
+			login.PasswordSecret = nil
+			if state != nil && state.GracePeriodEnd != nil &&
+				(login.ValidUntil == nil || state.GracePeriodEnd.Before(login.ValidUntil)) {
+				login.ValidUntil = state.GracePeriodEnd
+			}
+		}
+		login.PasswordRotation = nil
+	}
+
+	return logins
+}
+
+// expandManagedPasswordRotation returns a copy of the passed managed
+// configuration where the roles whose password is rotated are expanded
+// into the logins backing them
+func expandManagedPasswordRotation(
+	config *apiv1.ManagedConfiguration,
+	passwordStatus map[string]apiv1.PasswordState,
+) *apiv1.ManagedConfiguration {
+	if config == nil {
+		return nil
+	}
+
+	result := config.DeepCopy()
+	result.Roles = make([]apiv1.RoleConfiguration, 0, len(config.Roles))
+	for _, role := range config.Roles {
+		result.Roles = append(result.Roles, ExpandPasswordRotation(role, passwordStatus[role.Name].Rotation)...)
+	}
+	return result
+}
+
+// preservePasswordRotationState copies the password rotation state, which is
+// owned by the operator, from the current password status into the passed one
+func preservePasswordRotationState(
+	passwordStatus map[string]apiv1.PasswordState,
+	currentPasswordStatus map[string]apiv1.PasswordState,
+) map[string]apiv1.PasswordState {
+	for role, state := range passwordStatus {
+		state.Rotation = currentPasswordStatus[role].Rotation
+		passwordStatus[role] = state
+	}
+	for role, state := range currentPasswordStatus {
+		if _, found := passwordStatus[role]; !found && state.Rotation != nil {
+			if passwordStatus == nil {
+				passwordStatus = make(map[string]apiv1.PasswordState)
+			}
+			passwordStatus[role] = apiv1.PasswordState{Rotation: state.Rotation}
+		}
+	}
+	return passwordStatus
+}
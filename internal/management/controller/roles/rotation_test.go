@@ -0,0 +1,122 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package roles
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExpandPasswordRotation", func() {
+	gracePeriodEnd := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var role apiv1.RoleConfiguration
+	BeforeEach(func() {
+		role = apiv1.RoleConfiguration{
+			Name:            "app",
+			Ensure:          apiv1.EnsurePresent,
+			Login:           true,
+			CreateDB:        true,
+			ConnectionLimit: 10,
+			PasswordSecret:  &apiv1.LocalObjectReference{Name: "app-password"},
+			PasswordRotation: &apiv1.PasswordRotationConfiguration{
+				Interval: metav1.Duration{Duration: 720 * time.Hour},
+			},
+		}
+	})
+
+	It("returns the roles without a password rotation policy unchanged", func() {
+		role.PasswordRotation = nil
+		Expect(ExpandPasswordRotation(role, nil)).To(Equal([]apiv1.RoleConfiguration{role}))
+	})
+
+	It("adds the next login as a member of the role", func() {
+		logins := ExpandPasswordRotation(role, nil)
+		Expect(logins).To(HaveLen(2))
+
+		Expect(logins[0].Name).To(Equal("app"))
+		Expect(logins[0].PasswordSecret.Name).To(Equal("app-password"))
+		Expect(logins[0].PasswordRotation).To(BeNil())
+
+		Expect(logins[1].Name).To(Equal("app_next"))
+		Expect(logins[1].InRoles).To(Equal([]string{"app"}))
+		Expect(logins[1].GetRoleInherit()).To(BeTrue())
+		Expect(logins[1].Login).To(BeTrue())
+		Expect(logins[1].CreateDB).To(BeTrue())
+		Expect(logins[1].ConnectionLimit).To(BeEquivalentTo(10))
+		Expect(logins[1].PasswordSecret).To(BeNil())
+		Expect(logins[1].ValidUntil).To(BeNil())
+	})
+
+	It("expires the login not in use at the end of the grace period", func() {
+		logins := ExpandPasswordRotation(role, &apiv1.PasswordRotationState{
+			ActiveLogin:    "app_next",
+			GracePeriodEnd: &gracePeriodEnd,
+		})
+		Expect(logins).To(HaveLen(2))
+
+		Expect(logins[0].PasswordSecret).To(BeNil())
+		Expect(logins[0].ValidUntil).To(Equal(&gracePeriodEnd))
+
+		Expect(logins[1].PasswordSecret.Name).To(Equal("app-password"))
+		Expect(logins[1].ValidUntil).To(BeNil())
+	})
+
+	It("uses the alternate password secret for the next login", func() {
+		role.PasswordRotation.Strategy = apiv1.PasswordRotationStrategyAlternateSecrets
+		role.PasswordRotation.AlternatePasswordSecret = &apiv1.LocalObjectReference{Name: "app-next-password"}
+
+		logins := ExpandPasswordRotation(role, &apiv1.PasswordRotationState{ActiveLogin: "app_next"})
+		Expect(logins[1].PasswordSecret.Name).To(Equal("app-next-password"))
+	})
+
+	It("drops the next login together with the role", func() {
+		role.Ensure = apiv1.EnsureAbsent
+		logins := ExpandPasswordRotation(role, nil)
+		Expect(logins).To(HaveLen(2))
+		Expect(logins[1].Name).To(Equal("app_next"))
+		Expect(logins[1].Ensure).To(Equal(apiv1.EnsureAbsent))
+	})
+})
+
+var _ = Describe("preservePasswordRotationState", func() {
+	It("keeps the rotation state written by the operator", func() {
+		rotation := &apiv1.PasswordRotationState{ActiveLogin: "app_next"}
+		result := preservePasswordRotationState(
+			map[string]apiv1.PasswordState{
+				"app": {TransactionID: 42, SecretResourceVersion: "2"},
+			},
+			map[string]apiv1.PasswordState{
+				"app":   {TransactionID: 41, SecretResourceVersion: "1", Rotation: rotation},
+				"other": {Rotation: rotation},
+			},
+		)
+		Expect(result).To(Equal(map[string]apiv1.PasswordState{
+			"app":   {TransactionID: 42, SecretResourceVersion: "2", Rotation: rotation},
+			"other": {Rotation: rotation},
+		}))
+	})
+})
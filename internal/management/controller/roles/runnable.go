@@ -23,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 
@@ -50,6 +51,7 @@ const (
 	roleIsReserved        roleAction = "RESERVED"
 	roleSetComment        roleAction = "SET_COMMENT"
 	roleUpdateMemberships roleAction = "UPDATE_MEMBERSHIPS"
+	roleUpdateParameters  roleAction = "UPDATE_PARAMETERS"
 )
 
 type instanceInterface interface {
@@ -152,11 +154,17 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 	if rolePasswords == nil {
 		rolePasswords = map[string]apiv1.PasswordState{}
 	}
+	roleParameters := maps.Clone(remoteCluster.Status.ManagedRolesStatus.Parameters)
+	if roleParameters == nil {
+		roleParameters = map[string]map[string]string{}
+	}
 	superUserDB, err := sr.instance.GetSuperUserDB()
 	if err != nil {
 		return fmt.Errorf("while getting superuser connection: %w", err)
 	}
-	appliedState, unreconciledRoles, err := sr.synchronizeRoles(ctx, superUserDB, config, rolePasswords)
+	config = expandManagedPasswordRotation(config, rolePasswords)
+	appliedState, unreconciledRoles, err := sr.synchronizeRoles(
+		ctx, superUserDB, config, rolePasswords, roleParameters)
 	if err != nil {
 		return fmt.Errorf("while syncrhonizing managed roles: %w", err)
 	}
@@ -168,8 +176,13 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 		return err
 	}
 	updatedCluster := remoteCluster.DeepCopy()
-	updatedCluster.Status.ManagedRolesStatus.PasswordStatus = appliedState
+	updatedCluster.Status.ManagedRolesStatus.PasswordStatus = preservePasswordRotationState(
+		appliedState, remoteCluster.Status.ManagedRolesStatus.PasswordStatus)
 	updatedCluster.Status.ManagedRolesStatus.CannotReconcile = unreconciledRoles
+	updatedCluster.Status.ManagedRolesStatus.Parameters = nil
+	if len(roleParameters) > 0 {
+		updatedCluster.Status.ManagedRolesStatus.Parameters = roleParameters
+	}
 	return sr.client.Status().Patch(ctx, updatedCluster, client.MergeFrom(&remoteCluster))
 }
 
@@ -188,11 +201,15 @@ func getRoleNames(roles []roleConfigurationAdapter) []string {
 //     unrealizable postgres operations, missing password secret)
 //   - any error that prevents the whole synchronization from running
 //     (e.g. listing the roles in the database failed)
+//
+// The role-level parameters applied to each role are updated in place
+// in storedParameters
 func (sr *RoleSynchronizer) synchronizeRoles(
 	ctx context.Context,
 	db *sql.DB,
 	config *apiv1.ManagedConfiguration,
 	storedPasswordState map[string]apiv1.PasswordState,
+	storedParameters map[string]map[string]string,
 ) (map[string]apiv1.PasswordState, map[string][]string, error) {
 	latestSecretResourceVersion := getPasswordSecretResourceVersion(
 		ctx, sr.client, config.Roles, sr.instance.GetNamespaceName())
@@ -201,29 +218,49 @@ func (sr *RoleSynchronizer) synchronizeRoles(
 		return nil, nil, err
 	}
 	rolesByAction := evaluateNextRoleActions(
-		ctx, config, rolesInDB, storedPasswordState, latestSecretResourceVersion)
+		ctx, config, rolesInDB, storedPasswordState, latestSecretResourceVersion, storedParameters)
 
-	passwordStates, unreconciledRoles := sr.applyRoleActions(ctx, db, rolesByAction)
+	passwordStates, appliedParameters, unreconciledRoles := sr.applyRoleActions(
+		ctx, db, rolesByAction, storedParameters)
 
 	// Merge the status from database into spec. We should keep all the status
 	// otherwise in the next loop the user without status will be marked as need update
 	for role, stateInDatabase := range passwordStates {
 		storedPasswordState[role] = stateInDatabase
 	}
+
+	// Forget the parameters of the roles that are no longer managed
+	for role := range storedParameters {
+		if !slices.ContainsFunc(config.Roles, func(r apiv1.RoleConfiguration) bool {
+			return r.Name == role && r.Ensure != apiv1.EnsureAbsent
+		}) {
+			delete(storedParameters, role)
+		}
+	}
+	for role, parameters := range appliedParameters {
+		if len(parameters) == 0 {
+			delete(storedParameters, role)
+			continue
+		}
+		storedParameters[role] = parameters
+	}
+
 	return storedPasswordState, unreconciledRoles, nil
 }
 
 // applyRoleActions applies the actions to reconcile roles in the DB with the Spec.
-// It returns the apiv1.PasswordState for each successfully applied role, and a
-// map collecting the errors encountered per role. Both expectable errors
-// (e.g. dropping a role that owns content) and unexpected errors are recorded
-// in the map, so that an error on one role does not block the reconciliation
-// of the others.
+// It returns the apiv1.PasswordState for each successfully applied role, the
+// role-level parameters applied to each role whose parameters were
+// reconciled, and a map collecting the errors encountered per role. Both
+// expectable errors (e.g. dropping a role that owns content) and unexpected
+// errors are recorded in the map, so that an error on one role does not block
+// the reconciliation of the others.
 func (sr *RoleSynchronizer) applyRoleActions(
 	ctx context.Context,
 	db *sql.DB,
 	rolesByAction rolesByAction,
-) (map[string]apiv1.PasswordState, map[string][]string) {
+	lastAppliedParameters map[string]map[string]string,
+) (map[string]apiv1.PasswordState, map[string]map[string]string, map[string][]string) {
 	contextLog := log.FromContext(ctx).WithName("roles_reconciler")
 	contextLog.Debug("applying role actions")
 
@@ -268,12 +305,35 @@ func (sr *RoleSynchronizer) applyRoleActions(
 		handleRoleError(err, role.Name, roleUpdateMemberships)
 	}
 
+	// The role-level parameters are reconciled for every role being changed,
+	// so that a role is fully reconciled in a single pass
+	appliedParameters := make(map[string]map[string]string)
+	actionsWithParameters := []roleAction{
+		roleCreate, roleUpdate, roleSetComment, roleUpdateMemberships, roleUpdateParameters,
+	}
+	for _, action := range actionsWithParameters {
+		for _, role := range rolesByAction[action] {
+			lastApplied := lastAppliedParameters[role.Name]
+			if len(unreconciledRoles[role.Name]) > 0 || len(role.Parameters)+len(lastApplied) == 0 {
+				continue
+			}
+			err := ReconcileParameters(ctx, db, role.toDatabaseRole(), slices.Collect(maps.Keys(lastApplied)))
+			if err == nil {
+				appliedParameters[role.Name] = role.Parameters
+			}
+			handleRoleError(err, role.Name, roleUpdateParameters)
+		}
+	}
+
 	for _, role := range rolesByAction[roleDelete] {
 		err := Delete(ctx, db, role.toDatabaseRole())
+		if err == nil {
+			appliedParameters[role.Name] = nil
+		}
 		handleRoleError(err, role.Name, roleDelete)
 	}
 
-	return appliedChanges, unreconciledRoles
+	return appliedChanges, appliedParameters, unreconciledRoles
 }
 
 // GetRoleMembershipDiff returns two lists of roles: those to be granted, and revoked
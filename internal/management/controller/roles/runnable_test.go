@@ -224,28 +224,28 @@ var _ = Describe("Role synchronizer tests", func() {
 		rowsInMockDatabase := sqlmock.NewRows([]string{
 			"rolname", "rolsuper", "rolinherit", "rolcreaterole", "rolcreatedb",
 			"rolcanlogin", "rolreplication", "rolconnlimit", "rolpassword", "rolvaliduntil", "rolbypassrls", "comment",
-			"xmin", "inroles",
+			"xmin", "inroles", "setconfig",
 		}).
 			AddRow("postgres", true, false, true, true, true, false, -1, []byte("12345"),
-				nil, false, []byte("This is postgres user"), 11, []byte("{}")).
+				nil, false, []byte("This is postgres user"), 11, []byte("{}"), nil).
 			AddRow("streaming_replica", false, false, true, true, false, true, 10, []byte("54321"),
 				pgtype.Timestamp{
 					Valid:            true,
 					Time:             testDate,
 					InfinityModifier: pgtype.Finite,
-				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`)).
+				}, false, []byte("This is streaming_replica user"), 22, []byte(`{"role1","role2"}`), nil).
 			AddRow("role_to_ignore", true, false, true, true, true, false, -1, []byte("12345"),
-				nil, false, []byte("This is a custom role in the DB"), 11, []byte("{}")).
+				nil, false, []byte("This is a custom role in the DB"), 11, []byte("{}"), nil).
 			AddRow("role_to_test1", true, true, false, false, false, false, -1, []byte("12345"),
-				nil, false, []byte("This is a role to test with"), 11, []byte("{}")).
+				nil, false, []byte("This is a role to test with"), 11, []byte("{}"), nil).
 			AddRow("role_to_test2", true, true, false, false, false, false, -1, []byte("12345"),
-				nil, false, []byte("This is a role to test with"), 11, []byte("{inrole}")).
+				nil, false, []byte("This is a role to test with"), 11, []byte("{inrole}"), nil).
 			AddRow("role_with_pass", false, true, false, false, false, false, -1, []byte(password),
 				pgtype.Timestamp{
 					Valid:            true,
 					Time:             testDate,
 					InfinityModifier: pgtype.Finite,
-				}, false, []byte(""), 11, []byte("{}"))
+				}, false, []byte(""), 11, []byte("{}"), nil)
 		mock.ExpectQuery(expectedSelStmt).WillReturnRows(rowsInMockDatabase)
 
 		// define various secrets as test cases to show failure modes
@@ -322,7 +322,7 @@ var _ = Describe("Role synchronizer tests", func() {
 			lastTransactionQuery := "SELECT xmin FROM pg_catalog.pg_authid WHERE rolname = $1"
 			mock.ExpectQuery(lastTransactionQuery).WithArgs("foo_bar").WillReturnRows(rows)
 			passwordState, rolesWithErrors, err := roleSynchronizer.synchronizeRoles(ctx, db, &managedConf,
-				map[string]apiv1.PasswordState{})
+				map[string]apiv1.PasswordState{}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(rolesWithErrors).To(BeEmpty())
 			Expect(passwordState).To(BeEquivalentTo(map[string]apiv1.PasswordState{
@@ -371,7 +371,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					TransactionID:         11, // defined in the mock query to the DB above
 					SecretResourceVersion: "1" + secret.ResourceVersion,
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -414,7 +414,7 @@ var _ = Describe("Role synchronizer tests", func() {
 						TransactionID:         11, // defined in the mock query to the DB above
 						SecretResourceVersion: "1" + secret.ResourceVersion,
 					},
-				})
+				}, nil)
 				Expect(err).ShouldNot(HaveOccurred())
 			})
 
@@ -457,7 +457,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					TransactionID:         12, // defined in the mock query to the DB above
 					SecretResourceVersion: secret.ResourceVersion,
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -501,7 +501,7 @@ var _ = Describe("Role synchronizer tests", func() {
 						TransactionID:         12, // defined in the mock query to the DB above
 						SecretResourceVersion: secret.ResourceVersion,
 					},
-				})
+				}, nil)
 				Expect(err).ShouldNot(HaveOccurred())
 			})
 
@@ -530,7 +530,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					TransactionID:         11,
 					SecretResourceVersion: secret.ResourceVersion,
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(unreconciled).To(BeEmpty())
 		})
@@ -555,7 +555,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					TransactionID:         11, // defined in the mock query to the DB above
 					SecretResourceVersion: "11",
 				},
-			})
+			}, nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(unreconciled).To(HaveLen(1))
 			Expect(unreconciled["role_to_test1"]).To(HaveLen(1))
@@ -587,7 +587,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					"role_to_test1": {
 						TransactionID: 11, // matches the mock DB row's xmin
 					},
-				})
+				}, nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(unreconciled).To(HaveLen(1))
 				Expect(unreconciled["role_to_test1"]).To(HaveLen(1))
@@ -604,7 +604,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				},
 			}
 
-			_, _, err := roleSynchronizer.synchronizeRoles(ctx, db, &managedConf, map[string]apiv1.PasswordState{})
+			_, _, err := roleSynchronizer.synchronizeRoles(ctx, db, &managedConf, map[string]apiv1.PasswordState{}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -643,7 +643,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test1": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(rolesWithErrors).To(BeEmpty())
 		})
@@ -677,7 +677,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test2": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(rolesWithErrors).To(BeEmpty())
 		})
@@ -702,10 +702,52 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test1": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
+		It("it will set the parameters in spec and reset the removed ones", func(ctx context.Context) {
+			managedConf := apiv1.ManagedConfiguration{
+				Roles: []apiv1.RoleConfiguration{
+					{
+						Name:            "role_to_test1",
+						Superuser:       true,
+						Inherit:         ptr.To(true),
+						Comment:         "This is a role to test with",
+						ConnectionLimit: -1,
+						Parameters: map[string]string{
+							"statement_timeout": "30s",
+							"search_path":       "app, public",
+						},
+					},
+				},
+			}
+			storedParameters := map[string]map[string]string{
+				"role_to_test1": {"work_mem": "64MB", "search_path": "app, public"},
+				"role_removed":  {"work_mem": "64MB"},
+			}
+			rows := sqlmock.NewRows([]string{"setconfig"}).
+				AddRow([]byte(`{work_mem=64MB,"search_path=app, public",lock_timeout=5s}`))
+			mock.ExpectQuery(expectedParametersStmt).WithArgs("role_to_test1").WillReturnRows(rows)
+			mock.ExpectBegin()
+			mock.ExpectExec(`ALTER ROLE "role_to_test1" SET "statement_timeout" TO '30s'`).
+				WillReturnResult(sqlmock.NewResult(2, 3))
+			mock.ExpectExec(`ALTER ROLE "role_to_test1" RESET "work_mem"`).
+				WillReturnResult(sqlmock.NewResult(2, 3))
+			mock.ExpectCommit()
+
+			_, rolesWithErrors, err := roleSynchronizer.synchronizeRoles(ctx, db, &managedConf, map[string]apiv1.PasswordState{
+				"role_to_test1": {
+					TransactionID: 11, // defined in the mock query to the DB above
+				},
+			}, storedParameters)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(rolesWithErrors).To(BeEmpty())
+			Expect(storedParameters).To(Equal(map[string]map[string]string{
+				"role_to_test1": {"statement_timeout": "30s", "search_path": "app, public"},
+			}))
+		})
+
 		It("it will no-op if the roles are reconciled", func(ctx context.Context) {
 			managedConf := apiv1.ManagedConfiguration{
 				Roles: []apiv1.RoleConfiguration{
@@ -722,7 +764,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test1": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -741,7 +783,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test1": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 
@@ -772,7 +814,7 @@ var _ = Describe("Role synchronizer tests", func() {
 					"role_to_test1": {
 						TransactionID: 11, // defined in the mock query to the DB above
 					},
-				})
+				}, nil)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(rolesWithErrors).To(BeEmpty())
 			Expect(passwordState).To(BeEquivalentTo(map[string]apiv1.PasswordState{
@@ -834,7 +876,7 @@ var _ = Describe("Role synchronizer tests", func() {
 				"role_to_test1": {
 					TransactionID: 11, // defined in the mock query to the DB above
 				},
-			})
+			}, nil)
 
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(unrealizable).To(HaveLen(2))
@@ -865,6 +907,10 @@ var _ = DescribeTable("Role status tests",
 			map[string]string{
 				"roleWithChangedPassInSpec": "102B",
 				"roleWithChangedPassInDB":   "101B",
+			},
+			map[string]map[string]string{
+				"parametersInSync":  {"work_mem": "64MB"},
+				"parametersDrifted": {"work_mem": "64MB"},
 			}).
 			convertToRolesByStatus()
 
@@ -950,6 +996,38 @@ var _ = DescribeTable("Role status tests",
 			"drifted":       apiv1.RoleStatusPendingReconciliation,
 		},
 	),
+	Entry("detects roles with drifted parameters",
+		&apiv1.ManagedConfiguration{
+			Roles: []apiv1.RoleConfiguration{
+				{
+					Name:       "parametersInSync",
+					Ensure:     apiv1.EnsurePresent,
+					Parameters: map[string]string{"work_mem": "64MB"},
+				},
+				{
+					Name:       "parametersDrifted",
+					Ensure:     apiv1.EnsurePresent,
+					Parameters: map[string]string{"work_mem": "64MB"},
+				},
+			},
+		},
+		[]DatabaseRole{
+			{
+				Name:       "parametersInSync",
+				Inherit:    true,
+				Parameters: map[string]string{"work_mem": "64MB", "lock_timeout": "5s"},
+			},
+			{
+				Name:       "parametersDrifted",
+				Inherit:    true,
+				Parameters: map[string]string{"work_mem": "32MB"},
+			},
+		},
+		map[string]apiv1.RoleStatus{
+			"parametersInSync":  apiv1.RoleStatusReconciled,
+			"parametersDrifted": apiv1.RoleStatusPendingReconciliation,
+		},
+	),
 	Entry("detects roles that are not in the spec and ignores them",
 		&apiv1.ManagedConfiguration{
 			Roles: []apiv1.RoleConfiguration{
@@ -35,7 +35,9 @@ const (
 	expectedSelStmt = `SELECT rolname, rolsuper, rolinherit, rolcreaterole, rolcreatedb, 
 		rolcanlogin, rolreplication, rolconnlimit, rolpassword, rolvaliduntil, rolbypassrls,
 		pg_catalog.shobj_description(auth.oid, 'pg_authid') as comment, auth.xmin,
-		mem.inroles
+		mem.inroles,
+		(SELECT s.setconfig FROM pg_catalog.pg_db_role_setting s
+		WHERE s.setrole = auth.oid AND s.setdatabase = 0) as setconfig
 	FROM pg_catalog.pg_authid as auth
 	LEFT JOIN (
 		SELECT pg_catalog.array_agg(pg_catalog.pg_get_userbyid(roleid)) as inroles, member
@@ -50,6 +52,10 @@ const (
 		FROM pg_catalog.pg_auth_members GROUP BY member
 	) mem ON member = oid
 	WHERE rolname = $1`
+	expectedParametersStmt = `SELECT s.setconfig
+		FROM pg_catalog.pg_db_role_setting s
+		JOIN pg_catalog.pg_authid auth ON auth.oid = s.setrole
+		WHERE auth.rolname = $1 AND s.setdatabase = 0`
 
 	wantedRoleCommentTpl = "COMMENT ON ROLE \"%s\" IS %s"
 )
@@ -26,7 +26,9 @@ import (
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +39,7 @@ import (
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -49,6 +52,7 @@ type SubscriptionReconciler struct {
 	finalizerReconciler     *finalizerReconciler[*apiv1.Subscription]
 	getDB                   func(name string) (*sql.DB, error)
 	getPostgresMajorVersion func() (int, error)
+	getPublisherDB          func(connString string) (*sql.DB, error)
 }
 
 // subscriptionReconciliationInterval is the time between the
@@ -101,9 +105,58 @@ func (r *SubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		// and evaluate the subscription again after the promotion.
 		result, proceed, err := handleReplicaRoleTransition(
 			ctx, r.Client, r.instance, cluster, &subscription, subscriptionReconciliationInterval)
-		if err != nil || !proceed {
+		if err != nil {
 			return result, err
 		}
+
+		// ...or the catalog drifted from the spec and the drift policy
+		// asks to correct it.
+		if !proceed {
+			var driftResult ctrl.Result
+			driftResult, proceed, err = handleDriftDetection(
+				ctx, r.Client, r.instance, cluster, &subscription,
+				func(ctx context.Context) ([]string, error) {
+					connString, err := getSubscriptionConnectionString(
+						cluster,
+						subscription.Spec.ExternalClusterName,
+						subscription.Spec.PublicationDBName,
+					)
+					if err != nil {
+						return nil, err
+					}
+					db, err := r.getDB(subscription.Spec.DBName)
+					if err != nil {
+						return nil, fmt.Errorf("while getting DB connection: %w", err)
+					}
+					return detectSubscriptionDrift(ctx, db, &subscription, connString)
+				},
+				time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, driftResult)
+		}
+
+		// Keep the state of the logical replication up to date
+		if !proceed {
+			statusResult, err := r.refreshReplicationStatus(ctx, cluster, &subscription, time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, statusResult)
+		}
+
+		// ...and the sequences aligned with the publisher
+		if !proceed {
+			sequencesResult, err := r.synchronizeSequences(ctx, cluster, &subscription, time.Now())
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			result = earliestRequeue(result, sequencesResult)
+		}
+		if !proceed {
+			return result, nil
+		}
 	}
 
 	// Still not for me, we're waiting for a switchover
@@ -216,6 +269,157 @@ func (r *SubscriptionReconciler) evaluateDropSubscription(ctx context.Context, s
 	return executeDropSubscription(ctx, db, sub.Spec.Name)
 }
 
+// refreshReplicationStatus records periodically in the status the state
+// of the logical replication of an applied subscription, on the primary of
+// a cluster that is not a replica
+func (r *SubscriptionReconciler) refreshReplicationStatus(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	subscription *apiv1.Subscription,
+	now time.Time,
+) (ctrl.Result, error) {
+	applied := subscription.Status.Applied
+	if !subscription.GetDeletionTimestamp().IsZero() || cluster.IsReplica() ||
+		cluster.Status.CurrentPrimary != r.instance.GetPodName() || applied == nil || !*applied {
+		return ctrl.Result{}, nil
+	}
+
+	previous := subscription.Status.Replication
+	if previous != nil && previous.LastUpdateTime != nil {
+		if next := previous.LastUpdateTime.Add(subscriptionReconciliationInterval).Sub(now); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+	}
+
+	status, err := r.collectReplicationStatus(ctx, subscription, now)
+	if err != nil {
+		// The state is refreshed again at the next interval, without
+		// affecting the applied status of the subscription
+		log.FromContext(ctx).Error(err, "while collecting the state of the logical replication")
+		return ctrl.Result{RequeueAfter: subscriptionReconciliationInterval}, nil
+	}
+
+	subscription.Status.Replication = status
+	if err := r.Status().Update(ctx, subscription); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while recording the state of the logical replication: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: subscriptionReconciliationInterval}, nil
+}
+
+func (r *SubscriptionReconciler) collectReplicationStatus(
+	ctx context.Context,
+	subscription *apiv1.Subscription,
+	now time.Time,
+) (*apiv1.SubscriptionReplicationStatus, error) {
+	version, err := r.getPostgresMajorVersion()
+	if err != nil {
+		return nil, fmt.Errorf("while getting the PostgreSQL major version: %w", err)
+	}
+	db, err := r.getDB(subscription.Spec.DBName)
+	if err != nil {
+		return nil, fmt.Errorf("while getting DB connection: %w", err)
+	}
+	return collectSubscriptionReplicationStatus(ctx, db, subscription, version, subscription.Status.Replication, now)
+}
+
+// synchronizeSequences copies the values of the sequences from the
+// publisher to an applied subscription, on the primary of a cluster that is
+// not a replica. This happens every time the `cnpg.io/syncSequences`
+// annotation changes and, in the periodic mode, at every interval.
+func (r *SubscriptionReconciler) synchronizeSequences(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	subscription *apiv1.Subscription,
+	now time.Time,
+) (ctrl.Result, error) {
+	applied := subscription.Status.Applied
+	if !subscription.GetDeletionTimestamp().IsZero() || cluster.IsReplica() ||
+		cluster.Status.CurrentPrimary != r.instance.GetPodName() || applied == nil || !*applied {
+		return ctrl.Result{}, nil
+	}
+
+	configuration := subscription.Spec.SequenceSync
+	periodic := configuration != nil && configuration.GetMode() == apiv1.SubscriptionSequenceSyncModePeriodic
+	result := ctrl.Result{}
+	if periodic {
+		result.RequeueAfter = configuration.GetInterval()
+	}
+
+	previous := subscription.Status.SequenceSync
+	request := subscription.Annotations[utils.SubscriptionSequenceSyncAnnotationName]
+	requested := request != "" && (previous == nil || previous.LastRequest != request)
+	if !requested {
+		if !periodic {
+			return ctrl.Result{}, nil
+		}
+		if previous != nil && previous.LastAttemptTime != nil {
+			if next := previous.LastAttemptTime.Add(configuration.GetInterval()).Sub(now); next > 0 {
+				return ctrl.Result{RequeueAfter: next}, nil
+			}
+		}
+	}
+
+	var offset int64
+	if configuration != nil {
+		offset = configuration.Offset
+	}
+
+	status := &apiv1.SubscriptionSequenceSyncStatus{
+		LastAttemptTime: ptr.To(metav1.NewTime(now)),
+		LastRequest:     request,
+	}
+	count, err := r.copySequences(ctx, cluster, subscription, offset)
+	switch {
+	case err != nil:
+		// The synchronization is attempted again at the next interval or
+		// request, without affecting the applied status of the subscription
+		log.FromContext(ctx).Error(err, "while synchronizing the sequences from the publisher")
+		status.Error = err.Error()
+		if previous != nil {
+			status.LastSyncTime = previous.LastSyncTime
+			status.SynchronizedSequences = previous.SynchronizedSequences
+		}
+	default:
+		status.LastSyncTime = status.LastAttemptTime
+		status.SynchronizedSequences = count
+	}
+
+	subscription.Status.SequenceSync = status
+	if err := r.Status().Update(ctx, subscription); err != nil {
+		return ctrl.Result{}, fmt.Errorf("while recording the synchronization of the sequences: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SubscriptionReconciler) copySequences(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	subscription *apiv1.Subscription,
+	offset int64,
+) (int, error) {
+	connString, err := getSubscriptionConnectionString(
+		cluster,
+		subscription.Spec.ExternalClusterName,
+		subscription.Spec.PublicationDBName,
+	)
+	if err != nil {
+		return 0, err
+	}
+	publisher, err := r.getPublisherDB(connString)
+	if err != nil {
+		return 0, fmt.Errorf("while connecting to the publisher: %w", err)
+	}
+	defer func() {
+		_ = publisher.Close()
+	}()
+
+	subscriber, err := r.getDB(subscription.Spec.DBName)
+	if err != nil {
+		return 0, fmt.Errorf("while getting DB connection: %w", err)
+	}
+	return synchronizeSubscriptionSequences(ctx, publisher, subscriber, offset)
+}
+
 // NewSubscriptionReconciler creates a new subscription reconciler
 func NewSubscriptionReconciler(
 	mgr manager.Manager,
@@ -231,6 +435,9 @@ func NewSubscriptionReconciler(
 			version, err := instance.GetPgVersion()
 			return int(version.Major()), err //nolint:gosec
 		},
+		getPublisherDB: func(connString string) (*sql.DB, error) {
+			return pool.NewDBConnection(connString, pool.ConnectionProfilePostgresql)
+		},
 	}
 	sr.finalizerReconciler = newFinalizerReconciler(
 		mgr.GetClient(),
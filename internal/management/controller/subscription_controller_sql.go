@@ -22,12 +22,19 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
 )
 
 func (r *SubscriptionReconciler) alignSubscription(
@@ -177,3 +184,270 @@ func executeDropSubscription(ctx context.Context, db *sql.DB, name string) error
 
 	return nil
 }
+
+// detectSubscriptionDrift compares the publication and the connection
+// string declared for a subscription with the ones in the catalog,
+// returning the differences found. The connection string is never
+// reported, as it may contain credentials.
+func detectSubscriptionDrift(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Subscription,
+	connString string,
+) ([]string, error) {
+	row := db.QueryRowContext(
+		ctx,
+		"SELECT subpublications, subconninfo FROM pg_catalog.pg_subscription WHERE subname = $1",
+		obj.Spec.Name)
+	var publications []string
+	var connInfo string
+	if err := row.Scan(pq.Array(&publications), &connInfo); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{fmt.Sprintf("subscription %q is missing", obj.Spec.Name)}, nil
+		}
+		return nil, fmt.Errorf("while detecting subscription %q: %w", obj.Spec.Name, err)
+	}
+
+	var differences []string
+	if len(publications) != 1 || publications[0] != obj.Spec.PublicationName {
+		differences = append(differences, fmt.Sprintf("subscription %q subscribes to %q instead of %q",
+			obj.Spec.Name, publications, obj.Spec.PublicationName))
+	}
+	if connInfo != connString {
+		differences = append(differences, fmt.Sprintf("subscription %q has a different connection string",
+			obj.Spec.Name))
+	}
+
+	return differences, nil
+}
+
+// maxSubscriptionTablesInStatus is the maximum number of tables reported
+// in the status of a subscription
+const maxSubscriptionTablesInStatus = 1000
+
+// collectSubscriptionReplicationStatus collects the state of the logical
+// replication of a subscription from the database it belongs to. The
+// previous state is used to detect an increase of the error counters.
+func collectSubscriptionReplicationStatus(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Subscription,
+	pgMajorVersion int,
+	previous *apiv1.SubscriptionReplicationStatus,
+	now time.Time,
+) (*apiv1.SubscriptionReplicationStatus, error) {
+	status := &apiv1.SubscriptionReplicationStatus{
+		LastUpdateTime: ptr.To(metav1.NewTime(now)),
+	}
+
+	tables, err := postgresutils.GetSubscriptionTables(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the tables of the subscription: %w", err)
+	}
+	for _, table := range tables {
+		if table.Subscription != obj.Spec.Name {
+			continue
+		}
+		state := apiv1.SubscriptionTableState(table.State)
+		if status.TableStates == nil {
+			status.TableStates = make(map[apiv1.SubscriptionTableState]int)
+		}
+		status.TableStates[state]++
+		status.Tables = append(status.Tables, apiv1.SubscriptionTableStatus{Name: table.Table, State: state})
+	}
+	slices.SortStableFunc(status.Tables, func(a, b apiv1.SubscriptionTableStatus) int {
+		aReady := a.State == apiv1.SubscriptionTableStateReady
+		bReady := b.State == apiv1.SubscriptionTableStateReady
+		switch {
+		case aReady == bReady:
+			return 0
+		case bReady:
+			return -1
+		default:
+			return 1
+		}
+	})
+	if len(status.Tables) > maxSubscriptionTablesInStatus {
+		status.Tables = status.Tables[:maxSubscriptionTablesInStatus]
+	}
+
+	workers, err := postgresutils.GetSubscriptionWorkers(ctx, db, pgMajorVersion)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the workers of the subscription: %w", err)
+	}
+	for _, worker := range workers {
+		if worker.Database != obj.Spec.DBName || worker.Subscription != obj.Spec.Name {
+			continue
+		}
+		workerStatus := apiv1.SubscriptionWorkerStatus{
+			Type:         worker.Type,
+			PID:          worker.PID,
+			Table:        worker.Table,
+			ReceivedLSN:  worker.ReceivedLSN,
+			LatestEndLSN: worker.LatestEndLSN,
+		}
+		if worker.LatestEndTime != nil {
+			workerStatus.LatestEndTime = ptr.To(metav1.NewTime(*worker.LatestEndTime))
+		}
+		status.Workers = append(status.Workers, workerStatus)
+
+		if worker.Type == "apply" && worker.ApplyLag != nil &&
+			(status.ApplyLag == nil || *worker.ApplyLag > status.ApplyLag.Duration) {
+			status.ApplyLag = &metav1.Duration{Duration: *worker.ApplyLag}
+		}
+	}
+
+	stats, err := postgresutils.GetSubscriptionStats(ctx, db, pgMajorVersion)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the statistics of the subscription: %w", err)
+	}
+	for _, stat := range stats {
+		if stat.Database == obj.Spec.DBName && stat.Subscription == obj.Spec.Name {
+			status.ApplyErrorCount = stat.ApplyErrorCount
+			status.SyncErrorCount = stat.SyncErrorCount
+		}
+	}
+
+	if previous != nil {
+		status.LastErrorTime = previous.LastErrorTime
+		if status.ApplyErrorCount > previous.ApplyErrorCount || status.SyncErrorCount > previous.SyncErrorCount {
+			status.LastErrorTime = status.LastUpdateTime
+		}
+	}
+
+	return status, nil
+}
+
+// sequenceValuesSQL lists the sequences of a database with their last
+// value, which is NULL when the sequence was never used or cannot be read
+// by the current user, and with their increment and bounds
+const sequenceValuesSQL = "SELECT schemaname, sequencename, last_value, increment_by, min_value, max_value " +
+	"FROM pg_catalog.pg_sequences"
+
+// sequenceValue is the state of a sequence
+type sequenceValue struct {
+	// lastValue is the last value of the sequence, nil if unknown
+	lastValue *int64
+
+	// incrementBy is the increment of the sequence, negative for
+	// the descending ones
+	incrementBy int64
+
+	// minValue and maxValue are the bounds of the sequence
+	minValue int64
+	maxValue int64
+}
+
+// advances checks if setting the sequence to the passed value moves it
+// forward, in the direction of its increment
+func (sequence sequenceValue) advances(value int64) bool {
+	if sequence.lastValue == nil {
+		return true
+	}
+	if sequence.incrementBy < 0 {
+		return value < *sequence.lastValue
+	}
+	return value > *sequence.lastValue
+}
+
+// getSequenceValues gets the state of the sequences of a database,
+// indexed by their quoted qualified name
+func getSequenceValues(ctx context.Context, db *sql.DB) (map[string]sequenceValue, error) {
+	rows, err := db.QueryContext(ctx, sequenceValuesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	values := make(map[string]sequenceValue)
+	for rows.Next() {
+		var schema, name string
+		var value sql.NullInt64
+		var sequence sequenceValue
+		if err := rows.Scan(
+			&schema, &name, &value,
+			&sequence.incrementBy, &sequence.minValue, &sequence.maxValue,
+		); err != nil {
+			return nil, err
+		}
+		if value.Valid {
+			sequence.lastValue = ptr.To(value.Int64)
+		}
+		values[pgx.Identifier{schema, name}.Sanitize()] = sequence
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// synchronizeSubscriptionSequences sets the sequences of the subscriber to
+// the values they have in the publisher, moved forward by the given offset
+// in the direction of each sequence of the subscriber. Only the sequences
+// existing in both databases and having a value in the publisher are set,
+// and only when they advance: a sequence already ahead in the subscriber,
+// e.g. after the writes moved there, is never moved back. A sequence that
+// cannot be set, for example because the value is out of its bounds, is
+// reported in the returned error without stopping the synchronization of
+// the other ones. The number of sequences set is returned.
+func synchronizeSubscriptionSequences(
+	ctx context.Context,
+	publisher *sql.DB,
+	subscriber *sql.DB,
+	offset int64,
+) (int, error) {
+	sourceValues, err := getSequenceValues(ctx, publisher)
+	if err != nil {
+		return 0, fmt.Errorf("while getting the sequences of the publisher: %w", err)
+	}
+	destinationValues, err := getSequenceValues(ctx, subscriber)
+	if err != nil {
+		return 0, fmt.Errorf("while getting the sequences of the subscriber: %w", err)
+	}
+
+	var errs []error
+	targetValues := make(map[string]int64, len(destinationValues))
+	for _, name := range slices.Sorted(maps.Keys(destinationValues)) {
+		sequence := destinationValues[name]
+		source, ok := sourceValues[name]
+		if !ok || source.lastValue == nil {
+			continue
+		}
+
+		delta := offset
+		if sequence.incrementBy < 0 {
+			delta = -offset
+		}
+		value := *source.lastValue + delta
+		overflows := (delta > 0 && value < *source.lastValue) || (delta < 0 && value > *source.lastValue)
+		if overflows || value < sequence.minValue || value > sequence.maxValue {
+			errs = append(errs, fmt.Errorf(
+				"the value of the sequence %s in the publisher, %d, moved by the offset %d, "+
+					"is out of its bounds in the subscriber [%d, %d]",
+				name, *source.lastValue, delta, sequence.minValue, sequence.maxValue))
+			continue
+		}
+
+		if sequence.advances(value) {
+			targetValues[name] = value
+		}
+	}
+	names := slices.Sorted(maps.Keys(targetValues))
+
+	count := 0
+	for _, name := range names {
+		if _, err := subscriber.ExecContext(
+			ctx,
+			"SELECT pg_catalog.setval($1, $2)",
+			name, targetValues[name],
+		); err != nil {
+			errs = append(errs, fmt.Errorf("while setting the value of the sequence %s: %w", name, err))
+			continue
+		}
+		count++
+	}
+
+	return count, errors.Join(errs...)
+}
@@ -21,7 +21,10 @@ package controller
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
@@ -193,4 +196,222 @@ var _ = Describe("subscription sql", func() {
 		Expect(sqls).To(ContainElement(`ALTER SUBSCRIPTION "test_sub" SET PUBLICATION "test_pub"`))
 		Expect(sqls).To(ContainElement(`ALTER SUBSCRIPTION "test_sub" CONNECTION 'host=localhost user=test dbname=test'`))
 	})
+	It("detects the drift of the subscription", func(ctx SpecContext) {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:            "sub",
+				PublicationName: "pub",
+			},
+		}
+
+		dbMock.ExpectQuery("SELECT subpublications, subconninfo FROM pg_catalog.pg_subscription WHERE subname = $1").
+			WithArgs("sub").
+			WillReturnRows(sqlmock.NewRows([]string{"subpublications", "subconninfo"}).
+				AddRow("{pub,other}", "host=old password=secret"))
+
+		differences, err := detectSubscriptionDrift(ctx, db, obj, "host=new password=secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(Equal([]string{
+			`subscription "sub" subscribes to ["pub" "other"] instead of "pub"`,
+			`subscription "sub" has a different connection string`,
+		}))
+	})
+
+	It("detects no drift on an aligned subscription", func(ctx SpecContext) {
+		obj := &apiv1.Subscription{
+			Spec: apiv1.SubscriptionSpec{
+				Name:            "sub",
+				PublicationName: "pub",
+			},
+		}
+
+		dbMock.ExpectQuery("SELECT subpublications, subconninfo FROM pg_catalog.pg_subscription WHERE subname = $1").
+			WithArgs("sub").
+			WillReturnRows(sqlmock.NewRows([]string{"subpublications", "subconninfo"}).
+				AddRow("{pub}", "host=new"))
+
+		differences, err := detectSubscriptionDrift(ctx, db, obj, "host=new")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(differences).To(BeEmpty())
+	})
+
+	sequenceColumns := []string{
+		"schemaname", "sequencename", "last_value", "increment_by", "min_value", "max_value",
+	}
+
+	It("synchronizes only the sequences with a value in both databases", func(ctx SpecContext) {
+		publisherDB, publisherMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		publisherMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 10, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", nil, 1, 1, math.MaxInt64).
+				AddRow("sales", "Order_seq", 20, 1, 1, math.MaxInt64).
+				AddRow("public", "publisher_only_seq", 30, 1, 1, math.MaxInt64))
+		dbMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 5, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", 1, 1, 1, math.MaxInt64).
+				AddRow("sales", "Order_seq", nil, 1, 1, math.MaxInt64).
+				AddRow("public", "subscriber_only_seq", 1, 1, 1, math.MaxInt64))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."a_id_seq"`, int64(15)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"sales"."Order_seq"`, int64(25)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		count, err := synchronizeSubscriptionSequences(ctx, publisherDB, db, 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(2))
+		Expect(publisherMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("doesn't move back the sequences ahead in the subscriber", func(ctx SpecContext) {
+		publisherDB, publisherMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		publisherMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 10, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", 20, 1, 1, math.MaxInt64).
+				AddRow("public", "c_id_seq", 30, 1, 1, math.MaxInt64))
+		dbMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 100, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", 25, 1, 1, math.MaxInt64).
+				AddRow("public", "c_id_seq", 34, 1, 1, math.MaxInt64))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."c_id_seq"`, int64(35)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		count, err := synchronizeSubscriptionSequences(ctx, publisherDB, db, 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(publisherMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("moves the descending sequences downwards", func(ctx SpecContext) {
+		publisherDB, publisherMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		publisherMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_desc_seq", -10, -1, math.MinInt64, -1).
+				AddRow("public", "b_desc_seq", -20, -1, math.MinInt64, -1))
+		dbMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_desc_seq", -5, -1, math.MinInt64, -1).
+				AddRow("public", "b_desc_seq", -100, -1, math.MinInt64, -1))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."a_desc_seq"`, int64(-15)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		count, err := synchronizeSubscriptionSequences(ctx, publisherDB, db, 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(publisherMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("reports the sequences that cannot be set without stopping the synchronization", func(ctx SpecContext) {
+		publisherDB, publisherMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		publisherMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 10, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", 98, 1, 1, 100).
+				AddRow("public", "c_id_seq", math.MaxInt64-1, 1, 1, math.MaxInt64).
+				AddRow("public", "d_id_seq", 20, 1, 1, math.MaxInt64))
+		dbMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 1, 1, 1, math.MaxInt64).
+				AddRow("public", "b_id_seq", 1, 1, 1, 100).
+				AddRow("public", "c_id_seq", 1, 1, 1, math.MaxInt64).
+				AddRow("public", "d_id_seq", 1, 1, 1, math.MaxInt64))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."a_id_seq"`, int64(15)).
+			WillReturnError(errors.New("permission denied for sequence a_id_seq"))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."d_id_seq"`, int64(25)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		count, err := synchronizeSubscriptionSequences(ctx, publisherDB, db, 5)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`"public"."a_id_seq": permission denied`))
+		Expect(err.Error()).To(ContainSubstring(`"public"."b_id_seq" in the publisher, 98`))
+		Expect(err.Error()).To(ContainSubstring(`"public"."c_id_seq" in the publisher`))
+		Expect(count).To(Equal(1))
+		Expect(publisherMock.ExpectationsWereMet()).To(Succeed())
+	})
+})
+
+var _ = Describe("subscription replication status", func() {
+	var (
+		dbMock sqlmock.Sqlmock
+		db     *sql.DB
+		obj    *apiv1.Subscription
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+		Expect(err).ToNot(HaveOccurred())
+		obj = &apiv1.Subscription{Spec: apiv1.SubscriptionSpec{Name: "sub", DBName: "app"}}
+		now = time.Now().Truncate(time.Second)
+
+		dbMock.ExpectQuery("FROM pg_catalog.pg_subscription_rel").WillReturnRows(
+			sqlmock.NewRows([]string{"subname", "table", "srsubstate"}).
+				AddRow("other", "public.z", "d").
+				AddRow("sub", "public.a", "r").
+				AddRow("sub", "public.b", "d").
+				AddRow("sub", "public.c", "r"))
+		dbMock.ExpectQuery("FROM pg_catalog.pg_stat_subscription st").WillReturnRows(
+			sqlmock.NewRows([]string{
+				"datname", "subname", "type", "pid", "table", "received_lsn", "latest_end_lsn", "latest_end_time", "lag",
+			}).
+				AddRow("app", "other", "apply", 41, "", "", "", nil, 60.0).
+				AddRow("postgres", "sub", "apply", 40, "", "", "", nil, 90.0).
+				AddRow("app", "sub", "apply", 42, "", "0/3000000", "0/3000000", now, 2.0).
+				AddRow("app", "sub", "table synchronization", 43, "public.b", "", "", nil, nil))
+		dbMock.ExpectQuery("FROM pg_catalog.pg_stat_subscription_stats").WillReturnRows(
+			sqlmock.NewRows([]string{"datname", "subname", "apply_error_count", "sync_error_count"}).
+				AddRow("app", "other", 9, 9).
+				AddRow("app", "sub", 1, 0).
+				AddRow("postgres", "sub", 7, 7))
+	})
+
+	AfterEach(func() {
+		Expect(dbMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("collects the state of the subscription", func(ctx SpecContext) {
+		status, err := collectSubscriptionReplicationStatus(ctx, db, obj, 17, nil, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.LastUpdateTime.Time).To(BeTemporally("==", now))
+		Expect(status.TableStates).To(Equal(map[apiv1.SubscriptionTableState]int{
+			apiv1.SubscriptionTableStateReady:    2,
+			apiv1.SubscriptionTableStateDataCopy: 1,
+		}))
+		Expect(status.Tables).To(Equal([]apiv1.SubscriptionTableStatus{
+			{Name: "public.b", State: apiv1.SubscriptionTableStateDataCopy},
+			{Name: "public.a", State: apiv1.SubscriptionTableStateReady},
+			{Name: "public.c", State: apiv1.SubscriptionTableStateReady},
+		}))
+		Expect(status.Workers).To(HaveLen(2))
+		Expect(status.Workers[1].Table).To(Equal("public.b"))
+		Expect(status.ApplyLag.Duration).To(Equal(2 * time.Second))
+		Expect(status.ApplyErrorCount).To(BeEquivalentTo(1))
+		Expect(status.SyncErrorCount).To(BeZero())
+		Expect(status.LastErrorTime).To(BeNil())
+	})
+
+	It("records when the error counters increase", func(ctx SpecContext) {
+		previous := &apiv1.SubscriptionReplicationStatus{}
+		status, err := collectSubscriptionReplicationStatus(ctx, db, obj, 17, previous, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.LastErrorTime.Time).To(BeTemporally("==", now))
+	})
 })
@@ -23,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
@@ -504,6 +505,134 @@ var _ = Describe("Managed subscription controller tests", func() {
 		Expect(subscription.Status.ObservedGeneration).NotTo(BeZero())
 	})
 
+	It("refreshes periodically the replication status of an applied subscription", func(ctx SpecContext) {
+		subscription.Status.Applied = ptr.To(true)
+		subscription.Status.ObservedGeneration = subscription.Generation
+		Expect(fakeClient.Status().Update(ctx, subscription)).To(Succeed())
+
+		statusDB, statusMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+		Expect(err).ToNot(HaveOccurred())
+		r.getDB = func(_ string) (*sql.DB, error) {
+			return statusDB, nil
+		}
+		statusMock.ExpectQuery("FROM pg_catalog.pg_subscription_rel").WillReturnRows(
+			sqlmock.NewRows([]string{"subname", "table", "srsubstate"}).
+				AddRow(subscription.Spec.Name, "public.a", "r"))
+		statusMock.ExpectQuery("FROM pg_catalog.pg_stat_subscription st").WillReturnRows(
+			sqlmock.NewRows([]string{
+				"subname", "type", "pid", "table", "received_lsn", "latest_end_lsn", "latest_end_time", "lag",
+			}))
+		statusMock.ExpectQuery("FROM pg_catalog.pg_stat_subscription_stats").WillReturnRows(
+			sqlmock.NewRows([]string{"subname", "apply_error_count", "sync_error_count"}))
+
+		request := ctrl.Request{NamespacedName: types.NamespacedName{
+			Namespace: subscription.GetNamespace(),
+			Name:      subscription.GetName(),
+		}}
+		result, err := r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(subscriptionReconciliationInterval))
+		Expect(statusMock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(subscription), subscription)).To(Succeed())
+		Expect(subscription.Status.Replication).ToNot(BeNil())
+		Expect(subscription.Status.Replication.Tables).To(Equal([]apiv1.SubscriptionTableStatus{
+			{Name: "public.a", State: apiv1.SubscriptionTableStateReady},
+		}))
+
+		// The status is not refreshed again before the interval elapses
+		result, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", subscriptionReconciliationInterval))
+		Expect(statusMock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("synchronizes the sequences from the publisher when requested", func(ctx SpecContext) {
+		subscription.Annotations = map[string]string{
+			utils.SubscriptionSequenceSyncAnnotationName: "2026-10-19T10:00:00Z",
+		}
+		subscription.Spec.SequenceSync = &apiv1.SubscriptionSequenceSyncConfiguration{
+			Mode:   apiv1.SubscriptionSequenceSyncModeOnDemand,
+			Offset: 100,
+		}
+		Expect(fakeClient.Update(ctx, subscription)).To(Succeed())
+		subscription.Status.Applied = ptr.To(true)
+		subscription.Status.ObservedGeneration = subscription.Generation
+		subscription.Status.Replication = &apiv1.SubscriptionReplicationStatus{
+			LastUpdateTime: ptr.To(metav1.Now()),
+		}
+		Expect(fakeClient.Status().Update(ctx, subscription)).To(Succeed())
+
+		publisherDB, publisherMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		r.getPublisherDB = func(publisherConnString string) (*sql.DB, error) {
+			Expect(publisherConnString).To(Equal(connString))
+			return publisherDB, nil
+		}
+		sequenceColumns := []string{
+			"schemaname", "sequencename", "last_value", "increment_by", "min_value", "max_value",
+		}
+		publisherMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", 41, 1, 1, math.MaxInt64))
+		publisherMock.ExpectClose()
+		dbMock.ExpectQuery(sequenceValuesSQL).WillReturnRows(
+			sqlmock.NewRows(sequenceColumns).
+				AddRow("public", "a_id_seq", nil, 1, 1, math.MaxInt64))
+		dbMock.ExpectExec("SELECT pg_catalog.setval($1, $2)").
+			WithArgs(`"public"."a_id_seq"`, int64(141)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		request := ctrl.Request{NamespacedName: types.NamespacedName{
+			Namespace: subscription.GetNamespace(),
+			Name:      subscription.GetName(),
+		}}
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(publisherMock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(subscription), subscription)).To(Succeed())
+		Expect(subscription.Status.SequenceSync).ToNot(BeNil())
+		Expect(subscription.Status.SequenceSync.LastSyncTime).ToNot(BeNil())
+		Expect(subscription.Status.SequenceSync.SynchronizedSequences).To(Equal(1))
+		Expect(subscription.Status.SequenceSync.LastRequest).To(Equal("2026-10-19T10:00:00Z"))
+		Expect(subscription.Status.SequenceSync.Error).To(BeEmpty())
+
+		// The same request is not handled twice
+		_, err = r.Reconcile(ctx, request)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("records the failures of the synchronization of the sequences", func(ctx SpecContext) {
+		subscription.Spec.SequenceSync = &apiv1.SubscriptionSequenceSyncConfiguration{}
+		Expect(fakeClient.Update(ctx, subscription)).To(Succeed())
+		subscription.Status.Applied = ptr.To(true)
+		subscription.Status.ObservedGeneration = subscription.Generation
+		subscription.Status.Replication = &apiv1.SubscriptionReplicationStatus{
+			LastUpdateTime: ptr.To(metav1.Now()),
+		}
+		Expect(fakeClient.Status().Update(ctx, subscription)).To(Succeed())
+
+		r.getPublisherDB = func(_ string) (*sql.DB, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{
+			Namespace: subscription.GetNamespace(),
+			Name:      subscription.GetName(),
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(subscription), subscription)).To(Succeed())
+		Expect(subscription.Status.SequenceSync).ToNot(BeNil())
+		Expect(subscription.Status.SequenceSync.LastAttemptTime).ToNot(BeNil())
+		Expect(subscription.Status.SequenceSync.LastSyncTime).To(BeNil())
+		Expect(subscription.Status.SequenceSync.Error).To(ContainSubstring("connection refused"))
+		Expect(subscription.Status.Applied).To(HaveValue(BeTrue()))
+	})
+
 	// The cluster-fetch behavior is identical across the three
 	// managed-object controllers, and so are its tests.
 	It("keeps a reconciled subscription status when the cluster cannot be fetched", func(ctx SpecContext) { //nolint:dupl
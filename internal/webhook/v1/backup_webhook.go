@@ -150,6 +150,14 @@ func (v *BackupCustomValidator) validate(r *apiv1.Backup) field.ErrorList {
 		))
 	}
 
+	if r.Spec.Method != apiv1.BackupMethodVolumeSnapshot && r.Spec.Instance != "" {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "instance"),
+			r.Spec.Instance,
+			"Instance parameter can be specified only if the backup method is volumeSnapshot",
+		))
+	}
+
 	if r.Spec.Method == apiv1.BackupMethodPlugin && r.Spec.PluginConfiguration.IsEmpty() {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "pluginConfiguration"),
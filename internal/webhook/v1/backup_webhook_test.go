@@ -83,6 +83,18 @@ var _ = Describe("Backup webhook validate", func() {
 		Expect(result[0].Field).To(Equal("spec.onlineConfiguration"))
 	})
 
+	It("complains if instance is set on a barman backup", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Method:   apiv1.BackupMethodBarmanObjectStore,
+				Instance: "cluster-example-2",
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.instance"))
+	})
+
 	It("returns error if BackupVolumeSnapshotDeadlineAnnotationName is not an integer", func() {
 		backup := &apiv1.Backup{
 			ObjectMeta: metav1.ObjectMeta{
@@ -23,11 +23,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	barmanWebhooks "github.com/cloudnative-pg/barman-cloud/pkg/api/webhooks"
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
@@ -188,6 +190,8 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateTablespaceBackupSnapshot,
 		v.validateBootstrapRecoverySource,
 		v.validateBootstrapRecoveryDataSource,
+		v.validateBootstrapClone,
+		v.validatePostRecoverySQLRefs,
 		v.validateExternalClusters,
 		v.validateTolerations,
 		v.validateAntiAffinity,
@@ -195,6 +199,8 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateBackupConfiguration,
 		v.validateRetentionPolicy,
 		v.validateConfiguration,
+		v.validateInstanceOverrides,
+		v.validateParametersFrom,
 		v.validateSynchronousReplicaConfiguration,
 		v.validateFailoverQuorumAlphaAnnotation,
 		v.validateFailoverQuorum,
@@ -212,6 +218,7 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validatePluginConfiguration,
 		v.validateLivenessPingerProbe,
 		v.validatePodSelectorRefs,
+		v.validateHBARules,
 		v.validateExtensions,
 		v.validateServiceAccountConfig,
 		v.validatePrimaryLease,
@@ -615,6 +622,9 @@ func (v *ClusterCustomValidator) validateBootstrapMethod(r *apiv1.Cluster) field
 	if r.Spec.Bootstrap.PgBaseBackup != nil {
 		bootstrapMethods++
 	}
+	if r.Spec.Bootstrap.Clone != nil {
+		bootstrapMethods++
+	}
 
 	if bootstrapMethods > 1 {
 		result = append(
@@ -690,6 +700,118 @@ func (v *ClusterCustomValidator) validateBootstrapRecoverySource(r *apiv1.Cluste
 	return result
 }
 
+// validateBootstrapClone is used to ensure that the Cluster to be cloned
+// and the cloning method are correctly defined
+func (v *ClusterCustomValidator) validateBootstrapClone(r *apiv1.Cluster) field.ErrorList {
+	source, ok := r.GetCloneSource()
+	if !ok {
+		return nil
+	}
+
+	var result field.ErrorList
+	clonePath := field.NewPath("spec", "bootstrap", "clone")
+	cloneSection := r.Spec.Bootstrap.Clone
+
+	if source.Name == r.Name && source.Namespace == r.Namespace {
+		result = append(result, field.Invalid(
+			clonePath.Child("cluster", "name"),
+			source.Name,
+			"A Cluster cannot clone itself"))
+	}
+
+	if errs := validationutil.IsDNS1123Label(source.Namespace); len(errs) > 0 {
+		result = append(result, field.Invalid(
+			clonePath.Child("cluster", "namespace"),
+			source.Namespace,
+			strings.Join(errs, ", ")))
+	}
+
+	switch cloneSection.Method {
+	case apiv1.CloneMethodVolumeSnapshot:
+		if cloneSection.VolumeSnapshots == nil {
+			result = append(result, field.Required(
+				clonePath.Child("volumeSnapshots"),
+				"volumeSnapshots is required when cloning via volume snapshots"))
+			break
+		}
+
+		result = append(result, validateVolumeSnapshotSource(
+			cloneSection.VolumeSnapshots.Storage,
+			clonePath.Child("volumeSnapshots", "storage"))...)
+
+		if cloneSection.VolumeSnapshots.WalStorage != nil {
+			walStoragePath := clonePath.Child("volumeSnapshots", "walStorage")
+			if r.Spec.WalStorage == nil {
+				result = append(result, field.Invalid(
+					walStoragePath,
+					cloneSection.VolumeSnapshots.WalStorage,
+					"A WAL storage configuration is required when cloning using a volume snapshot for WALs"))
+			}
+			result = append(result, validateVolumeSnapshotSource(
+				*cloneSection.VolumeSnapshots.WalStorage, walStoragePath)...)
+		}
+
+	default:
+		if cloneSection.VolumeSnapshots != nil {
+			result = append(result, field.Invalid(
+				clonePath.Child("volumeSnapshots"),
+				cloneSection.VolumeSnapshots,
+				"volumeSnapshots can only be used with the volumeSnapshot method"))
+		}
+	}
+
+	return result
+}
+
+// validatePostRecoverySQLRefs is used to ensure that the references to the
+// SQL files to be executed after recovery are complete
+func (v *ClusterCustomValidator) validatePostRecoverySQLRefs(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Bootstrap == nil {
+		return nil
+	}
+
+	var refsPath *field.Path
+	var refs []apiv1.PostRecoverySQLRefs
+	switch {
+	case r.Spec.Bootstrap.Recovery != nil:
+		refsPath = field.NewPath("spec", "bootstrap", "recovery", "postRecoverySQLRefs")
+		refs = r.Spec.Bootstrap.Recovery.PostRecoverySQLRefs
+	case r.Spec.Bootstrap.PgBaseBackup != nil:
+		refsPath = field.NewPath("spec", "bootstrap", "pg_basebackup", "postRecoverySQLRefs")
+		refs = r.Spec.Bootstrap.PgBaseBackup.PostRecoverySQLRefs
+	default:
+		return nil
+	}
+
+	var result field.ErrorList
+	for idx, entry := range refs {
+		entryPath := refsPath.Index(idx)
+		if !entry.HasElements() {
+			result = append(
+				result,
+				field.Required(entryPath, "at least one of secretRefs and configMapRefs must be specified"))
+		}
+
+		for _, item := range entry.SecretRefs {
+			if item.Name == "" || item.Key == "" {
+				result = append(
+					result,
+					field.Invalid(entryPath.Child("secretRefs"), item, "key and name must be specified"))
+			}
+		}
+
+		for _, item := range entry.ConfigMapRefs {
+			if item.Name == "" || item.Key == "" {
+				result = append(
+					result,
+					field.Invalid(entryPath.Child("configMapRefs"), item, "key and name must be specified"))
+			}
+		}
+	}
+
+	return result
+}
+
 // validateBootstrapRecoveryDataSource is used to ensure that the data
 // source is correctly defined
 func (v *ClusterCustomValidator) validateBootstrapRecoveryDataSource(r *apiv1.Cluster) field.ErrorList {
@@ -1045,6 +1167,13 @@ var postgresParameterNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.
 func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
 
+	// The parameters of the applied configuration profile are validated
+	// together with the ones of the cluster, as the instances use both
+	if parameters := r.GetPostgresParameters(); !maps.Equal(parameters, r.Spec.PostgresConfiguration.Parameters) {
+		r = r.DeepCopy()
+		r.Spec.PostgresConfiguration.Parameters = parameters
+	}
+
 	// We cannot have both old-style synchronous replica configuration
 	// and new-style synchronous replica configuration
 	haveOldStyleSyncReplicaConfig := r.Spec.PostgresConfiguration.Synchronous != nil
@@ -1387,6 +1516,10 @@ func (v *ClusterCustomValidator) validateImageChange(r, old *apiv1.Cluster) fiel
 	}
 	oldVersion := old.Status.PGDataImageInfo.MajorVersion
 
+	if oldVersion > newVersion && isMajorUpgradeRollback(r, old, newVersion) {
+		return result
+	}
+
 	if oldVersion > newVersion {
 		result = append(
 			result,
@@ -1410,6 +1543,15 @@ func (v *ClusterCustomValidator) validateImageChange(r, old *apiv1.Cluster) fiel
 	return result
 }
 
+// isMajorUpgradeRollback checks if the requested major version is the one
+// restored by the rollback of the latest in-place major upgrade
+func isMajorUpgradeRollback(r, old *apiv1.Cluster, newVersion int) bool {
+	rollbackPoint := old.Status.MajorUpgradeRollbackPoint
+	return rollbackPoint != nil &&
+		r.Annotations[utils.MajorUpgradeRollbackAnnotationName] == rollbackPoint.BackupName &&
+		rollbackPoint.PGDataImageInfo.MajorVersion == newVersion
+}
+
 // Validate the recovery target to ensure that the mutual exclusivity
 // of options is respected and plus validating the format of targetTime
 // if specified
@@ -2605,6 +2747,114 @@ func (v *ClusterCustomValidator) validateManagedRoles(r *apiv1.Cluster) field.Er
 					role.Name,
 					"This role both sets and disables a password"))
 		}
+		result = append(
+			result,
+			validateParameterNames(
+				field.NewPath("spec", "managed", "roles").Key(role.Name).Child("parameters"),
+				role.Parameters)...)
+		result = append(
+			result,
+			validatePasswordRotation(
+				field.NewPath("spec", "managed", "roles").Key(role.Name),
+				&role)...)
+	}
+
+	result = append(result, v.validateServiceBindings(r)...)
+
+	// The login created next to a role whose password is rotated
+	// cannot be managed as a role on its own
+	for _, role := range r.Spec.Managed.Roles {
+		if role.PasswordRotation == nil {
+			continue
+		}
+		if _, found := managedRoles[role.GetPasswordRotationNextLogin()]; found {
+			result = append(
+				result,
+				field.Invalid(
+					field.NewPath("spec", "managed", "roles"),
+					role.GetPasswordRotationNextLogin(),
+					fmt.Sprintf("This role is reserved for the password rotation of role %s", role.Name)))
+		}
+	}
+
+	return result
+}
+
+// validateServiceBindings validates the service bindings of the managed
+// roles: the names of the generated Secrets must be valid and unique
+func (v *ClusterCustomValidator) validateServiceBindings(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	secretNames := make(map[string]string)
+	for _, role := range r.Spec.Managed.Roles {
+		path := field.NewPath("spec", "managed", "roles").Key(role.Name).Child("serviceBindings")
+		for _, binding := range role.ServiceBindings {
+			secretName := binding.GetSecretName(r.Name, role.Name)
+			for _, msg := range validationutil.IsDNS1123Subdomain(secretName) {
+				result = append(
+					result,
+					field.Invalid(
+						path.Key(binding.Database),
+						secretName,
+						fmt.Sprintf("invalid service binding secret name, set secretName explicitly: %s", msg)))
+			}
+			if owner, found := secretNames[secretName]; found {
+				result = append(
+					result,
+					field.Duplicate(
+						path.Key(binding.Database).Child("secretName"),
+						fmt.Sprintf("%s (already used by role %s)", secretName, owner)))
+			}
+			secretNames[secretName] = role.Name
+		}
+	}
+
+	return result
+}
+
+// validatePasswordRotation validates the password rotation policy of a role
+func validatePasswordRotation(path *field.Path, role *apiv1.RoleConfiguration) field.ErrorList {
+	rotation := role.PasswordRotation
+	if rotation == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	if role.PasswordSecret == nil || role.PasswordSecret.Name == "" {
+		result = append(result, field.Required(
+			path.Child("passwordSecret"), "passwordRotation requires passwordSecret"))
+	}
+	if !role.Login {
+		result = append(result, field.Invalid(
+			path.Child("login"), role.Login, "passwordRotation requires the role to have login enabled"))
+	}
+
+	path = path.Child("passwordRotation")
+	if rotation.Interval.Duration < time.Hour {
+		result = append(result, field.Invalid(
+			path.Child("interval"), rotation.Interval.Duration.String(), "the interval must be at least one hour"))
+	}
+	if rotation.GracePeriod != nil &&
+		(rotation.GracePeriod.Duration <= 0 || rotation.GracePeriod.Duration >= rotation.Interval.Duration) {
+		result = append(result, field.Invalid(
+			path.Child("gracePeriod"), rotation.GracePeriod.Duration.String(),
+			"the grace period must be positive and shorter than the interval"))
+	}
+
+	alternateSecret := rotation.AlternatePasswordSecret
+	switch {
+	case rotation.GetStrategy() == apiv1.PasswordRotationStrategyAlternateSecrets &&
+		(alternateSecret == nil || alternateSecret.Name == ""):
+		result = append(result, field.Required(
+			path.Child("alternatePasswordSecret"), "alternatePasswordSecret is required by the alternateSecrets strategy"))
+	case rotation.GetStrategy() != apiv1.PasswordRotationStrategyAlternateSecrets && alternateSecret != nil:
+		result = append(result, field.Forbidden(
+			path.Child("alternatePasswordSecret"),
+			"alternatePasswordSecret is only allowed with the alternateSecrets strategy"))
+	case alternateSecret != nil && alternateSecret.Name == role.GetRoleSecretName():
+		result = append(result, field.Invalid(
+			path.Child("alternatePasswordSecret"), alternateSecret.Name,
+			"alternatePasswordSecret must be different from passwordSecret"))
 	}
 
 	return result
@@ -3132,6 +3382,238 @@ func (v *ClusterCustomValidator) validatePodSelectorRefs(r *apiv1.Cluster) field
 	return allErrors
 }
 
+// validateHBARules checks that the structured pg_hba rules can be rendered
+// and that the pod selectors they reference are defined
+func (v *ClusterCustomValidator) validateHBARules(r *apiv1.Cluster) field.ErrorList {
+	var allErrors field.ErrorList
+	path := field.NewPath("spec", "postgresql", "hbaRules")
+
+	knownSelectors := stringset.New()
+	for _, ref := range r.Spec.PodSelectorRefs {
+		knownSelectors.Put(ref.Name)
+	}
+
+	for i := range r.Spec.PostgresConfiguration.HBARules {
+		rule := &r.Spec.PostgresConfiguration.HBARules[i]
+		if err := rule.Validate(); err != nil {
+			allErrors = append(allErrors, field.Invalid(path.Index(i), rule.String(), err.Error()))
+		}
+		if rule.PodSelectorRef != "" && !knownSelectors.Has(rule.PodSelectorRef) {
+			allErrors = append(allErrors, field.Invalid(
+				path.Index(i).Child("podSelectorRef"),
+				rule.PodSelectorRef,
+				(&hba.ErrPodSelectorNotFound{SelectorName: rule.PodSelectorRef}).Error()))
+		}
+	}
+
+	return allErrors
+}
+
+// validateInstanceOverrides validates the PostgreSQL parameters overridden
+// on specific instances, which cannot include the fixed parameters and the
+// ones that must be consistent across the instances
+func (v *ClusterCustomValidator) validateInstanceOverrides(r *apiv1.Cluster) field.ErrorList {
+	var allErrors field.ErrorList
+	path := field.NewPath("spec", "postgresql", "instanceOverrides")
+
+	for i, override := range r.Spec.PostgresConfiguration.InstanceOverrides {
+		overridePath := path.Index(i)
+
+		switch {
+		case override.InstanceName == "" && override.Selector == nil:
+			allErrors = append(allErrors, field.Required(
+				overridePath,
+				"one of instanceName and selector is required"))
+		case override.InstanceName != "" && override.Selector != nil:
+			allErrors = append(allErrors, field.Invalid(
+				overridePath.Child("selector"),
+				override.Selector,
+				"instanceName and selector are mutually exclusive"))
+		case override.Selector != nil:
+			allErrors = append(allErrors,
+				validation.ValidateLabelSelector(override.Selector,
+					validation.LabelSelectorValidationOptions{},
+					overridePath.Child("selector"))...)
+		}
+
+		parametersPath := overridePath.Child("parameters")
+		for key := range override.Parameters {
+			switch {
+			case !postgresParameterNameRegex.MatchString(key):
+				allErrors = append(allErrors, field.Invalid(
+					parametersPath.Key(key),
+					key,
+					"must be a valid PostgreSQL configuration parameter name "+
+						"(a GUC name, optionally namespaced with a single dot)"))
+			case postgres.HotStandbySensitiveParameters[key]:
+				allErrors = append(allErrors, field.Forbidden(
+					parametersPath.Key(key),
+					"this parameter must have the same value on the primary and on the standbys, "+
+						"and cannot be overridden on specific instances"))
+			default:
+				if _, isFixed := postgres.FixedConfigurationParameters[key]; isFixed {
+					allErrors = append(allErrors, field.Forbidden(
+						parametersPath.Key(key),
+						"Can't set fixed configuration parameter"))
+				}
+			}
+		}
+
+		allErrors = append(allErrors, v.validateInstanceOverrideConfiguration(r, i, allErrors)...)
+	}
+
+	return allErrors
+}
+
+// canMatchSameInstance checks if the two overrides can be applied to the
+// same instance. The labels of the Pods are not known in advance, so a
+// selector can match any instance
+func canMatchSameInstance(a, b apiv1.InstanceParametersOverride) bool {
+	if a.InstanceName != "" && b.InstanceName != "" {
+		return a.InstanceName == b.InstanceName
+	}
+	return true
+}
+
+// validateInstanceOverrideConfiguration runs the validation of the PostgreSQL
+// configuration on the parameters of the instances matched by the override
+// with the passed index. The overrides matching the same instance are
+// applied in list order, so the parameters of the previous overrides that
+// can match the same instances are validated together with the ones of
+// this override. Only the errors not already reported for the whole
+// cluster or for the parameters of the overrides are returned
+func (v *ClusterCustomValidator) validateInstanceOverrideConfiguration(
+	r *apiv1.Cluster,
+	index int,
+	reportedErrors field.ErrorList,
+) field.ErrorList {
+	overrides := r.Spec.PostgresConfiguration.InstanceOverrides
+	override := overrides[index]
+	overridesPath := field.NewPath("spec", "postgresql", "instanceOverrides")
+
+	// The origin of each overridden parameter is tracked to report
+	// the errors on the override setting it
+	parameters := make(map[string]string, len(r.Spec.PostgresConfiguration.Parameters))
+	maps.Copy(parameters, r.Spec.PostgresConfiguration.Parameters)
+	origins := make(map[string]int)
+	for i, previous := range overrides[:index] {
+		if !canMatchSameInstance(previous, override) {
+			continue
+		}
+		for key, value := range previous.Parameters {
+			if _, isFixed := postgres.FixedConfigurationParameters[key]; isFixed ||
+				postgres.HotStandbySensitiveParameters[key] {
+				continue
+			}
+			parameters[key] = value
+			origins[key] = i
+		}
+	}
+	for key, value := range override.Parameters {
+		parameters[key] = value
+		origins[key] = index
+	}
+
+	instanceCluster := r.DeepCopy()
+	instanceCluster.Spec.PostgresConfiguration.Parameters = parameters
+
+	clusterErrors := v.validateConfiguration(r)
+	isReported := func(errorList field.ErrorList, err *field.Error) bool {
+		return slices.ContainsFunc(errorList, func(reported *field.Error) bool {
+			return reported.Field == err.Field && reported.Detail == err.Detail
+		})
+	}
+
+	clusterParametersPath := field.NewPath("spec", "postgresql", "parameters").String() + "."
+	var result field.ErrorList
+	for _, err := range v.validateConfiguration(instanceCluster) {
+		if isReported(clusterErrors, err) {
+			continue
+		}
+		key, isParameter := strings.CutPrefix(err.Field, clusterParametersPath)
+		origin, isOverridden := origins[key]
+		if !isParameter || !isOverridden {
+			if !isReported(reportedErrors, err) && !isReported(result, err) {
+				result = append(result, err)
+			}
+			continue
+		}
+
+		err.Field = overridesPath.Index(origin).Child("parameters").Key(key).String()
+		if slices.ContainsFunc(slices.Concat(reportedErrors, result), func(reported *field.Error) bool {
+			return reported.Field == err.Field
+		}) {
+			continue
+		}
+		if origin != index {
+			err.Detail = fmt.Sprintf("%s, when applied together with %s", err.Detail, overridesPath.Index(index))
+		}
+		result = append(result, err)
+	}
+
+	return result
+}
+
+// validateParametersFrom validates the PostgreSQL parameters read
+// from ConfigMaps and Secrets
+func (v *ClusterCustomValidator) validateParametersFrom(r *apiv1.Cluster) field.ErrorList {
+	var allErrors field.ErrorList
+	path := field.NewPath("spec", "postgresql", "parametersFrom")
+
+	for i, source := range r.Spec.PostgresConfiguration.ParametersFrom {
+		sourcePath := path.Index(i)
+
+		switch {
+		case source.ConfigMapKeyRef == nil && source.SecretKeyRef == nil:
+			allErrors = append(allErrors, field.Required(
+				sourcePath,
+				"one of configMapKeyRef and secretKeyRef is required"))
+		case source.ConfigMapKeyRef != nil && source.SecretKeyRef != nil:
+			allErrors = append(allErrors, field.Invalid(
+				sourcePath.Child("secretKeyRef"),
+				source.SecretKeyRef,
+				"configMapKeyRef and secretKeyRef are mutually exclusive"))
+		}
+
+		namePath := sourcePath.Child("name")
+		if !postgresParameterNameRegex.MatchString(source.Name) {
+			allErrors = append(allErrors, field.Invalid(
+				namePath,
+				source.Name,
+				"must be a valid PostgreSQL configuration parameter name "+
+					"(a GUC name, optionally namespaced with a single dot)"))
+			continue
+		}
+
+		if _, isFixed := postgres.FixedConfigurationParameters[source.Name]; isFixed ||
+			source.Name == postgres.ParameterWalLevel {
+			allErrors = append(allErrors, field.Forbidden(
+				namePath,
+				"this parameter is validated or managed by the operator and "+
+					"cannot be read from a ConfigMap or a Secret"))
+			continue
+		}
+
+		// The core parameters hold no confidential values, while the
+		// options of the extensions may contain credentials
+		if source.SecretKeyRef != nil && source.ConfigMapKeyRef == nil &&
+			!strings.Contains(source.Name, ".") {
+			allErrors = append(allErrors, field.Forbidden(
+				sourcePath.Child("secretKeyRef"),
+				"only the options of the extensions and the custom parameters "+
+					"can be read from a Secret: use a ConfigMap instead"))
+		}
+
+		if _, isInline := r.Spec.PostgresConfiguration.Parameters[source.Name]; isInline {
+			allErrors = append(allErrors, field.Duplicate(
+				namePath,
+				source.Name))
+		}
+	}
+
+	return allErrors
+}
+
 // validateServiceAccountConfig validates the ServiceAccount configuration
 // ensuring that serviceAccountName and serviceAccountTemplate are mutually exclusive.
 func (v *ClusterCustomValidator) validateServiceAccountConfig(r *apiv1.Cluster) field.ErrorList {
@@ -95,6 +95,187 @@ var _ = Describe("bootstrap methods validation", func() {
 	})
 })
 
+var _ = Describe("post-recovery SQL refs validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("doesn't complain without a bootstrap section", func() {
+		Expect(v.validatePostRecoverySQLRefs(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("doesn't complain if the references are complete", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						PostRecoverySQLRefs: []apiv1.PostRecoverySQLRefs{
+							{
+								Database: "app",
+								SQLRefs: apiv1.SQLRefs{
+									SecretRefs: []apiv1.SecretKeySelector{
+										{LocalObjectReference: apiv1.LocalObjectReference{Name: "secret1"}, Key: "key"},
+									},
+									ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+										{LocalObjectReference: apiv1.LocalObjectReference{Name: "configmap1"}, Key: "key"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validatePostRecoverySQLRefs(cluster)).To(BeEmpty())
+	})
+
+	It("complains if an entry has no references", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					PgBaseBackup: &apiv1.BootstrapPgBaseBackup{
+						PostRecoverySQLRefs: []apiv1.PostRecoverySQLRefs{{Database: "app"}},
+					},
+				},
+			},
+		}
+		Expect(v.validatePostRecoverySQLRefs(cluster)).To(HaveLen(1))
+	})
+
+	It("complains if name or key are missing", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					PgBaseBackup: &apiv1.BootstrapPgBaseBackup{
+						PostRecoverySQLRefs: []apiv1.PostRecoverySQLRefs{
+							{
+								SQLRefs: apiv1.SQLRefs{
+									SecretRefs: []apiv1.SecretKeySelector{
+										{Key: "key"},
+									},
+									ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+										{LocalObjectReference: apiv1.LocalObjectReference{Name: "configmap1"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validatePostRecoverySQLRefs(cluster)).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("bootstrap clone validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	cloneCluster := func(clone *apiv1.BootstrapClone) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "target"},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{Clone: clone},
+			},
+		}
+	}
+
+	It("doesn't complain if we are not cloning", func() {
+		Expect(v.validateBootstrapClone(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("complains when clone is used together with another bootstrap method", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source"},
+		})
+		cluster.Spec.Bootstrap.InitDB = &apiv1.BootstrapInitDB{}
+		Expect(v.validateBootstrapMethod(cluster)).To(HaveLen(1))
+	})
+
+	It("accepts cloning a Cluster in another namespace via pg_basebackup", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source", Namespace: "production"},
+			Method:  apiv1.CloneMethodPgBaseBackup,
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(BeEmpty())
+	})
+
+	It("complains if the Cluster clones itself", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "clone"},
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(HaveLen(1))
+	})
+
+	It("complains if the source namespace is not valid", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source", Namespace: "Not_Valid"},
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(HaveLen(1))
+	})
+
+	It("accepts cloning via volume snapshots", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source"},
+			Method:  apiv1.CloneMethodVolumeSnapshot,
+			VolumeSnapshots: &apiv1.DataSource{
+				Storage: corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To(volumesnapshotv1.GroupName),
+					Kind:     apiv1.VolumeSnapshotKind,
+					Name:     "pgdata",
+				},
+			},
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(BeEmpty())
+	})
+
+	It("complains if volume snapshots are missing with the volumeSnapshot method", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source"},
+			Method:  apiv1.CloneMethodVolumeSnapshot,
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(HaveLen(1))
+	})
+
+	It("complains if volume snapshots are used with the pg_basebackup method", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source"},
+			Method:  apiv1.CloneMethodPgBaseBackup,
+			VolumeSnapshots: &apiv1.DataSource{
+				Storage: corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To(volumesnapshotv1.GroupName),
+					Kind:     apiv1.VolumeSnapshotKind,
+					Name:     "pgdata",
+				},
+			},
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(HaveLen(1))
+	})
+
+	It("complains if a WAL snapshot is given without WAL storage", func() {
+		cluster := cloneCluster(&apiv1.BootstrapClone{
+			Cluster: apiv1.ClusterReference{Name: "source"},
+			Method:  apiv1.CloneMethodVolumeSnapshot,
+			VolumeSnapshots: &apiv1.DataSource{
+				Storage: corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To(volumesnapshotv1.GroupName),
+					Kind:     apiv1.VolumeSnapshotKind,
+					Name:     "pgdata",
+				},
+				WalStorage: &corev1.TypedLocalObjectReference{
+					APIGroup: ptr.To(volumesnapshotv1.GroupName),
+					Kind:     apiv1.VolumeSnapshotKind,
+					Name:     "pgwal",
+				},
+			},
+		})
+		Expect(v.validateBootstrapClone(cluster)).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("certificates options validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -1221,6 +1402,33 @@ var _ = Describe("configuration change validation", func() {
 			Expect(v.validateConfiguration(cluster)).ToNot(BeEmpty())
 		})
 
+		It("should not allow a configuration profile setting wal_log_hints to off "+
+			"for clusters having more than one instance", func() {
+			cluster := &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						utils.SkipWalArchiving: "enabled",
+					},
+				},
+				Spec: apiv1.ClusterSpec{
+					Instances: 3,
+				},
+				Status: apiv1.ClusterStatus{
+					ConfigurationProfile: &apiv1.AppliedConfigurationProfile{
+						Spec: apiv1.PostgresConfigurationProfileSpec{
+							Parameters: map[string]string{
+								"wal_log_hints": "off",
+							},
+						},
+					},
+				},
+			}
+			Expect(v.validateConfiguration(cluster)).ToNot(BeEmpty())
+
+			cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"wal_log_hints": "on"}
+			Expect(v.validateConfiguration(cluster)).To(BeEmpty())
+		})
+
 		It("should allow wal_log_hints set to on for clusters having just one instance", func() {
 			cluster := &apiv1.Cluster{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1308,6 +1516,51 @@ var _ = Describe("validate image name change", func() {
 			Expect(v.validateImageChange(clusterNew, clusterOld)).To(HaveLen(1))
 		})
 
+		It("allows restoring the previous major version from the rollback point", func() {
+			clusterOld := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					ImageName: "postgres:17.0",
+				},
+				Status: apiv1.ClusterStatus{
+					Image: "postgres:17.0",
+					PGDataImageInfo: &apiv1.ImageInfo{
+						Image:        "postgres:17.0",
+						MajorVersion: 17,
+					},
+					MajorUpgradeRollbackPoint: &apiv1.MajorUpgradeRollbackPoint{
+						BackupName: "cluster-pre-upgrade",
+						PGDataImageInfo: apiv1.ImageInfo{
+							Image:        "postgres:16.0",
+							MajorVersion: 16,
+						},
+						TargetMajorVersion: 17,
+					},
+				},
+			}
+			clusterNew := &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						utils.MajorUpgradeRollbackAnnotationName: "cluster-pre-upgrade",
+					},
+				},
+				Spec: apiv1.ClusterSpec{
+					ImageName: "postgres:16.0",
+				},
+			}
+			Expect(v.validateImageChange(clusterNew, clusterOld)).To(BeEmpty())
+
+			By("refusing a rollback to a different major version", func() {
+				clusterNew.Spec.ImageName = "postgres:15.0"
+				Expect(v.validateImageChange(clusterNew, clusterOld)).To(HaveLen(1))
+			})
+
+			By("refusing a rollback from a different backup", func() {
+				clusterNew.Spec.ImageName = "postgres:16.0"
+				clusterNew.Annotations[utils.MajorUpgradeRollbackAnnotationName] = "another-backup"
+				Expect(v.validateImageChange(clusterNew, clusterOld)).To(HaveLen(1))
+			})
+		})
+
 		It("doesn't complain if image change is valid", func() {
 			clusterOld := &apiv1.Cluster{
 				Spec: apiv1.ClusterSpec{
@@ -4490,6 +4743,25 @@ var _ = Describe("Role management validation", func() {
 		}
 		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
 	})
+	It("should produce an error if a role has an invalid parameter name", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "app",
+							ConnectionLimit: -1,
+							Parameters: map[string]string{
+								"statement_timeout": "30s",
+								"bad-name":          "on",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
+	})
 	It("should produce an error if we have a password secret AND DisablePassword in a role", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -4511,6 +4783,81 @@ var _ = Describe("Role management validation", func() {
 		}
 		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
 	})
+
+	It("should validate the password rotation policy of the roles", func() {
+		rotatedRole := func() apiv1.RoleConfiguration {
+			return apiv1.RoleConfiguration{
+				Name:            "app",
+				Login:           true,
+				ConnectionLimit: -1,
+				PasswordSecret:  &apiv1.LocalObjectReference{Name: "app-password"},
+				PasswordRotation: &apiv1.PasswordRotationConfiguration{
+					Interval: metav1.Duration{Duration: 720 * time.Hour},
+				},
+			}
+		}
+		newCluster := func(roles ...apiv1.RoleConfiguration) *apiv1.Cluster {
+			return &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{Managed: &apiv1.ManagedConfiguration{Roles: roles}},
+			}
+		}
+
+		Expect(v.validateManagedRoles(newCluster(rotatedRole()))).To(BeEmpty())
+
+		withoutSecret := rotatedRole()
+		withoutSecret.PasswordSecret = nil
+		withoutSecret.Login = false
+		Expect(v.validateManagedRoles(newCluster(withoutSecret))).To(HaveLen(2))
+
+		longGracePeriod := rotatedRole()
+		longGracePeriod.PasswordRotation.GracePeriod = &metav1.Duration{Duration: 720 * time.Hour}
+		Expect(v.validateManagedRoles(newCluster(longGracePeriod))).To(HaveLen(1))
+
+		missingAlternateSecret := rotatedRole()
+		missingAlternateSecret.PasswordRotation.Strategy = apiv1.PasswordRotationStrategyAlternateSecrets
+		Expect(v.validateManagedRoles(newCluster(missingAlternateSecret))).To(HaveLen(1))
+
+		unexpectedAlternateSecret := rotatedRole()
+		unexpectedAlternateSecret.PasswordRotation.AlternatePasswordSecret = &apiv1.LocalObjectReference{
+			Name: "app-next-password",
+		}
+		Expect(v.validateManagedRoles(newCluster(unexpectedAlternateSecret))).To(HaveLen(1))
+
+		nextLogin := apiv1.RoleConfiguration{Name: "app_next", ConnectionLimit: -1}
+		Expect(v.validateManagedRoles(newCluster(rotatedRole(), nextLogin))).To(HaveLen(1))
+	})
+
+	It("should validate the service bindings of the roles", func() {
+		newCluster := func(roles ...apiv1.RoleConfiguration) *apiv1.Cluster {
+			return &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+				Spec:       apiv1.ClusterSpec{Managed: &apiv1.ManagedConfiguration{Roles: roles}},
+			}
+		}
+
+		app := apiv1.RoleConfiguration{
+			Name:            "app_user",
+			ConnectionLimit: -1,
+			ServiceBindings: []apiv1.ServiceBinding{{Database: "app"}},
+		}
+		Expect(v.validateManagedRoles(newCluster(app))).To(BeEmpty())
+
+		invalidName := apiv1.RoleConfiguration{
+			Name:            "App User",
+			ConnectionLimit: -1,
+			ServiceBindings: []apiv1.ServiceBinding{{Database: "app"}},
+		}
+		Expect(v.validateManagedRoles(newCluster(invalidName))).To(HaveLen(1))
+		invalidName.ServiceBindings[0].SecretName = "app-user-app"
+		Expect(v.validateManagedRoles(newCluster(invalidName))).To(BeEmpty())
+
+		duplicate := apiv1.RoleConfiguration{
+			Name:            "reports",
+			ConnectionLimit: -1,
+			ServiceBindings: []apiv1.ServiceBinding{{Database: "app", SecretName: "cluster-example-app-user-app"}},
+		}
+		Expect(v.validateManagedRoles(newCluster(app, duplicate))).To(HaveLen(1))
+	})
 })
 
 var _ = Describe("Managed Extensions validation", func() {
@@ -7099,6 +7446,307 @@ var _ = Describe("podSelectorRefs validation", func() {
 	})
 })
 
+var _ = Describe("instanceOverrides validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	clusterWithOverrides := func(overrides ...apiv1.InstanceParametersOverride) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					InstanceOverrides: overrides,
+				},
+			},
+		}
+	}
+
+	It("accepts overrides by instance name and by selector", func() {
+		cluster := clusterWithOverrides(
+			apiv1.InstanceParametersOverride{
+				InstanceName: "cluster-example-2",
+				Parameters:   map[string]string{"log_min_duration_statement": "100ms"},
+			},
+			apiv1.InstanceParametersOverride{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"workload": "reporting"},
+				},
+				Parameters: map[string]string{"work_mem": "64MB"},
+			},
+		)
+		Expect(v.validateInstanceOverrides(cluster)).To(BeEmpty())
+	})
+
+	It("requires either the instance name or the selector", func() {
+		cluster := clusterWithOverrides(
+			apiv1.InstanceParametersOverride{
+				Parameters: map[string]string{"work_mem": "64MB"},
+			},
+			apiv1.InstanceParametersOverride{
+				InstanceName: "cluster-example-2",
+				Selector:     &metav1.LabelSelector{},
+				Parameters:   map[string]string{"work_mem": "64MB"},
+			},
+		)
+		result := v.validateInstanceOverrides(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeRequired))
+		Expect(result[1].Field).To(Equal("spec.postgresql.instanceOverrides[1].selector"))
+	})
+
+	It("rejects parameters that must be consistent across the instances", func() {
+		cluster := clusterWithOverrides(apiv1.InstanceParametersOverride{
+			InstanceName: "cluster-example-2",
+			Parameters: map[string]string{
+				"max_connections": "500",
+				"work_mem":        "64MB",
+			},
+		})
+		result := v.validateInstanceOverrides(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeForbidden))
+		Expect(result[0].Field).To(Equal("spec.postgresql.instanceOverrides[0].parameters[max_connections]"))
+	})
+
+	It("rejects fixed and invalid parameters", func() {
+		cluster := clusterWithOverrides(apiv1.InstanceParametersOverride{
+			InstanceName: "cluster-example-2",
+			Parameters: map[string]string{
+				"port":        "5433",
+				"not a param": "on",
+			},
+		})
+		Expect(v.validateInstanceOverrides(cluster)).To(HaveLen(2))
+	})
+
+	It("validates the configuration of the instances with the overridden parameters", func() {
+		cluster := clusterWithOverrides(apiv1.InstanceParametersOverride{
+			InstanceName: "cluster-example-2",
+			Parameters: map[string]string{
+				"wal_level":            "minimal",
+				"wal_log_hints":        "off",
+				"shared_buffers":       "one gigabyte",
+				"hot_standby_feedback": "maybe",
+			},
+		})
+		cluster.Spec.ImageName = "postgres:17"
+		cluster.Spec.Instances = 3
+
+		result := v.validateInstanceOverrides(cluster)
+		var fields []string
+		for _, err := range result {
+			fields = append(fields, err.Field)
+		}
+		Expect(fields).To(ContainElements(
+			"spec.postgresql.instanceOverrides[0].parameters[wal_level]",
+			"spec.postgresql.instanceOverrides[0].parameters[wal_log_hints]",
+			"spec.postgresql.instanceOverrides[0].parameters[shared_buffers]",
+			"spec.postgresql.instanceOverrides[0].parameters[hot_standby_feedback]",
+		))
+	})
+
+	It("validates the parameters of the overrides that can match the same instance together", func() {
+		walOverride := apiv1.InstanceParametersOverride{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"workload": "batch"},
+			},
+			Parameters: map[string]string{"min_wal_size": "3GB", "max_wal_size": "4GB"},
+		}
+		cluster := clusterWithOverrides(
+			walOverride,
+			apiv1.InstanceParametersOverride{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"disk": "small"},
+				},
+				Parameters: map[string]string{"max_wal_size": "2GB"},
+			},
+		)
+		cluster.Spec.ImageName = "postgres:17"
+
+		result := v.validateInstanceOverrides(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.instanceOverrides[0].parameters[min_wal_size]"))
+		Expect(result[0].Detail).To(ContainSubstring("spec.postgresql.instanceOverrides[1]"))
+
+		// The overrides of two different instances are never applied together
+		walOverride.Selector = nil
+		walOverride.InstanceName = "cluster-example-1"
+		cluster = clusterWithOverrides(
+			walOverride,
+			apiv1.InstanceParametersOverride{
+				InstanceName: "cluster-example-2",
+				Parameters:   map[string]string{"max_wal_size": "2GB"},
+			},
+		)
+		cluster.Spec.ImageName = "postgres:17"
+		Expect(v.validateInstanceOverrides(cluster)).To(BeEmpty())
+	})
+
+	It("doesn't report again the errors of the previous overrides", func() {
+		cluster := clusterWithOverrides(
+			apiv1.InstanceParametersOverride{
+				InstanceName: "cluster-example-2",
+				Parameters:   map[string]string{"shared_buffers": "one gigabyte"},
+			},
+			apiv1.InstanceParametersOverride{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"workload": "reporting"},
+				},
+				Parameters: map[string]string{"work_mem": "64MB"},
+			},
+		)
+		cluster.Spec.ImageName = "postgres:17"
+
+		result := v.validateInstanceOverrides(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.instanceOverrides[0].parameters[shared_buffers]"))
+	})
+
+	It("doesn't report again the errors of the cluster configuration", func() {
+		cluster := clusterWithOverrides(apiv1.InstanceParametersOverride{
+			InstanceName: "cluster-example-2",
+			Parameters:   map[string]string{"work_mem": "64MB"},
+		})
+		cluster.Spec.ImageName = "postgres:17"
+		cluster.Spec.Instances = 3
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"wal_log_hints": "off"}
+
+		Expect(v.validateInstanceOverrides(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("parametersFrom validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	clusterWithSources := func(
+		parameters map[string]string,
+		sources ...apiv1.ParameterSource,
+	) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters:     parameters,
+					ParametersFrom: sources,
+				},
+			},
+		}
+	}
+
+	It("accepts parameters read from ConfigMaps and Secrets", func() {
+		cluster := clusterWithSources(
+			map[string]string{"work_mem": "64MB"},
+			apiv1.ParameterSource{
+				Name: "shared_buffers",
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "shared_buffers",
+				},
+			},
+			apiv1.ParameterSource{
+				Name: "pgaudit.role",
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "audit"},
+					Key:                  "role",
+				},
+			},
+			apiv1.ParameterSource{
+				Name: "ai.openai_api_key",
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "ai"},
+					Key:                  "api-key",
+				},
+			},
+		)
+		Expect(v.validateParametersFrom(cluster)).To(BeEmpty())
+	})
+
+	It("rejects Secrets for the core parameters", func() {
+		cluster := clusterWithSources(
+			nil,
+			apiv1.ParameterSource{
+				Name: "krb_server_keyfile",
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "kerberos"},
+					Key:                  "keyfile",
+				},
+			},
+			apiv1.ParameterSource{
+				Name: "work_mem",
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "work_mem",
+				},
+			},
+		)
+		result := v.validateParametersFrom(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeForbidden))
+		Expect(result[0].Field).To(Equal("spec.postgresql.parametersFrom[0].secretKeyRef"))
+		Expect(result[1].Field).To(Equal("spec.postgresql.parametersFrom[1].secretKeyRef"))
+	})
+
+	It("requires exactly one reference", func() {
+		cluster := clusterWithSources(
+			nil,
+			apiv1.ParameterSource{Name: "work_mem"},
+			apiv1.ParameterSource{
+				Name: "shared_buffers",
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "shared_buffers",
+				},
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "shared_buffers",
+				},
+			},
+		)
+		result := v.validateParametersFrom(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeRequired))
+		Expect(result[1].Field).To(Equal("spec.postgresql.parametersFrom[1].secretKeyRef"))
+	})
+
+	It("rejects parameters that are also set inline", func() {
+		cluster := clusterWithSources(
+			map[string]string{"work_mem": "64MB"},
+			apiv1.ParameterSource{
+				Name: "work_mem",
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "work_mem",
+				},
+			},
+		)
+		result := v.validateParametersFrom(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		Expect(result[0].Field).To(Equal("spec.postgresql.parametersFrom[0].name"))
+	})
+
+	It("rejects fixed, operator-validated and invalid parameters", func() {
+		reference := &apiv1.ConfigMapKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+			Key:                  "value",
+		}
+		cluster := clusterWithSources(
+			nil,
+			apiv1.ParameterSource{Name: "port", ConfigMapKeyRef: reference},
+			apiv1.ParameterSource{Name: "wal_level", ConfigMapKeyRef: reference},
+			apiv1.ParameterSource{Name: "not a param", ConfigMapKeyRef: reference},
+		)
+		result := v.validateParametersFrom(cluster)
+		Expect(result).To(HaveLen(3))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeForbidden))
+		Expect(result[1].Type).To(Equal(field.ErrorTypeForbidden))
+		Expect(result[2].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+})
+
 var _ = Describe("ServiceAccount configuration validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -7306,3 +7954,69 @@ var _ = Describe("getFailureDomainTopologyWarnings", func() {
 		Expect(warnings[0]).To(ContainSubstring("Topology labels could not be extracted"))
 	})
 })
+
+var _ = Describe("hbaRules validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	newCluster := func(rules ...apiv1.HBARule) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PodSelectorRefs: []apiv1.PodSelectorRef{
+					{
+						Name: "app-pods",
+						Selector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "myapp"},
+						},
+					},
+				},
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					HBARules: rules,
+				},
+			},
+		}
+	}
+
+	It("accepts valid rules", func() {
+		result := v.validateHBARules(newCluster(
+			apiv1.HBARule{Type: apiv1.HBARuleTypeHostSSL, Address: "10.0.0.0/8", Method: "scram-sha-256"},
+			apiv1.HBARule{PodSelectorRef: "app-pods", Users: []string{"app"}, Method: "cert"},
+			apiv1.HBARule{Type: apiv1.HBARuleTypeLocal, Method: "peer", Options: map[string]string{"map": "local"}},
+			apiv1.HBARule{Address: ".example.com", Method: "md5"},
+		))
+		Expect(result).To(BeEmpty())
+	})
+
+	It("rejects an IP address not in CIDR notation", func() {
+		result := v.validateHBARules(newCluster(
+			apiv1.HBARule{Address: "10.0.0.1", Method: "scram-sha-256"},
+		))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.hbaRules[0]"))
+		Expect(result[0].Detail).To(ContainSubstring("CIDR notation"))
+	})
+
+	It("rejects an undefined pod selector", func() {
+		result := v.validateHBARules(newCluster(
+			apiv1.HBARule{PodSelectorRef: "undefined-ref", Method: "scram-sha-256"},
+		))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.hbaRules[0].podSelectorRef"))
+	})
+
+	It("rejects file inclusions and invalid options", func() {
+		result := v.validateHBARules(newCluster(
+			apiv1.HBARule{
+				Address:   "all",
+				Databases: []string{"@databases"},
+				Method:    "ldap",
+				Options:   map[string]string{"ldapserver": "a\"b"},
+			},
+		))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Detail).To(ContainSubstring("file inclusions"))
+		Expect(result[0].Detail).To(ContainSubstring("ldapserver"))
+	})
+})
@@ -21,17 +21,25 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/webhook/guard"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // databaseLog is for logging in this package.
@@ -40,7 +48,9 @@ var databaseLog = log.WithName("database-resource").WithValues("version", "v1")
 // SetupDatabaseWebhookWithManager registers the webhook for Database in the manager.
 func SetupDatabaseWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &apiv1.Database{}).
-		WithValidator(newBypassableValidator[*apiv1.Database](&DatabaseCustomValidator{})).
+		WithValidator(newBypassableValidator[*apiv1.Database](&DatabaseCustomValidator{
+			client: mgr.GetClient(),
+		})).
 		WithDefaulter(&DatabaseCustomDefaulter{}).
 		Complete()
 }
@@ -73,11 +83,16 @@ func (d *DatabaseCustomDefaulter) Default(_ context.Context, database *apiv1.Dat
 
 // DatabaseCustomValidator is responsible for validating the Database
 // resource when it is created, updated, or deleted.
-type DatabaseCustomValidator struct{}
+type DatabaseCustomValidator struct {
+	// client is used to read the Secrets used by the user mappings. When
+	// nil, like in the admission guard of the instance manager, those
+	// Secrets are not checked
+	client client.Reader
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Database .
 func (v *DatabaseCustomValidator) ValidateCreate(
-	_ context.Context, database *apiv1.Database,
+	ctx context.Context, database *apiv1.Database,
 ) (admission.Warnings, error) {
 	databaseLog.Info(
 		"Validation for Database upon creation",
@@ -86,6 +101,10 @@ func (v *DatabaseCustomValidator) ValidateCreate(
 	allErrs := v.validate(database)
 	allWarnings := v.getAdmissionWarnings(database)
 
+	secretErrs, secretWarnings := v.validateUserMappingSecrets(ctx, database)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
 	if len(allErrs) == 0 {
 		return allWarnings, nil
 	}
@@ -97,7 +116,7 @@ func (v *DatabaseCustomValidator) ValidateCreate(
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Database .
 func (v *DatabaseCustomValidator) ValidateUpdate(
-	_ context.Context,
+	ctx context.Context,
 	oldDatabase *apiv1.Database, database *apiv1.Database,
 ) (admission.Warnings, error) {
 	databaseLog.Info(
@@ -110,6 +129,10 @@ func (v *DatabaseCustomValidator) ValidateUpdate(
 	)
 	allWarnings := v.getAdmissionWarnings(database)
 
+	secretErrs, secretWarnings := v.validateUserMappingSecrets(ctx, database)
+	allErrs = append(allErrs, secretErrs...)
+	allWarnings = append(allWarnings, secretWarnings...)
+
 	if len(allErrs) == 0 {
 		return allWarnings, nil
 	}
@@ -144,6 +167,9 @@ func (v *DatabaseCustomValidator) validate(d *apiv1.Database) (allErrs field.Err
 		v.validateSchemas,
 		v.validateFDWs,
 		v.validateForeignServers,
+		v.validateParameters,
+		v.validatePrivileges,
+		v.validateDefaultPrivileges,
 	}
 
 	for _, validate := range validations {
@@ -236,11 +262,95 @@ func (v *DatabaseCustomValidator) validateForeignServers(d *apiv1.Database) fiel
 
 		allErrs = append(allErrs,
 			validateNameOptionsUsages(itemPath, server.Name, server.Options, server.Usages, nameSet)...)
+
+		allErrs = append(allErrs, validateUserMappings(itemPath.Child("userMappings"), server.UserMappings)...)
 	}
 
 	return allErrs
 }
 
+// validateUserMappings validates the user mappings of a foreign server:
+// each role can be mapped only once, and the options must be unique
+func validateUserMappings(basePath *field.Path, mappings []apiv1.UserMappingSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	roles := stringset.New()
+	for i, mapping := range mappings {
+		itemPath := basePath.Index(i)
+
+		// PUBLIC is a keyword, and it is case-insensitive
+		role := mapping.Role
+		if strings.EqualFold(role, "public") {
+			role = "public"
+		}
+		if roles.Has(role) {
+			errs = append(errs, field.Duplicate(itemPath.Child("role"), mapping.Role))
+		}
+		roles.Put(role)
+
+		optionNames := stringset.New()
+		for j, option := range mapping.Options {
+			if optionNames.Has(option.Name) {
+				errs = append(errs, field.Duplicate(itemPath.Child("options").Index(j).Child("name"), option.Name))
+			}
+			optionNames.Put(option.Name)
+		}
+	}
+
+	return errs
+}
+
+// validateUserMappingSecrets checks that the Secrets used as the password
+// of the user mappings carry the opt-in label. A Secret that cannot be
+// read, for example because it has not been created yet, is reported with
+// a warning and checked again by the database controller.
+func (v *DatabaseCustomValidator) validateUserMappingSecrets(
+	ctx context.Context,
+	d *apiv1.Database,
+) (field.ErrorList, admission.Warnings) {
+	if v.client == nil {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	var warnings admission.Warnings
+	checked := make(map[string]bool)
+	basePath := field.NewPath("spec", "servers")
+	for i, server := range d.Spec.Servers {
+		for j, mapping := range server.UserMappings {
+			if mapping.PasswordSecret == nil || mapping.Ensure == apiv1.EnsureAbsent ||
+				server.GetEnsure() == apiv1.EnsureAbsent {
+				continue
+			}
+
+			name := mapping.PasswordSecret.Name
+			allowed, found := checked[name]
+			if !found {
+				var secret corev1.Secret
+				if err := v.client.Get(ctx, client.ObjectKey{Namespace: d.Namespace, Name: name}, &secret); err != nil {
+					warnings = append(warnings, fmt.Sprintf(
+						"the password secret %q of the user mappings was not validated: %v", name, err))
+					allowed = true
+				} else {
+					allowed = apiv1.IsUserMappingSecret(&secret)
+				}
+				checked[name] = allowed
+			}
+
+			if !allowed {
+				errs = append(errs, field.Invalid(
+					basePath.Index(i).Child("userMappings").Index(j).Child("passwordSecret", "name"),
+					name,
+					fmt.Sprintf("the secret must be labeled with %s=true to be used by a user mapping",
+						utils.UserMappingSecretLabelName),
+				))
+			}
+		}
+	}
+
+	return errs, warnings
+}
+
 // validateServerFDWReference ensures the server references an existing FDW (and is non-empty).
 func (v *DatabaseCustomValidator) validateServerFDWReference(
 	fdwNames *stringset.Data,
@@ -258,6 +368,111 @@ func (v *DatabaseCustomValidator) validateServerFDWReference(
 	)}
 }
 
+// validateParameters validates the database-level configuration parameters
+func (v *DatabaseCustomValidator) validateParameters(d *apiv1.Database) field.ErrorList {
+	return validateParameterNames(field.NewPath("spec", "parameters"), d.Spec.Parameters)
+}
+
+// validatePrivileges validates the privileges of the database. Each
+// combination of object and role must be unique in .spec.privileges
+func (v *DatabaseCustomValidator) validatePrivileges(d *apiv1.Database) field.ErrorList {
+	var result field.ErrorList
+
+	names := stringset.New()
+	for i, privilege := range d.Spec.Privileges {
+		itemPath := field.NewPath("spec", "privileges").Index(i)
+
+		name := privilege.GetName()
+		if names.Has(name) {
+			result = append(result, field.Duplicate(itemPath, name))
+		}
+		names.Put(name)
+
+		switch {
+		case privilege.ObjectType == apiv1.PrivilegeObjectTypeDatabase && privilege.Schema != "":
+			result = append(result, field.Forbidden(
+				itemPath.Child("schema"),
+				"schema cannot be set when objectType is database"))
+		case privilege.ObjectType != apiv1.PrivilegeObjectTypeDatabase && privilege.Schema == "":
+			result = append(result, field.Required(
+				itemPath.Child("schema"),
+				fmt.Sprintf("schema is required when objectType is %s", privilege.ObjectType)))
+		}
+
+		result = append(result, validatePrivilegeList(
+			itemPath.Child("privileges"), privilege.ObjectType, privilege.Privileges)...)
+	}
+
+	return result
+}
+
+// validateDefaultPrivileges validates the default privileges of the database.
+// Each combination of object type, schema and roles must be unique in
+// .spec.defaultPrivileges
+func (v *DatabaseCustomValidator) validateDefaultPrivileges(d *apiv1.Database) field.ErrorList {
+	var result field.ErrorList
+
+	names := stringset.New()
+	for i, privilege := range d.Spec.DefaultPrivileges {
+		itemPath := field.NewPath("spec", "defaultPrivileges").Index(i)
+
+		name := privilege.GetName()
+		if names.Has(name) {
+			result = append(result, field.Duplicate(itemPath, name))
+		}
+		names.Put(name)
+
+		result = append(result, validatePrivilegeList(
+			itemPath.Child("privileges"), privilege.ObjectType, privilege.Privileges)...)
+	}
+
+	return result
+}
+
+// validatePrivilegeList checks that the privileges are available on the
+// object type
+func validatePrivilegeList(
+	path *field.Path,
+	objectType apiv1.PrivilegeObjectType,
+	privileges []string,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	available := objectType.GetPrivileges()
+	for i, privilege := range privileges {
+		if privilege != "ALL" && !slices.Contains(available, privilege) {
+			errs = append(errs, field.Invalid(
+				path.Index(i),
+				privilege,
+				fmt.Sprintf("privilege is not available on %s", objectType)))
+		}
+	}
+
+	return errs
+}
+
+// validateParameterNames validates the names of the configuration parameters
+// set with `ALTER ROLE/DATABASE ... SET`. Since PostgreSQL compares them
+// case-insensitively, names differing only by case are duplicates
+func validateParameterNames(path *field.Path, parameters map[string]string) field.ErrorList {
+	var errs field.ErrorList
+
+	names := stringset.New()
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		if err := postgres.ValidateParameterName(name); err != nil {
+			errs = append(errs, field.Invalid(path.Key(name), name, err.Error()))
+		}
+
+		lowerName := strings.ToLower(name)
+		if names.Has(lowerName) {
+			errs = append(errs, field.Duplicate(path.Key(name), name))
+		}
+		names.Put(lowerName)
+	}
+
+	return errs
+}
+
 // validateNameOptionsUsages validates a single named object with options and usages, tracking duplicates.
 func validateNameOptionsUsages(
 	itemPath *field.Path,
@@ -20,9 +20,14 @@ SPDX-License-Identifier: Apache-2.0
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -319,4 +324,178 @@ var _ = Describe("Database validation", func() {
 			"spec.servers[1].name":            "server1",
 		})
 	})
+
+	It("complains for duplicate roles and options within the user mappings of a foreign server", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				FDWs: []apiv1.FDWSpec{{DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "fdw1"}}},
+				Servers: []apiv1.ServerSpec{
+					{
+						FdwName:            "fdw1",
+						DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "server1", Ensure: apiv1.EnsurePresent},
+						UserMappings: []apiv1.UserMappingSpec{
+							{Role: "app", Options: []apiv1.OptionSpec{{Name: "user"}, {Name: "user"}}},
+							{Role: "PUBLIC"},
+							{Role: "app"},
+							{Role: "public"},
+						},
+					},
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf(
+			"spec.servers[0].userMappings[0].options[1].name",
+			"spec.servers[0].userMappings[2].role",
+			"spec.servers[0].userMappings[3].role",
+		))
+		expectDuplicateErrors(errs, map[string]string{
+			"spec.servers[0].userMappings[0].options[1].name": "user",
+			"spec.servers[0].userMappings[2].role":            "app",
+			"spec.servers[0].userMappings[3].role":            "public",
+		})
+	})
+
+	It("doesn't complain with valid parameters", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Parameters: map[string]string{
+					"search_path":              "app, public",
+					"pg_stat_statements.track": "all",
+				},
+			},
+		}
+		Expect(v.validate(db)).To(BeEmpty())
+	})
+
+	It("complains for invalid and duplicate parameter names", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Parameters: map[string]string{
+					"work_mem":       "64MB",
+					"WORK_MEM":       "32MB",
+					"work mem; DROP": "x",
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf(
+			"spec.parameters[work mem; DROP]",
+			"spec.parameters[work_mem]",
+		))
+	})
+
+	It("doesn't complain with valid privileges and default privileges", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Privileges: []apiv1.PrivilegeSpec{
+					{ObjectType: apiv1.PrivilegeObjectTypeDatabase, Role: "PUBLIC", Privileges: []string{"CONNECT"}},
+					{ObjectType: apiv1.PrivilegeObjectTypeSchema, Schema: "app", Role: "reporting", Privileges: []string{"USAGE"}},
+					{ObjectType: apiv1.PrivilegeObjectTypeTables, Schema: "app", Role: "reporting", Privileges: []string{"ALL"}},
+				},
+				DefaultPrivileges: []apiv1.DefaultPrivilegeSpec{
+					{
+						ForRole:    "app",
+						Schema:     "app",
+						ObjectType: apiv1.PrivilegeObjectTypeTables,
+						Role:       "reporting",
+						Privileges: []string{"SELECT"},
+					},
+				},
+			},
+		}
+		Expect(v.validate(db)).To(BeEmpty())
+	})
+
+	It("complains for invalid privileges", func() {
+		db := &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Privileges: []apiv1.PrivilegeSpec{
+					{ObjectType: apiv1.PrivilegeObjectTypeDatabase, Schema: "app", Role: "app", Privileges: []string{"CONNECT"}},
+					{ObjectType: apiv1.PrivilegeObjectTypeTables, Role: "app", Privileges: []string{"SELECT"}},
+					{ObjectType: apiv1.PrivilegeObjectTypeSchema, Schema: "app", Role: "app", Privileges: []string{"SELECT"}},
+					{ObjectType: apiv1.PrivilegeObjectTypeSchema, Schema: "app", Role: "app", Privileges: []string{"USAGE"}},
+				},
+				DefaultPrivileges: []apiv1.DefaultPrivilegeSpec{
+					{
+						ForRole:    "app",
+						Schema:     "app",
+						ObjectType: apiv1.PrivilegeObjectTypeFunctions,
+						Role:       "reporting",
+						Privileges: []string{"SELECT"},
+					},
+				},
+			},
+		}
+		errs := v.validate(db)
+		Expect(extractErrorFields(errs)).To(ConsistOf(
+			"spec.privileges[0].schema",
+			"spec.privileges[1].schema",
+			"spec.privileges[2].privileges[0]",
+			"spec.privileges[3]",
+			"spec.defaultPrivileges[0].privileges[0]",
+		))
+	})
+
+	It("accepts only the labeled secrets as the password of the user mappings", func(ctx SpecContext) {
+		v := &DatabaseCustomValidator{
+			client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "app-credentials", Namespace: "default"},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fdw-password",
+							Namespace: "default",
+							Labels:    map[string]string{utils.UserMappingSecretLabelName: "true"},
+						},
+					},
+				).
+				Build(),
+		}
+		newMapping := func(role, secretName string) apiv1.UserMappingSpec {
+			return apiv1.UserMappingSpec{
+				Role: role,
+				PasswordSecret: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: secretName},
+					Key:                  "password",
+				},
+			}
+		}
+		db := &apiv1.Database{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: apiv1.DatabaseSpec{
+				FDWs: []apiv1.FDWSpec{createFDWSpec("postgres_fdw")},
+				Servers: []apiv1.ServerSpec{{
+					DatabaseObjectSpec: apiv1.DatabaseObjectSpec{Name: "remote", Ensure: apiv1.EnsurePresent},
+					FdwName:            "postgres_fdw",
+					UserMappings: []apiv1.UserMappingSpec{
+						newMapping("reporting", "fdw-password"),
+						newMapping("app", "app-credentials"),
+						newMapping("etl", "not-yet-created"),
+					},
+				}},
+			},
+		}
+
+		errs, warnings := v.validateUserMappingSecrets(ctx, db)
+		Expect(extractErrorFields(errs)).To(ConsistOf("spec.servers[0].userMappings[1].passwordSecret.name"))
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("not-yet-created"))
+
+		warnings, err := v.ValidateCreate(ctx, db)
+		Expect(err).To(MatchError(ContainSubstring(utils.UserMappingSecretLabelName)))
+		Expect(warnings).To(HaveLen(1))
+
+		db.Spec.Servers[0].UserMappings[1].Ensure = apiv1.EnsureAbsent
+		_, err = v.ValidateUpdate(ctx, db, db)
+		Expect(err).ToNot(HaveOccurred())
+
+		db.Spec.Servers[0].UserMappings[1].Ensure = apiv1.EnsurePresent
+		errs, warnings = (&DatabaseCustomValidator{}).validateUserMappingSecrets(ctx, db)
+		Expect(errs).To(BeEmpty())
+		Expect(warnings).To(BeEmpty())
+	})
 })
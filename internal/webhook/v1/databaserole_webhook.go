@@ -0,0 +1,115 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// databaseRoleLog is for logging in this package.
+var databaseRoleLog = log.WithName("databaserole-resource").WithValues("version", "v1")
+
+// SetupDatabaseRoleWebhookWithManager registers the webhook for DatabaseRole in the manager.
+func SetupDatabaseRoleWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &apiv1.DatabaseRole{}).
+		WithValidator(newBypassableValidator[*apiv1.DatabaseRole](&DatabaseRoleCustomValidator{})).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+//
+// +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-databaserole,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=databaseroles,versions=v1,name=vdatabaserole.cnpg.io,sideEffects=None
+
+// DatabaseRoleCustomValidator is responsible for validating the DatabaseRole
+// resource when it is created, updated, or deleted.
+type DatabaseRoleCustomValidator struct{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DatabaseRole.
+func (v *DatabaseRoleCustomValidator) ValidateCreate(
+	_ context.Context, role *apiv1.DatabaseRole,
+) (admission.Warnings, error) {
+	databaseRoleLog.Info(
+		"Validation for DatabaseRole upon creation",
+		"name", role.GetName(), "namespace", role.GetNamespace())
+
+	return nil, v.validateAndReport(role)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DatabaseRole.
+func (v *DatabaseRoleCustomValidator) ValidateUpdate(
+	_ context.Context,
+	_ *apiv1.DatabaseRole, role *apiv1.DatabaseRole,
+) (admission.Warnings, error) {
+	databaseRoleLog.Info(
+		"Validation for DatabaseRole upon update",
+		"name", role.GetName(), "namespace", role.GetNamespace())
+
+	return nil, v.validateAndReport(role)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DatabaseRole.
+func (v *DatabaseRoleCustomValidator) ValidateDelete(
+	_ context.Context, role *apiv1.DatabaseRole,
+) (admission.Warnings, error) {
+	databaseRoleLog.Info(
+		"Validation for DatabaseRole upon deletion",
+		"name", role.GetName(), "namespace", role.GetNamespace())
+
+	return nil, nil
+}
+
+func (v *DatabaseRoleCustomValidator) validateAndReport(role *apiv1.DatabaseRole) error {
+	allErrs := v.validateHBARules(role)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: apiv1.SchemeGroupVersion.Group, Kind: "DatabaseRole"},
+		role.Name, allErrs)
+}
+
+// validateHBARules checks that the structured pg_hba rules can be rendered
+// for the role, as the operator does before applying them. The pod
+// selectors are defined in the cluster, and are checked by the operator
+func (v *DatabaseRoleCustomValidator) validateHBARules(role *apiv1.DatabaseRole) field.ErrorList {
+	var allErrors field.ErrorList
+	path := field.NewPath("spec", "hbaRules")
+
+	for i := range role.Spec.HBARules {
+		rule := role.Spec.HBARules[i].DeepCopy()
+		rule.Users = []string{role.Spec.Name}
+		if err := rule.Validate(); err != nil {
+			allErrors = append(allErrors, field.Invalid(path.Index(i), rule.String(), err.Error()))
+		}
+	}
+
+	return allErrors
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DatabaseRole validation", func() {
+	var (
+		v    *DatabaseRoleCustomValidator
+		role *apiv1.DatabaseRole
+	)
+
+	BeforeEach(func() {
+		v = &DatabaseRoleCustomValidator{}
+		role = &apiv1.DatabaseRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "reporting", Namespace: "default"},
+			Spec: apiv1.DatabaseRoleSpec{
+				RoleConfiguration: apiv1.RoleConfiguration{Name: "reporting"},
+				HBARules: []apiv1.HBARule{
+					{Databases: []string{"app"}, Address: "10.0.0.0/8", Method: "scram-sha-256"},
+				},
+			},
+		}
+	})
+
+	It("accepts valid pg_hba rules", func(ctx SpecContext) {
+		_, err := v.ValidateCreate(ctx, role)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects pg_hba rules that cannot be rendered", func(ctx SpecContext) {
+		role.Spec.HBARules = append(role.Spec.HBARules,
+			apiv1.HBARule{Databases: []string{"app"}, Method: "scram-sha-256"},
+			apiv1.HBARule{
+				Databases: []string{"app"},
+				Address:   "10.0.0.0/8",
+				Method:    "ldap",
+				Options:   map[string]string{"ldapserver": "ldap\"\nhost all all 0.0.0.0/0 trust"},
+			},
+		)
+
+		errs := v.validateHBARules(role)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("spec.hbaRules[1]"))
+		Expect(errs[0].Detail).To(ContainSubstring("one of address and podSelectorRef is required"))
+		Expect(errs[1].Field).To(Equal("spec.hbaRules[2]"))
+
+		_, err := v.ValidateUpdate(ctx, role, role)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects a role name that cannot be used in a pg_hba rule", func() {
+		role.Spec.Name = "reporting\nhost"
+		Expect(v.validateHBARules(role)).To(HaveLen(1))
+	})
+})
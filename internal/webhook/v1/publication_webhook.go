@@ -0,0 +1,352 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// publicationLog is for logging in this package.
+var publicationLog = log.WithName("publication-resource").WithValues("version", "v1")
+
+// SetupPublicationWebhookWithManager registers the webhook for Publication in the manager.
+func SetupPublicationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &apiv1.Publication{}).
+		WithValidator(newBypassableValidator[*apiv1.Publication](&PublicationCustomValidator{
+			client: mgr.GetClient(),
+		})).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+//
+// +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-publication,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=publications,versions=v1,name=vpublication.cnpg.io,sideEffects=None
+
+// PublicationCustomValidator is responsible for validating the Publication
+// resource when it is created, updated, or deleted.
+type PublicationCustomValidator struct {
+	// client is used to read the cluster of the publication, to validate
+	// the publication against its PostgreSQL version
+	client client.Reader
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Publication.
+func (v *PublicationCustomValidator) ValidateCreate(
+	ctx context.Context, publication *apiv1.Publication,
+) (admission.Warnings, error) {
+	publicationLog.Info(
+		"Validation for Publication upon creation",
+		"name", publication.GetName(), "namespace", publication.GetNamespace())
+
+	return v.validateAndReport(ctx, publication)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Publication.
+func (v *PublicationCustomValidator) ValidateUpdate(
+	ctx context.Context,
+	_ *apiv1.Publication, publication *apiv1.Publication,
+) (admission.Warnings, error) {
+	publicationLog.Info(
+		"Validation for Publication upon update",
+		"name", publication.GetName(), "namespace", publication.GetNamespace())
+
+	return v.validateAndReport(ctx, publication)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Publication.
+func (v *PublicationCustomValidator) ValidateDelete(
+	_ context.Context, publication *apiv1.Publication,
+) (admission.Warnings, error) {
+	publicationLog.Info(
+		"Validation for Publication upon deletion",
+		"name", publication.GetName(), "namespace", publication.GetNamespace())
+
+	return nil, nil
+}
+
+func (v *PublicationCustomValidator) validateAndReport(
+	ctx context.Context,
+	publication *apiv1.Publication,
+) (admission.Warnings, error) {
+	allErrs := v.validateParameters(publication)
+	allErrs = append(allErrs, v.validateRowFilters(publication)...)
+
+	pgMajorVersion, warning := v.getPostgresMajorVersion(ctx, publication)
+	var allWarnings admission.Warnings
+	if warning != "" {
+		allWarnings = append(allWarnings, warning)
+	} else {
+		allErrs = append(allErrs, v.validatePostgresVersion(publication, pgMajorVersion)...)
+	}
+
+	if len(allErrs) == 0 {
+		return allWarnings, nil
+	}
+
+	return allWarnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: apiv1.SchemeGroupVersion.Group, Kind: "Publication"},
+		publication.Name, allErrs)
+}
+
+// getPostgresMajorVersion returns the PostgreSQL major version of the
+// cluster of the publication. When it cannot be determined, it returns a
+// warning explaining why the publication was not validated against it.
+func (v *PublicationCustomValidator) getPostgresMajorVersion(
+	ctx context.Context,
+	publication *apiv1.Publication,
+) (int, string) {
+	var cluster apiv1.Cluster
+	if err := v.client.Get(ctx, client.ObjectKey{
+		Namespace: publication.Namespace,
+		Name:      publication.Spec.ClusterRef.Name,
+	}, &cluster); err != nil {
+		return 0, fmt.Sprintf(
+			"the publication was not validated against the PostgreSQL version of cluster %q: %v",
+			publication.Spec.ClusterRef.Name, err)
+	}
+
+	pgMajorVersion, err := cluster.GetPostgresqlMajorVersion()
+	if err != nil {
+		return 0, fmt.Sprintf(
+			"the publication was not validated against the PostgreSQL version of cluster %q: %v",
+			publication.Spec.ClusterRef.Name, err)
+	}
+
+	return pgMajorVersion, ""
+}
+
+// validateParameters forbids setting in the parameters the options
+// having a dedicated field
+func (v *PublicationCustomValidator) validateParameters(publication *apiv1.Publication) field.ErrorList {
+	var result field.ErrorList
+
+	typedParameters := []struct {
+		parameter string
+		field     string
+		isSet     bool
+	}{
+		{"publish", "publish", len(publication.Spec.Publish) > 0},
+		{"publish_via_partition_root", "publishViaPartitionRoot", publication.Spec.PublishViaPartitionRoot != nil},
+		{"publish_generated_columns", "publishGeneratedColumns", publication.Spec.PublishGeneratedColumns != ""},
+	}
+	for _, typedParameter := range typedParameters {
+		if _, found := publication.Spec.Parameters[typedParameter.parameter]; found && typedParameter.isSet {
+			result = append(result, field.Forbidden(
+				field.NewPath("spec", "parameters").Key(typedParameter.parameter),
+				fmt.Sprintf("already set through the %s field", typedParameter.field)))
+		}
+	}
+
+	return result
+}
+
+// validateRowFilters forbids the row filters that could end the WHERE
+// clause they are written in, as they are pasted into the statements
+// creating the publication
+func (v *PublicationCustomValidator) validateRowFilters(publication *apiv1.Publication) field.ErrorList {
+	var result field.ErrorList
+
+	for i, object := range publication.Spec.Target.Objects {
+		if object.Table == nil || object.Table.Where == "" {
+			continue
+		}
+
+		wherePath := field.NewPath("spec", "target", "objects").Index(i).Child("table", "where")
+		unquoted, err := unquotedText(object.Table.Where)
+		if err != nil {
+			result = append(result, field.Invalid(wherePath, object.Table.Where, err.Error()))
+			continue
+		}
+		for _, token := range []string{";", "--", "/*", "*/"} {
+			if strings.Contains(unquoted, token) {
+				result = append(result, field.Invalid(
+					wherePath,
+					object.Table.Where,
+					fmt.Sprintf("row filters cannot contain %q", token)))
+			}
+		}
+		if !hasBalancedParentheses(unquoted) {
+			result = append(result, field.Invalid(
+				wherePath,
+				object.Table.Where,
+				"row filters must have balanced parentheses"))
+		}
+	}
+
+	return result
+}
+
+// dollarQuoteRegex matches the opening delimiter of a dollar-quoted
+// string constant, with its optional tag
+var dollarQuoteRegex = regexp.MustCompile(`^\$(?:[A-Za-z_[:^ascii:]][A-Za-z0-9_[:^ascii:]]*)?\$`)
+
+// unquotedText returns the expression without its string constants and
+// quoted identifiers, each of them being replaced by a space so that the
+// text around it is not joined. The escape string constants (E'...') and
+// the dollar-quoted ones are recognized, while the backslashes are refused
+// in the other string constants, as they escape the quotes depending on
+// the standard_conforming_strings setting. The doubled quotes escaping a
+// quote character just close and reopen the quoted span, and don't need
+// to be handled
+func unquotedText(expression string) (string, error) {
+	errUnclosed := errors.New("row filters must close their string constants and quoted identifiers")
+
+	var builder strings.Builder
+	for i := 0; i < len(expression); i++ {
+		var end int
+		switch c := expression[i]; {
+		case c == '"':
+			end = strings.IndexByte(expression[i+1:], '"')
+			if end < 0 {
+				return "", errUnclosed
+			}
+			end += i + 1
+
+		case c == '\'':
+			isEscapeString := i > 0 && (expression[i-1] == 'E' || expression[i-1] == 'e') &&
+				(i == 1 || !isIdentifierByte(expression[i-2]))
+			end = -1
+			for j := i + 1; j < len(expression) && end < 0; j++ {
+				switch expression[j] {
+				case '\\':
+					if !isEscapeString {
+						return "", errors.New(
+							"row filters can only contain backslashes in the escape string constants (E'...')")
+					}
+					j++
+				case '\'':
+					end = j
+				}
+			}
+			if end < 0 {
+				return "", errUnclosed
+			}
+
+		case c == '$' && (i == 0 || !isIdentifierByte(expression[i-1])):
+			// A dollar sign not opening a string constant is a positional
+			// parameter, which is kept
+			delimiter := dollarQuoteRegex.FindString(expression[i:])
+			if delimiter == "" {
+				builder.WriteByte(c)
+				continue
+			}
+			closing := strings.Index(expression[i+len(delimiter):], delimiter)
+			if closing < 0 {
+				return "", errUnclosed
+			}
+			end = i + len(delimiter) + closing + len(delimiter) - 1
+
+		default:
+			builder.WriteByte(c)
+			continue
+		}
+
+		builder.WriteByte(' ')
+		i = end
+	}
+	return builder.String(), nil
+}
+
+// isIdentifierByte checks if the passed byte can be part of an unquoted
+// identifier, which can contain dollar signs and any non-ASCII character
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// hasBalancedParentheses checks that every parenthesis of the expression
+// is closed after being opened
+func hasBalancedParentheses(expression string) bool {
+	depth := 0
+	for _, c := range expression {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// validatePostgresVersion validates the publication against the
+// PostgreSQL major version of its cluster
+func (v *PublicationCustomValidator) validatePostgresVersion(
+	publication *apiv1.Publication,
+	pgMajorVersion int,
+) field.ErrorList {
+	var result field.ErrorList
+
+	if pgMajorVersion < 18 && publication.Spec.PublishGeneratedColumns != "" {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "publishGeneratedColumns"),
+			publication.Spec.PublishGeneratedColumns,
+			"requires PostgreSQL 18 or later"))
+	}
+
+	if pgMajorVersion >= 15 {
+		return result
+	}
+
+	for i, object := range publication.Spec.Target.Objects {
+		objectPath := field.NewPath("spec", "target", "objects").Index(i)
+		if object.TablesInSchema != "" {
+			result = append(result, field.Invalid(
+				objectPath.Child("tablesInSchema"),
+				object.TablesInSchema,
+				"requires PostgreSQL 15 or later"))
+		}
+		if object.Table == nil {
+			continue
+		}
+		if len(object.Table.Columns) > 0 {
+			result = append(result, field.Invalid(
+				objectPath.Child("table", "columns"),
+				object.Table.Columns,
+				"column lists require PostgreSQL 15 or later"))
+		}
+		if object.Table.Where != "" {
+			result = append(result, field.Invalid(
+				objectPath.Child("table", "where"),
+				object.Table.Where,
+				"row filters require PostgreSQL 15 or later"))
+		}
+	}
+
+	return result
+}
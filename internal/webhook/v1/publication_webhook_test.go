@@ -0,0 +1,241 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Publication validation", func() {
+	var (
+		v           *PublicationCustomValidator
+		publication *apiv1.Publication
+	)
+
+	newValidator := func(imageName string) *PublicationCustomValidator {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec:       apiv1.ClusterSpec{ImageName: imageName},
+		}
+		return &PublicationCustomValidator{
+			client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+		}
+	}
+
+	BeforeEach(func() {
+		v = newValidator("ghcr.io/cloudnative-pg/postgresql:18.1")
+		publication = &apiv1.Publication{
+			ObjectMeta: metav1.ObjectMeta{Name: "pub", Namespace: "default"},
+			Spec: apiv1.PublicationSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: "cluster-example"},
+				Name:       "pub",
+				DBName:     "app",
+				Target: apiv1.PublicationTarget{
+					Objects: []apiv1.PublicationTargetObject{
+						{Table: &apiv1.PublicationTargetTable{
+							Name:    "orders",
+							Columns: []string{"id", "region"},
+							Where:   "region = 'EU'",
+						}},
+					},
+				},
+				Publish:                 []apiv1.PublicationOperation{apiv1.PublicationOperationInsert},
+				PublishViaPartitionRoot: ptr.To(true),
+				PublishGeneratedColumns: apiv1.PublicationGeneratedColumnsStored,
+			},
+		}
+	})
+
+	It("accepts row filters and publish options on a recent PostgreSQL version", func(ctx SpecContext) {
+		warnings, err := v.ValidateCreate(ctx, publication)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("rejects the options not supported by the PostgreSQL version", func(ctx SpecContext) {
+		pgMajorVersion, warning := newValidator("ghcr.io/cloudnative-pg/postgresql:14.10").
+			getPostgresMajorVersion(ctx, publication)
+		Expect(warning).To(BeEmpty())
+		Expect(pgMajorVersion).To(Equal(14))
+
+		errs := v.validatePostgresVersion(publication, pgMajorVersion)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Field).To(Equal("spec.publishGeneratedColumns"))
+		Expect(errs[1].Field).To(Equal("spec.target.objects[0].table.columns"))
+		Expect(errs[2].Field).To(Equal("spec.target.objects[0].table.where"))
+
+		errs = v.validatePostgresVersion(publication, 17)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.publishGeneratedColumns"))
+	})
+
+	It("forbids setting an option both in the parameters and in its field", func(ctx SpecContext) {
+		publication.Spec.Parameters = map[string]string{
+			"publish":                    "insert",
+			"publish_via_partition_root": "true",
+			"other":                      "value",
+		}
+
+		errs := v.validateParameters(publication)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+		Expect(errs[0].Field).To(Equal("spec.parameters[publish]"))
+		Expect(errs[1].Field).To(Equal("spec.parameters[publish_via_partition_root]"))
+
+		_, err := v.ValidateUpdate(ctx, publication, publication)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects the row filters that could end their WHERE clause", func(ctx SpecContext) {
+		publication.Spec.Target.Objects = append(publication.Spec.Target.Objects,
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "customers",
+				Where: "true); ALTER ROLE app SUPERUSER; --",
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "invoices",
+				Where: "true) OR (true",
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "payments",
+				Where: "amount > 0 /* positive */",
+			}},
+		)
+
+		errs := v.validateRowFilters(publication)
+		Expect(errs).To(HaveLen(6))
+		for _, err := range errs[:3] {
+			Expect(err.Field).To(Equal("spec.target.objects[1].table.where"))
+		}
+		Expect(errs[0].Detail).To(ContainSubstring(`";"`))
+		Expect(errs[1].Detail).To(ContainSubstring(`"--"`))
+		Expect(errs[2].Detail).To(ContainSubstring("balanced parentheses"))
+		Expect(errs[3].Field).To(Equal("spec.target.objects[2].table.where"))
+		Expect(errs[4].Field).To(Equal("spec.target.objects[3].table.where"))
+		Expect(errs[5].Field).To(Equal("spec.target.objects[3].table.where"))
+
+		_, err := v.ValidateCreate(ctx, publication)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("ignores the parentheses in the literals and in the quoted identifiers", func() {
+		publication.Spec.Target.Objects = append(publication.Spec.Target.Objects,
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "customers",
+				Where: "name = '('",
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "invoices",
+				Where: `"col(1)" > 0 AND (note <> 'it''s (' OR "a""(" IS NULL)`,
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "payments",
+				Where: "name = ')' OR (true",
+			}},
+		)
+
+		errs := v.validateRowFilters(publication)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.target.objects[3].table.where"))
+		Expect(errs[0].Detail).To(ContainSubstring("balanced parentheses"))
+	})
+
+	It("ignores the forbidden tokens in the literals and in the quoted identifiers", func() {
+		publication.Spec.Target.Objects = append(publication.Spec.Target.Objects,
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "customers",
+				Where: "note <> 'a;b'",
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "invoices",
+				Where: `path LIKE '%--%' AND "a;/*b" IS NOT NULL`,
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "payments",
+				Where: "note <> 'a;b'; SELECT 1",
+			}},
+		)
+
+		errs := v.validateRowFilters(publication)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.target.objects[3].table.where"))
+		Expect(errs[0].Detail).To(ContainSubstring(`";"`))
+	})
+
+	It("recognizes the dollar-quoted and the escape string constants", func() {
+		publication.Spec.Target.Objects = append(publication.Spec.Target.Objects,
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "customers",
+				Where: `note <> $$a;b)$$ AND note <> $tag$--$$$tag$ AND price$ > 0 AND note <> E'it\'s ('`,
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "invoices",
+				Where: "$$'$$) , TABLE other WHERE ($$'$$ = $$x$$",
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "payments",
+				Where: `note = E'\'' OR true); DROP TABLE orders; --'`,
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "refunds",
+				Where: `note = '\'' ); DROP TABLE orders; --'`,
+			}},
+			apiv1.PublicationTargetObject{Table: &apiv1.PublicationTargetTable{
+				Name:  "shipments",
+				Where: "note = $tag$ unclosed",
+			}},
+		)
+
+		// The quote closing the second string constant seen without
+		// recognizing the escape string constant is in a comment
+		errs := v.validateRowFilters(publication)
+		Expect(errs).To(HaveLen(4))
+		Expect(errs[0].Field).To(Equal("spec.target.objects[2].table.where"))
+		Expect(errs[0].Detail).To(ContainSubstring("balanced parentheses"))
+		Expect(errs[1].Field).To(Equal("spec.target.objects[3].table.where"))
+		Expect(errs[1].Detail).To(ContainSubstring("close"))
+		Expect(errs[2].Field).To(Equal("spec.target.objects[4].table.where"))
+		Expect(errs[2].Detail).To(ContainSubstring("backslashes"))
+		Expect(errs[3].Field).To(Equal("spec.target.objects[5].table.where"))
+		Expect(errs[3].Detail).To(ContainSubstring("close"))
+	})
+
+	It("warns when the cluster cannot be found", func(ctx SpecContext) {
+		publication.Spec.ClusterRef.Name = "missing"
+
+		warnings, err := v.ValidateCreate(ctx, publication)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring(`cluster "missing"`))
+	})
+})
@@ -142,6 +142,80 @@ func WritePostgresConfiguration(
 	return fileutils.WriteLinesToFile(fileName, UpdateConfigurationContents(nil, options))
 }
 
+// ParsePostgresConfiguration reads the options contained in the passed
+// lines of a PostgreSQL configuration file, such as the ones written by
+// ALTER SYSTEM. Comments and include directives are skipped and, as in
+// PostgreSQL, the last occurrence of an option wins.
+func ParsePostgresConfiguration(lines []string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The equal sign between name and value is optional
+		separator := strings.IndexAny(line, " \t=")
+		if separator < 0 {
+			continue
+		}
+		key := strings.ToLower(line[:separator])
+		if key == "include" || key == "include_if_exists" || key == "include_dir" {
+			continue
+		}
+		value := strings.TrimLeft(line[separator:], " \t")
+		value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+
+		result[key] = parsePostgresConfValue(value)
+	}
+
+	return result
+}
+
+// parsePostgresConfValue reverses escapePostgresConfLiteral, also accepting
+// unquoted values followed by an optional comment
+func parsePostgresConfValue(value string) string {
+	if !strings.HasPrefix(value, "'") {
+		value, _, _ = strings.Cut(value, "#")
+		return strings.TrimSpace(value)
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\'':
+			if i+1 < len(value) && value[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String()
+		case '\\':
+			if i+1 >= len(value) {
+				return b.String()
+			}
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			default:
+				b.WriteByte(value[i])
+			}
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
 // RemoveOptionsFromConfigurationContents deletes all the lines containing one of the given options
 // from the provided configuration content
 func RemoveOptionsFromConfigurationContents(lines []string, options ...string) []string {
@@ -340,6 +340,37 @@ var _ = Describe("escapePostgresConfLiteral", func() {
 	})
 })
 
+var _ = Describe("ParsePostgresConfiguration", func() {
+	It("reads the options of a configuration file", func() {
+		lines := []string{
+			"# Do not edit this file manually!",
+			"# It will be overwritten by the ALTER SYSTEM command.",
+			"work_mem = '8MB'",
+			"Log_Min_Duration_Statement = 100   # trailing comment",
+			"search_path '\"$user\", public'",
+			"primary_conninfo='host=a user=b'",
+			"include 'other.conf'",
+			"",
+			"work_mem = '16MB'",
+		}
+		Expect(ParsePostgresConfiguration(lines)).To(Equal(map[string]string{
+			"work_mem":                   "16MB",
+			"log_min_duration_statement": "100",
+			"search_path":                `"$user", public`,
+			"primary_conninfo":           "host=a user=b",
+		}))
+	})
+
+	It("reverses the escaping of the rendered configuration", func() {
+		options := map[string]string{
+			"application_name": "a'b\\c\nd\te",
+			"archive_command":  "/bin/true # not a comment",
+		}
+		lines := UpdateConfigurationContents(nil, options)
+		Expect(ParsePostgresConfiguration(lines)).To(Equal(options))
+	})
+})
+
 var _ = Describe("UpdateConfigurationContents with special characters", func() {
 	It("escapes single quotes in values", func() {
 		updated := UpdateConfigurationContents(nil, map[string]string{
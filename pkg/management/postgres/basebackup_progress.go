@@ -0,0 +1,171 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// baseBackupProgressInterval is the interval between two
+// checks of the progress of a running pg_basebackup
+const baseBackupProgressInterval = 15 * time.Second
+
+// BaseBackupProgressReporter receives the progress of a running pg_basebackup
+type BaseBackupProgressReporter func(ctx context.Context, progress apiv1.BaseBackupProgress)
+
+// NewBaseBackupProgressReporter creates a reporter storing the progress
+// of the copy of the data directory of an instance in the cluster status
+func NewBaseBackupProgressReporter(
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	instanceName string,
+) BaseBackupProgressReporter {
+	// The status of the cluster is refreshed by every patch, so we
+	// use a copy of it not to race with the caller
+	cluster = cluster.DeepCopy()
+	return func(ctx context.Context, progress apiv1.BaseBackupProgress) {
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			cli,
+			cluster,
+			status.SetBaseBackupProgress(apiv1.PodName(instanceName), &progress),
+		); err != nil {
+			log.FromContext(ctx).Warning("Cannot report the progress of pg_basebackup", "err", err)
+		}
+	}
+}
+
+// ClearBaseBackupProgress removes the progress of the copy of the
+// data directory of an instance from the cluster status
+func ClearBaseBackupProgress(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	instanceName string,
+) error {
+	return status.PatchWithOptimisticLock(
+		ctx,
+		cli,
+		cluster,
+		status.SetBaseBackupProgress(apiv1.PodName(instanceName), nil),
+	)
+}
+
+// monitorBaseBackupProgress periodically reports the progress of the
+// pg_basebackup using the passed application name, until the context
+// is cancelled. The progress is read from a regular connection to the
+// source server, and errors are only logged as the progress is
+// informative
+func monitorBaseBackupProgress(
+	ctx context.Context,
+	connectionString string,
+	applicationName string,
+	reportProgress BaseBackupProgressReporter,
+) {
+	contextLogger := log.FromContext(ctx)
+
+	db, err := pool.NewDBConnection(connectionString, pool.ConnectionProfilePostgresql)
+	if err != nil {
+		contextLogger.Warning("Cannot monitor the progress of pg_basebackup", "err", err)
+		return
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	ticker := time.NewTicker(baseBackupProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		progress, err := getBaseBackupProgress(ctx, db, applicationName)
+		if err != nil {
+			contextLogger.Debug("Error while reading the progress of pg_basebackup", "err", err)
+			continue
+		}
+		if progress == nil {
+			continue
+		}
+
+		contextLogger.Info("pg_basebackup progress",
+			"phase", progress.Phase,
+			"backupStreamed", progress.BackupStreamed,
+			"backupTotal", progress.BackupTotal)
+		reportProgress(ctx, *progress)
+	}
+}
+
+// getBaseBackupProgress reads the progress of the base backup taken by
+// the passed application name from `pg_stat_progress_basebackup`.
+// It returns nil if no such base backup is running
+func getBaseBackupProgress(
+	ctx context.Context,
+	db *sql.DB,
+	applicationName string,
+) (*apiv1.BaseBackupProgress, error) {
+	row := db.QueryRowContext(
+		ctx,
+		`SELECT p.phase, p.backup_total, p.backup_streamed,
+			p.tablespaces_total, p.tablespaces_streamed
+		FROM pg_catalog.pg_stat_progress_basebackup p
+		JOIN pg_catalog.pg_stat_activity a USING (pid)
+		WHERE a.application_name = $1`,
+		applicationName)
+
+	var progress apiv1.BaseBackupProgress
+	var backupTotal sql.NullInt64
+	err := row.Scan(
+		&progress.Phase,
+		&backupTotal,
+		&progress.BackupStreamed,
+		&progress.TablespacesTotal,
+		&progress.TablespacesStreamed,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if backupTotal.Valid {
+		progress.BackupTotal = ptr.To(backupTotal.Int64)
+	}
+	progress.LastUpdateTime = metav1.Now()
+
+	return &progress, nil
+}
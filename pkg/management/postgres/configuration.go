@@ -22,6 +22,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
@@ -64,11 +65,14 @@ func InstallPgDataFileContent(ctx context.Context, pgdata, contents, destination
 }
 
 // RefreshConfigurationFilesFromCluster receives a cluster object, then generates the
-// PostgreSQL configuration and rewrites the file in the PGDATA if needed. This
-// function will return "true" if the configuration has been really changed.
+// PostgreSQL configuration and rewrites the file in the PGDATA if needed. The
+// parametersFrom map contains the values of the options read from ConfigMaps
+// and Secrets. This function will return "true" if the configuration has been
+// really changed.
 func (instance *Instance) RefreshConfigurationFilesFromCluster(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
+	parametersFrom map[string]string,
 	preserveUserSettings bool,
 	operationType postgresClient.OperationType_Type,
 ) (bool, error) {
@@ -78,7 +82,7 @@ func (instance *Instance) RefreshConfigurationFilesFromCluster(
 	}
 
 	postgresConfiguration, sha256, err := createPostgresqlConfiguration(
-		ctx, cluster, preserveUserSettings, pgMajor,
+		ctx, cluster, instance.GetPodName(), parametersFrom, preserveUserSettings, pgMajor,
 		operationType,
 	)
 	if err != nil {
@@ -100,7 +104,7 @@ func (instance *Instance) RefreshConfigurationFilesFromCluster(
 }
 
 // GeneratePostgresqlHBA generates the pg_hba.conf content with the LDAP configuration if configured.
-func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBindPassword string) (string, error) {
+func GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBindPassword string) (string, error) {
 	majorVersion, err := cluster.GetPostgresqlMajorVersion()
 	if err != nil {
 		return "", err
@@ -119,7 +123,7 @@ func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBind
 	}
 
 	return postgres.CreateHBARules(
-		cluster.Spec.PostgresConfiguration.PgHBA,
+		cluster.GetPgHBA(),
 		postgres.HBAOptions{
 			DefaultAuthenticationMethod: defaultAuthenticationMethod,
 			LDAPConfigString:            buildLDAPConfigString(cluster, ldapBindPassword),
@@ -133,7 +137,7 @@ func (instance *Instance) RefreshPGHBA(ctx context.Context, cluster *apiv1.Clust
 	err error,
 ) {
 	// Generate pg_hba.conf file
-	pgHBAContent, err := instance.GeneratePostgresqlHBA(cluster, ldapBindPassword)
+	pgHBAContent, err := GeneratePostgresqlHBA(cluster, ldapBindPassword)
 	if err != nil {
 		return false, nil
 	}
@@ -332,20 +336,59 @@ func createStandbySignal(pgData string) error {
 }
 
 // createPostgresqlConfiguration creates the PostgreSQL configuration to be
-// used for this cluster and return it and its sha256 checksum
+// used for the passed instance of this cluster and return it and its sha256 checksum
 func createPostgresqlConfiguration(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
+	instanceName string,
+	parametersFrom map[string]string,
 	preserveUserSettings bool,
 	majorVersion int,
 	operationType postgresClient.OperationType_Type,
 ) (string, string, error) {
+	config, err := BuildPostgresqlConfiguration(
+		ctx, cluster, instanceName, parametersFrom, preserveUserSettings, majorVersion, operationType,
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	file, sha := postgres.CreatePostgresqlConfFile(config)
+	return file, sha, nil
+}
+
+// BuildPostgresqlConfiguration computes the PostgreSQL configuration of
+// the passed instance of this cluster, including the parameters set by
+// the operator and the ones added by the plugins available in the context
+func BuildPostgresqlConfiguration(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	instanceName string,
+	parametersFrom map[string]string,
+	preserveUserSettings bool,
+	majorVersion int,
+	operationType postgresClient.OperationType_Type,
+) (*postgres.PgConfiguration, error) {
+	// The parameters read from ConfigMaps and Secrets never overlap
+	// with the inline ones, while the ones overridden on this instance
+	// take precedence over the ones set for the whole cluster
+	userSettings := cluster.GetPostgresParameters()
+	overrides := cluster.Status.InstanceParameters[apiv1.PodName(instanceName)]
+	if len(parametersFrom) > 0 || len(overrides) > 0 {
+		clusterSettings := userSettings
+		userSettings = make(map[string]string, len(clusterSettings)+len(parametersFrom)+len(overrides))
+		maps.Copy(userSettings, clusterSettings)
+		maps.Copy(userSettings, parametersFrom)
+		maps.Copy(userSettings, overrides)
+	}
+
 	info := postgres.ConfigurationInfo{
 		Settings:                         postgres.CnpgConfigurationSettings,
 		MajorVersion:                     majorVersion,
-		UserSettings:                     cluster.Spec.PostgresConfiguration.Parameters,
+		UserSettings:                     userSettings,
+		TunedSettings:                    cluster.GetTunedParameters(),
 		IncludingSharedPreloadLibraries:  true,
-		AdditionalSharedPreloadLibraries: cluster.Spec.PostgresConfiguration.AdditionalLibraries,
+		AdditionalSharedPreloadLibraries: cluster.GetAdditionalLibraries(),
 		IsReplicaCluster:                 cluster.IsReplica(),
 		IsWalArchivingDisabled:           utils.IsWalArchivingDisabled(&cluster.ObjectMeta),
 		IsAlterSystemEnabled:             cluster.Spec.PostgresConfiguration.EnableAlterSystem,
@@ -375,7 +418,7 @@ func createPostgresqlConfiguration(
 	// PGDataImageInfo at the steady-state mount path.
 	exts, baseDir, err := selectAdditionalExtensions(cluster, operationType)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 	for _, extension := range exts {
 		info.AdditionalExtensions = append(
@@ -404,13 +447,7 @@ func createPostgresqlConfiguration(
 		info.SynchronizedStandbySlots = slots
 	}
 
-	config, err := plugin.CreatePostgresqlConfigurationWithPlugins(ctx, info, operationType)
-	if err != nil {
-		return "", "", err
-	}
-
-	file, sha := postgres.CreatePostgresqlConfFile(config)
-	return file, sha, nil
+	return plugin.CreatePostgresqlConfigurationWithPlugins(ctx, info, operationType)
 }
 
 func isSynchronizeLogicalDecodingEnabled(cluster *apiv1.Cluster) bool {
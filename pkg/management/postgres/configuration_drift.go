@@ -0,0 +1,119 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"database/sql"
+	"path"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// postgresqlAutoConfigurationFile is the file written by ALTER SYSTEM
+const postgresqlAutoConfigurationFile = "postgresql.auto.conf"
+
+// GetPendingRestartParameters gets the names of the parameters whose
+// change requires a restart of PostgreSQL to be applied
+func GetPendingRestartParameters(db *sql.DB) (result []string, err error) {
+	rows, err := db.Query(
+		"SELECT name FROM pg_catalog.pg_settings WHERE pending_restart ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+
+	return result, rows.Err()
+}
+
+// readConfigurationFile reads the options of a configuration file in
+// PGDATA, returning an empty map when the file doesn't exist
+func (instance *Instance) readConfigurationFile(fileName string) (map[string]string, error) {
+	filePath := path.Join(instance.PgData, fileName)
+	exists, err := fileutils.FileExists(filePath)
+	if err != nil || !exists {
+		return map[string]string{}, err
+	}
+
+	lines, err := fileutils.ReadFileLines(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return configfile.ParsePostgresConfiguration(lines), nil
+}
+
+// GetConfigurationFileHash gets the hash of the configuration generated
+// by the instance manager from the cluster definition, which is loaded by
+// PostgreSQL at the next reload
+func (instance *Instance) GetConfigurationFileHash() (string, error) {
+	options, err := instance.readConfigurationFile(constants.PostgresqlCustomConfigurationFile)
+	if err != nil {
+		return "", err
+	}
+	return options[postgres.CNPGConfigSha256], nil
+}
+
+// GetAlterSystemParameters gets the parameters set via ALTER SYSTEM whose
+// value differs from the one in the configuration managed by the operator
+func (instance *Instance) GetAlterSystemParameters() (map[string]string, error) {
+	alterSystemOptions, err := instance.readConfigurationFile(postgresqlAutoConfigurationFile)
+	if err != nil || len(alterSystemOptions) == 0 {
+		return nil, err
+	}
+
+	managedOptions, err := instance.readConfigurationFile(constants.PostgresqlCustomConfigurationFile)
+	if err != nil {
+		return nil, err
+	}
+	overrideOptions, err := instance.readConfigurationFile(constants.PostgresqlOverrideConfigurationFile)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range overrideOptions {
+		managedOptions[key] = value
+	}
+
+	var result map[string]string
+	for key, value := range alterSystemOptions {
+		if managedValue, ok := managedOptions[key]; ok && managedValue == value {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
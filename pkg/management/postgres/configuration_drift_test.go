@@ -0,0 +1,106 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"os"
+	"path"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration drift", func() {
+	var instance *Instance
+
+	writeFile := func(name, content string) {
+		Expect(os.WriteFile(path.Join(instance.PgData, name), []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		instance = NewInstance()
+		instance.PgData = GinkgoT().TempDir()
+	})
+
+	It("reads the names of the parameters pending restart", func() {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+
+		mock.ExpectQuery(
+			"SELECT name FROM pg_catalog.pg_settings WHERE pending_restart ORDER BY name").
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).
+				AddRow("max_connections").
+				AddRow("shared_buffers"))
+
+		names, err := GetPendingRestartParameters(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"max_connections", "shared_buffers"}))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("reads the hash of the generated configuration", func() {
+		writeFile(constants.PostgresqlCustomConfigurationFile,
+			"max_connections = '100'\ncnpg.config_sha256 = 'abc'\n")
+
+		hash, err := instance.GetConfigurationFileHash()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hash).To(Equal("abc"))
+	})
+
+	It("returns an empty hash when the configuration is missing", func() {
+		hash, err := instance.GetConfigurationFileHash()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hash).To(BeEmpty())
+	})
+
+	It("reports nothing when ALTER SYSTEM was never used", func() {
+		writeFile(constants.PostgresqlCustomConfigurationFile, "work_mem = '4MB'\n")
+
+		parameters, err := instance.GetAlterSystemParameters()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(BeNil())
+	})
+
+	It("reports the ALTER SYSTEM values differing from the managed configuration", func() {
+		writeFile(constants.PostgresqlCustomConfigurationFile,
+			"work_mem = '4MB'\nshared_buffers = '128MB'\nmax_connections = '100'\n")
+		writeFile(constants.PostgresqlOverrideConfigurationFile, "max_connections = '200'\n")
+		writeFile(postgresqlAutoConfigurationFile,
+			"# Do not edit this file manually!\n"+
+				"work_mem = '4MB'\n"+
+				"shared_buffers = '256MB'\n"+
+				"max_connections = '200'\n"+
+				"log_min_duration_statement = '1s'\n")
+
+		parameters, err := instance.GetAlterSystemParameters()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(Equal(map[string]string{
+			"shared_buffers":             "256MB",
+			"log_min_duration_statement": "1s",
+		}))
+	})
+})
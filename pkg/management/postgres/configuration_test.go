@@ -30,6 +30,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/image/reference"
 	"github.com/cloudnative-pg/machinery/pkg/postgres/version"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/ptr"
@@ -183,7 +184,7 @@ var _ = Describe("Test building of the list of temporary tablespaces", func() {
 
 	It("doesn't set temp_tablespaces if there are no declared tablespaces", func(ctx SpecContext) {
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &clusterWithoutTablespaces, true, defaultMajor,
+			ctx, &clusterWithoutTablespaces, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -192,7 +193,7 @@ var _ = Describe("Test building of the list of temporary tablespaces", func() {
 
 	It("doesn't set temp_tablespaces if there are no temporary tablespaces", func(ctx SpecContext) {
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &clusterWithoutTemporaryTablespaces, true, defaultMajor,
+			ctx, &clusterWithoutTemporaryTablespaces, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -201,7 +202,7 @@ var _ = Describe("Test building of the list of temporary tablespaces", func() {
 
 	It("sets temp_tablespaces when there are temporary tablespaces", func(ctx SpecContext) {
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &clusterWithTemporaryTablespaces, true, defaultMajor,
+			ctx, &clusterWithTemporaryTablespaces, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -263,7 +264,7 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(primaryCluster.IsReplica()).To(BeFalse())
 
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &primaryCluster, true, defaultMajor,
+			ctx, &primaryCluster, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -274,7 +275,7 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(replicaCluster.IsReplica()).To(BeTrue())
 
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &replicaCluster, true, defaultMajor,
+			ctx, &replicaCluster, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -285,7 +286,7 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(replicaClusterWithNoDelay.IsReplica()).To(BeTrue())
 
 		config, _, err := createPostgresqlConfiguration(
-			ctx, &replicaClusterWithNoDelay, true, defaultMajor,
+			ctx, &replicaClusterWithNoDelay, "", nil, true, defaultMajor,
 			postgres.OperationType_TYPE_UNSPECIFIED,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -293,6 +294,99 @@ var _ = Describe("recovery_min_apply_delay", func() {
 	})
 })
 
+var _ = Describe("instance parameter overrides", func() {
+	defaultVersion, defaultVersionErr := version.FromTag(reference.New(versions.DefaultImageName).Tag)
+	Expect(defaultVersionErr).ToNot(HaveOccurred())
+	defaultMajor := int(defaultVersion.Major())
+
+	cluster := apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			PostgresConfiguration: apiv1.PostgresConfiguration{
+				Parameters: map[string]string{
+					"work_mem": "4MB",
+				},
+			},
+		},
+		Status: apiv1.ClusterStatus{
+			InstanceParameters: map[apiv1.PodName]map[string]string{
+				"cluster-example-2": {"work_mem": "64MB"},
+			},
+		},
+	}
+
+	It("applies the overrides of the instance", func(ctx SpecContext) {
+		config, _, err := createPostgresqlConfiguration(
+			ctx, &cluster, "cluster-example-2", nil, true, defaultMajor,
+			postgres.OperationType_TYPE_UNSPECIFIED,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("work_mem = '64MB'"))
+		Expect(cluster.Spec.PostgresConfiguration.Parameters).To(HaveKeyWithValue("work_mem", "4MB"))
+	})
+
+	It("doesn't apply the overrides of other instances", func(ctx SpecContext) {
+		config, _, err := createPostgresqlConfiguration(
+			ctx, &cluster, "cluster-example-1", nil, true, defaultMajor,
+			postgres.OperationType_TYPE_UNSPECIFIED,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("work_mem = '4MB'"))
+	})
+
+	It("applies the parameters read from ConfigMaps and Secrets", func(ctx SpecContext) {
+		config, _, err := createPostgresqlConfiguration(
+			ctx, &cluster, "cluster-example-1",
+			map[string]string{"maintenance_work_mem": "256MB"}, true, defaultMajor,
+			postgres.OperationType_TYPE_UNSPECIFIED,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("maintenance_work_mem = '256MB'"))
+		Expect(config).To(ContainSubstring("work_mem = '4MB'"))
+	})
+
+	It("gives precedence to the overrides of the instance", func(ctx SpecContext) {
+		config, _, err := createPostgresqlConfiguration(
+			ctx, &cluster, "cluster-example-2",
+			map[string]string{"work_mem": "16MB"}, true, defaultMajor,
+			postgres.OperationType_TYPE_UNSPECIFIED,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("work_mem = '64MB'"))
+	})
+})
+
+var _ = Describe("tuned parameters", func() {
+	defaultVersion, defaultVersionErr := version.FromTag(reference.New(versions.DefaultImageName).Tag)
+	Expect(defaultVersionErr).ToNot(HaveOccurred())
+	defaultMajor := int(defaultVersion.Major())
+
+	It("keeps the explicit values matching the CloudNativePG defaults", func(ctx SpecContext) {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Tuning: apiv1.TuningProfileOLTP,
+					Parameters: map[string]string{
+						"max_parallel_workers": "32",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+				},
+			},
+		}
+
+		config, _, err := createPostgresqlConfiguration(
+			ctx, &cluster, "cluster-example-1", nil, true, defaultMajor,
+			postgres.OperationType_TYPE_UNSPECIFIED,
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config).To(ContainSubstring("max_parallel_workers = '32'"))
+		Expect(config).To(ContainSubstring("max_parallel_workers_per_gather = '1'"))
+	})
+})
+
 var _ = Describe("selectAdditionalExtensions", func() {
 	steadyStateCluster := func() *apiv1.Cluster {
 		return &apiv1.Cluster{
@@ -59,4 +59,9 @@ const (
 	// if present, requires the WAL archiver to check that the backup object
 	// store is empty.
 	CheckEmptyWalArchiveFile = ".check-empty-wal-archive"
+
+	// BaseBackupCompletedFile is the name of the file in the PGDATA that,
+	// if present, tells that the data directory has been completely copied
+	// by pg_basebackup and can be reused if the job copying it is retried
+	BaseBackupCompletedFile = ".cnpg-basebackup-completed"
 )
@@ -120,6 +120,11 @@ type InitInfo struct {
 	// to be executed inside the `template1` database right after having configured a new instance
 	PostInitTemplateSQLRefsFolder string
 
+	// PostRecoverySQLRefsFolder is the folder which contains a folder of SQL
+	// files for each entry of the post recovery SQL refs, named after its index,
+	// to be executed right after the promotion of a recovered instance
+	PostRecoverySQLRefsFolder string
+
 	// BackupLabelFile holds the content returned by pg_stop_backup. Needed for a hot backup restore
 	BackupLabelFile []byte
 
@@ -508,9 +513,15 @@ func (info InitInfo) Bootstrap(ctx context.Context) error {
 		cluster.Spec.Bootstrap.InitDB != nil &&
 		cluster.Spec.Bootstrap.InitDB.Import != nil
 
+	parametersFrom, err := ReadParametersFrom(ctx, typedClient, cluster)
+	if err != nil {
+		return fmt.Errorf("while reading the parameters from ConfigMaps and Secrets: %w", err)
+	}
+
 	if applied, err := instance.RefreshConfigurationFilesFromCluster(
 		ctx,
 		cluster,
+		parametersFrom,
 		true,
 		postgres.OperationType_TYPE_INIT,
 	); err != nil {
@@ -23,11 +23,14 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 	"github.com/cloudnative-pg/machinery/pkg/log"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/system"
 
@@ -35,9 +38,28 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// CloneOptions are the options of the copy of the data directory
+// of another server
+type CloneOptions struct {
+	// PgBaseBackup contains the options passed to pg_basebackup
+	PgBaseBackup *apiv1.PgBaseBackupOptions
+
+	// ApplicationName is the name used by pg_basebackup to connect
+	// to the source server, and to find its progress there
+	ApplicationName string
+
+	// ReportProgress, if set, is periodically invoked with the
+	// progress of the copy
+	ReportProgress BaseBackupProgressReporter
+}
+
 // ClonePgData clones an existing server, given its connection string,
 // to a certain data directory
-func ClonePgData(ctx context.Context, connectionString, targetPgData, walDir string) error {
+func ClonePgData(
+	ctx context.Context,
+	connectionString, targetPgData, walDir string,
+	cloneOptions CloneOptions,
+) error {
 	log.Info("Waiting for server to be available", "connectionString", connectionString)
 
 	db, err := pool.NewDBConnection(connectionString, pool.ConnectionProfilePostgresqlPhysicalReplication)
@@ -53,6 +75,10 @@ func ClonePgData(ctx context.Context, connectionString, targetPgData, walDir str
 		return fmt.Errorf("source server not available: %v", connectionString)
 	}
 
+	if cloneOptions.ApplicationName != "" {
+		connectionString += fmt.Sprintf(" application_name=%v", cloneOptions.ApplicationName)
+	}
+
 	options := []string{
 		"-D", targetPgData,
 		"-v",
@@ -64,6 +90,22 @@ func ClonePgData(ctx context.Context, connectionString, targetPgData, walDir str
 		options = append(options, "--waldir", walDir)
 	}
 
+	options = append(options, buildPgBaseBackupOptions(cloneOptions.PgBaseBackup)...)
+
+	if cloneOptions.ReportProgress != nil && cloneOptions.ApplicationName != "" {
+		monitorCtx, cancel := context.WithCancel(ctx)
+		monitorDone := make(chan struct{})
+		go func() {
+			defer close(monitorDone)
+			monitorBaseBackupProgress(monitorCtx, connectionString, cloneOptions.ApplicationName,
+				cloneOptions.ReportProgress)
+		}()
+		defer func() {
+			cancel()
+			<-monitorDone
+		}()
+	}
+
 	pgBaseBackupCmd := exec.Command(pgBaseBackupName, options...) // #nosec
 	err = execlog.RunStreaming(pgBaseBackupCmd, pgBaseBackupName)
 	if err != nil {
@@ -73,8 +115,86 @@ func ClonePgData(ctx context.Context, connectionString, targetPgData, walDir str
 	return nil
 }
 
+// buildPgBaseBackupOptions builds the command line options of
+// pg_basebackup corresponding to the passed configuration
+func buildPgBaseBackupOptions(options *apiv1.PgBaseBackupOptions) []string {
+	if options == nil {
+		return nil
+	}
+
+	var result []string
+	if options.MaxRate != "" {
+		result = append(result, "--max-rate", options.MaxRate)
+	}
+	if options.VerifyChecksums != nil && !*options.VerifyChecksums {
+		result = append(result, "--no-verify-checksums")
+	}
+	if options.ManifestChecksums != "" {
+		result = append(result, "--manifest-checksums", options.ManifestChecksums)
+	}
+
+	return result
+}
+
+// CloneDataDirectory copies the data directory of the server reachable
+// via the passed connection string. If a previous execution already
+// completed the copy on the same volumes, the copy is reused
+func (info InitInfo) CloneDataDirectory(
+	ctx context.Context,
+	connectionString string,
+	options CloneOptions,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	completed, err := info.isBaseBackupCompleted()
+	if err != nil {
+		return err
+	}
+	if completed {
+		contextLogger.Info("The data directory has already been copied, reusing it")
+		return nil
+	}
+
+	if err := info.EnsureTargetDirectoriesDoNotExist(ctx); err != nil {
+		return err
+	}
+
+	if err := ClonePgData(ctx, connectionString, info.PgData, info.PgWal, options); err != nil {
+		return err
+	}
+
+	return fileutils.CreateEmptyFile(filepath.Join(info.PgData, constants.BaseBackupCompletedFile))
+}
+
+// isBaseBackupCompleted checks if the data directory has been
+// completely copied by a previous execution of pg_basebackup
+func (info InitInfo) isBaseBackupCompleted() (bool, error) {
+	markerExists, err := fileutils.FileExists(filepath.Join(info.PgData, constants.BaseBackupCompletedFile))
+	if err != nil || !markerExists {
+		return false, err
+	}
+
+	// The control file is the last file written by pg_basebackup
+	if _, err := info.GetInstance(nil).GetPgControldata(); err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ClearBaseBackupCompleted removes the marker of a completed copy of the
+// data directory. This must happen before the data directory is changed,
+// as it cannot be reused anymore from that moment
+func (info InitInfo) ClearBaseBackupCompleted() error {
+	return fileutils.RemoveFile(filepath.Join(info.PgData, constants.BaseBackupCompletedFile))
+}
+
 // Join creates a new instance joined to an existing PostgreSQL cluster
-func (info InitInfo) Join(ctx context.Context, cluster *apiv1.Cluster) error {
+func (info InitInfo) Join(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	reportProgress BaseBackupProgressReporter,
+) error {
 	primaryConnInfo := buildPrimaryConnInfo(info.ParentNode, info.PodName) + " dbname=postgres connect_timeout=5"
 
 	// We explicitly disable wal_sender_timeout for join-related pg_basebackup executions.
@@ -87,11 +207,18 @@ func (info InitInfo) Join(ctx context.Context, cluster *apiv1.Cluster) error {
 		return err
 	}
 
-	if err := ClonePgData(ctx, primaryConnInfo, info.PgData, info.PgWal); err != nil {
+	if err := info.CloneDataDirectory(ctx, primaryConnInfo, CloneOptions{
+		PgBaseBackup:    cluster.Spec.Join,
+		ApplicationName: info.PodName,
+		ReportProgress:  reportProgress,
+	}); err != nil {
 		return err
 	}
 
 	slotName := cluster.GetSlotNameFromInstanceName(info.PodName)
-	_, err := UpdateReplicaConfiguration(info.PgData, info.GetPrimaryConnInfo(), slotName)
-	return err
+	if _, err := UpdateReplicaConfiguration(info.PgData, info.GetPrimaryConnInfo(), slotName); err != nil {
+		return err
+	}
+
+	return info.ClearBaseBackupCompleted()
 }
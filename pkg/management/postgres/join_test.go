@@ -0,0 +1,145 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("buildPgBaseBackupOptions", func() {
+	It("returns no options by default", func() {
+		Expect(buildPgBaseBackupOptions(nil)).To(BeEmpty())
+		Expect(buildPgBaseBackupOptions(&apiv1.PgBaseBackupOptions{})).To(BeEmpty())
+	})
+
+	It("builds the options of pg_basebackup", func() {
+		Expect(buildPgBaseBackupOptions(&apiv1.PgBaseBackupOptions{
+			MaxRate:           "100M",
+			VerifyChecksums:   ptr.To(false),
+			ManifestChecksums: "SHA256",
+		})).To(Equal([]string{
+			"--max-rate", "100M",
+			"--no-verify-checksums",
+			"--manifest-checksums", "SHA256",
+		}))
+	})
+
+	It("verifies the checksums when requested", func() {
+		Expect(buildPgBaseBackupOptions(&apiv1.PgBaseBackupOptions{
+			VerifyChecksums: ptr.To(true),
+		})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("getBaseBackupProgress", func() {
+	columns := []string{
+		"phase", "backup_total", "backup_streamed", "tablespaces_total", "tablespaces_streamed",
+	}
+
+	It("returns the progress of the base backup", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() { _ = db.Close() })
+
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_progress_basebackup").
+			WithArgs("cluster-example-2").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("streaming database files", 4096, 1024, 2, 1))
+
+		progress, err := getBaseBackupProgress(ctx, db, "cluster-example-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(progress).ToNot(BeNil())
+		Expect(progress.Phase).To(Equal("streaming database files"))
+		Expect(progress.BackupTotal).To(HaveValue(BeEquivalentTo(4096)))
+		Expect(progress.BackupStreamed).To(BeEquivalentTo(1024))
+		Expect(progress.TablespacesTotal).To(BeEquivalentTo(2))
+		Expect(progress.TablespacesStreamed).To(BeEquivalentTo(1))
+		Expect(progress.LastUpdateTime.IsZero()).To(BeFalse())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("leaves the total empty while it is being estimated", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() { _ = db.Close() })
+
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_progress_basebackup").
+			WithArgs("cluster-example-2").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("estimating backup size", nil, 0, 0, 0))
+
+		progress, err := getBaseBackupProgress(ctx, db, "cluster-example-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(progress).ToNot(BeNil())
+		Expect(progress.BackupTotal).To(BeNil())
+	})
+
+	It("returns nil when no base backup is running", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() { _ = db.Close() })
+
+		mock.ExpectQuery("FROM pg_catalog.pg_stat_progress_basebackup").
+			WithArgs("cluster-example-2").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		progress, err := getBaseBackupProgress(ctx, db, "cluster-example-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(progress).To(BeNil())
+	})
+})
+
+var _ = Describe("Completed base backup marker", func() {
+	var info InitInfo
+
+	BeforeEach(func() {
+		info = InitInfo{
+			PgData: GinkgoT().TempDir(),
+		}
+	})
+
+	It("is not completed without the marker", func() {
+		completed, err := info.isBaseBackupCompleted()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(completed).To(BeFalse())
+	})
+
+	It("can be cleared even if missing", func() {
+		Expect(info.ClearBaseBackupCompleted()).To(Succeed())
+	})
+
+	It("is removed when cleared", func() {
+		markerPath := filepath.Join(info.PgData, constants.BaseBackupCompletedFile)
+		Expect(os.WriteFile(markerPath, nil, 0o600)).To(Succeed())
+
+		Expect(info.ClearBaseBackupCompleted()).To(Succeed())
+		Expect(markerPath).ToNot(BeAnExistingFile())
+	})
+})
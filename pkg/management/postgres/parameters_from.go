@@ -0,0 +1,90 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// ErrParametersFromNotAvailable is returned when a ConfigMap or a Secret
+// referenced by the parametersFrom stanza, or one of their keys, is missing
+var ErrParametersFromNotAvailable = errors.New("parameter source not available")
+
+// ReadParametersFrom reads the values of the PostgreSQL parameters
+// referenced by the parametersFrom stanza of the cluster
+func ReadParametersFrom(
+	ctx context.Context,
+	cli client.Reader,
+	cluster *apiv1.Cluster,
+) (map[string]string, error) {
+	sources := cluster.Spec.PostgresConfiguration.ParametersFrom
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	getObject := func(name string, object client.Object) error {
+		err := cli.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, object)
+		if apierrs.IsNotFound(err) {
+			return fmt.Errorf("%w: %v", ErrParametersFromNotAvailable, err)
+		}
+		return err
+	}
+
+	result := make(map[string]string, len(sources))
+	for _, source := range sources {
+		switch {
+		case source.ConfigMapKeyRef != nil:
+			var configMap corev1.ConfigMap
+			if err := getObject(source.ConfigMapKeyRef.Name, &configMap); err != nil {
+				return nil, fmt.Errorf("while reading parameter %s: %w", source.Name, err)
+			}
+			value, ok := configMap.Data[source.ConfigMapKeyRef.Key]
+			if !ok {
+				return nil, fmt.Errorf("%w: missing key %q inside configmap %q for parameter %s",
+					ErrParametersFromNotAvailable,
+					source.ConfigMapKeyRef.Key, source.ConfigMapKeyRef.Name, source.Name)
+			}
+			result[source.Name] = value
+
+		case source.SecretKeyRef != nil:
+			var secret corev1.Secret
+			if err := getObject(source.SecretKeyRef.Name, &secret); err != nil {
+				return nil, fmt.Errorf("while reading parameter %s: %w", source.Name, err)
+			}
+			value, ok := secret.Data[source.SecretKeyRef.Key]
+			if !ok {
+				return nil, fmt.Errorf("%w: missing key %q inside secret %q for parameter %s",
+					ErrParametersFromNotAvailable,
+					source.SecretKeyRef.Key, source.SecretKeyRef.Name, source.Name)
+			}
+			result[source.Name] = string(value)
+		}
+	}
+
+	return result, nil
+}
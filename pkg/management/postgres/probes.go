@@ -98,12 +98,25 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 	}
 
 	if result.PendingRestart {
+		result.PendingRestartParameters, err = GetPendingRestartParameters(superUserDB)
+		if err != nil {
+			return result, err
+		}
+
 		err = updateResultForDecrease(instance, superUserDB, result)
 		if err != nil {
 			return result, err
 		}
 	}
 
+	// The parameters set via ALTER SYSTEM are only reported, and an
+	// unreadable postgresql.auto.conf must not make the instance look down
+	if alterSystemParameters, err := instance.GetAlterSystemParameters(); err != nil {
+		log.Error(err, "while reading the parameters set via ALTER SYSTEM, skipping")
+	} else {
+		result.AlterSystemParameters = alterSystemParameters
+	}
+
 	err = instance.fillStatus(result)
 	if err != nil {
 		return result, err
@@ -30,6 +30,7 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -118,6 +119,12 @@ func (info InitInfo) RestoreSnapshot(ctx context.Context, cli client.Client, imm
 		return nil
 	}
 
+	// We're cloning another Cluster from its volume snapshots: there's
+	// no WAL archive to recover from, and the data directory is used as is.
+	if cluster.IsCloningViaVolumeSnapshot() {
+		return nil
+	}
+
 	// We're creating a new cluster from a snapshot backup, but we
 	// have no recovery section defined. This is not possible.
 	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Recovery == nil {
@@ -126,7 +133,7 @@ func (info InitInfo) RestoreSnapshot(ctx context.Context, cli client.Client, imm
 
 	// We've no WAL archive, so we can't proceed with a PITR
 	if cluster.Spec.Bootstrap.Recovery.Source == "" {
-		return nil
+		return info.executePostRecoverySQLOnSnapshot(ctx, cli, cluster)
 	}
 
 	contextLogger.Info("Recovering from volume snapshot",
@@ -161,6 +168,36 @@ func (info InitInfo) RestoreSnapshot(ctx context.Context, cli client.Client, imm
 	return info.concludeRestore(ctx, cli, cluster, getRestoreWalConfig(), envs)
 }
 
+// executePostRecoverySQLOnSnapshot runs the post-recovery SQL on an instance restored
+// from a volume snapshot without replaying any WAL file, which will be
+// started as a primary as it is
+func (info InitInfo) executePostRecoverySQLOnSnapshot(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) error {
+	if !cluster.ShouldRunPostRecoverySQL() {
+		return nil
+	}
+
+	if _, err := info.restoreCustomWalDir(ctx); err != nil {
+		return err
+	}
+
+	if err := info.WriteInitialPostgresqlConf(ctx, cli, cluster); err != nil {
+		return err
+	}
+
+	if err := info.WriteRestoreHbaConf(ctx); err != nil {
+		return err
+	}
+
+	instance := info.GetInstance(cluster)
+	return instance.WithActiveInstance(func() error {
+		return info.executePostRecoverySQL(ctx, instance, cluster)
+	})
+}
+
 func (info InitInfo) concludeRestore(
 	ctx context.Context,
 	cli client.Client,
@@ -174,7 +211,7 @@ func (info InitInfo) concludeRestore(
 		return err
 	}
 
-	if err := info.WriteInitialPostgresqlConf(ctx, cluster); err != nil {
+	if err := info.WriteInitialPostgresqlConf(ctx, cli, cluster); err != nil {
 		return err
 	}
 
@@ -425,7 +462,9 @@ func (info InitInfo) restoreDataDir(
 	return nil
 }
 
-// loadCluster loads the cluster definition from the API server
+// loadCluster loads the cluster definition from the API server.
+// While the data of the cluster is being refreshed, the bootstrap
+// section is replaced with the recovery from the chosen backup.
 func (info InitInfo) loadCluster(ctx context.Context, typedClient client.Client) (*apiv1.Cluster, error) {
 	var cluster apiv1.Cluster
 	err := typedClient.Get(ctx, client.ObjectKey{Namespace: info.Namespace, Name: info.ClusterName}, &cluster)
@@ -433,7 +472,7 @@ func (info InitInfo) loadCluster(ctx context.Context, typedClient client.Client)
 		return nil, err
 	}
 
-	return &cluster, nil
+	return cluster.GetBootstrapCluster(), nil
 }
 
 // loadBackup loads the backup manifest from the API server of from the object store.
@@ -777,7 +816,7 @@ func LoadEnforcedParametersFromPgControldata(pgData string) (map[string]int, err
 func LoadEnforcedParametersFromCluster(
 	cluster *apiv1.Cluster,
 ) (map[string]int, error) {
-	clusterParams := cluster.Spec.PostgresConfiguration.Parameters
+	clusterParams := cluster.GetPostgresParameters()
 	enforcedParams := map[string]int{}
 	for _, param := range pgControldataSettingsToParamsMap {
 		value, found := clusterParams[param]
@@ -799,7 +838,11 @@ func LoadEnforcedParametersFromCluster(
 
 // WriteInitialPostgresqlConf resets the postgresql.conf that there is in the instance using
 // a new bootstrapped instance as reference
-func (info InitInfo) WriteInitialPostgresqlConf(ctx context.Context, cluster *apiv1.Cluster) error {
+func (info InitInfo) WriteInitialPostgresqlConf(
+	ctx context.Context,
+	cli client.Reader,
+	cluster *apiv1.Cluster,
+) error {
 	contextLogger := log.FromContext(ctx)
 	if err := fileutils.EnsureDirectoryExists(postgresSpec.RecoveryTemporaryDirectory); err != nil {
 		return err
@@ -848,9 +891,14 @@ func (info InitInfo) WriteInitialPostgresqlConf(ctx context.Context, cluster *ap
 	if err != nil {
 		return fmt.Errorf("while generating pg_ident.conf: %w", err)
 	}
+	parametersFrom, err := ReadParametersFrom(ctx, cli, cluster)
+	if err != nil {
+		return fmt.Errorf("while reading the parameters from ConfigMaps and Secrets: %w", err)
+	}
 	_, err = temporaryInstance.RefreshConfigurationFilesFromCluster(
 		ctx,
 		cluster,
+		parametersFrom,
 		false,
 		postgres.OperationType_TYPE_RESTORE,
 	)
@@ -947,21 +995,99 @@ func (info InitInfo) ConfigureInstanceAfterRestore(ctx context.Context, cluster
 		return fmt.Errorf("while configuring replica: %w", err)
 	}
 
-	if info.ApplicationUser == "" || info.ApplicationDatabase == "" {
+	shouldConfigureApplication := info.ApplicationUser != "" && info.ApplicationDatabase != ""
+	if !shouldConfigureApplication && !cluster.ShouldRunPostRecoverySQL() {
 		log.Debug("configure new instance not ran, cluster is running in replica mode or missing user or database")
 		return nil
 	}
 
 	// Configure the application database information for restored instance
 	return instance.WithActiveInstance(func() error {
-		if err := info.ConfigureNewInstance(instance); err != nil {
-			return fmt.Errorf("while configuring restored instance: %w", err)
+		if shouldConfigureApplication {
+			if err := info.ConfigureNewInstance(instance); err != nil {
+				return fmt.Errorf("while configuring restored instance: %w", err)
+			}
 		}
 
-		return nil
+		return info.executePostRecoverySQL(ctx, instance, cluster)
 	})
 }
 
+// executePostRecoverySQL runs the queries and the SQL files configured
+// to be executed after the promotion of a recovered instance
+func (info InitInfo) executePostRecoverySQL(
+	ctx context.Context,
+	instance *Instance,
+	cluster *apiv1.Cluster,
+) error {
+	if !cluster.ShouldRunPostRecoverySQL() {
+		return nil
+	}
+
+	contextLogger := log.FromContext(ctx)
+	queries, refs := cluster.GetPostRecoverySQL()
+
+	defaultDatabase := cluster.GetApplicationDatabaseName()
+	if defaultDatabase == "" {
+		defaultDatabase = "postgres"
+	}
+
+	getDatabase := func(name string) (*sql.DB, error) {
+		db, err := instance.ConnectionPool().Connection(name)
+		if err != nil {
+			return nil, fmt.Errorf("could not get connection to database %s: %w", name, err)
+		}
+		return db, nil
+	}
+
+	if len(queries) > 0 {
+		contextLogger.Info("Executing post-recovery SQL instructions", "database", defaultDatabase)
+		db, err := getDatabase(defaultDatabase)
+		if err != nil {
+			return err
+		}
+		if err := info.executeQueries(db, queries); err != nil {
+			return fmt.Errorf("could not execute post-recovery queries: %w", err)
+		}
+	}
+
+	if info.PostRecoverySQLRefsFolder == "" {
+		return nil
+	}
+
+	folders, err := fileutils.GetDirectoryContent(info.PostRecoverySQLRefsFolder)
+	if err != nil {
+		return fmt.Errorf("could not get directory content from: %s, err: %w",
+			info.PostRecoverySQLRefsFolder, err)
+	}
+
+	// Folders are named after the index of the corresponding entry,
+	// padded with zeros, so sorting them preserves the execution order
+	sort.Strings(folders)
+	for _, folder := range folders {
+		index, err := strconv.Atoi(folder)
+		if err != nil || index < 0 || index >= len(refs) {
+			return fmt.Errorf("unexpected post-recovery SQL refs folder: %s", folder)
+		}
+
+		database := refs[index].Database
+		if database == "" {
+			database = defaultDatabase
+		}
+
+		contextLogger.Info("Executing post-recovery SQL files", "database", database, "entry", index)
+		db, err := getDatabase(database)
+		if err != nil {
+			return err
+		}
+		if err := info.executeSQLRefs(db, path.Join(info.PostRecoverySQLRefsFolder, folder)); err != nil {
+			return fmt.Errorf("could not execute post-recovery SQL refs in database %s: %w", database, err)
+		}
+	}
+
+	return nil
+}
+
 // GetPrimaryConnInfo returns the DSN to reach the primary
 func (info InitInfo) GetPrimaryConnInfo() string {
 	result := buildPrimaryConnInfo(info.ClusterName+"-rw", info.PodName) + " dbname=postgres"
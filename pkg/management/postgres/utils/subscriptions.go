@@ -0,0 +1,289 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SubscriptionTable is the synchronization state of a table of a
+// subscription, as recorded in pg_subscription_rel
+type SubscriptionTable struct {
+	// Subscription is the name of the subscription
+	Subscription string
+
+	// Table is the schema-qualified name of the table
+	Table string
+
+	// State is the synchronization state of the table: `init`,
+	// `dataCopy`, `finishedCopy`, `synchronized`, `ready` or `unknown`
+	State string
+}
+
+// subscriptionTableStates maps the state codes of pg_subscription_rel to
+// the names of the synchronization states
+var subscriptionTableStates = map[string]string{
+	"i": "init",
+	"d": "dataCopy",
+	"f": "finishedCopy",
+	"s": "synchronized",
+	"r": "ready",
+}
+
+// SubscriptionWorker is a worker of a subscription, as reported by
+// pg_stat_subscription
+type SubscriptionWorker struct {
+	// Database is the name of the database of the subscription
+	Database string
+
+	// Subscription is the name of the subscription
+	Subscription string
+
+	// Type is the type of the worker: `apply`, `parallel apply` or
+	// `table synchronization`
+	Type string
+
+	// PID is the process ID of the worker
+	PID int64
+
+	// Table is the schema-qualified name of the table being synchronized,
+	// when known
+	Table string
+
+	// ReceivedLSN is the last write-ahead log location received
+	ReceivedLSN string
+
+	// LatestEndLSN is the last write-ahead log location reported to the
+	// publisher
+	LatestEndLSN string
+
+	// LatestEndTime is when the last write-ahead log location was reported
+	// to the publisher
+	LatestEndTime *time.Time
+
+	// ApplyLag is the time elapsed since LatestEndTime
+	ApplyLag *time.Duration
+}
+
+// SubscriptionStats are the error counters of a subscription, as reported
+// by pg_stat_subscription_stats
+type SubscriptionStats struct {
+	// Database is the name of the database of the subscription
+	Database string
+
+	// Subscription is the name of the subscription
+	Subscription string
+
+	// ApplyErrorCount is the number of errors occurred while applying changes
+	ApplyErrorCount int64
+
+	// SyncErrorCount is the number of errors occurred during the initial
+	// table synchronization
+	SyncErrorCount int64
+}
+
+const subscriptionDatabasesSQL = `
+SELECT DISTINCT d.datname
+FROM pg_catalog.pg_subscription s
+JOIN pg_catalog.pg_database d ON s.subdbid = d.oid
+ORDER BY d.datname
+`
+
+// pg_subscription_rel is not shared across databases: it only contains the
+// tables of the subscriptions of the current database
+const subscriptionTablesSQL = `
+SELECT s.subname, n.nspname || '.' || c.relname, sr.srsubstate
+FROM pg_catalog.pg_subscription_rel sr
+JOIN pg_catalog.pg_subscription s ON sr.srsubid = s.oid
+JOIN pg_catalog.pg_class c ON sr.srrelid = c.oid
+JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+ORDER BY s.subname, n.nspname, c.relname
+`
+
+// The worker_type column is only available since PostgreSQL 17: before it,
+// workers synchronizing a table are the ones having a relid. The names of
+// the subscriptions are only unique within a database.
+const subscriptionWorkersSQL = `
+SELECT d.datname,
+	st.subname,
+	%s,
+	st.pid,
+	COALESCE(n.nspname || '.' || c.relname, ''),
+	COALESCE(st.received_lsn::text, ''),
+	COALESCE(st.latest_end_lsn::text, ''),
+	st.latest_end_time,
+	EXTRACT(EPOCH FROM (pg_catalog.now() - st.latest_end_time))
+FROM pg_catalog.pg_stat_subscription st
+JOIN pg_catalog.pg_subscription s ON st.subid = s.oid
+JOIN pg_catalog.pg_database d ON s.subdbid = d.oid
+LEFT JOIN pg_catalog.pg_class c ON st.relid = c.oid
+LEFT JOIN pg_catalog.pg_namespace n ON c.relnamespace = n.oid
+WHERE st.pid IS NOT NULL
+ORDER BY d.datname, st.subname, st.pid
+`
+
+const subscriptionStatsSQL = `
+SELECT d.datname, st.subname, st.apply_error_count, st.sync_error_count
+FROM pg_catalog.pg_stat_subscription_stats st
+JOIN pg_catalog.pg_subscription s ON st.subid = s.oid
+JOIN pg_catalog.pg_database d ON s.subdbid = d.oid
+ORDER BY d.datname, st.subname
+`
+
+// GetSubscriptionDatabases returns the names of the databases having at
+// least a subscription
+func GetSubscriptionDatabases(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, subscriptionDatabasesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result = append(result, name)
+	}
+
+	return result, rows.Err()
+}
+
+// GetSubscriptionTables returns the synchronization state of the tables of
+// the subscriptions of the database the connection points to
+func GetSubscriptionTables(ctx context.Context, db *sql.DB) ([]SubscriptionTable, error) {
+	rows, err := db.QueryContext(ctx, subscriptionTablesSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []SubscriptionTable
+	for rows.Next() {
+		var table SubscriptionTable
+		var stateCode string
+		if err := rows.Scan(&table.Subscription, &table.Table, &stateCode); err != nil {
+			return nil, err
+		}
+		table.State = subscriptionTableStates[stateCode]
+		if table.State == "" {
+			table.State = "unknown"
+		}
+		result = append(result, table)
+	}
+
+	return result, rows.Err()
+}
+
+// GetSubscriptionWorkers returns the running workers of the subscriptions
+// of the PostgreSQL instance
+func GetSubscriptionWorkers(
+	ctx context.Context,
+	db *sql.DB,
+	pgMajorVersion int,
+) ([]SubscriptionWorker, error) {
+	workerType := "CASE WHEN st.relid IS NULL THEN 'apply' ELSE 'table synchronization' END"
+	if pgMajorVersion >= 17 {
+		workerType = "st.worker_type"
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(subscriptionWorkersSQL, workerType))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []SubscriptionWorker
+	for rows.Next() {
+		var worker SubscriptionWorker
+		var latestEndTime sql.NullTime
+		var applyLag sql.NullFloat64
+		if err := rows.Scan(
+			&worker.Database,
+			&worker.Subscription,
+			&worker.Type,
+			&worker.PID,
+			&worker.Table,
+			&worker.ReceivedLSN,
+			&worker.LatestEndLSN,
+			&latestEndTime,
+			&applyLag,
+		); err != nil {
+			return nil, err
+		}
+		if latestEndTime.Valid {
+			worker.LatestEndTime = &latestEndTime.Time
+		}
+		if applyLag.Valid {
+			lag := time.Duration(applyLag.Float64 * float64(time.Second))
+			worker.ApplyLag = &lag
+		}
+		result = append(result, worker)
+	}
+
+	return result, rows.Err()
+}
+
+// GetSubscriptionStats returns the error counters of the subscriptions of
+// the PostgreSQL instance. They are only available since PostgreSQL 15.
+func GetSubscriptionStats(
+	ctx context.Context,
+	db *sql.DB,
+	pgMajorVersion int,
+) ([]SubscriptionStats, error) {
+	if pgMajorVersion < 15 {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, subscriptionStatsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []SubscriptionStats
+	for rows.Next() {
+		var stats SubscriptionStats
+		if err := rows.Scan(
+			&stats.Database,
+			&stats.Subscription,
+			&stats.ApplyErrorCount,
+			&stats.SyncErrorCount,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+
+	return result, rows.Err()
+}
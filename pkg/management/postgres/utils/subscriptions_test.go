@@ -0,0 +1,117 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Subscription state functions", func() {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("decodes the synchronization state of the tables", func(ctx SpecContext) {
+		mock.ExpectQuery(subscriptionTablesSQL).WillReturnRows(
+			sqlmock.NewRows([]string{"subname", "table", "srsubstate"}).
+				AddRow("sub", "public.a", "r").
+				AddRow("sub", "public.b", "d").
+				AddRow("sub", "public.c", "x"))
+
+		tables, err := GetSubscriptionTables(ctx, db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tables).To(Equal([]SubscriptionTable{
+			{Subscription: "sub", Table: "public.a", State: "ready"},
+			{Subscription: "sub", Table: "public.b", State: "dataCopy"},
+			{Subscription: "sub", Table: "public.c", State: "unknown"},
+		}))
+	})
+
+	It("detects the type of the workers before PostgreSQL 17", func(ctx SpecContext) {
+		latestEndTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(fmt.Sprintf(subscriptionWorkersSQL,
+			"CASE WHEN st.relid IS NULL THEN 'apply' ELSE 'table synchronization' END")).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"datname", "subname", "type", "pid", "table", "received_lsn", "latest_end_lsn", "latest_end_time", "lag",
+			}).
+				AddRow("app", "sub", "apply", 42, "", "0/3000000", "0/3000000", latestEndTime, 1.5).
+				AddRow("app", "sub", "table synchronization", 43, "public.a", "", "", nil, nil))
+
+		workers, err := GetSubscriptionWorkers(ctx, db, 16)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workers).To(HaveLen(2))
+		Expect(workers[0].Database).To(Equal("app"))
+		Expect(workers[0].Type).To(Equal("apply"))
+		Expect(workers[0].LatestEndTime).To(Equal(&latestEndTime))
+		Expect(*workers[0].ApplyLag).To(Equal(1500 * time.Millisecond))
+		Expect(workers[1].Table).To(Equal("public.a"))
+		Expect(workers[1].LatestEndTime).To(BeNil())
+		Expect(workers[1].ApplyLag).To(BeNil())
+	})
+
+	It("uses the worker type column since PostgreSQL 17", func(ctx SpecContext) {
+		mock.ExpectQuery(fmt.Sprintf(subscriptionWorkersSQL, "st.worker_type")).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"datname", "subname", "type", "pid", "table", "received_lsn", "latest_end_lsn", "latest_end_time", "lag",
+			}).
+				AddRow("app", "sub", "parallel apply", 44, "", "", "", nil, nil))
+
+		workers, err := GetSubscriptionWorkers(ctx, db, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workers).To(HaveLen(1))
+		Expect(workers[0].Type).To(Equal("parallel apply"))
+	})
+
+	It("reports the error counters since PostgreSQL 15", func(ctx SpecContext) {
+		stats, err := GetSubscriptionStats(ctx, db, 14)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stats).To(BeNil())
+
+		mock.ExpectQuery(subscriptionStatsSQL).WillReturnRows(
+			sqlmock.NewRows([]string{"datname", "subname", "apply_error_count", "sync_error_count"}).
+				AddRow("app", "sub", 3, 1))
+		stats, err = GetSubscriptionStats(ctx, db, 15)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stats).To(Equal([]SubscriptionStats{{
+			Database:        "app",
+			Subscription:    "sub",
+			ApplyErrorCount: 3,
+			SyncErrorCount:  1,
+		}}))
+	})
+})
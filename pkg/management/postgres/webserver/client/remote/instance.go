@@ -20,6 +20,7 @@ SPDX-License-Identifier: Apache-2.0
 package remote
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/client-go/util/retry"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
@@ -85,6 +87,21 @@ type InstanceClient interface {
 	// ArchivePartialWAL trigger the archiver for the latest partial WAL
 	// file created in a specific Pod
 	ArchivePartialWAL(context.Context, *corev1.Pod) (string, error)
+
+	// GetUpgradeFinalizationStatus gets the progress of the maintenance
+	// operations executed after a major upgrade in a specific Pod
+	GetUpgradeFinalizationStatus(
+		ctx context.Context,
+		pod *corev1.Pod,
+	) (*webserver.UpgradeFinalizationData, error)
+
+	// StartUpgradeFinalization starts the maintenance operations
+	// executed after a major upgrade in a specific Pod
+	StartUpgradeFinalization(
+		ctx context.Context,
+		pod *corev1.Pod,
+		request webserver.StartUpgradeFinalizationRequest,
+	) (*webserver.UpgradeFinalizationData, error)
 }
 
 type instanceClientImpl struct {
@@ -436,3 +453,49 @@ func (r *instanceClientImpl) ArchivePartialWAL(ctx context.Context, pod *corev1.
 
 	return result.Data, nil
 }
+
+func (r *instanceClientImpl) GetUpgradeFinalizationStatus(
+	ctx context.Context,
+	pod *corev1.Pod,
+) (*webserver.UpgradeFinalizationData, error) {
+	httpURL := url.Build(
+		GetStatusSchemeFromPod(pod).ToString(), pod.Status.PodIP, url.PathPgUpgradeFinalization, url.StatusPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := executeRequestWithError[webserver.UpgradeFinalizationData](ctx, r.Client, req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (r *instanceClientImpl) StartUpgradeFinalization(
+	ctx context.Context,
+	pod *corev1.Pod,
+	request webserver.StartUpgradeFinalizationRequest,
+) (*webserver.UpgradeFinalizationData, error) {
+	httpURL := url.Build(
+		GetStatusSchemeFromPod(pod).ToString(), pod.Status.PodIP, url.PathPgUpgradeFinalization, url.StatusPort)
+
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal start payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, httpURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	result, err := executeRequestWithError[webserver.UpgradeFinalizationData](ctx, r.Client, req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
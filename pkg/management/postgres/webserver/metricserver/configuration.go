@@ -0,0 +1,78 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"database/sql"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	postgresconf "github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// collectConfigurationStatus compares the configuration loaded by
+// PostgreSQL with the one generated from the cluster definition
+func collectConfigurationStatus(e *Exporter, db *sql.DB) error {
+	var loadedHash string
+	if err := db.QueryRow(
+		"SELECT COALESCE(current_setting($1, true), '')",
+		postgresconf.CNPGConfigSha256).Scan(&loadedHash); err != nil {
+		return err
+	}
+
+	pendingRestart, err := postgres.GetPendingRestartParameters(db)
+	if err != nil {
+		return err
+	}
+
+	fileHash, err := e.instance.GetConfigurationFileHash()
+	if err != nil {
+		return err
+	}
+
+	alterSystem, err := e.instance.GetAlterSystemParameters()
+	if err != nil {
+		return err
+	}
+
+	e.Metrics.ConfigurationHash.Reset()
+	if loadedHash != "" {
+		e.Metrics.ConfigurationHash.WithLabelValues(loadedHash).Set(1)
+	}
+
+	e.Metrics.PendingRestartParameter.Reset()
+	for _, name := range pendingRestart {
+		e.Metrics.PendingRestartParameter.WithLabelValues(name).Set(1)
+	}
+
+	e.Metrics.AlterSystemParameter.Reset()
+	for name := range alterSystem {
+		e.Metrics.AlterSystemParameter.WithLabelValues(name).Set(1)
+	}
+
+	applied := loadedHash != "" && loadedHash == fileHash &&
+		len(pendingRestart) == 0 && len(alterSystem) == 0
+	if applied {
+		e.Metrics.ConfigurationApplied.Set(1)
+	} else {
+		e.Metrics.ConfigurationApplied.Set(0)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"os"
+	"path"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/constants"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration status metrics", func() {
+	const (
+		hashQuery           = "SELECT COALESCE(current_setting($1, true), '')"
+		pendingRestartQuery = "SELECT name FROM pg_catalog.pg_settings WHERE pending_restart ORDER BY name"
+	)
+
+	var (
+		exporter *Exporter
+		mock     sqlmock.Sqlmock
+		collect  func() error
+	)
+
+	writeFile := func(name, content string) {
+		Expect(os.WriteFile(path.Join(exporter.instance.PgData, name), []byte(content), 0o600)).To(Succeed())
+	}
+
+	gather := func() map[string][]string {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			exporter.Metrics.ConfigurationHash,
+			exporter.Metrics.PendingRestartParameter,
+			exporter.Metrics.AlterSystemParameter,
+		)
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		result := make(map[string][]string)
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				result[family.GetName()] = append(result[family.GetName()], metric.GetLabel()[0].GetValue())
+			}
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		instance := postgres.NewInstance()
+		instance.PgData = GinkgoT().TempDir()
+		exporter = NewExporter(instance, fakePluginCollector{})
+
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			_ = db.Close()
+		})
+		mock = sqlMock
+		collect = func() error {
+			return collectConfigurationStatus(exporter, db)
+		}
+
+		writeFile(constants.PostgresqlCustomConfigurationFile,
+			"shared_buffers = '128MB'\ncnpg.config_sha256 = 'current'\n")
+	})
+
+	It("reports the configuration as applied", func() {
+		mock.ExpectQuery(hashQuery).WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("current"))
+		mock.ExpectQuery(pendingRestartQuery).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		Expect(collect()).To(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(gather()).To(Equal(map[string][]string{
+			"cnpg_collector_configuration_hash_info": {"current"},
+		}))
+		Expect(gaugeValue(exporter.Metrics.ConfigurationApplied)).To(BeEquivalentTo(1))
+	})
+
+	It("reports the configuration not yet reloaded", func() {
+		mock.ExpectQuery(hashQuery).WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("previous"))
+		mock.ExpectQuery(pendingRestartQuery).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		Expect(collect()).To(Succeed())
+		Expect(gaugeValue(exporter.Metrics.ConfigurationApplied)).To(BeEquivalentTo(0))
+	})
+
+	It("reports the parameters pending restart and the ALTER SYSTEM overrides", func() {
+		writeFile("postgresql.auto.conf", "shared_buffers = '256MB'\n")
+		mock.ExpectQuery(hashQuery).WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("current"))
+		mock.ExpectQuery(pendingRestartQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"name"}).AddRow("max_connections"))
+
+		Expect(collect()).To(Succeed())
+		Expect(gather()).To(Equal(map[string][]string{
+			"cnpg_collector_configuration_hash_info":   {"current"},
+			"cnpg_collector_pending_restart_parameter": {"max_connections"},
+			"cnpg_collector_alter_system_parameter":    {"shared_buffers"},
+		}))
+		Expect(gaugeValue(exporter.Metrics.ConfigurationApplied)).To(BeEquivalentTo(0))
+	})
+})
+
+func gaugeValue(gauge prometheus.Gauge) float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(gauge)
+	families, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+	return families[0].GetMetric()[0].GetGauge().GetValue()
+}
@@ -79,7 +79,12 @@ type metrics struct {
 	LastFailedBackupTimestamp    prometheus.Gauge
 	FencingOn                    prometheus.Gauge
 	PgStatWalMetrics             PgStatWalMetrics
+	PgStatSubscriptionMetrics    PgStatSubscriptionMetrics
 	NodesUsed                    prometheus.Gauge
+	ConfigurationApplied         prometheus.Gauge
+	ConfigurationHash            *prometheus.GaugeVec
+	PendingRestartParameter      *prometheus.GaugeVec
+	AlterSystemParameter         *prometheus.GaugeVec
 }
 
 // PgStatWalMetrics is available from PG14+
@@ -94,6 +99,16 @@ type PgStatWalMetrics struct {
 	WalSyncTime    *prometheus.GaugeVec
 }
 
+// PgStatSubscriptionMetrics are the metrics about the subscriptions of the
+// primary instance
+type PgStatSubscriptionMetrics struct {
+	Tables          *prometheus.GaugeVec
+	Workers         *prometheus.GaugeVec
+	ApplyLag        *prometheus.GaugeVec
+	ApplyErrorCount *prometheus.GaugeVec
+	SyncErrorCount  *prometheus.GaugeVec
+}
+
 // NewExporter creates an exporter
 func NewExporter(instance *postgres.Instance, pluginCollector m.PluginCollector) *Exporter {
 	clusterGetter := local.NewClient().Cache().GetCluster
@@ -213,6 +228,32 @@ func newMetrics() *metrics {
 				"implying the absence of High Availability (HA). Ideally this value " +
 				"should match the number of instances in the cluster.",
 		}),
+		ConfigurationApplied: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "configuration_applied",
+			Help: "1 if PostgreSQL is running the configuration declared in the cluster " +
+				"(no pending reload or restart, no ALTER SYSTEM override), 0 otherwise",
+		}),
+		ConfigurationHash: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "configuration_hash_info",
+			Help:      "Hash of the configuration loaded by PostgreSQL",
+		}, []string{"hash"}),
+		PendingRestartParameter: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "pending_restart_parameter",
+			Help:      "1 for each parameter whose change requires a restart of PostgreSQL",
+		}, []string{"name"}),
+		AlterSystemParameter: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: subsystem,
+			Name:      "alter_system_parameter",
+			Help: "1 for each parameter set via ALTER SYSTEM with a value differing " +
+				"from the one declared in the cluster",
+		}, []string{"name"}),
 		PgStatWalMetrics: PgStatWalMetrics{
 			WalRecords: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 				Namespace: PrometheusNamespace,
@@ -271,6 +312,40 @@ func newMetrics() *metrics {
 					"fsync_writethrough, otherwise zero). Only available on PG 14 to 17.",
 			}, []string{"stats_reset"}),
 		},
+		PgStatSubscriptionMetrics: PgStatSubscriptionMetrics{
+			Tables: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Subsystem: subsystem,
+				Name:      "subscription_tables",
+				Help: "Number of tables of a subscription in each synchronization state " +
+					"(init, dataCopy, finishedCopy, synchronized, ready)",
+			}, []string{"datname", "subname", "state"}),
+			Workers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Subsystem: subsystem,
+				Name:      "subscription_workers",
+				Help:      "Number of running workers of a subscription, by type",
+			}, []string{"datname", "subname", "type"}),
+			ApplyLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Subsystem: subsystem,
+				Name:      "subscription_apply_lag_seconds",
+				Help:      "Time elapsed since the apply worker of a subscription last reported its position to the publisher",
+			}, []string{"datname", "subname"}),
+			ApplyErrorCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Subsystem: subsystem,
+				Name:      "subscription_apply_error_count",
+				Help:      "Number of errors occurred while applying the changes of a subscription. Only available on PG 15+",
+			}, []string{"datname", "subname"}),
+			SyncErrorCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Subsystem: subsystem,
+				Name:      "subscription_sync_error_count",
+				Help: "Number of errors occurred during the initial table synchronization of a subscription." +
+					" Only available on PG 15+",
+			}, []string{"datname", "subname"}),
+		},
 	}
 }
 
@@ -292,6 +367,15 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.LastFailedBackupTimestamp.Describe(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Describe(ch)
 	e.Metrics.NodesUsed.Describe(ch)
+	e.Metrics.ConfigurationApplied.Describe(ch)
+	e.Metrics.ConfigurationHash.Describe(ch)
+	e.Metrics.PendingRestartParameter.Describe(ch)
+	e.Metrics.AlterSystemParameter.Describe(ch)
+	e.Metrics.PgStatSubscriptionMetrics.Tables.Describe(ch)
+	e.Metrics.PgStatSubscriptionMetrics.Workers.Describe(ch)
+	e.Metrics.PgStatSubscriptionMetrics.ApplyLag.Describe(ch)
+	e.Metrics.PgStatSubscriptionMetrics.ApplyErrorCount.Describe(ch)
+	e.Metrics.PgStatSubscriptionMetrics.SyncErrorCount.Describe(ch)
 
 	if e.queries != nil {
 		e.queries.Describe(ch)
@@ -375,6 +459,15 @@ func (e *Exporter) collectInstanceMetrics(ch chan<- prometheus.Metric) {
 	e.Metrics.LastFailedBackupTimestamp.Collect(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Collect(ch)
 	e.Metrics.NodesUsed.Collect(ch)
+	e.Metrics.ConfigurationApplied.Collect(ch)
+	e.Metrics.ConfigurationHash.Collect(ch)
+	e.Metrics.PendingRestartParameter.Collect(ch)
+	e.Metrics.AlterSystemParameter.Collect(ch)
+	e.Metrics.PgStatSubscriptionMetrics.Tables.Collect(ch)
+	e.Metrics.PgStatSubscriptionMetrics.Workers.Collect(ch)
+	e.Metrics.PgStatSubscriptionMetrics.ApplyLag.Collect(ch)
+	e.Metrics.PgStatSubscriptionMetrics.ApplyErrorCount.Collect(ch)
+	e.Metrics.PgStatSubscriptionMetrics.SyncErrorCount.Collect(ch)
 
 	if version, _ := e.instance.GetPgVersion(); version.Major() >= 14 {
 		e.Metrics.PgStatWalMetrics.WalRecords.Collect(ch)
@@ -456,6 +549,12 @@ func (e *Exporter) updateInstanceMetrics() {
 		e.collectFromPrimaryLastFailedBackupTimestamp()
 	}
 
+	if err := collectPGStatSubscription(e, db, isPrimary); err != nil {
+		log.Error(err, "while collecting subscription metrics")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.PGStatSubscription").Inc()
+	}
+
 	if err := collectPGWalArchiveMetric(e); err != nil {
 		log.Error(err, "while collecting WAL archive metrics", "path", specs.PgWalArchiveStatusPath)
 		e.Metrics.Error.Set(1)
@@ -477,6 +576,16 @@ func (e *Exporter) updateInstanceMetrics() {
 		e.Metrics.PgVersion.Reset()
 	}
 
+	if err := collectConfigurationStatus(e, db); err != nil {
+		log.Error(err, "while collecting configuration status")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues("Collect.ConfigurationStatus").Inc()
+		e.Metrics.ConfigurationApplied.Set(0)
+		e.Metrics.ConfigurationHash.Reset()
+		e.Metrics.PendingRestartParameter.Reset()
+		e.Metrics.AlterSystemParameter.Reset()
+	}
+
 	if version, _ := e.instance.GetPgVersion(); version.Major() >= 14 {
 		if err := collectPGStatWAL(e); err != nil {
 			log.Error(err, "while collecting pg_stat_wal")
@@ -0,0 +1,103 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	postgresutils "github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
+)
+
+// collectPGStatSubscription collects the metrics about the subscriptions.
+// Subscription workers only run on the primary, so the metrics are only
+// reported there.
+func collectPGStatSubscription(e *Exporter, db *sql.DB, isPrimary bool) error {
+	subscriptionMetrics := e.Metrics.PgStatSubscriptionMetrics
+	subscriptionMetrics.Tables.Reset()
+	subscriptionMetrics.Workers.Reset()
+	subscriptionMetrics.ApplyLag.Reset()
+	subscriptionMetrics.ApplyErrorCount.Reset()
+	subscriptionMetrics.SyncErrorCount.Reset()
+
+	if !isPrimary {
+		return nil
+	}
+
+	ctx := context.Background()
+	version, err := e.instance.GetPgVersion()
+	if err != nil {
+		return err
+	}
+	pgMajorVersion := int(version.Major()) //nolint:gosec
+
+	workers, err := postgresutils.GetSubscriptionWorkers(ctx, db, pgMajorVersion)
+	if err != nil {
+		return fmt.Errorf("while getting the subscription workers: %w", err)
+	}
+	type subscriptionKey struct {
+		datname string
+		subname string
+	}
+	applyLag := make(map[subscriptionKey]float64)
+	for _, worker := range workers {
+		subscriptionMetrics.Workers.WithLabelValues(worker.Database, worker.Subscription, worker.Type).Inc()
+		if worker.Type == "apply" && worker.ApplyLag != nil {
+			key := subscriptionKey{datname: worker.Database, subname: worker.Subscription}
+			applyLag[key] = max(applyLag[key], worker.ApplyLag.Seconds())
+		}
+	}
+	for key, lag := range applyLag {
+		subscriptionMetrics.ApplyLag.WithLabelValues(key.datname, key.subname).Set(lag)
+	}
+
+	stats, err := postgresutils.GetSubscriptionStats(ctx, db, pgMajorVersion)
+	if err != nil {
+		return fmt.Errorf("while getting the subscription statistics: %w", err)
+	}
+	for _, stat := range stats {
+		subscriptionMetrics.ApplyErrorCount.WithLabelValues(stat.Database, stat.Subscription).
+			Set(float64(stat.ApplyErrorCount))
+		subscriptionMetrics.SyncErrorCount.WithLabelValues(stat.Database, stat.Subscription).
+			Set(float64(stat.SyncErrorCount))
+	}
+
+	// The state of the tables is stored in each database having subscriptions
+	databases, err := postgresutils.GetSubscriptionDatabases(ctx, db)
+	if err != nil {
+		return fmt.Errorf("while getting the databases with subscriptions: %w", err)
+	}
+	for _, datname := range databases {
+		subscriberDB, err := e.instance.GetMetricsDB(datname)
+		if err != nil {
+			return fmt.Errorf("while connecting to database %q: %w", datname, err)
+		}
+		tables, err := postgresutils.GetSubscriptionTables(ctx, subscriberDB)
+		if err != nil {
+			return fmt.Errorf("while getting the subscription tables of database %q: %w", datname, err)
+		}
+		for _, table := range tables {
+			subscriptionMetrics.Tables.WithLabelValues(datname, table.Subscription, table.State).Inc()
+		}
+	}
+
+	return nil
+}
@@ -110,6 +110,9 @@ type remoteWebserverEndpoints struct {
 	instance             *postgres.Instance
 	currentBackup        *backupConnection
 	ongoingBackupRequest sync.Mutex
+	// The maintenance operations executed after a major upgrade
+	currentUpgradeFinalization *upgradeFinalization
+	upgradeFinalizationRequest sync.Mutex
 	// Stateful probes with persistent caches for API server resilience
 	livenessChecker  probes.Checker
 	readinessChecker probes.Checker
@@ -178,6 +181,10 @@ func NewRemoteWebServer(
 	serveMux.HandleFunc(url.PathPGControlData, endpoints.withOperatorAuth(endpoints.pgControlData))
 	// Authenticated: pgarchivepartial triggers WAL archival and must not be callable by arbitrary clients.
 	serveMux.HandleFunc(url.PathPgArchivePartial, endpoints.withOperatorAuth(endpoints.pgArchivePartial))
+	// Authenticated: upgrade finalization runs maintenance operations on every database.
+	serveMux.HandleFunc(
+		url.PathPgUpgradeFinalization,
+		endpoints.withOperatorAuth(endpoints.upgradeFinalization))
 	// Authenticated: update replaces the running instance manager binary.
 	serveMux.HandleFunc(
 		url.PathUpdate,
@@ -537,6 +544,60 @@ func (ws *remoteWebserverEndpoints) backup(w http.ResponseWriter, req *http.Requ
 	}
 }
 
+func (ws *remoteWebserverEndpoints) upgradeFinalization(w http.ResponseWriter, req *http.Request) {
+	ws.upgradeFinalizationRequest.Lock()
+	defer ws.upgradeFinalizationRequest.Unlock()
+
+	switch req.Method {
+	case http.MethodGet:
+		if ws.currentUpgradeFinalization == nil {
+			sendJSONResponseWithData(w, 200, struct{}{})
+			return
+		}
+
+		data := ws.currentUpgradeFinalization.getData()
+		sendJSONResponseWithData(w, 200, data)
+		return
+
+	case http.MethodPost:
+		var p StartUpgradeFinalizationRequest
+		if err := json.NewDecoder(req.Body).Decode(&p); err != nil {
+			sendBadRequestJSONResponse(w, "FAILED_TO_PARSE_REQUEST", "Failed to parse request body")
+			return
+		}
+		defer func() {
+			if err := req.Body.Close(); err != nil {
+				log.Error(err, "while closing the body")
+			}
+		}()
+
+		if ws.currentUpgradeFinalization != nil && ws.currentUpgradeFinalization.isRunning() {
+			sendUnprocessableEntityJSONResponse(w, errCodeAnotherRequestInProgress, "")
+			return
+		}
+
+		isPrimary, err := ws.instance.IsPrimary()
+		if err != nil {
+			sendUnprocessableEntityJSONResponse(w, "CANNOT_DETECT_ROLE", err.Error())
+			return
+		}
+		if !isPrimary {
+			sendBadRequestJSONResponse(w, "NOT_PRIMARY", "")
+			return
+		}
+
+		ws.currentUpgradeFinalization = newUpgradeFinalization(ws.instance, p)
+
+		// The maintenance operations continue when the request already terminated.
+		//nolint:gosec
+		go ws.currentUpgradeFinalization.run(context.Background())
+
+		data := ws.currentUpgradeFinalization.getData()
+		sendJSONResponseWithData(w, 200, data)
+		return
+	}
+}
+
 func (ws *remoteWebserverEndpoints) pgArchivePartial(w http.ResponseWriter, req *http.Request) {
 	if !ws.instance.IsFenced() {
 		sendBadRequestJSONResponse(w, "NOT_FENCED", "")
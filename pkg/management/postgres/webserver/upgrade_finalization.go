@@ -0,0 +1,266 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os/exec"
+	"slices"
+	"sync"
+
+	"github.com/cloudnative-pg/machinery/pkg/execlog"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/utils"
+)
+
+const vacuumdbName = "vacuumdb"
+
+// UpgradeFinalizationPhase is the phase of the maintenance operations
+// executed after a major upgrade
+type UpgradeFinalizationPhase string
+
+// A phase of the maintenance operations executed after a major upgrade
+const (
+	UpgradeFinalizationRunning   UpgradeFinalizationPhase = "running"
+	UpgradeFinalizationCompleted UpgradeFinalizationPhase = "completed"
+	UpgradeFinalizationFailed    UpgradeFinalizationPhase = "failed"
+)
+
+// StartUpgradeFinalizationRequest is the request to start the maintenance
+// operations after a major upgrade
+type StartUpgradeFinalizationRequest struct {
+	// MajorVersion is the major version the instance has been upgraded to
+	MajorVersion int `json:"majorVersion"`
+	// CompletedDatabases is the list of databases that have already been
+	// processed, and can be skipped
+	CompletedDatabases []string `json:"completedDatabases,omitempty"`
+}
+
+// UpgradeFinalizationData is the progress of the maintenance operations
+// executed after a major upgrade
+type UpgradeFinalizationData struct {
+	MajorVersion       int                      `json:"majorVersion"`
+	Phase              UpgradeFinalizationPhase `json:"phase"`
+	TotalDatabases     int                      `json:"totalDatabases"`
+	CompletedDatabases []string                 `json:"completedDatabases,omitempty"`
+	CurrentDatabase    string                   `json:"currentDatabase,omitempty"`
+	Error              string                   `json:"error,omitempty"`
+}
+
+// upgradeFinalization runs the maintenance operations needed after
+// pg_upgrade: updating the installed extensions to the version shipped
+// with the new binaries, and rebuilding the planner statistics
+type upgradeFinalization struct {
+	instance *postgres.Instance
+
+	mu   sync.Mutex
+	data UpgradeFinalizationData
+}
+
+func newUpgradeFinalization(
+	instance *postgres.Instance,
+	request StartUpgradeFinalizationRequest,
+) *upgradeFinalization {
+	return &upgradeFinalization{
+		instance: instance,
+		data: UpgradeFinalizationData{
+			MajorVersion:       request.MajorVersion,
+			Phase:              UpgradeFinalizationRunning,
+			CompletedDatabases: slices.Clone(request.CompletedDatabases),
+		},
+	}
+}
+
+// getData returns a copy of the current progress
+func (uf *upgradeFinalization) getData() UpgradeFinalizationData {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	result := uf.data
+	result.CompletedDatabases = slices.Clone(uf.data.CompletedDatabases)
+	return result
+}
+
+func (uf *upgradeFinalization) isRunning() bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return uf.data.Phase == UpgradeFinalizationRunning
+}
+
+func (uf *upgradeFinalization) update(f func(data *UpgradeFinalizationData)) {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	f(&uf.data)
+}
+
+func (uf *upgradeFinalization) run(ctx context.Context) {
+	contextLogger := log.FromContext(ctx).WithValues("majorVersion", uf.data.MajorVersion)
+
+	if err := uf.processDatabases(ctx); err != nil {
+		contextLogger.Error(err, "Post-upgrade maintenance failed")
+		uf.update(func(data *UpgradeFinalizationData) {
+			data.Phase = UpgradeFinalizationFailed
+			data.Error = err.Error()
+		})
+		return
+	}
+
+	contextLogger.Info("Post-upgrade maintenance completed")
+	uf.update(func(data *UpgradeFinalizationData) {
+		data.Phase = UpgradeFinalizationCompleted
+		data.CurrentDatabase = ""
+	})
+}
+
+func (uf *upgradeFinalization) processDatabases(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	databases, err := uf.getDatabases(ctx)
+	if err != nil {
+		return err
+	}
+
+	completed := uf.getData().CompletedDatabases
+	uf.update(func(data *UpgradeFinalizationData) {
+		data.TotalDatabases = len(databases)
+	})
+
+	for _, databaseName := range getPendingDatabases(databases, completed) {
+		contextLogger.Info("Running post-upgrade maintenance", "databaseName", databaseName)
+		uf.update(func(data *UpgradeFinalizationData) {
+			data.CurrentDatabase = databaseName
+		})
+
+		if err := uf.updateExtensions(ctx, databaseName); err != nil {
+			return fmt.Errorf("while updating the extensions in database %s: %w", databaseName, err)
+		}
+
+		if err := analyzeInStages(ctx, databaseName); err != nil {
+			return fmt.Errorf("while analyzing database %s: %w", databaseName, err)
+		}
+
+		uf.update(func(data *UpgradeFinalizationData) {
+			data.CompletedDatabases = append(data.CompletedDatabases, databaseName)
+		})
+	}
+
+	return nil
+}
+
+// getDatabases returns the list of databases accepting connections
+func (uf *upgradeFinalization) getDatabases(ctx context.Context) ([]string, error) {
+	db, err := uf.instance.GetSuperUserDB()
+	if err != nil {
+		return nil, fmt.Errorf("while getting the superuser connection: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	databases, errs := utils.GetAllAccessibleDatabases(tx, "datallowconn")
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("while listing the databases: %v", errs)
+	}
+
+	return databases, nil
+}
+
+// updateExtensions updates every extension installed in the passed
+// database whose version differs from the default one available
+// with the new binaries
+func (uf *upgradeFinalization) updateExtensions(ctx context.Context, databaseName string) error {
+	contextLogger := log.FromContext(ctx)
+
+	db, err := uf.instance.ConnectionPool().Connection(databaseName)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx,
+		"SELECT e.extname FROM pg_catalog.pg_extension e "+
+			"JOIN pg_catalog.pg_available_extensions a ON a.name = e.extname "+
+			"WHERE a.default_version IS NOT NULL AND e.extversion <> a.default_version")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var extensions []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		extensions = append(extensions, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range extensions {
+		contextLogger.Info("Updating extension", "databaseName", databaseName, "extension", name)
+		if _, err := db.ExecContext(ctx,
+			fmt.Sprintf("ALTER EXTENSION %s UPDATE", pgx.Identifier{name}.Sanitize())); err != nil {
+			return fmt.Errorf("while updating extension %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// analyzeInStages rebuilds the planner statistics of the passed database,
+// that are not transferred by pg_upgrade
+func analyzeInStages(ctx context.Context, databaseName string) error {
+	// We just use the environment variables we already have
+	// to pass the connection parameters
+	options := []string{
+		"-U", "postgres",
+		"--analyze-in-stages",
+		"--dbname", databaseName,
+	}
+
+	cmd := exec.CommandContext(ctx, vacuumdbName, options...) // #nosec G204
+	return execlog.RunBuffering(cmd, vacuumdbName)
+}
+
+// getPendingDatabases returns the databases that have not been processed yet
+func getPendingDatabases(databases []string, completed []string) []string {
+	result := make([]string, 0, len(databases))
+	for _, databaseName := range databases {
+		if !slices.Contains(completed, databaseName) {
+			result = append(result, databaseName)
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getPendingDatabases", func() {
+	It("skips the databases that have already been processed", func() {
+		Expect(getPendingDatabases(
+			[]string{"postgres", "app", "template1"},
+			[]string{"postgres"},
+		)).To(Equal([]string{"app", "template1"}))
+	})
+
+	It("returns every database when nothing has been processed", func() {
+		Expect(getPendingDatabases([]string{"postgres", "app"}, nil)).To(Equal([]string{"postgres", "app"}))
+	})
+})
+
+var _ = Describe("upgradeFinalization endpoint", func() {
+	var ws *remoteWebserverEndpoints
+
+	BeforeEach(func() {
+		ws = &remoteWebserverEndpoints{}
+	})
+
+	get := func() Response[UpgradeFinalizationData] {
+		req := httptest.NewRequest(http.MethodGet, url.PathPgUpgradeFinalization, nil)
+		w := httptest.NewRecorder()
+		ws.upgradeFinalization(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var response Response[UpgradeFinalizationData]
+		Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+		return response
+	}
+
+	It("reports an empty status when nothing has been started", func() {
+		response := get()
+		Expect(response.Data).ToNot(BeNil())
+		Expect(response.Data.MajorVersion).To(BeZero())
+	})
+
+	It("reports the progress of the current maintenance", func() {
+		ws.currentUpgradeFinalization = newUpgradeFinalization(nil, StartUpgradeFinalizationRequest{
+			MajorVersion:       17,
+			CompletedDatabases: []string{"postgres"},
+		})
+
+		response := get()
+		Expect(response.Data.MajorVersion).To(Equal(17))
+		Expect(response.Data.Phase).To(Equal(UpgradeFinalizationRunning))
+		Expect(response.Data.CompletedDatabases).To(ConsistOf("postgres"))
+	})
+
+	It("refuses to start another maintenance while one is running", func() {
+		ws.currentUpgradeFinalization = newUpgradeFinalization(nil, StartUpgradeFinalizationRequest{
+			MajorVersion: 17,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, url.PathPgUpgradeFinalization,
+			strings.NewReader(`{"majorVersion": 17}`))
+		w := httptest.NewRecorder()
+		ws.upgradeFinalization(w, req)
+		Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(w.Body.String()).To(ContainSubstring(errCodeAnotherRequestInProgress))
+	})
+})
@@ -66,6 +66,10 @@ const (
 	// PathPgArchivePartial is the URL path to interact with the partial wal archive
 	PathPgArchivePartial string = "/pg/archive/partial"
 
+	// PathPgUpgradeFinalization is the URL path to interact with the
+	// maintenance operations executed after a major upgrade
+	PathPgUpgradeFinalization string = "/pg/upgrade/finalization"
+
 	// PathMetrics is the URL path for Metrics
 	PathMetrics string = "/metrics"
 
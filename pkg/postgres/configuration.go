@@ -359,6 +359,10 @@ type ConfigurationInfo struct {
 	// The list of user-level settings
 	UserSettings map[string]string
 
+	// The settings computed by the automatic tuning, applied on top
+	// of the default settings and overridden by the user-level ones
+	TunedSettings SettingsCollection
+
 	// The synchronous_standby_names configuration to be applied
 	SynchronousStandbyNames SynchronousStandbyNamesConfig
 
@@ -569,7 +573,7 @@ var (
 			"log_rotation_size":          "0",
 			"log_truncate_on_rotation":   "false",
 			"max_parallel_workers":       "32",
-			"max_worker_processes":       "32",
+			"max_worker_processes":       fmt.Sprint(defaultMaxWorkerProcesses),
 			"max_replication_slots":      "32",
 			"shared_memory_type":         "mmap",
 			"ssl_max_protocol_version":   "TLSv1.3",
@@ -601,6 +605,157 @@ var (
 	}
 )
 
+const (
+	// defaultMaxConnections is the default value of max_connections
+	defaultMaxConnections = 100
+
+	// defaultMaxParallelWorkersPerGather is the default value of
+	// max_parallel_workers_per_gather
+	defaultMaxParallelWorkersPerGather = 2
+
+	// defaultMaxWorkerProcesses is the CloudNativePG default value
+	// of max_worker_processes
+	defaultMaxWorkerProcesses = 32
+
+	// hashMemMultiplier is the default value of hash_mem_multiplier,
+	// used to estimate the shared memory used by parallel hash joins
+	hashMemMultiplier = 2
+
+	// maxMaintenanceWorkMem is the maximum value of maintenance_work_mem
+	// set by the automatic tuning, as larger values are rarely useful
+	maxMaintenanceWorkMem = 2 * 1024 * 1024 * 1024
+
+	// minWorkMem is the minimum value of work_mem accepted by PostgreSQL
+	minWorkMem = 64 * 1024
+)
+
+// HotStandbySensitiveParameters is the set of parameters whose value on
+// a standby cannot be lower than on the primary. They must be consistent
+// across all the instances, as any standby can be promoted
+var HotStandbySensitiveParameters = map[string]bool{
+	"max_connections":           true,
+	"max_locks_per_transaction": true,
+	"max_prepared_transactions": true,
+	"max_wal_senders":           true,
+	"max_worker_processes":      true,
+}
+
+// TunedConfigurationParameters is the list of parameters computed
+// by the automatic tuning
+var TunedConfigurationParameters = []string{
+	"shared_buffers",
+	"effective_cache_size",
+	"work_mem",
+	"maintenance_work_mem",
+	"max_parallel_workers",
+	"max_parallel_workers_per_gather",
+}
+
+// TuningCoefficients contains the coefficients used by the automatic
+// tuning to size the configuration parameters for a kind of workload
+type TuningCoefficients struct {
+	// WorkMemDivisor is the number of sort or hash operations each
+	// connection is expected to run at the same time
+	WorkMemDivisor int64
+
+	// MaintenanceWorkMemDivisor is the inverse of the fraction of the
+	// memory used for maintenance_work_mem
+	MaintenanceWorkMemDivisor int64
+
+	// MaxParallelWorkersPerGather is the upper limit of
+	// max_parallel_workers_per_gather
+	MaxParallelWorkersPerGather int64
+}
+
+// TuningInfo contains the information needed to compute the
+// configuration parameters depending on the resources of the instances
+type TuningInfo struct {
+	// Profile contains the coefficients of the kind of workload,
+	// or nil if the automatic tuning is not enabled
+	Profile *TuningCoefficients
+
+	// Memory is the memory available to PostgreSQL, in bytes,
+	// or zero if unknown
+	Memory int64
+
+	// SharedMemory is the size limit of /dev/shm, in bytes,
+	// or zero if not limited
+	SharedMemory int64
+
+	// CPUCount is the number of CPUs available to PostgreSQL,
+	// or zero if unknown
+	CPUCount int64
+
+	// MaxConnections is the value of max_connections, or zero
+	// for the PostgreSQL default
+	MaxConnections int64
+
+	// MaxWorkerProcesses is the value of max_worker_processes, or zero
+	// for the CloudNativePG default
+	MaxWorkerProcesses int64
+}
+
+// CreateTunedConfiguration computes the memory and parallelism
+// parameters for the passed profile and resources. Parameters
+// depending on unknown resources are not computed
+func CreateTunedConfiguration(info TuningInfo) SettingsCollection {
+	if info.Profile == nil {
+		return nil
+	}
+	workMemDivisor := info.Profile.WorkMemDivisor
+	maintenanceWorkMemDivisor := info.Profile.MaintenanceWorkMemDivisor
+	maxWorkersPerGather := info.Profile.MaxParallelWorkersPerGather
+
+	result := make(SettingsCollection)
+
+	workersPerGather := int64(defaultMaxParallelWorkersPerGather)
+	if info.CPUCount > 0 {
+		// PostgreSQL takes the parallel workers from the pool of the
+		// background worker processes, and silently caps their number
+		maxWorkerProcesses := info.MaxWorkerProcesses
+		if maxWorkerProcesses <= 0 {
+			maxWorkerProcesses = defaultMaxWorkerProcesses
+		}
+		maxParallelWorkers := min(info.CPUCount, maxWorkerProcesses)
+		workersPerGather = min(info.CPUCount/2, maxWorkersPerGather, maxParallelWorkers)
+		result["max_parallel_workers"] = fmt.Sprint(maxParallelWorkers)
+		result["max_parallel_workers_per_gather"] = fmt.Sprint(workersPerGather)
+	}
+
+	if info.Memory <= 0 {
+		return result
+	}
+
+	maxConnections := info.MaxConnections
+	if maxConnections <= 0 {
+		maxConnections = defaultMaxConnections
+	}
+
+	sharedBuffers := info.Memory / 4
+	result["shared_buffers"] = formatMegabytes(sharedBuffers)
+	result["effective_cache_size"] = formatMegabytes(info.Memory * 3 / 4)
+
+	maintenanceWorkMem := min(info.Memory/maintenanceWorkMemDivisor, maxMaintenanceWorkMem)
+	result["maintenance_work_mem"] = formatMegabytes(maintenanceWorkMem)
+
+	// Every connection can use work_mem for each sort or hash
+	// operation of each of its parallel workers
+	workMem := (info.Memory - sharedBuffers) / (maxConnections * workMemDivisor) / max(workersPerGather, 1)
+	if info.SharedMemory > 0 {
+		// Parallel hash joins allocate their hash tables in /dev/shm
+		workMem = min(workMem, info.SharedMemory/(hashMemMultiplier*(workersPerGather+1)))
+	}
+	result["work_mem"] = fmt.Sprintf("%dkB", max(workMem, minWorkMem)/1024)
+
+	return result
+}
+
+// formatMegabytes formats a size in bytes as a PostgreSQL
+// memory parameter in megabytes, with a minimum of 1MB
+func formatMegabytes(size int64) string {
+	return fmt.Sprintf("%dMB", max(size/(1024*1024), 1))
+}
+
 // HBAOptions holds the configuration for rendering a pg_hba.conf file.
 type HBAOptions struct {
 	// DefaultAuthenticationMethod is the fallback auth method (e.g. "scram-sha-256").
@@ -712,6 +867,11 @@ func CreatePostgresqlConfiguration(info ConfigurationInfo) *PgConfiguration {
 	// Set all the default settings
 	configuration.setDefaultConfigurations(info)
 
+	// Apply the automatically tuned settings, overriding defaults
+	for key, value := range info.TunedSettings {
+		configuration.OverwriteConfig(key, value)
+	}
+
 	// Apply all the values from the user, overriding defaults,
 	// ignoring those which are fixed if ignoreFixedSettingsFromUser is true
 	for key, value := range info.UserSettings {
@@ -290,6 +290,98 @@ var _ = Describe("PostgreSQL configuration creation", func() {
 	})
 })
 
+var _ = Describe("Automatic tuning", func() {
+	const gigabyte = 1024 * 1024 * 1024
+
+	oltp := &TuningCoefficients{WorkMemDivisor: 4, MaintenanceWorkMemDivisor: 16, MaxParallelWorkersPerGather: 2}
+	olap := &TuningCoefficients{WorkMemDivisor: 1, MaintenanceWorkMemDivisor: 8, MaxParallelWorkersPerGather: 1024}
+	mixed := &TuningCoefficients{WorkMemDivisor: 2, MaintenanceWorkMemDivisor: 16, MaxParallelWorkersPerGather: 4}
+
+	It("doesn't compute anything without a profile", func() {
+		Expect(CreateTunedConfiguration(TuningInfo{Memory: 4 * gigabyte})).To(BeNil())
+	})
+
+	It("computes the OLTP parameters", func() {
+		config := CreateTunedConfiguration(TuningInfo{
+			Profile:  oltp,
+			Memory:   4 * gigabyte,
+			CPUCount: 8,
+		})
+		Expect(config).To(Equal(SettingsCollection{
+			"shared_buffers":                  "1024MB",
+			"effective_cache_size":            "3072MB",
+			"maintenance_work_mem":            "256MB",
+			"work_mem":                        "3932kB",
+			"max_parallel_workers":            "8",
+			"max_parallel_workers_per_gather": "2",
+		}))
+	})
+
+	It("computes the OLAP parameters", func() {
+		config := CreateTunedConfiguration(TuningInfo{
+			Profile:        olap,
+			Memory:         16 * gigabyte,
+			CPUCount:       16,
+			MaxConnections: 20,
+		})
+		Expect(config).To(HaveKeyWithValue("maintenance_work_mem", "2048MB"))
+		Expect(config).To(HaveKeyWithValue("max_parallel_workers_per_gather", "8"))
+		Expect(config).To(HaveKeyWithValue("work_mem", "78643kB"))
+	})
+
+	It("limits work_mem to the size of the shared memory", func() {
+		config := CreateTunedConfiguration(TuningInfo{
+			Profile:      mixed,
+			Memory:       16 * gigabyte,
+			CPUCount:     8,
+			SharedMemory: 100 * 1024 * 1024,
+		})
+		Expect(config).To(HaveKeyWithValue("work_mem", "10240kB"))
+	})
+
+	It("only computes the parameters depending on the known resources", func() {
+		Expect(CreateTunedConfiguration(TuningInfo{
+			Profile:  oltp,
+			CPUCount: 4,
+		})).To(Equal(SettingsCollection{
+			"max_parallel_workers":            "4",
+			"max_parallel_workers_per_gather": "2",
+		}))
+	})
+
+	It("limits the parallel workers to max_worker_processes", func() {
+		Expect(CreateTunedConfiguration(TuningInfo{
+			Profile:  olap,
+			CPUCount: 64,
+		})).To(Equal(SettingsCollection{
+			"max_parallel_workers":            "32",
+			"max_parallel_workers_per_gather": "32",
+		}))
+
+		Expect(CreateTunedConfiguration(TuningInfo{
+			Profile:            olap,
+			CPUCount:           64,
+			MaxWorkerProcesses: 40,
+		})).To(HaveKeyWithValue("max_parallel_workers", "40"))
+	})
+
+	It("applies the tuned settings between the defaults and the user settings", func() {
+		config := CreatePostgresqlConfiguration(ConfigurationInfo{
+			Settings:     CnpgConfigurationSettings,
+			MajorVersion: 17,
+			UserSettings: map[string]string{
+				"work_mem": "64MB",
+			},
+			TunedSettings: SettingsCollection{
+				"max_parallel_workers": "4",
+				"work_mem":             "4MB",
+			},
+		})
+		Expect(config.GetConfig("max_parallel_workers")).To(Equal("4"))
+		Expect(config.GetConfig("work_mem")).To(Equal("64MB"))
+	})
+})
+
 var _ = Describe("pg_hba.conf generation", func() {
 	specRules := []string{
 		"one",
@@ -0,0 +1,162 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// listParameters are the configuration parameters holding a list of
+// identifiers, whose elements are quoted individually when set with
+// `ALTER ROLE/DATABASE ... SET`
+var listParameters = []string{
+	"local_preload_libraries",
+	"search_path",
+	"session_preload_libraries",
+	"temp_tablespaces",
+}
+
+// ValidateParameterName checks that a configuration parameter name can be
+// set with `ALTER ROLE/DATABASE ... SET`. Custom parameters are made of
+// identifiers separated by dots
+func ValidateParameterName(name string) error {
+	for part := range strings.SplitSeq(name, ".") {
+		if !postgresIdentifierRegex.MatchString(part) {
+			return fmt.Errorf("%q is not a valid configuration parameter name", name)
+		}
+	}
+	return nil
+}
+
+// ParseParameterSettings parses the `name=value` entries stored in the
+// `setconfig` column of `pg_db_role_setting`. The parameter names are
+// lowercase, as PostgreSQL looks them up case-insensitively
+func ParseParameterSettings(setconfig []string) map[string]string {
+	result := make(map[string]string, len(setconfig))
+	for _, setting := range setconfig {
+		name, value, _ := strings.Cut(setting, "=")
+		result[strings.ToLower(name)] = value
+	}
+	return result
+}
+
+// ParameterSetClause renders the `SET` clause of `ALTER ROLE/DATABASE`
+// setting the passed configuration parameter
+func ParameterSetClause(name, value string) string {
+	if !isListParameter(name) {
+		return fmt.Sprintf("SET %s TO %s", quoteParameterName(name), quoteLiteral(value))
+	}
+
+	elements := splitListValue(value)
+	for i := range elements {
+		elements[i] = quoteIdentifier(elements[i])
+	}
+	if len(elements) == 0 {
+		return fmt.Sprintf("SET %s TO ''", quoteParameterName(name))
+	}
+	return fmt.Sprintf("SET %s TO %s", quoteParameterName(name), strings.Join(elements, ", "))
+}
+
+// ParameterResetClause renders the `RESET` clause of `ALTER ROLE/DATABASE`
+// removing the passed configuration parameter
+func ParameterResetClause(name string) string {
+	return fmt.Sprintf("RESET %s", quoteParameterName(name))
+}
+
+// ParametersDiff compares the desired configuration parameters with the
+// ones stored in PostgreSQL, as returned by ParseParameterSettings, and
+// returns the parameters to set and the ones to reset. Only the
+// previously managed parameters are reset, leaving alone the settings
+// applied outside the operator
+func ParametersDiff(
+	desired map[string]string,
+	current map[string]string,
+	previouslyManaged []string,
+) (toSet map[string]string, toReset []string) {
+	toSet = make(map[string]string)
+	wanted := make(map[string]bool, len(desired))
+	for name, value := range desired {
+		wanted[strings.ToLower(name)] = true
+		stored, found := current[strings.ToLower(name)]
+		if !found || !parameterValueMatches(name, value, stored) {
+			toSet[name] = value
+		}
+	}
+
+	for _, name := range previouslyManaged {
+		lowerName := strings.ToLower(name)
+		if _, found := current[lowerName]; found && !wanted[lowerName] && !slices.Contains(toReset, name) {
+			toReset = append(toReset, name)
+		}
+	}
+	slices.Sort(toReset)
+
+	return toSet, toReset
+}
+
+// parameterValueMatches checks whether the value stored in PostgreSQL
+// corresponds to the desired one
+func parameterValueMatches(name, desired, stored string) bool {
+	if !isListParameter(name) {
+		return desired == stored
+	}
+
+	return slices.Equal(splitListValue(desired), splitListValue(stored))
+}
+
+// isListParameter checks whether the parameter holds a list of identifiers
+func isListParameter(name string) bool {
+	return slices.Contains(listParameters, strings.ToLower(name))
+}
+
+// splitListValue splits the value of a list parameter into its unquoted
+// elements
+func splitListValue(value string) []string {
+	var elements []string
+	for element := range strings.SplitSeq(value, ",") {
+		element = strings.TrimSpace(element)
+		if len(element) >= 2 && strings.HasPrefix(element, `"`) && strings.HasSuffix(element, `"`) {
+			element = strings.ReplaceAll(element[1:len(element)-1], `""`, `"`)
+		}
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// quoteParameterName quotes every identifier of a parameter name
+func quoteParameterName(name string) string {
+	parts := strings.Split(name, ".")
+	for i := range parts {
+		parts[i] = quoteIdentifier(parts[i])
+	}
+	return strings.Join(parts, ".")
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteLiteral(literal string) string {
+	return `'` + strings.ReplaceAll(literal, `'`, `''`) + `'`
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration parameters of roles and databases", func() {
+	It("validates the parameter names", func() {
+		Expect(ValidateParameterName("work_mem")).To(Succeed())
+		Expect(ValidateParameterName("pg_stat_statements.track")).To(Succeed())
+		Expect(ValidateParameterName("")).ToNot(Succeed())
+		Expect(ValidateParameterName("work mem")).ToNot(Succeed())
+		Expect(ValidateParameterName("work_mem; DROP TABLE x")).ToNot(Succeed())
+		Expect(ValidateParameterName("myext.")).ToNot(Succeed())
+	})
+
+	It("parses the settings stored in pg_db_role_setting", func() {
+		Expect(ParseParameterSettings([]string{
+			"statement_timeout=30s",
+			"search_path=\"$user\", public",
+			"application_name=a=b",
+			"DateStyle=ISO, MDY",
+		})).To(Equal(map[string]string{
+			"statement_timeout": "30s",
+			"search_path":       "\"$user\", public",
+			"application_name":  "a=b",
+			"datestyle":         "ISO, MDY",
+		}))
+		Expect(ParseParameterSettings(nil)).To(BeEmpty())
+	})
+
+	It("renders the SET and RESET clauses", func() {
+		Expect(ParameterSetClause("statement_timeout", "30s")).To(
+			Equal(`SET "statement_timeout" TO '30s'`))
+		Expect(ParameterSetClause("application_name", "it's me")).To(
+			Equal(`SET "application_name" TO 'it''s me'`))
+		Expect(ParameterSetClause("pg_stat_statements.track", "all")).To(
+			Equal(`SET "pg_stat_statements"."track" TO 'all'`))
+		Expect(ParameterSetClause("search_path", `"$user", public, app`)).To(
+			Equal(`SET "search_path" TO "$user", "public", "app"`))
+		Expect(ParameterSetClause("search_path", "")).To(
+			Equal(`SET "search_path" TO ''`))
+		Expect(ParameterResetClause("work_mem")).To(Equal(`RESET "work_mem"`))
+	})
+
+	It("detects the parameters to set", func() {
+		toSet, toReset := ParametersDiff(
+			map[string]string{
+				"work_mem":          "64MB",
+				"statement_timeout": "30s",
+				"search_path":       "app, public",
+			},
+			map[string]string{
+				"work_mem":    "32MB",
+				"search_path": `"app", "public"`,
+			},
+			nil,
+		)
+		Expect(toSet).To(Equal(map[string]string{
+			"work_mem":          "64MB",
+			"statement_timeout": "30s",
+		}))
+		Expect(toReset).To(BeEmpty())
+	})
+
+	It("only resets the parameters that were previously managed", func() {
+		toSet, toReset := ParametersDiff(
+			map[string]string{"work_mem": "64MB"},
+			map[string]string{
+				"work_mem":          "64MB",
+				"statement_timeout": "30s",
+				"lock_timeout":      "5s",
+				"search_path":       "app",
+			},
+			[]string{"work_mem", "statement_timeout", "search_path", "idle_session_timeout"},
+		)
+		Expect(toSet).To(BeEmpty())
+		Expect(toReset).To(Equal([]string{"search_path", "statement_timeout"}))
+	})
+
+	It("compares the parameter names case-insensitively", func() {
+		toSet, toReset := ParametersDiff(
+			map[string]string{"DateStyle": "ISO, MDY"},
+			map[string]string{"datestyle": "ISO, MDY"},
+			[]string{"DateStyle"},
+		)
+		Expect(toSet).To(BeEmpty())
+		Expect(toReset).To(BeEmpty())
+	})
+})
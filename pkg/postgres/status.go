@@ -54,6 +54,13 @@ type PostgresqlStatus struct {
 	// Hash of the current PostgreSQL configuration
 	LoadedConfigurationHash string `json:"loadedConfigurationHash,omitempty"`
 
+	// The parameters whose change requires a restart to be applied
+	PendingRestartParameters []string `json:"pendingRestartParameters,omitempty"`
+
+	// The parameters set via ALTER SYSTEM whose value differs
+	// from the one in the configuration managed by the operator
+	AlterSystemParameters map[string]string `json:"alterSystemParameters,omitempty"`
+
 	// Archiver status
 	LastArchivedWAL     string `json:"lastArchivedWAL,omitempty"`
 	LastArchivedWALTime string `json:"lastArchivedWALTime,omitempty"`
@@ -474,6 +481,8 @@ func (list PostgresqlStatusList) GetConfigurationReport() ConfigurationReport {
 	for i := range list.Items {
 		result[i].PodName = list.Items[i].Pod.Name
 		result[i].ConfigHash = list.Items[i].LoadedConfigurationHash
+		result[i].PendingRestartParameters = list.Items[i].PendingRestartParameters
+		result[i].AlterSystemParameters = list.Items[i].AlterSystemParameters
 	}
 
 	return result
@@ -488,6 +497,19 @@ type ConfigurationReportEntry struct {
 	// ConfigHash is the hash of the currently loaded configuration or empty
 	// if the instance manager didn't report it.
 	ConfigHash string `json:"configHash"`
+
+	// Group identifies the Pods expected to load the same configuration,
+	// as the parameters overridden on specific instances make their
+	// configuration differ from the one of the other instances.
+	Group string `json:"group,omitempty"`
+
+	// PendingRestartParameters are the parameters whose change requires
+	// a restart to be applied.
+	PendingRestartParameters []string `json:"pendingRestartParameters,omitempty"`
+
+	// AlterSystemParameters are the parameters set via ALTER SYSTEM
+	// overriding the configuration managed by the operator.
+	AlterSystemParameters map[string]string `json:"alterSystemParameters,omitempty"`
 }
 
 // ConfigurationReport contains information about the current
@@ -495,23 +517,31 @@ type ConfigurationReportEntry struct {
 type ConfigurationReport []ConfigurationReportEntry
 
 // IsUniform checks if every Pod has loaded the same PostgreSQL
-// configuration. Returns:
+// configuration of the other Pods in its group. Returns:
 //
 //   - true if every Pod reports the configuration, and the same
-//     configuration is used across all Pods.
+//     configuration is used across all Pods of each group.
 //   - false if every Pod reports the configuration and there
-//     are two Pods using different configurations.
+//     are two Pods of the same group using different configurations.
 //   - nil if any Pod doesn't report the configuration.
 func (report ConfigurationReport) IsUniform() *bool {
-	detectedConfigurationHash := stringset.New()
+	detectedConfigurationHash := make(map[string]*stringset.Data)
 	for _, item := range report {
 		if item.ConfigHash == "" {
 			// a Pod that isn't reporting its configuration,
 			// and we can't tell whether the configurations are uniform or not.
 			return nil
 		}
-		detectedConfigurationHash.Put(item.ConfigHash)
+		if detectedConfigurationHash[item.Group] == nil {
+			detectedConfigurationHash[item.Group] = stringset.New()
+		}
+		detectedConfigurationHash[item.Group].Put(item.ConfigHash)
 	}
 
-	return ptr.To(detectedConfigurationHash.Len() == 1)
+	for _, hashes := range detectedConfigurationHash {
+		if hashes.Len() != 1 {
+			return ptr.To(false)
+		}
+	}
+	return ptr.To(len(detectedConfigurationHash) > 0)
 }
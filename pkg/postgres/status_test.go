@@ -352,5 +352,40 @@ var _ = Describe("Configuration report", func() {
 			},
 			ptr.To(true),
 		),
+		Entry(
+			"with instances overriding some parameters",
+			ConfigurationReport{
+				{
+					PodName:    "cluster-example-1",
+					ConfigHash: "abc",
+				},
+				{
+					PodName:    "cluster-example-2",
+					ConfigHash: "abc",
+				},
+				{
+					PodName:    "cluster-example-3",
+					ConfigHash: "def",
+					Group:      "work_mem=64MB",
+				},
+			},
+			ptr.To(true),
+		),
+		Entry(
+			"with instances of the same group reporting different configurations",
+			ConfigurationReport{
+				{
+					PodName:    "cluster-example-2",
+					ConfigHash: "abc",
+					Group:      "work_mem=64MB",
+				},
+				{
+					PodName:    "cluster-example-3",
+					ConfigHash: "def",
+					Group:      "work_mem=64MB",
+				},
+			},
+			ptr.To(false),
+		),
 	)
 })
@@ -0,0 +1,223 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+const (
+	// finalizationPollInterval is the interval between two checks
+	// of the progress of the post-upgrade maintenance
+	finalizationPollInterval = 30 * time.Second
+
+	// finalizationRetryInterval is the time to wait before retrying
+	// a failed post-upgrade maintenance
+	finalizationRetryInterval = time.Minute
+)
+
+// ReconcileFinalization drives the maintenance operations needed after an
+// in-place major upgrade, that are executed by the instance manager of the
+// primary instance. The progress is tracked in the cluster status, and the
+// cluster is kept in the UpgradeFinalizing condition until it completes.
+func ReconcileFinalization(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	instanceClient remote.InstanceClient,
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	finalization := cluster.Status.MajorUpgradeFinalization
+	if finalization == nil || finalization.CompletedAt != nil {
+		return nil, nil
+	}
+
+	primary := getReadyPrimaryStatus(cluster, instancesStatus)
+	if primary == nil {
+		contextLogger.Debug("Waiting for the primary instance to run the post-upgrade maintenance")
+		return &ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	data, err := instanceClient.GetUpgradeFinalizationStatus(ctx, primary.Pod)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the post-upgrade maintenance status: %w", err)
+	}
+
+	if shouldStartFinalization(cluster, data) {
+		contextLogger.Info("Starting the post-upgrade maintenance",
+			"instance", primary.Pod.Name,
+			"majorVersion", finalization.MajorVersion)
+		data, err = instanceClient.StartUpgradeFinalization(ctx, primary.Pod, webserver.StartUpgradeFinalizationRequest{
+			MajorVersion:       finalization.MajorVersion,
+			CompletedDatabases: finalization.CompletedDatabases,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("while starting the post-upgrade maintenance: %w", err)
+		}
+	}
+
+	if data == nil || data.MajorVersion != finalization.MajorVersion {
+		return &ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	progress := &apiv1.MajorUpgradeFinalizationStatus{
+		MajorVersion:       finalization.MajorVersion,
+		TotalDatabases:     data.TotalDatabases,
+		CompletedDatabases: data.CompletedDatabases,
+		CurrentDatabase:    data.CurrentDatabase,
+	}
+
+	switch data.Phase {
+	case webserver.UpgradeFinalizationCompleted:
+		progress.CompletedAt = ptr.To(metav1.Now())
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			c,
+			cluster,
+			status.SetMajorUpgradeFinalization(progress),
+			setUpgradeFinalizingCondition(
+				metav1.ConditionFalse,
+				apiv1.ConditionReasonUpgradeFinalizationCompleted,
+				fmt.Sprintf("Post-upgrade maintenance completed on %d databases", data.TotalDatabases),
+			),
+		); err != nil {
+			return nil, err
+		}
+		recorder.Eventf(cluster, "Normal", "MajorUpgradeFinalized",
+			"Post-upgrade maintenance completed on %d databases", data.TotalDatabases)
+		return nil, nil
+
+	case webserver.UpgradeFinalizationFailed:
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			c,
+			cluster,
+			status.SetMajorUpgradeFinalization(progress),
+			setUpgradeFinalizingCondition(
+				metav1.ConditionTrue,
+				apiv1.ConditionReasonUpgradeFinalizationFailed,
+				data.Error,
+			),
+		); err != nil {
+			return nil, err
+		}
+		recorder.Eventf(cluster, "Warning", "MajorUpgradeFinalizationFailed",
+			"Post-upgrade maintenance failed, will be retried: %s", data.Error)
+		return &ctrl.Result{RequeueAfter: finalizationRetryInterval}, nil
+
+	default:
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			c,
+			cluster,
+			status.SetMajorUpgradeFinalization(progress),
+			setUpgradeFinalizingCondition(
+				metav1.ConditionTrue,
+				apiv1.ConditionReasonUpgradeFinalizationInProgress,
+				fmt.Sprintf("Post-upgrade maintenance running on %s (%d of %d databases completed)",
+					primary.Pod.Name, len(data.CompletedDatabases), data.TotalDatabases),
+			),
+		); err != nil {
+			return nil, err
+		}
+		return &ctrl.Result{RequeueAfter: finalizationPollInterval}, nil
+	}
+}
+
+// shouldStartFinalization checks if the post-upgrade maintenance needs to
+// be started on the primary instance. This happens when it has never been
+// started by the running instance manager, for example because the primary
+// has been restarted, or when the failure of the previous attempt has
+// already been reported in the cluster status.
+func shouldStartFinalization(cluster *apiv1.Cluster, data *webserver.UpgradeFinalizationData) bool {
+	if data == nil || data.MajorVersion != cluster.Status.MajorUpgradeFinalization.MajorVersion {
+		return true
+	}
+
+	if data.Phase != webserver.UpgradeFinalizationFailed {
+		return false
+	}
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionUpgradeFinalizing))
+	return condition != nil &&
+		condition.Reason == string(apiv1.ConditionReasonUpgradeFinalizationFailed)
+}
+
+// getReadyPrimaryStatus returns the status of the current primary,
+// if it is ready and reporting its status
+func getReadyPrimaryStatus(
+	cluster *apiv1.Cluster,
+	instancesStatus postgres.PostgresqlStatusList,
+) *postgres.PostgresqlStatus {
+	for idx := range instancesStatus.Items {
+		item := &instancesStatus.Items[idx]
+		if item.Pod == nil || item.Pod.Name != cluster.Status.CurrentPrimary {
+			continue
+		}
+		if item.Error != nil || !item.IsPrimary || !item.IsPodReady {
+			return nil
+		}
+		return item
+	}
+
+	return nil
+}
+
+// setUpgradeFinalizingCondition is a transaction setting the
+// UpgradeFinalizing condition
+func setUpgradeFinalizingCondition(
+	conditionStatus metav1.ConditionStatus,
+	reason apiv1.ConditionReason,
+	message string,
+) status.Transaction {
+	return func(cluster *apiv1.Cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:    string(apiv1.ConditionUpgradeFinalizing),
+			Status:  conditionStatus,
+			Reason:  string(reason),
+			Message: message,
+		})
+	}
+}
+
+// removeUpgradeFinalizingCondition is a transaction removing the
+// UpgradeFinalizing condition
+func removeUpgradeFinalizingCondition(cluster *apiv1.Cluster) {
+	meta.RemoveStatusCondition(&cluster.Status.Conditions, string(apiv1.ConditionUpgradeFinalizing))
+}
@@ -0,0 +1,228 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// finalizationClientMock is a minimal remote.InstanceClient implementation
+// returning canned post-upgrade maintenance responses. The other
+// InstanceClient methods are inherited from the embedded nil interface
+// and panic if called.
+type finalizationClientMock struct {
+	remote.InstanceClient
+	current       *webserver.UpgradeFinalizationData
+	startRequests []webserver.StartUpgradeFinalizationRequest
+}
+
+func (m *finalizationClientMock) GetUpgradeFinalizationStatus(
+	_ context.Context,
+	_ *corev1.Pod,
+) (*webserver.UpgradeFinalizationData, error) {
+	return m.current, nil
+}
+
+func (m *finalizationClientMock) StartUpgradeFinalization(
+	_ context.Context,
+	_ *corev1.Pod,
+	request webserver.StartUpgradeFinalizationRequest,
+) (*webserver.UpgradeFinalizationData, error) {
+	m.startRequests = append(m.startRequests, request)
+	m.current = &webserver.UpgradeFinalizationData{
+		MajorVersion:       request.MajorVersion,
+		Phase:              webserver.UpgradeFinalizationRunning,
+		TotalDatabases:     3,
+		CompletedDatabases: request.CompletedDatabases,
+	}
+	return m.current, nil
+}
+
+var _ = Describe("Post-upgrade maintenance", func() {
+	var (
+		cluster         *apiv1.Cluster
+		fakeClient      client.Client
+		instancesStatus postgres.PostgresqlStatusList
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+				MajorUpgradeFinalization: &apiv1.MajorUpgradeFinalizationStatus{
+					MajorVersion: 17,
+				},
+			},
+		}
+		instancesStatus = postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-1"}},
+					IsPrimary:  true,
+					IsPodReady: true,
+				},
+			},
+		}
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+	})
+
+	getCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionUpgradeFinalizing))
+	}
+
+	It("does nothing when there is no pending maintenance", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeFinalization = nil
+		mock := &finalizationClientMock{}
+
+		result, err := ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(mock.startRequests).To(BeEmpty())
+	})
+
+	It("waits for the primary to be ready", func(ctx SpecContext) {
+		instancesStatus.Items[0].IsPodReady = false
+		mock := &finalizationClientMock{}
+
+		result, err := ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(mock.startRequests).To(BeEmpty())
+	})
+
+	It("starts the maintenance on the primary and tracks its progress", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeFinalization.CompletedDatabases = []string{"postgres"}
+		mock := &finalizationClientMock{
+			current: &webserver.UpgradeFinalizationData{},
+		}
+
+		result, err := ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).To(Equal(finalizationPollInterval))
+
+		Expect(mock.startRequests).To(HaveLen(1))
+		Expect(mock.startRequests[0].MajorVersion).To(Equal(17))
+		Expect(mock.startRequests[0].CompletedDatabases).To(ConsistOf("postgres"))
+
+		Expect(cluster.Status.MajorUpgradeFinalization.TotalDatabases).To(Equal(3))
+		condition := getCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonUpgradeFinalizationInProgress)))
+
+		By("not starting it again while it is running", func() {
+			_, err := ReconcileFinalization(
+				ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mock.startRequests).To(HaveLen(1))
+		})
+	})
+
+	It("marks the maintenance as completed", func(ctx SpecContext) {
+		mock := &finalizationClientMock{
+			current: &webserver.UpgradeFinalizationData{
+				MajorVersion:       17,
+				Phase:              webserver.UpgradeFinalizationCompleted,
+				TotalDatabases:     2,
+				CompletedDatabases: []string{"postgres", "app"},
+			},
+		}
+
+		result, err := ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+
+		Expect(cluster.Status.MajorUpgradeFinalization.CompletedAt).ToNot(BeNil())
+		Expect(cluster.Status.MajorUpgradeFinalization.CompletedDatabases).To(ConsistOf("postgres", "app"))
+		condition := getCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonUpgradeFinalizationCompleted)))
+
+		By("ignoring a completed maintenance", func() {
+			mock.current = nil
+			result, err := ReconcileFinalization(
+				ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+			Expect(mock.startRequests).To(BeEmpty())
+		})
+	})
+
+	It("reports a failure and retries on the following reconciliation", func(ctx SpecContext) {
+		mock := &finalizationClientMock{
+			current: &webserver.UpgradeFinalizationData{
+				MajorVersion:       17,
+				Phase:              webserver.UpgradeFinalizationFailed,
+				TotalDatabases:     2,
+				CompletedDatabases: []string{"postgres"},
+				Error:              "extension update failed",
+			},
+		}
+
+		result, err := ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(mock.startRequests).To(BeEmpty())
+
+		condition := getCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonUpgradeFinalizationFailed)))
+		Expect(condition.Message).To(Equal("extension update failed"))
+
+		_, err = ReconcileFinalization(
+			ctx, fakeClient, record.NewFakeRecorder(10), mock, cluster, instancesStatus)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mock.startRequests).To(HaveLen(1))
+		Expect(mock.startRequests[0].CompletedDatabases).To(ConsistOf("postgres"))
+	})
+})
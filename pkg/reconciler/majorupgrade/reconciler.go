@@ -64,6 +64,10 @@ func Reconcile(
 ) (*ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
+	if cluster.IsMajorUpgradeRollbackRequested() {
+		return reconcileMajorUpgradeRollback(ctx, c, recorder, cluster, instances, pvcs, jobs)
+	}
+
 	if majorUpgradeJob := getMajorUpdateJob(jobs); majorUpgradeJob != nil {
 		if utils.JobHasOneCompletion(*majorUpgradeJob) {
 			return majorVersionUpgradeHandleCompletion(ctx, c, cluster, majorUpgradeJob, pvcs)
@@ -133,6 +137,15 @@ func Reconcile(
 		return nil, err
 	}
 
+	// The volume snapshot backup needs the instances to be running, so it
+	// must be completed before shutting them down
+	if result, err := reconcileRollbackPoint(ctx, c, recorder, cluster, requestedMajor); err != nil {
+		contextLogger.Error(err, "Unable to take the pre-upgrade volume snapshot backup")
+		return nil, err
+	} else if result != nil {
+		return result, err
+	}
+
 	if result, err := deleteAllPodsInMajorUpgradePreparation(ctx, c, instances, jobs); err != nil {
 		contextLogger.Error(err, "Unable to delete pods and jobs in preparation for major upgrade")
 		return nil, err
@@ -168,6 +181,13 @@ func clearStaleUpgradeTarget(ctx context.Context, c client.Client, cluster *apiv
 		cluster.Status.Image != cluster.Status.PGDataImageInfo.Image {
 		transactions = append(transactions, status.SetImage(cluster.Status.PGDataImageInfo.Image))
 	}
+	// A rollback point is kept after a successful upgrade, but it's useless
+	// when the upgrade didn't happen
+	if cluster.Status.PGDataImageInfo != nil &&
+		cluster.Status.MajorUpgradeRollbackPoint != nil &&
+		cluster.Status.MajorUpgradeRollbackPoint.TargetMajorVersion > cluster.Status.PGDataImageInfo.MajorVersion {
+		transactions = append(transactions, status.SetMajorUpgradeRollbackPoint(nil))
+	}
 	if len(transactions) == 0 {
 		return nil
 	}
@@ -361,6 +381,17 @@ func majorVersionUpgradeHandleCompletion(
 		}),
 		status.SetTargetPGDataImageInfo(nil),
 		status.SetTimelineID(1),
+		// pg_upgrade doesn't transfer the planner statistics and keeps the
+		// extensions at their previous version: the instance manager of the
+		// primary will take care of them once the cluster is up again
+		status.SetMajorUpgradeFinalization(&apiv1.MajorUpgradeFinalizationStatus{
+			MajorVersion: requestedMajor,
+		}),
+		setUpgradeFinalizingCondition(
+			metav1.ConditionTrue,
+			apiv1.ConditionReasonUpgradeFinalizationInProgress,
+			"Waiting for the primary instance to run the post-upgrade maintenance",
+		),
 	); err != nil {
 		contextLogger.Error(err, "Unable to update cluster status after major upgrade completed.")
 		return nil, err
@@ -429,6 +460,7 @@ func handleRollbackIfNeeded(
 		cluster,
 		status.SetImage(cluster.Status.PGDataImageInfo.Image),
 		status.SetTargetPGDataImageInfo(nil),
+		status.SetMajorUpgradeRollbackPoint(nil),
 	); err != nil {
 		contextLogger.Error(err, "Unable to reset status image after rollback")
 		return nil, err
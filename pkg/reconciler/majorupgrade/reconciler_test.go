@@ -26,6 +26,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -115,6 +116,12 @@ var _ = Describe("Major upgrade job status reconciliation", func() {
 		// the timeline ID has been reset to 1 to match pg_upgrade behavior
 		Expect(cluster.Status.TimelineID).To(Equal(1))
 
+		// the post-upgrade maintenance is pending
+		Expect(cluster.Status.MajorUpgradeFinalization).ToNot(BeNil())
+		Expect(cluster.Status.MajorUpgradeFinalization.MajorVersion).To(Equal(16))
+		Expect(meta.IsStatusConditionTrue(
+			cluster.Status.Conditions, string(apiv1.ConditionUpgradeFinalizing))).To(BeTrue())
+
 		// the job has been deleted
 		var tempJob batchv1.Job
 		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(job), &tempJob)
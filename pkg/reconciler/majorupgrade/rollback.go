@@ -0,0 +1,254 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ErrRollbackPointNotUsable is raised when the backup taken before the
+// major upgrade cannot be used to restore the previous major version
+var ErrRollbackPointNotUsable = fmt.Errorf("pre-upgrade backup cannot be used for the rollback")
+
+// reconcileMajorUpgradeRollback restores the data directory of the previous
+// major version from the volume snapshot backup taken before the latest
+// in-place major upgrade, as requested through the rollback annotation.
+//
+// The process consists of the following steps:
+//
+//  1. Delete all Pods and Jobs in the cluster.
+//  2. Delete all the PVCs in the cluster.
+//  3. Recreate the PVCs of the former primary from the snapshots.
+//  4. Restore the image information of the previous major version in the
+//     status, and remove the rollback annotation.
+//
+// The former primary is then started by the usual instance reconciliation,
+// and the replicas are recreated from it.
+func reconcileMajorUpgradeRollback(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	cluster *apiv1.Cluster,
+	instances []corev1.Pod,
+	pvcs []corev1.PersistentVolumeClaim,
+	jobs []batchv1.Job,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	backupName := cluster.Annotations[utils.MajorUpgradeRollbackAnnotationName]
+	rollbackPoint := cluster.Status.MajorUpgradeRollbackPoint
+	if rollbackPoint == nil {
+		// The rollback has already been completed
+		contextLogger.Info("No major upgrade rollback point found, removing the rollback annotation",
+			"backupName", backupName)
+		return &ctrl.Result{Requeue: true}, removeRollbackAnnotation(ctx, c, cluster)
+	}
+	if !cluster.IsMajorUpgradeRollbackPoint(backupName) {
+		recorder.Eventf(cluster, "Warning", "MajorUpgradeRollback",
+			"Ignoring the rollback request: %s is not the pre-upgrade backup %s",
+			backupName, rollbackPoint.BackupName)
+		return &ctrl.Result{Requeue: true}, removeRollbackAnnotation(ctx, c, cluster)
+	}
+
+	var backup apiv1.Backup
+	if err := c.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: rollbackPoint.BackupName},
+		&backup,
+	); err != nil {
+		return nil, fmt.Errorf("while getting the pre-upgrade backup %s: %w", rollbackPoint.BackupName, err)
+	}
+
+	serial, err := getRollbackPointSerial(cluster, &backup)
+	if err != nil {
+		if regErr := registerPhase(
+			ctx,
+			c,
+			cluster,
+			apiv1.PhaseMajorUpgradeRollback,
+			fmt.Sprintf("Cannot roll back the major upgrade from backup %s: %v", backup.Name, err),
+		); regErr != nil {
+			contextLogger.Error(regErr, "Unable to register phase after rollback failure")
+		}
+		return nil, err
+	}
+
+	if cluster.Status.Phase != apiv1.PhaseMajorUpgradeRollback {
+		if err := registerPhase(
+			ctx,
+			c,
+			cluster,
+			apiv1.PhaseMajorUpgradeRollback,
+			fmt.Sprintf("Restoring major version %d from backup %s",
+				rollbackPoint.PGDataImageInfo.MajorVersion, backup.Name),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if result, err := deleteAllPodsInMajorUpgradePreparation(ctx, c, instances, jobs); err != nil {
+		contextLogger.Error(err, "Unable to delete pods and jobs in preparation for major upgrade rollback")
+		return nil, err
+	} else if result != nil {
+		return result, err
+	}
+
+	if result, err := deletePVCsNotRestoredFromBackup(ctx, c, pvcs, &backup); err != nil {
+		contextLogger.Error(err, "Unable to delete PVCs in preparation for major upgrade rollback")
+		return nil, err
+	} else if result != nil {
+		return result, err
+	}
+
+	if err := persistentvolumeclaim.RestoreInstancePVCs(
+		ctx,
+		c,
+		cluster,
+		persistentvolumeclaim.GetCandidateStorageSourceForPrimary(cluster, &backup),
+		serial,
+	); err != nil {
+		return nil, fmt.Errorf("cannot restore the PVCs from backup %s: %w", backup.Name, err)
+	}
+
+	instanceName := backup.Status.InstanceID.PodName
+	if err := status.PatchWithOptimisticLock(
+		ctx,
+		c,
+		cluster,
+		status.SetPGDataImageInfo(rollbackPoint.PGDataImageInfo.DeepCopy()),
+		status.SetImage(rollbackPoint.PGDataImageInfo.Image),
+		status.SetTargetPGDataImageInfo(nil),
+		status.SetMajorUpgradeRollbackPoint(nil),
+		status.SetMajorUpgradeFinalization(nil),
+		removeUpgradeFinalizingCondition,
+		func(cluster *apiv1.Cluster) {
+			cluster.Status.TargetPrimary = instanceName
+			cluster.Status.CurrentPrimary = instanceName
+		},
+	); err != nil {
+		contextLogger.Error(err, "Unable to update cluster status after major upgrade rollback")
+		return nil, err
+	}
+
+	if err := removeRollbackAnnotation(ctx, c, cluster); err != nil {
+		return nil, err
+	}
+
+	recorder.Eventf(cluster, "Normal", "MajorUpgradeRollback",
+		"Restored major version %d from backup %s",
+		rollbackPoint.PGDataImageInfo.MajorVersion, backup.Name)
+
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+// getRollbackPointSerial checks that the passed backup can be restored,
+// and returns the serial of the instance it has been taken from
+func getRollbackPointSerial(cluster *apiv1.Cluster, backup *apiv1.Backup) (int, error) {
+	if !backup.IsCompletedVolumeSnapshot() {
+		return 0, fmt.Errorf("%w: not a completed volume snapshot backup", ErrRollbackPointNotUsable)
+	}
+
+	if backup.Status.InstanceID == nil {
+		return 0, fmt.Errorf("%w: missing source instance", ErrRollbackPointNotUsable)
+	}
+
+	serial, err := strconv.Atoi(strings.TrimPrefix(backup.Status.InstanceID.PodName, cluster.Name+"-"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: unexpected source instance %q",
+			ErrRollbackPointNotUsable, backup.Status.InstanceID.PodName)
+	}
+
+	return serial, nil
+}
+
+// deletePVCsNotRestoredFromBackup deletes every PVC that has not been
+// created from the snapshots of the passed backup, waiting for them
+// to be removed
+func deletePVCsNotRestoredFromBackup(
+	ctx context.Context,
+	c client.Client,
+	pvcs []corev1.PersistentVolumeClaim,
+	backup *apiv1.Backup,
+) (*ctrl.Result, error) {
+	snapshotNames := stringset.New()
+	for _, element := range backup.Status.BackupSnapshotStatus.Elements {
+		snapshotNames.Put(element.Name)
+	}
+
+	isRestored := func(pvc *corev1.PersistentVolumeClaim) bool {
+		source := pvc.Spec.DataSource
+		return source != nil &&
+			source.Kind == apiv1.VolumeSnapshotKind &&
+			snapshotNames.Has(source.Name)
+	}
+
+	waitingForDeletion := false
+	for idx := range pvcs {
+		pvc := &pvcs[idx]
+		if isRestored(pvc) {
+			continue
+		}
+
+		waitingForDeletion = true
+		if pvc.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		if err := c.Delete(ctx, pvc); err != nil && !apierrs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if waitingForDeletion {
+		return &ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	return nil, nil
+}
+
+// removeRollbackAnnotation removes the major upgrade rollback request
+// from the cluster
+func removeRollbackAnnotation(ctx context.Context, c client.Client, cluster *apiv1.Cluster) error {
+	if _, ok := cluster.Annotations[utils.MajorUpgradeRollbackAnnotationName]; !ok {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, utils.MajorUpgradeRollbackAnnotationName)
+	return c.Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
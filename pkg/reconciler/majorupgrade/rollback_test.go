@@ -0,0 +1,179 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Major upgrade rollback from the pre-upgrade backup", func() {
+	var (
+		cluster *apiv1.Cluster
+		backup  *apiv1.Backup
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+				Annotations: map[string]string{
+					utils.MajorUpgradeRollbackAnnotationName: "cluster-example-pre-upgrade",
+				},
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16",
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "1Gi",
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				Image: "postgres:17",
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:17",
+					MajorVersion: 17,
+				},
+				CurrentPrimary: "cluster-example-1",
+				TargetPrimary:  "cluster-example-1",
+				MajorUpgradeRollbackPoint: &apiv1.MajorUpgradeRollbackPoint{
+					BackupName: "cluster-example-pre-upgrade",
+					PGDataImageInfo: apiv1.ImageInfo{
+						Image:        "postgres:16",
+						MajorVersion: 16,
+					},
+					TargetMajorVersion: 17,
+				},
+			},
+		}
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example-pre-upgrade",
+				Namespace: "default",
+			},
+			Spec: apiv1.BackupSpec{
+				Method: apiv1.BackupMethodVolumeSnapshot,
+			},
+			Status: apiv1.BackupStatus{
+				Phase: apiv1.BackupPhaseCompleted,
+				InstanceID: &apiv1.InstanceID{
+					PodName: "cluster-example-2",
+				},
+				BackupSnapshotStatus: apiv1.BackupSnapshotStatus{
+					Elements: []apiv1.BackupSnapshotElementStatus{
+						{
+							Name: "cluster-example-pre-upgrade",
+							Type: string(utils.PVCRolePgData),
+						},
+					},
+				},
+			},
+		}
+	})
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			WithStatusSubresource(&apiv1.Cluster{}).
+			Build()
+	}
+
+	It("deletes the PVCs that were not restored from the backup", func(ctx SpecContext) {
+		pvcs := []corev1.PersistentVolumeClaim{
+			buildPrimaryPVC(1),
+			buildReplicaPVC(2),
+		}
+		for idx := range pvcs {
+			pvcs[idx].Namespace = "default"
+		}
+		fakeClient := newClient(cluster, backup, &pvcs[0], &pvcs[1])
+
+		result, err := reconcileMajorUpgradeRollback(
+			ctx, fakeClient, record.NewFakeRecorder(10), cluster, nil, pvcs, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseMajorUpgradeRollback))
+
+		var pvcList corev1.PersistentVolumeClaimList
+		Expect(fakeClient.List(ctx, &pvcList)).To(Succeed())
+		Expect(pvcList.Items).To(BeEmpty())
+	})
+
+	It("restores the PVCs and the previous image once the old PVCs are gone", func(ctx SpecContext) {
+		fakeClient := newClient(cluster, backup)
+
+		result, err := reconcileMajorUpgradeRollback(
+			ctx, fakeClient, record.NewFakeRecorder(10), cluster, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(&ctrl.Result{Requeue: true}))
+
+		var pvc corev1.PersistentVolumeClaim
+		Expect(fakeClient.Get(ctx,
+			client.ObjectKey{Namespace: "default", Name: "cluster-example-2"}, &pvc)).To(Succeed())
+		Expect(pvc.Spec.DataSource).ToNot(BeNil())
+		Expect(pvc.Spec.DataSource.Name).To(Equal("cluster-example-pre-upgrade"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(utils.PVCStatusAnnotationName, "ready"))
+
+		var updatedCluster apiv1.Cluster
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.Image).To(Equal("postgres:16"))
+		Expect(updatedCluster.Status.PGDataImageInfo.MajorVersion).To(Equal(16))
+		Expect(updatedCluster.Status.MajorUpgradeRollbackPoint).To(BeNil())
+		Expect(updatedCluster.Status.CurrentPrimary).To(Equal("cluster-example-2"))
+		Expect(updatedCluster.Status.TargetPrimary).To(Equal("cluster-example-2"))
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.MajorUpgradeRollbackAnnotationName))
+	})
+
+	It("ignores requests for a backup that is not the rollback point", func(ctx SpecContext) {
+		cluster.Annotations[utils.MajorUpgradeRollbackAnnotationName] = "another-backup"
+		fakeClient := newClient(cluster, backup)
+
+		_, err := reconcileMajorUpgradeRollback(
+			ctx, fakeClient, record.NewFakeRecorder(10), cluster, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		var updatedCluster apiv1.Cluster
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.MajorUpgradeRollbackAnnotationName))
+		Expect(updatedCluster.Status.MajorUpgradeRollbackPoint).ToNot(BeNil())
+	})
+
+	It("refuses to use a backup that is not completed", func(ctx SpecContext) {
+		backup.Status.Phase = apiv1.BackupPhaseFailed
+		fakeClient := newClient(cluster, backup)
+
+		_, err := reconcileMajorUpgradeRollback(
+			ctx, fakeClient, record.NewFakeRecorder(10), cluster, nil, nil, nil)
+		Expect(err).To(MatchError(ErrRollbackPointNotUsable))
+	})
+})
@@ -0,0 +1,177 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// ErrRollbackPointFailed is raised when the volume snapshot backup
+// taken before the major upgrade failed
+var ErrRollbackPointFailed = fmt.Errorf("pre-upgrade volume snapshot backup failed")
+
+// isRollbackPointEnabled checks if a volume snapshot backup should be
+// taken before the major upgrade changes the data directory
+func isRollbackPointEnabled(cluster *apiv1.Cluster) bool {
+	return cluster.Spec.Backup != nil && cluster.Spec.Backup.VolumeSnapshot != nil
+}
+
+// isRollbackPointForUpgrade checks if the passed rollback point has been
+// taken for the upgrade of the current data directory to the requested
+// major version
+func isRollbackPointForUpgrade(
+	rollbackPoint *apiv1.MajorUpgradeRollbackPoint,
+	pgDataImageInfo *apiv1.ImageInfo,
+	requestedMajor int,
+) bool {
+	return rollbackPoint != nil &&
+		pgDataImageInfo != nil &&
+		rollbackPoint.TargetMajorVersion == requestedMajor &&
+		rollbackPoint.PGDataImageInfo.MajorVersion == pgDataImageInfo.MajorVersion
+}
+
+// reconcileRollbackPoint makes sure a volume snapshot backup of the primary
+// instance is completed before the major upgrade starts, and records it in
+// the cluster status as the rollback point of the upgrade.
+// A nil result means the upgrade can proceed.
+func reconcileRollbackPoint(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	cluster *apiv1.Cluster,
+	requestedMajor int,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if !isRollbackPointEnabled(cluster) {
+		return nil, nil
+	}
+
+	rollbackPoint := cluster.Status.MajorUpgradeRollbackPoint
+	if !isRollbackPointForUpgrade(rollbackPoint, cluster.Status.PGDataImageInfo, requestedMajor) {
+		// The backup name is stored before creating the Backup, so that
+		// the following reconciliation loops will wait for the same one
+		rollbackPoint = &apiv1.MajorUpgradeRollbackPoint{
+			BackupName: fmt.Sprintf("%s-pre-upgrade-%s",
+				cluster.Name, time.Now().Format("20060102150405")),
+			PGDataImageInfo:    *cluster.Status.PGDataImageInfo.DeepCopy(),
+			TargetMajorVersion: requestedMajor,
+		}
+		if err := status.PatchWithOptimisticLock(
+			ctx,
+			c,
+			cluster,
+			status.SetMajorUpgradeRollbackPoint(rollbackPoint),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var backup apiv1.Backup
+	err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: rollbackPoint.BackupName}, &backup)
+	if apierrs.IsNotFound(err) {
+		return createRollbackPointBackup(ctx, c, recorder, cluster, rollbackPoint.BackupName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch backup.Status.Phase {
+	case apiv1.BackupPhaseCompleted:
+		return nil, nil
+
+	case apiv1.BackupPhaseFailed:
+		if regErr := registerPhase(
+			ctx,
+			c,
+			cluster,
+			apiv1.PhaseMajorUpgrade,
+			fmt.Sprintf("Pre-upgrade volume snapshot backup %s failed: %s. "+
+				"Delete the Backup to retry, or revert the image to cancel the upgrade",
+				backup.Name, backup.Status.Error),
+		); regErr != nil {
+			contextLogger.Error(regErr, "Unable to register phase after pre-upgrade backup failure")
+		}
+		return nil, fmt.Errorf("%w: %s", ErrRollbackPointFailed, backup.Name)
+
+	default:
+		contextLogger.Info("Waiting for the pre-upgrade volume snapshot backup to complete",
+			"backupName", backup.Name,
+			"backupPhase", backup.Status.Phase)
+		return &ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+}
+
+// createRollbackPointBackup creates the Backup used as the rollback point
+// of the major upgrade
+func createRollbackPointBackup(
+	ctx context.Context,
+	c client.Client,
+	recorder record.EventRecorder,
+	cluster *apiv1.Cluster,
+	backupName string,
+) (*ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	backup := &apiv1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupName,
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName: cluster.Name,
+			},
+		},
+		Spec: apiv1.BackupSpec{
+			Cluster: apiv1.LocalObjectReference{Name: cluster.Name},
+			Method:  apiv1.BackupMethodVolumeSnapshot,
+			Target:  apiv1.BackupTargetPrimary,
+			// The instances are going to be shut down by the upgrade anyway:
+			// a cold snapshot of the primary can be restored as is, without
+			// requiring any WAL file
+			Online: ptr.To(false),
+		},
+	}
+
+	contextLogger.Info("Creating the pre-upgrade volume snapshot backup",
+		"backupName", backup.Name)
+
+	if err := c.Create(ctx, backup); err != nil && !apierrs.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	recorder.Eventf(cluster, "Normal", "MajorUpgradeRollbackPoint",
+		"Taking the pre-upgrade volume snapshot backup %s", backup.Name)
+
+	return &ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+}
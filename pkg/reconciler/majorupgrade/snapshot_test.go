@@ -0,0 +1,169 @@
+/*
+Copyright © contributors to CloudNativePG, established as
+CloudNativePG a Series of LF Projects, LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package majorupgrade
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Major upgrade rollback point", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17",
+				Backup: &apiv1.BackupConfiguration{
+					VolumeSnapshot: &apiv1.VolumeSnapshotConfiguration{},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				PGDataImageInfo: &apiv1.ImageInfo{
+					Image:        "postgres:16",
+					MajorVersion: 16,
+				},
+			},
+		}
+	})
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Backup{}).
+			Build()
+	}
+
+	It("does nothing when volume snapshots are not configured", func(ctx SpecContext) {
+		cluster.Spec.Backup = nil
+		fakeClient := newClient(cluster)
+
+		result, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+		Expect(cluster.Status.MajorUpgradeRollbackPoint).To(BeNil())
+	})
+
+	It("records the rollback point and creates a cold volume snapshot backup", func(ctx SpecContext) {
+		fakeClient := newClient(cluster)
+
+		result, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+
+		rollbackPoint := cluster.Status.MajorUpgradeRollbackPoint
+		Expect(rollbackPoint).ToNot(BeNil())
+		Expect(rollbackPoint.TargetMajorVersion).To(Equal(17))
+		Expect(rollbackPoint.PGDataImageInfo.Image).To(Equal("postgres:16"))
+		Expect(rollbackPoint.BackupName).To(HavePrefix("cluster-example-pre-upgrade-"))
+
+		var backup apiv1.Backup
+		Expect(fakeClient.Get(ctx,
+			client.ObjectKey{Namespace: "default", Name: rollbackPoint.BackupName}, &backup)).To(Succeed())
+		Expect(backup.Spec.Method).To(Equal(apiv1.BackupMethodVolumeSnapshot))
+		Expect(backup.Spec.Cluster.Name).To(Equal("cluster-example"))
+		Expect(backup.Spec.Online).To(HaveValue(BeFalse()))
+	})
+
+	It("waits for the backup to complete", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeRollbackPoint = &apiv1.MajorUpgradeRollbackPoint{
+			BackupName:         "cluster-example-pre-upgrade",
+			PGDataImageInfo:    *cluster.Status.PGDataImageInfo,
+			TargetMajorVersion: 17,
+		}
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-pre-upgrade", Namespace: "default"},
+			Status:     apiv1.BackupStatus{Phase: apiv1.BackupPhaseRunning},
+		}
+		fakeClient := newClient(cluster, backup)
+
+		result, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+		Expect(cluster.Status.MajorUpgradeRollbackPoint.BackupName).To(Equal("cluster-example-pre-upgrade"))
+	})
+
+	It("lets the upgrade proceed when the backup is completed", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeRollbackPoint = &apiv1.MajorUpgradeRollbackPoint{
+			BackupName:         "cluster-example-pre-upgrade",
+			PGDataImageInfo:    *cluster.Status.PGDataImageInfo,
+			TargetMajorVersion: 17,
+		}
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-pre-upgrade", Namespace: "default"},
+			Status:     apiv1.BackupStatus{Phase: apiv1.BackupPhaseCompleted},
+		}
+		fakeClient := newClient(cluster, backup)
+
+		result, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("stops the upgrade when the backup failed", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeRollbackPoint = &apiv1.MajorUpgradeRollbackPoint{
+			BackupName:         "cluster-example-pre-upgrade",
+			PGDataImageInfo:    *cluster.Status.PGDataImageInfo,
+			TargetMajorVersion: 17,
+		}
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example-pre-upgrade", Namespace: "default"},
+			Status:     apiv1.BackupStatus{Phase: apiv1.BackupPhaseFailed, Error: "snapshot class not found"},
+		}
+		fakeClient := newClient(cluster, backup)
+
+		_, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).To(MatchError(ErrRollbackPointFailed))
+		Expect(cluster.Status.PhaseReason).To(ContainSubstring("snapshot class not found"))
+	})
+
+	It("replaces a rollback point taken for a different upgrade", func(ctx SpecContext) {
+		cluster.Status.MajorUpgradeRollbackPoint = &apiv1.MajorUpgradeRollbackPoint{
+			BackupName: "cluster-example-old",
+			PGDataImageInfo: apiv1.ImageInfo{
+				Image:        "postgres:15",
+				MajorVersion: 15,
+			},
+			TargetMajorVersion: 16,
+		}
+		fakeClient := newClient(cluster)
+
+		_, err := reconcileRollbackPoint(ctx, fakeClient, record.NewFakeRecorder(10), cluster, 17)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status.MajorUpgradeRollbackPoint.BackupName).ToNot(Equal("cluster-example-old"))
+		Expect(cluster.Status.MajorUpgradeRollbackPoint.PGDataImageInfo.MajorVersion).To(Equal(16))
+	})
+})
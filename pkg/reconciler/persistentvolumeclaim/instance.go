@@ -44,6 +44,40 @@ func CreateInstancePVCs(
 	return err
 }
 
+// RestoreInstancePVCs creates the expected pvcs for the instance from the
+// passed storage source, marking them as ready to be attached to a Pod.
+// No bootstrap Job will be run on these PVCs, so the source must contain
+// a data directory that can be started as is, such as a cold snapshot.
+func RestoreInstancePVCs(
+	ctx context.Context,
+	c client.Client,
+	cluster *apiv1.Cluster,
+	source *StorageSource,
+	serial int,
+) error {
+	instanceName := specs.GetInstanceName(cluster.Name, serial)
+	for _, expectedPVC := range getExpectedPVCsFromCluster(cluster, instanceName) {
+		conf, err := expectedPVC.calculator.GetStorageConfiguration(cluster)
+		if err != nil {
+			return err
+		}
+
+		pvcSource, err := expectedPVC.calculator.GetSource(source)
+		if err != nil {
+			return err
+		}
+
+		createConfiguration := expectedPVC.toCreateConfiguration(serial, conf, pvcSource)
+		createConfiguration.Status = StatusReady
+
+		if err := createIfNotExists(ctx, c, cluster, createConfiguration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // reconcileMultipleInstancesMissingPVCs evaluate multiple instances that may miss some PVCs.
 // It will work on the first instance where the PVCs should be reconciled, leaving the next
 // ones for the other reconciliation loops.
@@ -288,13 +288,21 @@ func storageSourceExistsInNamespace(
 // from a Cluster definition, taking into consideration the backup that the
 // cluster has been bootstrapped from
 func getCandidateSourceFromClusterDefinition(cluster *apiv1.Cluster) *StorageSource {
-	if cluster.Spec.Bootstrap == nil ||
-		cluster.Spec.Bootstrap.Recovery == nil ||
-		cluster.Spec.Bootstrap.Recovery.VolumeSnapshots == nil {
+	if cluster.Spec.Bootstrap == nil {
+		return nil
+	}
+
+	var volumeSnapshots *apiv1.DataSource
+	switch {
+	case cluster.Spec.Bootstrap.Recovery != nil:
+		volumeSnapshots = cluster.Spec.Bootstrap.Recovery.VolumeSnapshots
+	case cluster.IsCloningViaVolumeSnapshot():
+		volumeSnapshots = cluster.Spec.Bootstrap.Clone.VolumeSnapshots
+	}
+	if volumeSnapshots == nil {
 		return nil
 	}
 
-	volumeSnapshots := cluster.Spec.Bootstrap.Recovery.VolumeSnapshots
 	return &StorageSource{
 		DataSource:       volumeSnapshots.Storage,
 		WALSource:        volumeSnapshots.WalStorage,
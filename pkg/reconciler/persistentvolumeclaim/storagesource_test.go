@@ -304,6 +304,36 @@ var _ = Describe("Storage source", func() {
 			Expect(source).To(BeNil())
 		})
 	})
+
+	When("cloning another Cluster from its volume snapshots", func() {
+		It("should return the snapshots as the storage source of the primary", func() {
+			clusterCloning := clusterWithBootstrapSnapshot.DeepCopy()
+			clusterCloning.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+				Clone: &apiv1.BootstrapClone{
+					Cluster:         apiv1.ClusterReference{Name: "source"},
+					Method:          apiv1.CloneMethodVolumeSnapshot,
+					VolumeSnapshots: clusterWithBootstrapSnapshot.Spec.Bootstrap.Recovery.VolumeSnapshots,
+				},
+			}
+
+			source, err := NewPgDataCalculator().GetSource(GetCandidateStorageSourceForPrimary(clusterCloning, nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).ToNot(BeNil())
+			Expect(source.Name).To(Equal(pgDataSnapshotVolumeName))
+		})
+
+		It("should return an empty storage source when cloning via pg_basebackup", func() {
+			clusterCloning := clusterWithBootstrapSnapshot.DeepCopy()
+			clusterCloning.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+				Clone: &apiv1.BootstrapClone{
+					Cluster: apiv1.ClusterReference{Name: "source"},
+					Method:  apiv1.CloneMethodPgBaseBackup,
+				},
+			}
+
+			Expect(GetCandidateStorageSourceForPrimary(clusterCloning, nil)).To(BeNil())
+		})
+	})
 })
 
 var _ = Describe("candidate backups", func() {
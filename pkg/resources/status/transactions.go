@@ -85,9 +85,94 @@ func SetTargetPGDataImageInfo(imageInfo *apiv1.ImageInfo) Transaction {
 	}
 }
 
+// SetMajorUpgradeRollbackPoint is a transaction that sets the
+// MajorUpgradeRollbackPoint, representing the backup taken before
+// the latest in-place major upgrade.
+func SetMajorUpgradeRollbackPoint(rollbackPoint *apiv1.MajorUpgradeRollbackPoint) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.MajorUpgradeRollbackPoint = rollbackPoint
+	}
+}
+
+// SetMajorUpgradeFinalization is a transaction that sets the progress of
+// the maintenance operations executed after an in-place major upgrade.
+func SetMajorUpgradeFinalization(finalization *apiv1.MajorUpgradeFinalizationStatus) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.MajorUpgradeFinalization = finalization
+	}
+}
+
+// SetRefresh is a transaction that sets the details of the refresh of
+// the data of the cluster in progress
+func SetRefresh(refresh *apiv1.ClusterRefreshRequest) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.Refresh = refresh
+	}
+}
+
+// SetConfigurationProfile is a transaction that sets the version of the
+// configuration profile applied to the cluster
+func SetConfigurationProfile(profile *apiv1.AppliedConfigurationProfile) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.ConfigurationProfile = profile
+	}
+}
+
+// SetHBA is a transaction that sets the host based authentication
+// status of the cluster
+func SetHBA(hba *apiv1.HBAStatus) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		cluster.Status.HBA = hba
+	}
+}
+
+// SetPasswordRotation is a transaction that sets the password rotation
+// state of the managed roles, removing it from the roles not in the map
+func SetPasswordRotation(rotation map[string]*apiv1.PasswordRotationState) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		passwordStatus := cluster.Status.ManagedRolesStatus.PasswordStatus
+		for role, state := range passwordStatus {
+			if _, found := rotation[role]; !found && state.Rotation != nil {
+				state.Rotation = nil
+				passwordStatus[role] = state
+			}
+		}
+
+		for role, rotationState := range rotation {
+			if passwordStatus == nil {
+				passwordStatus = make(map[string]apiv1.PasswordState)
+			}
+			state := passwordStatus[role]
+			state.Rotation = rotationState
+			passwordStatus[role] = state
+		}
+		cluster.Status.ManagedRolesStatus.PasswordStatus = passwordStatus
+	}
+}
+
 // SetTimelineID is a transaction that sets the cluster timeline ID
 func SetTimelineID(timelineID int) Transaction {
 	return func(cluster *apiv1.Cluster) {
 		cluster.Status.TimelineID = timelineID
 	}
 }
+
+// SetBaseBackupProgress is a transaction that sets the progress of the
+// pg_basebackup copying the data directory of an instance. A nil
+// progress removes it
+func SetBaseBackupProgress(instanceName apiv1.PodName, progress *apiv1.BaseBackupProgress) Transaction {
+	return func(cluster *apiv1.Cluster) {
+		if progress == nil {
+			delete(cluster.Status.BaseBackupProgress, instanceName)
+			if len(cluster.Status.BaseBackupProgress) == 0 {
+				cluster.Status.BaseBackupProgress = nil
+			}
+			return
+		}
+
+		if cluster.Status.BaseBackupProgress == nil {
+			cluster.Status.BaseBackupProgress = make(map[apiv1.PodName]apiv1.BaseBackupProgress)
+		}
+		cluster.Status.BaseBackupProgress[instanceName] = *progress
+	}
+}
@@ -159,4 +159,35 @@ var _ = Describe("Status transactions", func() {
 			Expect(cluster.Status.TimelineID).To(Equal(10))
 		})
 	})
+
+	Describe("SetBaseBackupProgress", func() {
+		It("sets the progress of an instance", func() {
+			cluster := &apiv1.Cluster{}
+
+			SetBaseBackupProgress("cluster-example-2", &apiv1.BaseBackupProgress{
+				Phase:          "streaming database files",
+				BackupStreamed: 1024,
+			})(cluster)
+
+			Expect(cluster.Status.BaseBackupProgress).To(HaveKey(apiv1.PodName("cluster-example-2")))
+			Expect(cluster.Status.BaseBackupProgress["cluster-example-2"].BackupStreamed).To(BeEquivalentTo(1024))
+		})
+
+		It("removes the progress of an instance when passed nil", func() {
+			cluster := &apiv1.Cluster{
+				Status: apiv1.ClusterStatus{
+					BaseBackupProgress: map[apiv1.PodName]apiv1.BaseBackupProgress{
+						"cluster-example-2": {Phase: "streaming database files"},
+						"cluster-example-3": {Phase: "waiting for checkpoint to finish"},
+					},
+				},
+			}
+
+			SetBaseBackupProgress("cluster-example-2", nil)(cluster)
+			Expect(cluster.Status.BaseBackupProgress).To(HaveLen(1))
+
+			SetBaseBackupProgress("cluster-example-3", nil)(cluster)
+			Expect(cluster.Status.BaseBackupProgress).To(BeNil())
+		})
+	})
 })
@@ -42,6 +42,11 @@ const (
 	postInitApplicationSQLRefsFolder postInitFolder = "/etc/post-init-application-sql"
 	postInitTemplateQLRefsFolder     postInitFolder = "/etc/post-init-template-sql"
 	postInitSQLRefsFolder            postInitFolder = "/etc/post-init-sql"
+
+	// postRecoverySQLRefsFolder contains a folder for each entry of
+	// the post recovery SQL refs, in the primary job with recovery
+	// or pg_basebackup.
+	postRecoverySQLRefsFolder postInitFolder = "/etc/post-recovery-sql"
 )
 
 func (p postInitFolder) toString() string {
@@ -197,10 +202,12 @@ func CreatePrimaryJobViaRestoreSnapshot(
 	}
 
 	initCommand = append(initCommand, buildCommonInitJobFlags(cluster)...)
+	initCommand = append(initCommand, buildPostRecoverySQLFlags(cluster)...)
 
 	job := CreatePrimaryJob(cluster, nodeSerial, jobRoleSnapshotRecovery, initCommand, getExtensions(&cluster))
 
 	addBarmanEndpointCAToJobFromCluster(cluster, backup, job)
+	addPostRecoverySQLRefsToJob(cluster, job)
 
 	return job
 }
@@ -216,15 +223,21 @@ func CreatePrimaryJobViaRecovery(cluster apiv1.Cluster, nodeSerial int, backup *
 	)
 
 	initCommand = append(initCommand, commonFlags...)
+	initCommand = append(initCommand, buildPostRecoverySQLFlags(cluster)...)
 
 	job := CreatePrimaryJob(cluster, nodeSerial, jobRoleFullRecovery, initCommand, getExtensions(&cluster))
 
 	addBarmanEndpointCAToJobFromCluster(cluster, backup, job)
+	addPostRecoverySQLRefsToJob(cluster, job)
 
 	return job
 }
 
 func addBarmanEndpointCAToJobFromCluster(cluster apiv1.Cluster, backup *apiv1.Backup, job *batchv1.Job) {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Recovery == nil {
+		return
+	}
+
 	var credentials apiv1.BarmanCredentials
 	var endpointCA *apiv1.SecretKeySelector
 	switch {
@@ -251,27 +264,34 @@ func addBarmanEndpointCAToJobFromCluster(cluster apiv1.Cluster, backup *apiv1.Ba
 // CreatePrimaryJobViaPgBaseBackup creates a new primary instance in a Pod
 func CreatePrimaryJobViaPgBaseBackup(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job {
 	commonFlags := buildCommonInitJobFlags(cluster)
-	initCommand := make([]string, 0, 3+len(commonFlags))
+	initCommand := make([]string, 0, 5+len(commonFlags))
 	initCommand = append(initCommand,
 		"/controller/manager",
 		"instance",
 		"pgbasebackup",
+		"--instance-name", GetInstanceName(cluster.Name, nodeSerial),
 	)
 
 	initCommand = append(initCommand, commonFlags...)
+	initCommand = append(initCommand, buildPostRecoverySQLFlags(cluster)...)
 
-	return CreatePrimaryJob(cluster, nodeSerial, jobRolePGBaseBackup, initCommand, getExtensions(&cluster))
+	job := CreatePrimaryJob(cluster, nodeSerial, jobRolePGBaseBackup, initCommand, getExtensions(&cluster))
+
+	addPostRecoverySQLRefsToJob(cluster, job)
+
+	return job
 }
 
 // JoinReplicaInstance create a new PostgreSQL node, copying the contents from another Pod
 func JoinReplicaInstance(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job {
 	commonFlags := buildCommonInitJobFlags(cluster)
-	initCommand := make([]string, 0, 5+len(commonFlags))
+	initCommand := make([]string, 0, 7+len(commonFlags))
 	initCommand = append(initCommand,
 		"/controller/manager",
 		"instance",
 		"join",
 		"--parent-node", cluster.GetServiceReadWriteName(),
+		"--instance-name", GetInstanceName(cluster.Name, nodeSerial),
 	)
 
 	initCommand = append(initCommand, commonFlags...)
@@ -296,6 +316,30 @@ func RestoreReplicaInstance(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job
 	return job
 }
 
+// buildPostRecoverySQLFlags builds the flags pointing the bootstrap job
+// to the SQL files to be executed after the promotion
+func buildPostRecoverySQLFlags(cluster apiv1.Cluster) []string {
+	if _, refs := cluster.GetPostRecoverySQL(); len(refs) == 0 || !cluster.ShouldRunPostRecoverySQL() {
+		return nil
+	}
+
+	return []string{"--post-recovery-sql-refs-folder", postRecoverySQLRefsFolder.toString()}
+}
+
+// addPostRecoverySQLRefsToJob mounts the SQL files to be executed after
+// the promotion in the bootstrap job
+func addPostRecoverySQLRefsToJob(cluster apiv1.Cluster, job *batchv1.Job) {
+	if !cluster.ShouldRunPostRecoverySQL() {
+		return
+	}
+
+	_, refs := cluster.GetPostRecoverySQL()
+	volumes, volumeMounts := createVolumesAndVolumeMountsForPostRecoverySQLRefs(refs)
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, volumes...)
+	job.Spec.Template.Spec.Containers[0].VolumeMounts = append(
+		job.Spec.Template.Spec.Containers[0].VolumeMounts, volumeMounts...)
+}
+
 func buildCommonInitJobFlags(cluster apiv1.Cluster) []string {
 	var flags []string
 
@@ -141,6 +141,83 @@ var _ = Describe("Barman endpoint CA", func() {
 	})
 })
 
+var _ = Describe("Job created via pg_basebackup", func() {
+	It("contains the post-recovery SQL refs", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					PgBaseBackup: &apiv1.BootstrapPgBaseBackup{
+						Source: "origin",
+						PostRecoverySQLRefs: []apiv1.PostRecoverySQLRefs{
+							{
+								SQLRefs: apiv1.SQLRefs{
+									SecretRefs: []apiv1.SecretKeySelector{
+										{LocalObjectReference: apiv1.LocalObjectReference{Name: "secret1"}, Key: "key1"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		job := CreatePrimaryJobViaPgBaseBackup(cluster, 1)
+		Expect(job.Spec.Template.Spec.Containers[0].Command).Should(ContainElement(
+			postRecoverySQLRefsFolder.toString()))
+		Expect(job.Spec.Template.Spec.Volumes).Should(ContainElement(
+			HaveField("Name", "0-post-recovery-0-sql")))
+		Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).Should(ContainElement(
+			HaveField("MountPath", postRecoverySQLRefsFolder.toString()+"/0/0.sql")))
+	})
+
+	It("doesn't contain the post-recovery SQL refs folder if not needed", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					PgBaseBackup: &apiv1.BootstrapPgBaseBackup{
+						Source: "origin",
+					},
+				},
+			},
+		}
+		job := CreatePrimaryJobViaPgBaseBackup(cluster, 1)
+		Expect(job.Spec.Template.Spec.Containers[0].Command).ShouldNot(ContainElement(
+			postRecoverySQLRefsFolder.toString()))
+	})
+
+	It("passes the name of the instance being created", func() {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					PgBaseBackup: &apiv1.BootstrapPgBaseBackup{
+						Source: "origin",
+					},
+				},
+			},
+		}
+		job := CreatePrimaryJobViaPgBaseBackup(cluster, 1)
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(ContainElements(
+			"--instance-name", "cluster-example-1"))
+	})
+})
+
+var _ = Describe("Job joining a replica", func() {
+	It("passes the name of the instance being created", func() {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+		}
+		job := JoinReplicaInstance(cluster, 3)
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(ContainElements(
+			"--parent-node", "cluster-example-rw",
+			"--instance-name", "cluster-example-3"))
+	})
+})
+
 var _ = Describe("Job created via InitDB", func() {
 	It("contain cluster post-init SQL instructions", func() {
 		cluster := apiv1.Cluster{
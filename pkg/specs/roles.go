@@ -45,6 +45,11 @@ type RoleOptions struct {
 	// the instance manager permissions to read the secrets that
 	// contain the roles' password.
 	Roles []apiv1.DatabaseRole
+
+	// Databases is the list of PostgreSQL databases. It is used to grant
+	// the instance manager permissions to read the secrets that contain
+	// the passwords of the user mappings of their foreign servers.
+	Databases []apiv1.Database
 }
 
 // CreateRole create a role with the permissions needed by the instance manager
@@ -307,6 +312,62 @@ func CreateRole(opts RoleOptions) rbacv1.Role {
 				"update",
 			},
 		},
+		{
+			APIGroups: []string{
+				apiv1.SchemeGroupVersion.Group,
+			},
+			Resources: []string{
+				"replicationslots",
+			},
+			Verbs: []string{
+				"get",
+				"update",
+				"list",
+				"watch",
+			},
+			ResourceNames: []string{},
+		},
+		{
+			APIGroups: []string{
+				apiv1.SchemeGroupVersion.Group,
+			},
+			Resources: []string{
+				"replicationslots/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
+		{
+			APIGroups: []string{
+				"postgresql.cnpg.io",
+			},
+			Resources: []string{
+				"breakglassaccesses",
+			},
+			Verbs: []string{
+				"get",
+				"update",
+				"list",
+				"watch",
+			},
+			ResourceNames: []string{},
+		},
+		{
+			APIGroups: []string{
+				"postgresql.cnpg.io",
+			},
+			Resources: []string{
+				"breakglassaccesses/status",
+			},
+			Verbs: []string{
+				"get",
+				"patch",
+				"update",
+			},
+		},
 	}
 
 	return rbacv1.Role{
@@ -332,16 +393,17 @@ func getInvolvedSecretNames(opts RoleOptions) []string {
 		opts.Cluster.GetLDAPSecretName(),
 	}
 
-	if opts.Cluster.Spec.Monitoring != nil {
-		for _, secretName := range opts.Cluster.Spec.Monitoring.CustomQueriesSecret {
-			involvedSecretNames = append(involvedSecretNames, secretName.Name)
-		}
+	for _, secretName := range opts.Cluster.GetCustomQueriesSecrets() {
+		involvedSecretNames = append(involvedSecretNames, secretName.Name)
 	}
 
+	involvedSecretNames = append(involvedSecretNames, opts.Cluster.GetParametersFromSecrets().ToList()...)
 	involvedSecretNames = append(involvedSecretNames, backupSecrets(opts.Cluster, opts.BackupOrigin)...)
 	involvedSecretNames = append(involvedSecretNames, externalClusterSecrets(opts.Cluster)...)
+	involvedSecretNames = append(involvedSecretNames, cloneSourceSecrets(opts.Cluster)...)
 	involvedSecretNames = append(involvedSecretNames, managedRolesSecrets(opts.Cluster)...)
 	involvedSecretNames = append(involvedSecretNames, customResourceRolesSecrets(opts.Roles)...)
+	involvedSecretNames = append(involvedSecretNames, databaseUserMappingsSecrets(opts.Databases)...)
 
 	return cleanupResourceList(involvedSecretNames)
 }
@@ -351,14 +413,16 @@ func getInvolvedConfigMapNames(cluster *apiv1.Cluster) []string {
 		cluster.Name,
 	}
 
-	if cluster.Spec.Monitoring != nil {
-		// If custom queries are used, the instance manager need privileges to read those
-		// entries
-		for _, configMapName := range cluster.Spec.Monitoring.CustomQueriesConfigMap {
-			involvedConfigMapNames = append(involvedConfigMapNames, configMapName.Name)
-		}
+	// If custom queries are used, the instance manager need privileges to read those
+	// entries
+	for _, configMapName := range cluster.GetCustomQueriesConfigMaps() {
+		involvedConfigMapNames = append(involvedConfigMapNames, configMapName.Name)
 	}
 
+	// The instance manager reads the values of the configuration
+	// options passed via ConfigMaps
+	involvedConfigMapNames = append(involvedConfigMapNames, cluster.GetParametersFromConfigMaps().ToList()...)
+
 	return cleanupResourceList(involvedConfigMapNames)
 }
 
@@ -410,6 +474,14 @@ func externalClusterSecrets(cluster *apiv1.Cluster) []string {
 	return result
 }
 
+func cloneSourceSecrets(cluster *apiv1.Cluster) []string {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Clone == nil {
+		return nil
+	}
+
+	return []string{cluster.GetCloneSourceSecretName()}
+}
+
 func backupSecrets(cluster *apiv1.Cluster, backupOrigin *apiv1.Backup) []string {
 	var result []string
 
@@ -524,13 +596,10 @@ func managedRolesSecrets(cluster *apiv1.Cluster) []string {
 	}
 	secretNames := make([]string, 0, len(managedRoles))
 	for _, role := range managedRoles {
-		if role.DisablePassword || role.PasswordSecret == nil {
+		if role.DisablePassword {
 			continue
 		}
-		secretName := role.PasswordSecret.Name
-		if secretName != "" {
-			secretNames = append(secretNames, secretName)
-		}
+		secretNames = append(secretNames, role.GetPasswordSecretNames()...)
 	}
 
 	return secretNames
@@ -540,17 +609,24 @@ func customResourceRolesSecrets(roles []apiv1.DatabaseRole) []string {
 	result := make([]string, 0, len(roles))
 
 	for i := range roles {
-		if secretName := crdRoleSecretName(&roles[i]); secretName != "" {
-			result = append(result, secretName)
-		}
+		result = append(result, crdRoleSecretNames(&roles[i])...)
 	}
 
 	return result
 }
 
-func crdRoleSecretName(role *apiv1.DatabaseRole) string {
-	if role.Spec.DisablePassword || role.Spec.PasswordSecret == nil {
-		return ""
+func crdRoleSecretNames(role *apiv1.DatabaseRole) []string {
+	if role.Spec.DisablePassword {
+		return nil
+	}
+	return role.Spec.GetPasswordSecretNames()
+}
+
+func databaseUserMappingsSecrets(databases []apiv1.Database) []string {
+	var result []string
+	for i := range databases {
+		result = append(result, databases[i].GetUserMappingSecretNames()...)
 	}
-	return role.Spec.GetRoleSecretName()
+
+	return result
 }
@@ -168,7 +168,7 @@ var _ = Describe("Roles", func() {
 		serviceAccount := CreateRole(RoleOptions{Cluster: cluster})
 		Expect(serviceAccount.Name).To(Equal(cluster.Name))
 		Expect(serviceAccount.Namespace).To(Equal(cluster.Namespace))
-		Expect(serviceAccount.Rules).To(HaveLen(18))
+		Expect(serviceAccount.Rules).To(HaveLen(22))
 	})
 
 	It("should contain every secret of the origin backup and backup configuration of every external cluster", func() {
@@ -302,6 +302,27 @@ var _ = Describe("Secrets", func() {
 			"thisTest-superuser",
 		}))
 	})
+
+	It("should contain the secrets and config maps holding configuration parameters", func() {
+		cluster.Spec.PostgresConfiguration.ParametersFrom = []apiv1.ParameterSource{
+			{
+				Name: "pgaudit.role",
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "audit-secret"},
+					Key:                  "role",
+				},
+			},
+			{
+				Name: "work_mem",
+				ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "tuning"},
+					Key:                  "work_mem",
+				},
+			},
+		}
+		Expect(getInvolvedSecretNames(RoleOptions{Cluster: cluster})).To(ContainElement("audit-secret"))
+		Expect(getInvolvedConfigMapNames(cluster)).To(ContainElement("tuning"))
+	})
 })
 
 var _ = Describe("Database Roles", func() {
@@ -378,7 +399,7 @@ var _ = Describe("Database Roles", func() {
 	})
 })
 
-var _ = Describe("CRD database role secret name", func() {
+var _ = Describe("CRD database role secret names", func() {
 	It("should be empty when password is disabled", func() {
 		role := apiv1.DatabaseRole{
 			Spec: apiv1.DatabaseRoleSpec{
@@ -388,14 +409,14 @@ var _ = Describe("CRD database role secret name", func() {
 				},
 			},
 		}
-		secrets := crdRoleSecretName(&role)
+		secrets := crdRoleSecretNames(&role)
 		Expect(secrets).To(BeEmpty())
 	})
 	It("should be empty when password secret is nil", func() {
 		role := apiv1.DatabaseRole{
 			Spec: apiv1.DatabaseRoleSpec{},
 		}
-		secrets := crdRoleSecretName(&role)
+		secrets := crdRoleSecretNames(&role)
 		Expect(secrets).To(BeEmpty())
 	})
 	It("should be empty when password secret name is empty", func() {
@@ -406,7 +427,7 @@ var _ = Describe("CRD database role secret name", func() {
 				},
 			},
 		}
-		secrets := crdRoleSecretName(&role)
+		secrets := crdRoleSecretNames(&role)
 		Expect(secrets).To(BeEmpty())
 	})
 	It("should work properly when the password secret name is set", func() {
@@ -419,7 +440,49 @@ var _ = Describe("CRD database role secret name", func() {
 				},
 			},
 		}
-		secrets := crdRoleSecretName(&role)
-		Expect(secrets).To(BeIdenticalTo("secret-name"))
+		secrets := crdRoleSecretNames(&role)
+		Expect(secrets).To(Equal([]string{"secret-name"}))
+	})
+	It("should include the alternate password secret of the password rotation", func() {
+		role := apiv1.DatabaseRole{
+			Spec: apiv1.DatabaseRoleSpec{
+				RoleConfiguration: apiv1.RoleConfiguration{
+					PasswordSecret: &apiv1.LocalObjectReference{
+						Name: "secret-name",
+					},
+					PasswordRotation: &apiv1.PasswordRotationConfiguration{
+						Strategy: apiv1.PasswordRotationStrategyAlternateSecrets,
+						AlternatePasswordSecret: &apiv1.LocalObjectReference{
+							Name: "secret-name-next",
+						},
+					},
+				},
+			},
+		}
+		secrets := crdRoleSecretNames(&role)
+		Expect(secrets).To(Equal([]string{"secret-name", "secret-name-next"}))
+	})
+})
+
+var _ = Describe("Database user mapping secret names", func() {
+	It("should include the password secrets of the user mappings", func() {
+		password := func(name string) *apiv1.SecretKeySelector {
+			return &apiv1.SecretKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: name},
+				Key:                  "password",
+			}
+		}
+		databases := []apiv1.Database{{
+			Spec: apiv1.DatabaseSpec{
+				Servers: []apiv1.ServerSpec{{
+					UserMappings: []apiv1.UserMappingSpec{
+						{Role: "app", PasswordSecret: password("remote-app")},
+						{Role: "PUBLIC"},
+						{Role: "reports", PasswordSecret: password("remote-app")},
+					},
+				}},
+			},
+		}}
+		Expect(databaseUserMappingsSecrets(databases)).To(Equal([]string{"remote-app"}))
 	})
 })
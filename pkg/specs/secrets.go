@@ -97,6 +97,97 @@ func CreateSecret(
 	}
 }
 
+// ServiceBindingType is the type of the service binding Secrets, as defined
+// by the Kubernetes Service Binding specification
+const ServiceBindingType = "postgresql"
+
+// ServiceBindingHosts are the hosts exposed by a service binding Secret.
+// Empty hosts are omitted
+type ServiceBindingHosts struct {
+	// ReadWrite is the service pointing to the primary
+	ReadWrite string
+
+	// ReadOnly is the service pointing to the replicas
+	ReadOnly string
+
+	// PoolerReadWrite is the pooler pointing to the primary
+	PoolerReadWrite string
+
+	// PoolerReadOnly is the pooler pointing to the replicas
+	PoolerReadOnly string
+}
+
+// CreateServiceBindingSecret creates a Secret with the material needed to
+// connect to a database as a role, following the layout of the Kubernetes
+// Service Binding specification. The password and the certificates are
+// included only when set
+func CreateServiceBindingSecret(
+	name string,
+	namespace string,
+	hosts ServiceBindingHosts,
+	dbname string,
+	username string,
+	password string,
+	certificates map[string][]byte,
+) *corev1.Secret {
+	data := map[string][]byte{
+		"type":     []byte(ServiceBindingType),
+		"provider": []byte(utils.ManagerName),
+		"host":     []byte(hosts.ReadWrite),
+		"port":     []byte(fmt.Sprintf("%d", postgres.ServerPort)),
+		"database": []byte(dbname),
+		"dbname":   []byte(dbname),
+		"username": []byte(username),
+		"user":     []byte(username),
+	}
+	if password != "" {
+		data["password"] = []byte(password)
+		data["pgpass"] = []byte(fmt.Sprintf(
+			"%v:%v:%v:%v:%v\n",
+			hosts.ReadWrite,
+			postgres.ServerPort,
+			dbname,
+			username,
+			password))
+	}
+
+	addHost := func(prefix, hostname string) {
+		if hostname == "" {
+			return
+		}
+		builder := connectionStringBuilder{
+			host:     fmt.Sprintf("%s.%s:%d", hostname, namespace, postgres.ServerPort),
+			dbname:   dbname,
+			username: username,
+			password: password,
+		}
+		data[prefix+"host"] = []byte(hostname)
+		data[prefix+"uri"] = []byte(builder.buildPostgres())
+		data[prefix+"jdbc-uri"] = []byte(builder.buildJdbc())
+	}
+	addHost("", hosts.ReadWrite)
+	addHost("ro-", hosts.ReadOnly)
+	addHost("pooler-", hosts.PoolerReadWrite)
+	addHost("pooler-ro-", hosts.PoolerReadOnly)
+
+	for key, value := range certificates {
+		data[key] = value
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.WatchedLabelName:                "true",
+				utils.KubernetesAppManagedByLabelName: utils.ManagerName,
+			},
+		},
+		Type: corev1.SecretType("servicebinding.io/" + ServiceBindingType),
+		Data: data,
+	}
+}
+
 type connectionStringBuilder struct {
 	host     string
 	dbname   string
@@ -111,6 +202,9 @@ func (c connectionStringBuilder) buildPostgres() string {
 		Host:   c.host,
 		Path:   c.dbname,
 	}
+	if c.password == "" {
+		postgresURI.User = url.User(c.username)
+	}
 
 	return postgresURI.String()
 }
@@ -123,7 +217,9 @@ func (c connectionStringBuilder) buildJdbc() string {
 	}
 	q := jdbcURI.Query()
 	q.Set("user", c.username)
-	q.Set("password", c.password)
+	if c.password != "" {
+		q.Set("password", c.password)
+	}
 	jdbcURI.RawQuery = q.Encode()
 	return jdbcURI.String()
 }
@@ -186,6 +186,38 @@ func createVolumesAndVolumeMountsForSQLRefs(
 		suffix = "post-init"
 	}
 
+	return createVolumesAndVolumeMountsForSQLRefsWithSuffix(folder.toString(), suffix, refs)
+}
+
+// createVolumesAndVolumeMountsForPostRecoverySQLRefs mounts the SQL files
+// of each post-recovery entry in a dedicated folder, named after the index
+// of the entry, so that the instance manager can find the database where
+// they need to be executed
+func createVolumesAndVolumeMountsForPostRecoverySQLRefs(
+	refs []apiv1.PostRecoverySQLRefs,
+) ([]corev1.Volume, []corev1.VolumeMount) {
+	digitsCount := len(fmt.Sprintf("%d", len(refs)))
+
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	for i := range refs {
+		entryVolumes, entryVolumeMounts := createVolumesAndVolumeMountsForSQLRefsWithSuffix(
+			fmt.Sprintf("%s/%0*d", postRecoverySQLRefsFolder, digitsCount, i),
+			fmt.Sprintf("post-recovery-%0*d", digitsCount, i),
+			&refs[i].SQLRefs,
+		)
+		volumes = append(volumes, entryVolumes...)
+		volumeMounts = append(volumeMounts, entryVolumeMounts...)
+	}
+
+	return volumes, volumeMounts
+}
+
+func createVolumesAndVolumeMountsForSQLRefsWithSuffix(
+	folder string,
+	suffix string,
+	refs *apiv1.SQLRefs,
+) ([]corev1.Volume, []corev1.VolumeMount) {
 	length := len(refs.ConfigMapRefs) + len(refs.SecretRefs)
 	digitsCount := len(fmt.Sprintf("%d", length))
 	volumes := make([]corev1.Volume, 0, length)
@@ -300,6 +300,49 @@ var _ = Describe("test createVolumesAndVolumeMountsForSQLRefs", func() {
 	})
 })
 
+var _ = Describe("test createVolumesAndVolumeMountsForPostRecoverySQLRefs", func() {
+	It("mounts each entry in a dedicated folder", func() {
+		refs := []apiv1.PostRecoverySQLRefs{
+			{
+				SQLRefs: apiv1.SQLRefs{
+					SecretRefs: []apiv1.SecretKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "secret1"}, Key: "key1"},
+					},
+				},
+			},
+			{
+				Database: "app",
+				SQLRefs: apiv1.SQLRefs{
+					ConfigMapRefs: []apiv1.ConfigMapKeySelector{
+						{LocalObjectReference: apiv1.LocalObjectReference{Name: "configmap1"}, Key: "key2"},
+					},
+				},
+			},
+		}
+
+		volumes, volumeMounts := createVolumesAndVolumeMountsForPostRecoverySQLRefs(refs)
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[0].Name).To(Equal("0-post-recovery-0-sql"))
+		Expect(volumes[0].Secret.SecretName).To(Equal("secret1"))
+		Expect(volumes[1].Name).To(Equal("0-post-recovery-1-sql"))
+		Expect(volumes[1].ConfigMap.Name).To(Equal("configmap1"))
+		Expect(volumeMounts).To(Equal([]corev1.VolumeMount{
+			{
+				Name:      "0-post-recovery-0-sql",
+				MountPath: postRecoverySQLRefsFolder.toString() + "/0/0.sql",
+				SubPath:   "0.sql",
+				ReadOnly:  true,
+			},
+			{
+				Name:      "0-post-recovery-1-sql",
+				MountPath: postRecoverySQLRefsFolder.toString() + "/1/0.sql",
+				SubPath:   "0.sql",
+				ReadOnly:  true,
+			},
+		}))
+	})
+})
+
 var _ = DescribeTable("test creation of volume mounts",
 	func(cluster apiv1.Cluster, mounts []corev1.VolumeMount) {
 		mts := CreatePostgresVolumeMounts(VolumeMountsConfig{
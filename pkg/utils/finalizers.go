@@ -44,4 +44,12 @@ const (
 	// RoleFinalizerName is the name of the finalizer
 	// triggering the deletion of the role
 	RoleFinalizerName = MetadataNamespace + "/deleteRole"
+
+	// BreakGlassAccessFinalizerName is the name of the finalizer
+	// triggering the revocation of a break-glass access
+	BreakGlassAccessFinalizerName = MetadataNamespace + "/revokeBreakGlassAccess"
+
+	// ReplicationSlotFinalizerName is the name of the finalizer
+	// triggering the deletion of the replication slot
+	ReplicationSlotFinalizerName = MetadataNamespace + "/deleteReplicationSlot"
 )
@@ -145,10 +145,19 @@ const (
 	// IsManagedLabelName is the name of the label used to indicate a '.spec.managed' resource
 	IsManagedLabelName = MetadataNamespace + "/isManaged"
 
+	// ServiceBindingRoleLabelName is the name of the label containing the
+	// role of a service binding Secret
+	ServiceBindingRoleLabelName = MetadataNamespace + "/serviceBindingRole"
+
 	// PluginNameLabelName is the name of the label to be applied to services
 	// to have them detected as CNPG-i plugins
 	PluginNameLabelName = MetadataNamespace + "/pluginName"
 
+	// UserMappingSecretLabelName is the name of the label that must be set
+	// to "true" on the Secrets containing the password of a user mapping
+	// of a Database foreign server
+	UserMappingSecretLabelName = MetadataNamespace + "/userMappingSecret"
+
 	// LivenessPingerAnnotationName is the name of the pinger configuration
 	LivenessPingerAnnotationName = AlphaMetadataNamespace + "/livenessPinger"
 )
@@ -324,6 +333,12 @@ const (
 	// be deleted with the contents of their PVCs.
 	UnrecoverableInstanceAnnotationName = AlphaMetadataNamespace + "/unrecoverable"
 
+	// MajorUpgradeRollbackAnnotationName is the name of the annotation used to
+	// request the operator to restore the data directory of the previous major
+	// version from the backup taken before the latest in-place major upgrade.
+	// The value is the name of the Backup to be restored.
+	MajorUpgradeRollbackAnnotationName = MetadataNamespace + "/majorUpgradeRollback"
+
 	// PasswordPassthroughAnnotationName is the name of the annotation that, when
 	// set to "enabled" on a basic-auth Secret consumed by the operator, instructs
 	// the role reconciler to send the password literal verbatim in
@@ -332,6 +347,26 @@ const (
 	// password_encryption setting, restoring the behavior the operator had
 	// before client-side encoding was introduced.
 	PasswordPassthroughAnnotationName = MetadataNamespace + "/passwordPassthrough"
+
+	// SubscriptionSequenceSyncAnnotationName is the name of the annotation
+	// used to request the synchronization of the sequences of a Subscription
+	// from the publisher. The synchronization happens every time its value
+	// changes, for example when it is set to the current timestamp.
+	SubscriptionSequenceSyncAnnotationName = MetadataNamespace + "/syncSequences"
+
+	// PasswordRotationAnnotationName is the name of the annotation that, when
+	// set to "enabled" on a password Secret, allows the operator to overwrite
+	// it with the credentials generated by the password rotation. The operator
+	// sets it on the Secrets it generates, while the ones provided by the user
+	// are only rotated when they opt in.
+	PasswordRotationAnnotationName = MetadataNamespace + "/passwordRotation"
+
+	// DefaultedParametersAnnotationName is the name of the annotation
+	// recording the PostgreSQL parameters stored in the spec of a Cluster
+	// by the defaulting webhook, with their value. They are not considered
+	// set by the user until their value changes, and are removed from the
+	// spec when the automatic tuning computes them.
+	DefaultedParametersAnnotationName = MetadataNamespace + "/defaultedParameters"
 )
 
 type annotationStatus string
@@ -556,6 +591,21 @@ func IsPasswordPassthroughEnabled(object *metav1.ObjectMeta) bool {
 	return object.Annotations[PasswordPassthroughAnnotationName] == string(annotationStatusEnabled)
 }
 
+// IsPasswordRotationEnabled reports whether the given Secret's metadata
+// allows the operator to overwrite it when rotating the password
+func IsPasswordRotationEnabled(object *metav1.ObjectMeta) bool {
+	return object.Annotations[PasswordRotationAnnotationName] == string(annotationStatusEnabled)
+}
+
+// SetPasswordRotationEnabled marks the given Secret's metadata as
+// rotatable by the operator
+func SetPasswordRotationEnabled(object *metav1.ObjectMeta) {
+	if object.Annotations == nil {
+		object.Annotations = make(map[string]string)
+	}
+	object.Annotations[PasswordRotationAnnotationName] = string(annotationStatusEnabled)
+}
+
 // GetInstanceRole tries to fetch the ClusterRoleLabelName andClusterInstanceRoleLabelName value from a given labels map
 func GetInstanceRole(labels map[string]string) (string, bool) {
 	if value := labels[ClusterRoleLabelName]; value != "" {